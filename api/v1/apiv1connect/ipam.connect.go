@@ -39,6 +39,17 @@ const (
 	// IpamServiceDeletePrefixProcedure is the fully-qualified name of the IpamService's DeletePrefix
 	// RPC.
 	IpamServiceDeletePrefixProcedure = "/api.v1.IpamService/DeletePrefix"
+	// IpamServiceUpdatePrefixProcedure is the fully-qualified name of the IpamService's UpdatePrefix
+	// RPC.
+	IpamServiceUpdatePrefixProcedure = "/api.v1.IpamService/UpdatePrefix"
+	// IpamServiceResizePrefixProcedure is the fully-qualified name of the IpamService's ResizePrefix
+	// RPC.
+	IpamServiceResizePrefixProcedure = "/api.v1.IpamService/ResizePrefix"
+	// IpamServiceSplitPrefixProcedure is the fully-qualified name of the IpamService's SplitPrefix RPC.
+	IpamServiceSplitPrefixProcedure = "/api.v1.IpamService/SplitPrefix"
+	// IpamServiceMergePrefixesProcedure is the fully-qualified name of the IpamService's MergePrefixes
+	// RPC.
+	IpamServiceMergePrefixesProcedure = "/api.v1.IpamService/MergePrefixes"
 	// IpamServiceGetPrefixProcedure is the fully-qualified name of the IpamService's GetPrefix RPC.
 	IpamServiceGetPrefixProcedure = "/api.v1.IpamService/GetPrefix"
 	// IpamServiceListPrefixesProcedure is the fully-qualified name of the IpamService's ListPrefixes
@@ -46,16 +57,69 @@ const (
 	IpamServiceListPrefixesProcedure = "/api.v1.IpamService/ListPrefixes"
 	// IpamServicePrefixUsageProcedure is the fully-qualified name of the IpamService's PrefixUsage RPC.
 	IpamServicePrefixUsageProcedure = "/api.v1.IpamService/PrefixUsage"
+	// IpamServiceGetPrefixTreeProcedure is the fully-qualified name of the IpamService's GetPrefixTree
+	// RPC.
+	IpamServiceGetPrefixTreeProcedure = "/api.v1.IpamService/GetPrefixTree"
+	// IpamServiceFragmentationReportProcedure is the fully-qualified name of the IpamService's
+	// FragmentationReport RPC.
+	IpamServiceFragmentationReportProcedure = "/api.v1.IpamService/FragmentationReport"
+	// IpamServicePlanDefragmentationProcedure is the fully-qualified name of the IpamService's
+	// PlanDefragmentation RPC.
+	IpamServicePlanDefragmentationProcedure = "/api.v1.IpamService/PlanDefragmentation"
+	// IpamServiceCreatePoolProcedure is the fully-qualified name of the IpamService's CreatePool RPC.
+	IpamServiceCreatePoolProcedure = "/api.v1.IpamService/CreatePool"
+	// IpamServiceUpdatePoolProcedure is the fully-qualified name of the IpamService's UpdatePool RPC.
+	IpamServiceUpdatePoolProcedure = "/api.v1.IpamService/UpdatePool"
+	// IpamServiceGetPoolProcedure is the fully-qualified name of the IpamService's GetPool RPC.
+	IpamServiceGetPoolProcedure = "/api.v1.IpamService/GetPool"
+	// IpamServiceListPoolsProcedure is the fully-qualified name of the IpamService's ListPools RPC.
+	IpamServiceListPoolsProcedure = "/api.v1.IpamService/ListPools"
+	// IpamServiceDeletePoolProcedure is the fully-qualified name of the IpamService's DeletePool RPC.
+	IpamServiceDeletePoolProcedure = "/api.v1.IpamService/DeletePool"
+	// IpamServiceAcquireIPFromPoolProcedure is the fully-qualified name of the IpamService's
+	// AcquireIPFromPool RPC.
+	IpamServiceAcquireIPFromPoolProcedure = "/api.v1.IpamService/AcquireIPFromPool"
+	// IpamServiceAcquireChildPrefixFromPoolProcedure is the fully-qualified name of the IpamService's
+	// AcquireChildPrefixFromPool RPC.
+	IpamServiceAcquireChildPrefixFromPoolProcedure = "/api.v1.IpamService/AcquireChildPrefixFromPool"
 	// IpamServiceAcquireChildPrefixProcedure is the fully-qualified name of the IpamService's
 	// AcquireChildPrefix RPC.
 	IpamServiceAcquireChildPrefixProcedure = "/api.v1.IpamService/AcquireChildPrefix"
+	// IpamServiceAcquireChildPrefixesProcedure is the fully-qualified name of the IpamService's
+	// AcquireChildPrefixes RPC.
+	IpamServiceAcquireChildPrefixesProcedure = "/api.v1.IpamService/AcquireChildPrefixes"
 	// IpamServiceReleaseChildPrefixProcedure is the fully-qualified name of the IpamService's
 	// ReleaseChildPrefix RPC.
 	IpamServiceReleaseChildPrefixProcedure = "/api.v1.IpamService/ReleaseChildPrefix"
 	// IpamServiceAcquireIPProcedure is the fully-qualified name of the IpamService's AcquireIP RPC.
 	IpamServiceAcquireIPProcedure = "/api.v1.IpamService/AcquireIP"
+	// IpamServiceAcquireIPsProcedure is the fully-qualified name of the IpamService's AcquireIPs RPC.
+	IpamServiceAcquireIPsProcedure = "/api.v1.IpamService/AcquireIPs"
+	// IpamServiceAcquireDualStackProcedure is the fully-qualified name of the IpamService's
+	// AcquireDualStack RPC.
+	IpamServiceAcquireDualStackProcedure = "/api.v1.IpamService/AcquireDualStack"
+	// IpamServiceAcquireIPFromMACProcedure is the fully-qualified name of the IpamService's
+	// AcquireIPFromMAC RPC.
+	IpamServiceAcquireIPFromMACProcedure = "/api.v1.IpamService/AcquireIPFromMAC"
+	// IpamServiceAcquireIPForKeyProcedure is the fully-qualified name of the IpamService's
+	// AcquireIPForKey RPC.
+	IpamServiceAcquireIPForKeyProcedure = "/api.v1.IpamService/AcquireIPForKey"
+	// IpamServiceAcquireIPRangeProcedure is the fully-qualified name of the IpamService's
+	// AcquireIPRange RPC.
+	IpamServiceAcquireIPRangeProcedure = "/api.v1.IpamService/AcquireIPRange"
+	// IpamServiceReleaseIPRangeProcedure is the fully-qualified name of the IpamService's
+	// ReleaseIPRange RPC.
+	IpamServiceReleaseIPRangeProcedure = "/api.v1.IpamService/ReleaseIPRange"
 	// IpamServiceReleaseIPProcedure is the fully-qualified name of the IpamService's ReleaseIP RPC.
 	IpamServiceReleaseIPProcedure = "/api.v1.IpamService/ReleaseIP"
+	// IpamServiceGetIPProcedure is the fully-qualified name of the IpamService's GetIP RPC.
+	IpamServiceGetIPProcedure = "/api.v1.IpamService/GetIP"
+	// IpamServiceLookupIPProcedure is the fully-qualified name of the IpamService's LookupIP RPC.
+	IpamServiceLookupIPProcedure = "/api.v1.IpamService/LookupIP"
+	// IpamServiceRenewLeaseProcedure is the fully-qualified name of the IpamService's RenewLease RPC.
+	IpamServiceRenewLeaseProcedure = "/api.v1.IpamService/RenewLease"
+	// IpamServiceListLeasesProcedure is the fully-qualified name of the IpamService's ListLeases RPC.
+	IpamServiceListLeasesProcedure = "/api.v1.IpamService/ListLeases"
 	// IpamServiceDumpProcedure is the fully-qualified name of the IpamService's Dump RPC.
 	IpamServiceDumpProcedure = "/api.v1.IpamService/Dump"
 	// IpamServiceLoadProcedure is the fully-qualified name of the IpamService's Load RPC.
@@ -69,6 +133,12 @@ const (
 	// IpamServiceDeleteNamespaceProcedure is the fully-qualified name of the IpamService's
 	// DeleteNamespace RPC.
 	IpamServiceDeleteNamespaceProcedure = "/api.v1.IpamService/DeleteNamespace"
+	// IpamServiceSetNamespaceQuotaProcedure is the fully-qualified name of the IpamService's
+	// SetNamespaceQuota RPC.
+	IpamServiceSetNamespaceQuotaProcedure = "/api.v1.IpamService/SetNamespaceQuota"
+	// IpamServiceGetNamespaceQuotaProcedure is the fully-qualified name of the IpamService's
+	// GetNamespaceQuota RPC.
+	IpamServiceGetNamespaceQuotaProcedure = "/api.v1.IpamService/GetNamespaceQuota"
 	// IpamServiceVersionProcedure is the fully-qualified name of the IpamService's Version RPC.
 	IpamServiceVersionProcedure = "/api.v1.IpamService/Version"
 )
@@ -77,18 +147,45 @@ const (
 type IpamServiceClient interface {
 	CreatePrefix(context.Context, *connect.Request[v1.CreatePrefixRequest]) (*connect.Response[v1.CreatePrefixResponse], error)
 	DeletePrefix(context.Context, *connect.Request[v1.DeletePrefixRequest]) (*connect.Response[v1.DeletePrefixResponse], error)
+	UpdatePrefix(context.Context, *connect.Request[v1.UpdatePrefixRequest]) (*connect.Response[v1.UpdatePrefixResponse], error)
+	ResizePrefix(context.Context, *connect.Request[v1.ResizePrefixRequest]) (*connect.Response[v1.ResizePrefixResponse], error)
+	SplitPrefix(context.Context, *connect.Request[v1.SplitPrefixRequest]) (*connect.Response[v1.SplitPrefixResponse], error)
+	MergePrefixes(context.Context, *connect.Request[v1.MergePrefixesRequest]) (*connect.Response[v1.MergePrefixesResponse], error)
 	GetPrefix(context.Context, *connect.Request[v1.GetPrefixRequest]) (*connect.Response[v1.GetPrefixResponse], error)
 	ListPrefixes(context.Context, *connect.Request[v1.ListPrefixesRequest]) (*connect.Response[v1.ListPrefixesResponse], error)
 	PrefixUsage(context.Context, *connect.Request[v1.PrefixUsageRequest]) (*connect.Response[v1.PrefixUsageResponse], error)
+	GetPrefixTree(context.Context, *connect.Request[v1.GetPrefixTreeRequest]) (*connect.Response[v1.GetPrefixTreeResponse], error)
+	FragmentationReport(context.Context, *connect.Request[v1.FragmentationReportRequest]) (*connect.Response[v1.FragmentationReportResponse], error)
+	PlanDefragmentation(context.Context, *connect.Request[v1.PlanDefragmentationRequest]) (*connect.Response[v1.PlanDefragmentationResponse], error)
+	CreatePool(context.Context, *connect.Request[v1.CreatePoolRequest]) (*connect.Response[v1.CreatePoolResponse], error)
+	UpdatePool(context.Context, *connect.Request[v1.UpdatePoolRequest]) (*connect.Response[v1.UpdatePoolResponse], error)
+	GetPool(context.Context, *connect.Request[v1.GetPoolRequest]) (*connect.Response[v1.GetPoolResponse], error)
+	ListPools(context.Context, *connect.Request[v1.ListPoolsRequest]) (*connect.Response[v1.ListPoolsResponse], error)
+	DeletePool(context.Context, *connect.Request[v1.DeletePoolRequest]) (*connect.Response[v1.DeletePoolResponse], error)
+	AcquireIPFromPool(context.Context, *connect.Request[v1.AcquireIPFromPoolRequest]) (*connect.Response[v1.AcquireIPFromPoolResponse], error)
+	AcquireChildPrefixFromPool(context.Context, *connect.Request[v1.AcquireChildPrefixFromPoolRequest]) (*connect.Response[v1.AcquireChildPrefixFromPoolResponse], error)
 	AcquireChildPrefix(context.Context, *connect.Request[v1.AcquireChildPrefixRequest]) (*connect.Response[v1.AcquireChildPrefixResponse], error)
+	AcquireChildPrefixes(context.Context, *connect.Request[v1.AcquireChildPrefixesRequest]) (*connect.Response[v1.AcquireChildPrefixesResponse], error)
 	ReleaseChildPrefix(context.Context, *connect.Request[v1.ReleaseChildPrefixRequest]) (*connect.Response[v1.ReleaseChildPrefixResponse], error)
 	AcquireIP(context.Context, *connect.Request[v1.AcquireIPRequest]) (*connect.Response[v1.AcquireIPResponse], error)
+	AcquireIPs(context.Context, *connect.Request[v1.AcquireIPsRequest]) (*connect.Response[v1.AcquireIPsResponse], error)
+	AcquireDualStack(context.Context, *connect.Request[v1.AcquireDualStackRequest]) (*connect.Response[v1.AcquireDualStackResponse], error)
+	AcquireIPFromMAC(context.Context, *connect.Request[v1.AcquireIPFromMACRequest]) (*connect.Response[v1.AcquireIPFromMACResponse], error)
+	AcquireIPForKey(context.Context, *connect.Request[v1.AcquireIPForKeyRequest]) (*connect.Response[v1.AcquireIPForKeyResponse], error)
+	AcquireIPRange(context.Context, *connect.Request[v1.AcquireIPRangeRequest]) (*connect.Response[v1.AcquireIPRangeResponse], error)
+	ReleaseIPRange(context.Context, *connect.Request[v1.ReleaseIPRangeRequest]) (*connect.Response[v1.ReleaseIPRangeResponse], error)
 	ReleaseIP(context.Context, *connect.Request[v1.ReleaseIPRequest]) (*connect.Response[v1.ReleaseIPResponse], error)
+	GetIP(context.Context, *connect.Request[v1.GetIPRequest]) (*connect.Response[v1.GetIPResponse], error)
+	LookupIP(context.Context, *connect.Request[v1.LookupIPRequest]) (*connect.Response[v1.LookupIPResponse], error)
+	RenewLease(context.Context, *connect.Request[v1.RenewLeaseRequest]) (*connect.Response[v1.RenewLeaseResponse], error)
+	ListLeases(context.Context, *connect.Request[v1.ListLeasesRequest]) (*connect.Response[v1.ListLeasesResponse], error)
 	Dump(context.Context, *connect.Request[v1.DumpRequest]) (*connect.Response[v1.DumpResponse], error)
 	Load(context.Context, *connect.Request[v1.LoadRequest]) (*connect.Response[v1.LoadResponse], error)
 	CreateNamespace(context.Context, *connect.Request[v1.CreateNamespaceRequest]) (*connect.Response[v1.CreateNamespaceResponse], error)
 	ListNamespaces(context.Context, *connect.Request[v1.ListNamespacesRequest]) (*connect.Response[v1.ListNamespacesResponse], error)
 	DeleteNamespace(context.Context, *connect.Request[v1.DeleteNamespaceRequest]) (*connect.Response[v1.DeleteNamespaceResponse], error)
+	SetNamespaceQuota(context.Context, *connect.Request[v1.SetNamespaceQuotaRequest]) (*connect.Response[v1.SetNamespaceQuotaResponse], error)
+	GetNamespaceQuota(context.Context, *connect.Request[v1.GetNamespaceQuotaRequest]) (*connect.Response[v1.GetNamespaceQuotaResponse], error)
 	Version(context.Context, *connect.Request[v1.VersionRequest]) (*connect.Response[v1.VersionResponse], error)
 }
 
@@ -115,6 +212,30 @@ func NewIpamServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(ipamServiceMethods.ByName("DeletePrefix")),
 			connect.WithClientOptions(opts...),
 		),
+		updatePrefix: connect.NewClient[v1.UpdatePrefixRequest, v1.UpdatePrefixResponse](
+			httpClient,
+			baseURL+IpamServiceUpdatePrefixProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("UpdatePrefix")),
+			connect.WithClientOptions(opts...),
+		),
+		resizePrefix: connect.NewClient[v1.ResizePrefixRequest, v1.ResizePrefixResponse](
+			httpClient,
+			baseURL+IpamServiceResizePrefixProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("ResizePrefix")),
+			connect.WithClientOptions(opts...),
+		),
+		splitPrefix: connect.NewClient[v1.SplitPrefixRequest, v1.SplitPrefixResponse](
+			httpClient,
+			baseURL+IpamServiceSplitPrefixProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("SplitPrefix")),
+			connect.WithClientOptions(opts...),
+		),
+		mergePrefixes: connect.NewClient[v1.MergePrefixesRequest, v1.MergePrefixesResponse](
+			httpClient,
+			baseURL+IpamServiceMergePrefixesProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("MergePrefixes")),
+			connect.WithClientOptions(opts...),
+		),
 		getPrefix: connect.NewClient[v1.GetPrefixRequest, v1.GetPrefixResponse](
 			httpClient,
 			baseURL+IpamServiceGetPrefixProcedure,
@@ -133,12 +254,78 @@ func NewIpamServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(ipamServiceMethods.ByName("PrefixUsage")),
 			connect.WithClientOptions(opts...),
 		),
+		getPrefixTree: connect.NewClient[v1.GetPrefixTreeRequest, v1.GetPrefixTreeResponse](
+			httpClient,
+			baseURL+IpamServiceGetPrefixTreeProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("GetPrefixTree")),
+			connect.WithClientOptions(opts...),
+		),
+		fragmentationReport: connect.NewClient[v1.FragmentationReportRequest, v1.FragmentationReportResponse](
+			httpClient,
+			baseURL+IpamServiceFragmentationReportProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("FragmentationReport")),
+			connect.WithClientOptions(opts...),
+		),
+		planDefragmentation: connect.NewClient[v1.PlanDefragmentationRequest, v1.PlanDefragmentationResponse](
+			httpClient,
+			baseURL+IpamServicePlanDefragmentationProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("PlanDefragmentation")),
+			connect.WithClientOptions(opts...),
+		),
+		createPool: connect.NewClient[v1.CreatePoolRequest, v1.CreatePoolResponse](
+			httpClient,
+			baseURL+IpamServiceCreatePoolProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("CreatePool")),
+			connect.WithClientOptions(opts...),
+		),
+		updatePool: connect.NewClient[v1.UpdatePoolRequest, v1.UpdatePoolResponse](
+			httpClient,
+			baseURL+IpamServiceUpdatePoolProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("UpdatePool")),
+			connect.WithClientOptions(opts...),
+		),
+		getPool: connect.NewClient[v1.GetPoolRequest, v1.GetPoolResponse](
+			httpClient,
+			baseURL+IpamServiceGetPoolProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("GetPool")),
+			connect.WithClientOptions(opts...),
+		),
+		listPools: connect.NewClient[v1.ListPoolsRequest, v1.ListPoolsResponse](
+			httpClient,
+			baseURL+IpamServiceListPoolsProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("ListPools")),
+			connect.WithClientOptions(opts...),
+		),
+		deletePool: connect.NewClient[v1.DeletePoolRequest, v1.DeletePoolResponse](
+			httpClient,
+			baseURL+IpamServiceDeletePoolProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("DeletePool")),
+			connect.WithClientOptions(opts...),
+		),
+		acquireIPFromPool: connect.NewClient[v1.AcquireIPFromPoolRequest, v1.AcquireIPFromPoolResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireIPFromPoolProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireIPFromPool")),
+			connect.WithClientOptions(opts...),
+		),
+		acquireChildPrefixFromPool: connect.NewClient[v1.AcquireChildPrefixFromPoolRequest, v1.AcquireChildPrefixFromPoolResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireChildPrefixFromPoolProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireChildPrefixFromPool")),
+			connect.WithClientOptions(opts...),
+		),
 		acquireChildPrefix: connect.NewClient[v1.AcquireChildPrefixRequest, v1.AcquireChildPrefixResponse](
 			httpClient,
 			baseURL+IpamServiceAcquireChildPrefixProcedure,
 			connect.WithSchema(ipamServiceMethods.ByName("AcquireChildPrefix")),
 			connect.WithClientOptions(opts...),
 		),
+		acquireChildPrefixes: connect.NewClient[v1.AcquireChildPrefixesRequest, v1.AcquireChildPrefixesResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireChildPrefixesProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireChildPrefixes")),
+			connect.WithClientOptions(opts...),
+		),
 		releaseChildPrefix: connect.NewClient[v1.ReleaseChildPrefixRequest, v1.ReleaseChildPrefixResponse](
 			httpClient,
 			baseURL+IpamServiceReleaseChildPrefixProcedure,
@@ -151,12 +338,72 @@ func NewIpamServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(ipamServiceMethods.ByName("AcquireIP")),
 			connect.WithClientOptions(opts...),
 		),
+		acquireIPs: connect.NewClient[v1.AcquireIPsRequest, v1.AcquireIPsResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireIPsProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireIPs")),
+			connect.WithClientOptions(opts...),
+		),
+		acquireDualStack: connect.NewClient[v1.AcquireDualStackRequest, v1.AcquireDualStackResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireDualStackProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireDualStack")),
+			connect.WithClientOptions(opts...),
+		),
+		acquireIPFromMAC: connect.NewClient[v1.AcquireIPFromMACRequest, v1.AcquireIPFromMACResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireIPFromMACProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireIPFromMAC")),
+			connect.WithClientOptions(opts...),
+		),
+		acquireIPForKey: connect.NewClient[v1.AcquireIPForKeyRequest, v1.AcquireIPForKeyResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireIPForKeyProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireIPForKey")),
+			connect.WithClientOptions(opts...),
+		),
+		acquireIPRange: connect.NewClient[v1.AcquireIPRangeRequest, v1.AcquireIPRangeResponse](
+			httpClient,
+			baseURL+IpamServiceAcquireIPRangeProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("AcquireIPRange")),
+			connect.WithClientOptions(opts...),
+		),
+		releaseIPRange: connect.NewClient[v1.ReleaseIPRangeRequest, v1.ReleaseIPRangeResponse](
+			httpClient,
+			baseURL+IpamServiceReleaseIPRangeProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("ReleaseIPRange")),
+			connect.WithClientOptions(opts...),
+		),
 		releaseIP: connect.NewClient[v1.ReleaseIPRequest, v1.ReleaseIPResponse](
 			httpClient,
 			baseURL+IpamServiceReleaseIPProcedure,
 			connect.WithSchema(ipamServiceMethods.ByName("ReleaseIP")),
 			connect.WithClientOptions(opts...),
 		),
+		getIP: connect.NewClient[v1.GetIPRequest, v1.GetIPResponse](
+			httpClient,
+			baseURL+IpamServiceGetIPProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("GetIP")),
+			connect.WithClientOptions(opts...),
+		),
+		lookupIP: connect.NewClient[v1.LookupIPRequest, v1.LookupIPResponse](
+			httpClient,
+			baseURL+IpamServiceLookupIPProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("LookupIP")),
+			connect.WithClientOptions(opts...),
+		),
+		renewLease: connect.NewClient[v1.RenewLeaseRequest, v1.RenewLeaseResponse](
+			httpClient,
+			baseURL+IpamServiceRenewLeaseProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("RenewLease")),
+			connect.WithClientOptions(opts...),
+		),
+		listLeases: connect.NewClient[v1.ListLeasesRequest, v1.ListLeasesResponse](
+			httpClient,
+			baseURL+IpamServiceListLeasesProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("ListLeases")),
+			connect.WithClientOptions(opts...),
+		),
 		dump: connect.NewClient[v1.DumpRequest, v1.DumpResponse](
 			httpClient,
 			baseURL+IpamServiceDumpProcedure,
@@ -187,6 +434,18 @@ func NewIpamServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(ipamServiceMethods.ByName("DeleteNamespace")),
 			connect.WithClientOptions(opts...),
 		),
+		setNamespaceQuota: connect.NewClient[v1.SetNamespaceQuotaRequest, v1.SetNamespaceQuotaResponse](
+			httpClient,
+			baseURL+IpamServiceSetNamespaceQuotaProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("SetNamespaceQuota")),
+			connect.WithClientOptions(opts...),
+		),
+		getNamespaceQuota: connect.NewClient[v1.GetNamespaceQuotaRequest, v1.GetNamespaceQuotaResponse](
+			httpClient,
+			baseURL+IpamServiceGetNamespaceQuotaProcedure,
+			connect.WithSchema(ipamServiceMethods.ByName("GetNamespaceQuota")),
+			connect.WithClientOptions(opts...),
+		),
 		version: connect.NewClient[v1.VersionRequest, v1.VersionResponse](
 			httpClient,
 			baseURL+IpamServiceVersionProcedure,
@@ -198,21 +457,48 @@ func NewIpamServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 
 // ipamServiceClient implements IpamServiceClient.
 type ipamServiceClient struct {
-	createPrefix       *connect.Client[v1.CreatePrefixRequest, v1.CreatePrefixResponse]
-	deletePrefix       *connect.Client[v1.DeletePrefixRequest, v1.DeletePrefixResponse]
-	getPrefix          *connect.Client[v1.GetPrefixRequest, v1.GetPrefixResponse]
-	listPrefixes       *connect.Client[v1.ListPrefixesRequest, v1.ListPrefixesResponse]
-	prefixUsage        *connect.Client[v1.PrefixUsageRequest, v1.PrefixUsageResponse]
-	acquireChildPrefix *connect.Client[v1.AcquireChildPrefixRequest, v1.AcquireChildPrefixResponse]
-	releaseChildPrefix *connect.Client[v1.ReleaseChildPrefixRequest, v1.ReleaseChildPrefixResponse]
-	acquireIP          *connect.Client[v1.AcquireIPRequest, v1.AcquireIPResponse]
-	releaseIP          *connect.Client[v1.ReleaseIPRequest, v1.ReleaseIPResponse]
-	dump               *connect.Client[v1.DumpRequest, v1.DumpResponse]
-	load               *connect.Client[v1.LoadRequest, v1.LoadResponse]
-	createNamespace    *connect.Client[v1.CreateNamespaceRequest, v1.CreateNamespaceResponse]
-	listNamespaces     *connect.Client[v1.ListNamespacesRequest, v1.ListNamespacesResponse]
-	deleteNamespace    *connect.Client[v1.DeleteNamespaceRequest, v1.DeleteNamespaceResponse]
-	version            *connect.Client[v1.VersionRequest, v1.VersionResponse]
+	createPrefix               *connect.Client[v1.CreatePrefixRequest, v1.CreatePrefixResponse]
+	deletePrefix               *connect.Client[v1.DeletePrefixRequest, v1.DeletePrefixResponse]
+	updatePrefix               *connect.Client[v1.UpdatePrefixRequest, v1.UpdatePrefixResponse]
+	resizePrefix               *connect.Client[v1.ResizePrefixRequest, v1.ResizePrefixResponse]
+	splitPrefix                *connect.Client[v1.SplitPrefixRequest, v1.SplitPrefixResponse]
+	mergePrefixes              *connect.Client[v1.MergePrefixesRequest, v1.MergePrefixesResponse]
+	getPrefix                  *connect.Client[v1.GetPrefixRequest, v1.GetPrefixResponse]
+	listPrefixes               *connect.Client[v1.ListPrefixesRequest, v1.ListPrefixesResponse]
+	prefixUsage                *connect.Client[v1.PrefixUsageRequest, v1.PrefixUsageResponse]
+	getPrefixTree              *connect.Client[v1.GetPrefixTreeRequest, v1.GetPrefixTreeResponse]
+	fragmentationReport        *connect.Client[v1.FragmentationReportRequest, v1.FragmentationReportResponse]
+	planDefragmentation        *connect.Client[v1.PlanDefragmentationRequest, v1.PlanDefragmentationResponse]
+	createPool                 *connect.Client[v1.CreatePoolRequest, v1.CreatePoolResponse]
+	updatePool                 *connect.Client[v1.UpdatePoolRequest, v1.UpdatePoolResponse]
+	getPool                    *connect.Client[v1.GetPoolRequest, v1.GetPoolResponse]
+	listPools                  *connect.Client[v1.ListPoolsRequest, v1.ListPoolsResponse]
+	deletePool                 *connect.Client[v1.DeletePoolRequest, v1.DeletePoolResponse]
+	acquireIPFromPool          *connect.Client[v1.AcquireIPFromPoolRequest, v1.AcquireIPFromPoolResponse]
+	acquireChildPrefixFromPool *connect.Client[v1.AcquireChildPrefixFromPoolRequest, v1.AcquireChildPrefixFromPoolResponse]
+	acquireChildPrefix         *connect.Client[v1.AcquireChildPrefixRequest, v1.AcquireChildPrefixResponse]
+	acquireChildPrefixes       *connect.Client[v1.AcquireChildPrefixesRequest, v1.AcquireChildPrefixesResponse]
+	releaseChildPrefix         *connect.Client[v1.ReleaseChildPrefixRequest, v1.ReleaseChildPrefixResponse]
+	acquireIP                  *connect.Client[v1.AcquireIPRequest, v1.AcquireIPResponse]
+	acquireIPs                 *connect.Client[v1.AcquireIPsRequest, v1.AcquireIPsResponse]
+	acquireDualStack           *connect.Client[v1.AcquireDualStackRequest, v1.AcquireDualStackResponse]
+	acquireIPFromMAC           *connect.Client[v1.AcquireIPFromMACRequest, v1.AcquireIPFromMACResponse]
+	acquireIPForKey            *connect.Client[v1.AcquireIPForKeyRequest, v1.AcquireIPForKeyResponse]
+	acquireIPRange             *connect.Client[v1.AcquireIPRangeRequest, v1.AcquireIPRangeResponse]
+	releaseIPRange             *connect.Client[v1.ReleaseIPRangeRequest, v1.ReleaseIPRangeResponse]
+	releaseIP                  *connect.Client[v1.ReleaseIPRequest, v1.ReleaseIPResponse]
+	getIP                      *connect.Client[v1.GetIPRequest, v1.GetIPResponse]
+	lookupIP                   *connect.Client[v1.LookupIPRequest, v1.LookupIPResponse]
+	renewLease                 *connect.Client[v1.RenewLeaseRequest, v1.RenewLeaseResponse]
+	listLeases                 *connect.Client[v1.ListLeasesRequest, v1.ListLeasesResponse]
+	dump                       *connect.Client[v1.DumpRequest, v1.DumpResponse]
+	load                       *connect.Client[v1.LoadRequest, v1.LoadResponse]
+	createNamespace            *connect.Client[v1.CreateNamespaceRequest, v1.CreateNamespaceResponse]
+	listNamespaces             *connect.Client[v1.ListNamespacesRequest, v1.ListNamespacesResponse]
+	deleteNamespace            *connect.Client[v1.DeleteNamespaceRequest, v1.DeleteNamespaceResponse]
+	setNamespaceQuota          *connect.Client[v1.SetNamespaceQuotaRequest, v1.SetNamespaceQuotaResponse]
+	getNamespaceQuota          *connect.Client[v1.GetNamespaceQuotaRequest, v1.GetNamespaceQuotaResponse]
+	version                    *connect.Client[v1.VersionRequest, v1.VersionResponse]
 }
 
 // CreatePrefix calls api.v1.IpamService.CreatePrefix.
@@ -225,6 +511,26 @@ func (c *ipamServiceClient) DeletePrefix(ctx context.Context, req *connect.Reque
 	return c.deletePrefix.CallUnary(ctx, req)
 }
 
+// UpdatePrefix calls api.v1.IpamService.UpdatePrefix.
+func (c *ipamServiceClient) UpdatePrefix(ctx context.Context, req *connect.Request[v1.UpdatePrefixRequest]) (*connect.Response[v1.UpdatePrefixResponse], error) {
+	return c.updatePrefix.CallUnary(ctx, req)
+}
+
+// ResizePrefix calls api.v1.IpamService.ResizePrefix.
+func (c *ipamServiceClient) ResizePrefix(ctx context.Context, req *connect.Request[v1.ResizePrefixRequest]) (*connect.Response[v1.ResizePrefixResponse], error) {
+	return c.resizePrefix.CallUnary(ctx, req)
+}
+
+// SplitPrefix calls api.v1.IpamService.SplitPrefix.
+func (c *ipamServiceClient) SplitPrefix(ctx context.Context, req *connect.Request[v1.SplitPrefixRequest]) (*connect.Response[v1.SplitPrefixResponse], error) {
+	return c.splitPrefix.CallUnary(ctx, req)
+}
+
+// MergePrefixes calls api.v1.IpamService.MergePrefixes.
+func (c *ipamServiceClient) MergePrefixes(ctx context.Context, req *connect.Request[v1.MergePrefixesRequest]) (*connect.Response[v1.MergePrefixesResponse], error) {
+	return c.mergePrefixes.CallUnary(ctx, req)
+}
+
 // GetPrefix calls api.v1.IpamService.GetPrefix.
 func (c *ipamServiceClient) GetPrefix(ctx context.Context, req *connect.Request[v1.GetPrefixRequest]) (*connect.Response[v1.GetPrefixResponse], error) {
 	return c.getPrefix.CallUnary(ctx, req)
@@ -240,11 +546,66 @@ func (c *ipamServiceClient) PrefixUsage(ctx context.Context, req *connect.Reques
 	return c.prefixUsage.CallUnary(ctx, req)
 }
 
+// GetPrefixTree calls api.v1.IpamService.GetPrefixTree.
+func (c *ipamServiceClient) GetPrefixTree(ctx context.Context, req *connect.Request[v1.GetPrefixTreeRequest]) (*connect.Response[v1.GetPrefixTreeResponse], error) {
+	return c.getPrefixTree.CallUnary(ctx, req)
+}
+
+// FragmentationReport calls api.v1.IpamService.FragmentationReport.
+func (c *ipamServiceClient) FragmentationReport(ctx context.Context, req *connect.Request[v1.FragmentationReportRequest]) (*connect.Response[v1.FragmentationReportResponse], error) {
+	return c.fragmentationReport.CallUnary(ctx, req)
+}
+
+// PlanDefragmentation calls api.v1.IpamService.PlanDefragmentation.
+func (c *ipamServiceClient) PlanDefragmentation(ctx context.Context, req *connect.Request[v1.PlanDefragmentationRequest]) (*connect.Response[v1.PlanDefragmentationResponse], error) {
+	return c.planDefragmentation.CallUnary(ctx, req)
+}
+
+// CreatePool calls api.v1.IpamService.CreatePool.
+func (c *ipamServiceClient) CreatePool(ctx context.Context, req *connect.Request[v1.CreatePoolRequest]) (*connect.Response[v1.CreatePoolResponse], error) {
+	return c.createPool.CallUnary(ctx, req)
+}
+
+// UpdatePool calls api.v1.IpamService.UpdatePool.
+func (c *ipamServiceClient) UpdatePool(ctx context.Context, req *connect.Request[v1.UpdatePoolRequest]) (*connect.Response[v1.UpdatePoolResponse], error) {
+	return c.updatePool.CallUnary(ctx, req)
+}
+
+// GetPool calls api.v1.IpamService.GetPool.
+func (c *ipamServiceClient) GetPool(ctx context.Context, req *connect.Request[v1.GetPoolRequest]) (*connect.Response[v1.GetPoolResponse], error) {
+	return c.getPool.CallUnary(ctx, req)
+}
+
+// ListPools calls api.v1.IpamService.ListPools.
+func (c *ipamServiceClient) ListPools(ctx context.Context, req *connect.Request[v1.ListPoolsRequest]) (*connect.Response[v1.ListPoolsResponse], error) {
+	return c.listPools.CallUnary(ctx, req)
+}
+
+// DeletePool calls api.v1.IpamService.DeletePool.
+func (c *ipamServiceClient) DeletePool(ctx context.Context, req *connect.Request[v1.DeletePoolRequest]) (*connect.Response[v1.DeletePoolResponse], error) {
+	return c.deletePool.CallUnary(ctx, req)
+}
+
+// AcquireIPFromPool calls api.v1.IpamService.AcquireIPFromPool.
+func (c *ipamServiceClient) AcquireIPFromPool(ctx context.Context, req *connect.Request[v1.AcquireIPFromPoolRequest]) (*connect.Response[v1.AcquireIPFromPoolResponse], error) {
+	return c.acquireIPFromPool.CallUnary(ctx, req)
+}
+
+// AcquireChildPrefixFromPool calls api.v1.IpamService.AcquireChildPrefixFromPool.
+func (c *ipamServiceClient) AcquireChildPrefixFromPool(ctx context.Context, req *connect.Request[v1.AcquireChildPrefixFromPoolRequest]) (*connect.Response[v1.AcquireChildPrefixFromPoolResponse], error) {
+	return c.acquireChildPrefixFromPool.CallUnary(ctx, req)
+}
+
 // AcquireChildPrefix calls api.v1.IpamService.AcquireChildPrefix.
 func (c *ipamServiceClient) AcquireChildPrefix(ctx context.Context, req *connect.Request[v1.AcquireChildPrefixRequest]) (*connect.Response[v1.AcquireChildPrefixResponse], error) {
 	return c.acquireChildPrefix.CallUnary(ctx, req)
 }
 
+// AcquireChildPrefixes calls api.v1.IpamService.AcquireChildPrefixes.
+func (c *ipamServiceClient) AcquireChildPrefixes(ctx context.Context, req *connect.Request[v1.AcquireChildPrefixesRequest]) (*connect.Response[v1.AcquireChildPrefixesResponse], error) {
+	return c.acquireChildPrefixes.CallUnary(ctx, req)
+}
+
 // ReleaseChildPrefix calls api.v1.IpamService.ReleaseChildPrefix.
 func (c *ipamServiceClient) ReleaseChildPrefix(ctx context.Context, req *connect.Request[v1.ReleaseChildPrefixRequest]) (*connect.Response[v1.ReleaseChildPrefixResponse], error) {
 	return c.releaseChildPrefix.CallUnary(ctx, req)
@@ -255,11 +616,61 @@ func (c *ipamServiceClient) AcquireIP(ctx context.Context, req *connect.Request[
 	return c.acquireIP.CallUnary(ctx, req)
 }
 
+// AcquireIPs calls api.v1.IpamService.AcquireIPs.
+func (c *ipamServiceClient) AcquireIPs(ctx context.Context, req *connect.Request[v1.AcquireIPsRequest]) (*connect.Response[v1.AcquireIPsResponse], error) {
+	return c.acquireIPs.CallUnary(ctx, req)
+}
+
+// AcquireDualStack calls api.v1.IpamService.AcquireDualStack.
+func (c *ipamServiceClient) AcquireDualStack(ctx context.Context, req *connect.Request[v1.AcquireDualStackRequest]) (*connect.Response[v1.AcquireDualStackResponse], error) {
+	return c.acquireDualStack.CallUnary(ctx, req)
+}
+
+// AcquireIPFromMAC calls api.v1.IpamService.AcquireIPFromMAC.
+func (c *ipamServiceClient) AcquireIPFromMAC(ctx context.Context, req *connect.Request[v1.AcquireIPFromMACRequest]) (*connect.Response[v1.AcquireIPFromMACResponse], error) {
+	return c.acquireIPFromMAC.CallUnary(ctx, req)
+}
+
+// AcquireIPForKey calls api.v1.IpamService.AcquireIPForKey.
+func (c *ipamServiceClient) AcquireIPForKey(ctx context.Context, req *connect.Request[v1.AcquireIPForKeyRequest]) (*connect.Response[v1.AcquireIPForKeyResponse], error) {
+	return c.acquireIPForKey.CallUnary(ctx, req)
+}
+
+// AcquireIPRange calls api.v1.IpamService.AcquireIPRange.
+func (c *ipamServiceClient) AcquireIPRange(ctx context.Context, req *connect.Request[v1.AcquireIPRangeRequest]) (*connect.Response[v1.AcquireIPRangeResponse], error) {
+	return c.acquireIPRange.CallUnary(ctx, req)
+}
+
+// ReleaseIPRange calls api.v1.IpamService.ReleaseIPRange.
+func (c *ipamServiceClient) ReleaseIPRange(ctx context.Context, req *connect.Request[v1.ReleaseIPRangeRequest]) (*connect.Response[v1.ReleaseIPRangeResponse], error) {
+	return c.releaseIPRange.CallUnary(ctx, req)
+}
+
 // ReleaseIP calls api.v1.IpamService.ReleaseIP.
 func (c *ipamServiceClient) ReleaseIP(ctx context.Context, req *connect.Request[v1.ReleaseIPRequest]) (*connect.Response[v1.ReleaseIPResponse], error) {
 	return c.releaseIP.CallUnary(ctx, req)
 }
 
+// GetIP calls api.v1.IpamService.GetIP.
+func (c *ipamServiceClient) GetIP(ctx context.Context, req *connect.Request[v1.GetIPRequest]) (*connect.Response[v1.GetIPResponse], error) {
+	return c.getIP.CallUnary(ctx, req)
+}
+
+// LookupIP calls api.v1.IpamService.LookupIP.
+func (c *ipamServiceClient) LookupIP(ctx context.Context, req *connect.Request[v1.LookupIPRequest]) (*connect.Response[v1.LookupIPResponse], error) {
+	return c.lookupIP.CallUnary(ctx, req)
+}
+
+// RenewLease calls api.v1.IpamService.RenewLease.
+func (c *ipamServiceClient) RenewLease(ctx context.Context, req *connect.Request[v1.RenewLeaseRequest]) (*connect.Response[v1.RenewLeaseResponse], error) {
+	return c.renewLease.CallUnary(ctx, req)
+}
+
+// ListLeases calls api.v1.IpamService.ListLeases.
+func (c *ipamServiceClient) ListLeases(ctx context.Context, req *connect.Request[v1.ListLeasesRequest]) (*connect.Response[v1.ListLeasesResponse], error) {
+	return c.listLeases.CallUnary(ctx, req)
+}
+
 // Dump calls api.v1.IpamService.Dump.
 func (c *ipamServiceClient) Dump(ctx context.Context, req *connect.Request[v1.DumpRequest]) (*connect.Response[v1.DumpResponse], error) {
 	return c.dump.CallUnary(ctx, req)
@@ -285,6 +696,16 @@ func (c *ipamServiceClient) DeleteNamespace(ctx context.Context, req *connect.Re
 	return c.deleteNamespace.CallUnary(ctx, req)
 }
 
+// SetNamespaceQuota calls api.v1.IpamService.SetNamespaceQuota.
+func (c *ipamServiceClient) SetNamespaceQuota(ctx context.Context, req *connect.Request[v1.SetNamespaceQuotaRequest]) (*connect.Response[v1.SetNamespaceQuotaResponse], error) {
+	return c.setNamespaceQuota.CallUnary(ctx, req)
+}
+
+// GetNamespaceQuota calls api.v1.IpamService.GetNamespaceQuota.
+func (c *ipamServiceClient) GetNamespaceQuota(ctx context.Context, req *connect.Request[v1.GetNamespaceQuotaRequest]) (*connect.Response[v1.GetNamespaceQuotaResponse], error) {
+	return c.getNamespaceQuota.CallUnary(ctx, req)
+}
+
 // Version calls api.v1.IpamService.Version.
 func (c *ipamServiceClient) Version(ctx context.Context, req *connect.Request[v1.VersionRequest]) (*connect.Response[v1.VersionResponse], error) {
 	return c.version.CallUnary(ctx, req)
@@ -294,18 +715,45 @@ func (c *ipamServiceClient) Version(ctx context.Context, req *connect.Request[v1
 type IpamServiceHandler interface {
 	CreatePrefix(context.Context, *connect.Request[v1.CreatePrefixRequest]) (*connect.Response[v1.CreatePrefixResponse], error)
 	DeletePrefix(context.Context, *connect.Request[v1.DeletePrefixRequest]) (*connect.Response[v1.DeletePrefixResponse], error)
+	UpdatePrefix(context.Context, *connect.Request[v1.UpdatePrefixRequest]) (*connect.Response[v1.UpdatePrefixResponse], error)
+	ResizePrefix(context.Context, *connect.Request[v1.ResizePrefixRequest]) (*connect.Response[v1.ResizePrefixResponse], error)
+	SplitPrefix(context.Context, *connect.Request[v1.SplitPrefixRequest]) (*connect.Response[v1.SplitPrefixResponse], error)
+	MergePrefixes(context.Context, *connect.Request[v1.MergePrefixesRequest]) (*connect.Response[v1.MergePrefixesResponse], error)
 	GetPrefix(context.Context, *connect.Request[v1.GetPrefixRequest]) (*connect.Response[v1.GetPrefixResponse], error)
 	ListPrefixes(context.Context, *connect.Request[v1.ListPrefixesRequest]) (*connect.Response[v1.ListPrefixesResponse], error)
 	PrefixUsage(context.Context, *connect.Request[v1.PrefixUsageRequest]) (*connect.Response[v1.PrefixUsageResponse], error)
+	GetPrefixTree(context.Context, *connect.Request[v1.GetPrefixTreeRequest]) (*connect.Response[v1.GetPrefixTreeResponse], error)
+	FragmentationReport(context.Context, *connect.Request[v1.FragmentationReportRequest]) (*connect.Response[v1.FragmentationReportResponse], error)
+	PlanDefragmentation(context.Context, *connect.Request[v1.PlanDefragmentationRequest]) (*connect.Response[v1.PlanDefragmentationResponse], error)
+	CreatePool(context.Context, *connect.Request[v1.CreatePoolRequest]) (*connect.Response[v1.CreatePoolResponse], error)
+	UpdatePool(context.Context, *connect.Request[v1.UpdatePoolRequest]) (*connect.Response[v1.UpdatePoolResponse], error)
+	GetPool(context.Context, *connect.Request[v1.GetPoolRequest]) (*connect.Response[v1.GetPoolResponse], error)
+	ListPools(context.Context, *connect.Request[v1.ListPoolsRequest]) (*connect.Response[v1.ListPoolsResponse], error)
+	DeletePool(context.Context, *connect.Request[v1.DeletePoolRequest]) (*connect.Response[v1.DeletePoolResponse], error)
+	AcquireIPFromPool(context.Context, *connect.Request[v1.AcquireIPFromPoolRequest]) (*connect.Response[v1.AcquireIPFromPoolResponse], error)
+	AcquireChildPrefixFromPool(context.Context, *connect.Request[v1.AcquireChildPrefixFromPoolRequest]) (*connect.Response[v1.AcquireChildPrefixFromPoolResponse], error)
 	AcquireChildPrefix(context.Context, *connect.Request[v1.AcquireChildPrefixRequest]) (*connect.Response[v1.AcquireChildPrefixResponse], error)
+	AcquireChildPrefixes(context.Context, *connect.Request[v1.AcquireChildPrefixesRequest]) (*connect.Response[v1.AcquireChildPrefixesResponse], error)
 	ReleaseChildPrefix(context.Context, *connect.Request[v1.ReleaseChildPrefixRequest]) (*connect.Response[v1.ReleaseChildPrefixResponse], error)
 	AcquireIP(context.Context, *connect.Request[v1.AcquireIPRequest]) (*connect.Response[v1.AcquireIPResponse], error)
+	AcquireIPs(context.Context, *connect.Request[v1.AcquireIPsRequest]) (*connect.Response[v1.AcquireIPsResponse], error)
+	AcquireDualStack(context.Context, *connect.Request[v1.AcquireDualStackRequest]) (*connect.Response[v1.AcquireDualStackResponse], error)
+	AcquireIPFromMAC(context.Context, *connect.Request[v1.AcquireIPFromMACRequest]) (*connect.Response[v1.AcquireIPFromMACResponse], error)
+	AcquireIPForKey(context.Context, *connect.Request[v1.AcquireIPForKeyRequest]) (*connect.Response[v1.AcquireIPForKeyResponse], error)
+	AcquireIPRange(context.Context, *connect.Request[v1.AcquireIPRangeRequest]) (*connect.Response[v1.AcquireIPRangeResponse], error)
+	ReleaseIPRange(context.Context, *connect.Request[v1.ReleaseIPRangeRequest]) (*connect.Response[v1.ReleaseIPRangeResponse], error)
 	ReleaseIP(context.Context, *connect.Request[v1.ReleaseIPRequest]) (*connect.Response[v1.ReleaseIPResponse], error)
+	GetIP(context.Context, *connect.Request[v1.GetIPRequest]) (*connect.Response[v1.GetIPResponse], error)
+	LookupIP(context.Context, *connect.Request[v1.LookupIPRequest]) (*connect.Response[v1.LookupIPResponse], error)
+	RenewLease(context.Context, *connect.Request[v1.RenewLeaseRequest]) (*connect.Response[v1.RenewLeaseResponse], error)
+	ListLeases(context.Context, *connect.Request[v1.ListLeasesRequest]) (*connect.Response[v1.ListLeasesResponse], error)
 	Dump(context.Context, *connect.Request[v1.DumpRequest]) (*connect.Response[v1.DumpResponse], error)
 	Load(context.Context, *connect.Request[v1.LoadRequest]) (*connect.Response[v1.LoadResponse], error)
 	CreateNamespace(context.Context, *connect.Request[v1.CreateNamespaceRequest]) (*connect.Response[v1.CreateNamespaceResponse], error)
 	ListNamespaces(context.Context, *connect.Request[v1.ListNamespacesRequest]) (*connect.Response[v1.ListNamespacesResponse], error)
 	DeleteNamespace(context.Context, *connect.Request[v1.DeleteNamespaceRequest]) (*connect.Response[v1.DeleteNamespaceResponse], error)
+	SetNamespaceQuota(context.Context, *connect.Request[v1.SetNamespaceQuotaRequest]) (*connect.Response[v1.SetNamespaceQuotaResponse], error)
+	GetNamespaceQuota(context.Context, *connect.Request[v1.GetNamespaceQuotaRequest]) (*connect.Response[v1.GetNamespaceQuotaResponse], error)
 	Version(context.Context, *connect.Request[v1.VersionRequest]) (*connect.Response[v1.VersionResponse], error)
 }
 
@@ -328,6 +776,30 @@ func NewIpamServiceHandler(svc IpamServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(ipamServiceMethods.ByName("DeletePrefix")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ipamServiceUpdatePrefixHandler := connect.NewUnaryHandler(
+		IpamServiceUpdatePrefixProcedure,
+		svc.UpdatePrefix,
+		connect.WithSchema(ipamServiceMethods.ByName("UpdatePrefix")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceResizePrefixHandler := connect.NewUnaryHandler(
+		IpamServiceResizePrefixProcedure,
+		svc.ResizePrefix,
+		connect.WithSchema(ipamServiceMethods.ByName("ResizePrefix")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceSplitPrefixHandler := connect.NewUnaryHandler(
+		IpamServiceSplitPrefixProcedure,
+		svc.SplitPrefix,
+		connect.WithSchema(ipamServiceMethods.ByName("SplitPrefix")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceMergePrefixesHandler := connect.NewUnaryHandler(
+		IpamServiceMergePrefixesProcedure,
+		svc.MergePrefixes,
+		connect.WithSchema(ipamServiceMethods.ByName("MergePrefixes")),
+		connect.WithHandlerOptions(opts...),
+	)
 	ipamServiceGetPrefixHandler := connect.NewUnaryHandler(
 		IpamServiceGetPrefixProcedure,
 		svc.GetPrefix,
@@ -346,12 +818,78 @@ func NewIpamServiceHandler(svc IpamServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(ipamServiceMethods.ByName("PrefixUsage")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ipamServiceGetPrefixTreeHandler := connect.NewUnaryHandler(
+		IpamServiceGetPrefixTreeProcedure,
+		svc.GetPrefixTree,
+		connect.WithSchema(ipamServiceMethods.ByName("GetPrefixTree")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceFragmentationReportHandler := connect.NewUnaryHandler(
+		IpamServiceFragmentationReportProcedure,
+		svc.FragmentationReport,
+		connect.WithSchema(ipamServiceMethods.ByName("FragmentationReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServicePlanDefragmentationHandler := connect.NewUnaryHandler(
+		IpamServicePlanDefragmentationProcedure,
+		svc.PlanDefragmentation,
+		connect.WithSchema(ipamServiceMethods.ByName("PlanDefragmentation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceCreatePoolHandler := connect.NewUnaryHandler(
+		IpamServiceCreatePoolProcedure,
+		svc.CreatePool,
+		connect.WithSchema(ipamServiceMethods.ByName("CreatePool")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceUpdatePoolHandler := connect.NewUnaryHandler(
+		IpamServiceUpdatePoolProcedure,
+		svc.UpdatePool,
+		connect.WithSchema(ipamServiceMethods.ByName("UpdatePool")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceGetPoolHandler := connect.NewUnaryHandler(
+		IpamServiceGetPoolProcedure,
+		svc.GetPool,
+		connect.WithSchema(ipamServiceMethods.ByName("GetPool")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceListPoolsHandler := connect.NewUnaryHandler(
+		IpamServiceListPoolsProcedure,
+		svc.ListPools,
+		connect.WithSchema(ipamServiceMethods.ByName("ListPools")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceDeletePoolHandler := connect.NewUnaryHandler(
+		IpamServiceDeletePoolProcedure,
+		svc.DeletePool,
+		connect.WithSchema(ipamServiceMethods.ByName("DeletePool")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceAcquireIPFromPoolHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireIPFromPoolProcedure,
+		svc.AcquireIPFromPool,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireIPFromPool")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceAcquireChildPrefixFromPoolHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireChildPrefixFromPoolProcedure,
+		svc.AcquireChildPrefixFromPool,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireChildPrefixFromPool")),
+		connect.WithHandlerOptions(opts...),
+	)
 	ipamServiceAcquireChildPrefixHandler := connect.NewUnaryHandler(
 		IpamServiceAcquireChildPrefixProcedure,
 		svc.AcquireChildPrefix,
 		connect.WithSchema(ipamServiceMethods.ByName("AcquireChildPrefix")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ipamServiceAcquireChildPrefixesHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireChildPrefixesProcedure,
+		svc.AcquireChildPrefixes,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireChildPrefixes")),
+		connect.WithHandlerOptions(opts...),
+	)
 	ipamServiceReleaseChildPrefixHandler := connect.NewUnaryHandler(
 		IpamServiceReleaseChildPrefixProcedure,
 		svc.ReleaseChildPrefix,
@@ -364,12 +902,72 @@ func NewIpamServiceHandler(svc IpamServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(ipamServiceMethods.ByName("AcquireIP")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ipamServiceAcquireIPsHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireIPsProcedure,
+		svc.AcquireIPs,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireIPs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceAcquireDualStackHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireDualStackProcedure,
+		svc.AcquireDualStack,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireDualStack")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceAcquireIPFromMACHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireIPFromMACProcedure,
+		svc.AcquireIPFromMAC,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireIPFromMAC")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceAcquireIPForKeyHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireIPForKeyProcedure,
+		svc.AcquireIPForKey,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireIPForKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceAcquireIPRangeHandler := connect.NewUnaryHandler(
+		IpamServiceAcquireIPRangeProcedure,
+		svc.AcquireIPRange,
+		connect.WithSchema(ipamServiceMethods.ByName("AcquireIPRange")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceReleaseIPRangeHandler := connect.NewUnaryHandler(
+		IpamServiceReleaseIPRangeProcedure,
+		svc.ReleaseIPRange,
+		connect.WithSchema(ipamServiceMethods.ByName("ReleaseIPRange")),
+		connect.WithHandlerOptions(opts...),
+	)
 	ipamServiceReleaseIPHandler := connect.NewUnaryHandler(
 		IpamServiceReleaseIPProcedure,
 		svc.ReleaseIP,
 		connect.WithSchema(ipamServiceMethods.ByName("ReleaseIP")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ipamServiceGetIPHandler := connect.NewUnaryHandler(
+		IpamServiceGetIPProcedure,
+		svc.GetIP,
+		connect.WithSchema(ipamServiceMethods.ByName("GetIP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceLookupIPHandler := connect.NewUnaryHandler(
+		IpamServiceLookupIPProcedure,
+		svc.LookupIP,
+		connect.WithSchema(ipamServiceMethods.ByName("LookupIP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceRenewLeaseHandler := connect.NewUnaryHandler(
+		IpamServiceRenewLeaseProcedure,
+		svc.RenewLease,
+		connect.WithSchema(ipamServiceMethods.ByName("RenewLease")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceListLeasesHandler := connect.NewUnaryHandler(
+		IpamServiceListLeasesProcedure,
+		svc.ListLeases,
+		connect.WithSchema(ipamServiceMethods.ByName("ListLeases")),
+		connect.WithHandlerOptions(opts...),
+	)
 	ipamServiceDumpHandler := connect.NewUnaryHandler(
 		IpamServiceDumpProcedure,
 		svc.Dump,
@@ -400,6 +998,18 @@ func NewIpamServiceHandler(svc IpamServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(ipamServiceMethods.ByName("DeleteNamespace")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ipamServiceSetNamespaceQuotaHandler := connect.NewUnaryHandler(
+		IpamServiceSetNamespaceQuotaProcedure,
+		svc.SetNamespaceQuota,
+		connect.WithSchema(ipamServiceMethods.ByName("SetNamespaceQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ipamServiceGetNamespaceQuotaHandler := connect.NewUnaryHandler(
+		IpamServiceGetNamespaceQuotaProcedure,
+		svc.GetNamespaceQuota,
+		connect.WithSchema(ipamServiceMethods.ByName("GetNamespaceQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
 	ipamServiceVersionHandler := connect.NewUnaryHandler(
 		IpamServiceVersionProcedure,
 		svc.Version,
@@ -412,20 +1022,70 @@ func NewIpamServiceHandler(svc IpamServiceHandler, opts ...connect.HandlerOption
 			ipamServiceCreatePrefixHandler.ServeHTTP(w, r)
 		case IpamServiceDeletePrefixProcedure:
 			ipamServiceDeletePrefixHandler.ServeHTTP(w, r)
+		case IpamServiceUpdatePrefixProcedure:
+			ipamServiceUpdatePrefixHandler.ServeHTTP(w, r)
+		case IpamServiceResizePrefixProcedure:
+			ipamServiceResizePrefixHandler.ServeHTTP(w, r)
+		case IpamServiceSplitPrefixProcedure:
+			ipamServiceSplitPrefixHandler.ServeHTTP(w, r)
+		case IpamServiceMergePrefixesProcedure:
+			ipamServiceMergePrefixesHandler.ServeHTTP(w, r)
 		case IpamServiceGetPrefixProcedure:
 			ipamServiceGetPrefixHandler.ServeHTTP(w, r)
 		case IpamServiceListPrefixesProcedure:
 			ipamServiceListPrefixesHandler.ServeHTTP(w, r)
 		case IpamServicePrefixUsageProcedure:
 			ipamServicePrefixUsageHandler.ServeHTTP(w, r)
+		case IpamServiceGetPrefixTreeProcedure:
+			ipamServiceGetPrefixTreeHandler.ServeHTTP(w, r)
+		case IpamServiceFragmentationReportProcedure:
+			ipamServiceFragmentationReportHandler.ServeHTTP(w, r)
+		case IpamServicePlanDefragmentationProcedure:
+			ipamServicePlanDefragmentationHandler.ServeHTTP(w, r)
+		case IpamServiceCreatePoolProcedure:
+			ipamServiceCreatePoolHandler.ServeHTTP(w, r)
+		case IpamServiceUpdatePoolProcedure:
+			ipamServiceUpdatePoolHandler.ServeHTTP(w, r)
+		case IpamServiceGetPoolProcedure:
+			ipamServiceGetPoolHandler.ServeHTTP(w, r)
+		case IpamServiceListPoolsProcedure:
+			ipamServiceListPoolsHandler.ServeHTTP(w, r)
+		case IpamServiceDeletePoolProcedure:
+			ipamServiceDeletePoolHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireIPFromPoolProcedure:
+			ipamServiceAcquireIPFromPoolHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireChildPrefixFromPoolProcedure:
+			ipamServiceAcquireChildPrefixFromPoolHandler.ServeHTTP(w, r)
 		case IpamServiceAcquireChildPrefixProcedure:
 			ipamServiceAcquireChildPrefixHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireChildPrefixesProcedure:
+			ipamServiceAcquireChildPrefixesHandler.ServeHTTP(w, r)
 		case IpamServiceReleaseChildPrefixProcedure:
 			ipamServiceReleaseChildPrefixHandler.ServeHTTP(w, r)
 		case IpamServiceAcquireIPProcedure:
 			ipamServiceAcquireIPHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireIPsProcedure:
+			ipamServiceAcquireIPsHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireDualStackProcedure:
+			ipamServiceAcquireDualStackHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireIPFromMACProcedure:
+			ipamServiceAcquireIPFromMACHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireIPForKeyProcedure:
+			ipamServiceAcquireIPForKeyHandler.ServeHTTP(w, r)
+		case IpamServiceAcquireIPRangeProcedure:
+			ipamServiceAcquireIPRangeHandler.ServeHTTP(w, r)
+		case IpamServiceReleaseIPRangeProcedure:
+			ipamServiceReleaseIPRangeHandler.ServeHTTP(w, r)
 		case IpamServiceReleaseIPProcedure:
 			ipamServiceReleaseIPHandler.ServeHTTP(w, r)
+		case IpamServiceGetIPProcedure:
+			ipamServiceGetIPHandler.ServeHTTP(w, r)
+		case IpamServiceLookupIPProcedure:
+			ipamServiceLookupIPHandler.ServeHTTP(w, r)
+		case IpamServiceRenewLeaseProcedure:
+			ipamServiceRenewLeaseHandler.ServeHTTP(w, r)
+		case IpamServiceListLeasesProcedure:
+			ipamServiceListLeasesHandler.ServeHTTP(w, r)
 		case IpamServiceDumpProcedure:
 			ipamServiceDumpHandler.ServeHTTP(w, r)
 		case IpamServiceLoadProcedure:
@@ -436,6 +1096,10 @@ func NewIpamServiceHandler(svc IpamServiceHandler, opts ...connect.HandlerOption
 			ipamServiceListNamespacesHandler.ServeHTTP(w, r)
 		case IpamServiceDeleteNamespaceProcedure:
 			ipamServiceDeleteNamespaceHandler.ServeHTTP(w, r)
+		case IpamServiceSetNamespaceQuotaProcedure:
+			ipamServiceSetNamespaceQuotaHandler.ServeHTTP(w, r)
+		case IpamServiceGetNamespaceQuotaProcedure:
+			ipamServiceGetNamespaceQuotaHandler.ServeHTTP(w, r)
 		case IpamServiceVersionProcedure:
 			ipamServiceVersionHandler.ServeHTTP(w, r)
 		default:
@@ -455,6 +1119,22 @@ func (UnimplementedIpamServiceHandler) DeletePrefix(context.Context, *connect.Re
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.DeletePrefix is not implemented"))
 }
 
+func (UnimplementedIpamServiceHandler) UpdatePrefix(context.Context, *connect.Request[v1.UpdatePrefixRequest]) (*connect.Response[v1.UpdatePrefixResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.UpdatePrefix is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) ResizePrefix(context.Context, *connect.Request[v1.ResizePrefixRequest]) (*connect.Response[v1.ResizePrefixResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.ResizePrefix is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) SplitPrefix(context.Context, *connect.Request[v1.SplitPrefixRequest]) (*connect.Response[v1.SplitPrefixResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.SplitPrefix is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) MergePrefixes(context.Context, *connect.Request[v1.MergePrefixesRequest]) (*connect.Response[v1.MergePrefixesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.MergePrefixes is not implemented"))
+}
+
 func (UnimplementedIpamServiceHandler) GetPrefix(context.Context, *connect.Request[v1.GetPrefixRequest]) (*connect.Response[v1.GetPrefixResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.GetPrefix is not implemented"))
 }
@@ -467,10 +1147,54 @@ func (UnimplementedIpamServiceHandler) PrefixUsage(context.Context, *connect.Req
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.PrefixUsage is not implemented"))
 }
 
+func (UnimplementedIpamServiceHandler) GetPrefixTree(context.Context, *connect.Request[v1.GetPrefixTreeRequest]) (*connect.Response[v1.GetPrefixTreeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.GetPrefixTree is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) FragmentationReport(context.Context, *connect.Request[v1.FragmentationReportRequest]) (*connect.Response[v1.FragmentationReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.FragmentationReport is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) PlanDefragmentation(context.Context, *connect.Request[v1.PlanDefragmentationRequest]) (*connect.Response[v1.PlanDefragmentationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.PlanDefragmentation is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) CreatePool(context.Context, *connect.Request[v1.CreatePoolRequest]) (*connect.Response[v1.CreatePoolResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.CreatePool is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) UpdatePool(context.Context, *connect.Request[v1.UpdatePoolRequest]) (*connect.Response[v1.UpdatePoolResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.UpdatePool is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) GetPool(context.Context, *connect.Request[v1.GetPoolRequest]) (*connect.Response[v1.GetPoolResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.GetPool is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) ListPools(context.Context, *connect.Request[v1.ListPoolsRequest]) (*connect.Response[v1.ListPoolsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.ListPools is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) DeletePool(context.Context, *connect.Request[v1.DeletePoolRequest]) (*connect.Response[v1.DeletePoolResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.DeletePool is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) AcquireIPFromPool(context.Context, *connect.Request[v1.AcquireIPFromPoolRequest]) (*connect.Response[v1.AcquireIPFromPoolResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireIPFromPool is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) AcquireChildPrefixFromPool(context.Context, *connect.Request[v1.AcquireChildPrefixFromPoolRequest]) (*connect.Response[v1.AcquireChildPrefixFromPoolResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireChildPrefixFromPool is not implemented"))
+}
+
 func (UnimplementedIpamServiceHandler) AcquireChildPrefix(context.Context, *connect.Request[v1.AcquireChildPrefixRequest]) (*connect.Response[v1.AcquireChildPrefixResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireChildPrefix is not implemented"))
 }
 
+func (UnimplementedIpamServiceHandler) AcquireChildPrefixes(context.Context, *connect.Request[v1.AcquireChildPrefixesRequest]) (*connect.Response[v1.AcquireChildPrefixesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireChildPrefixes is not implemented"))
+}
+
 func (UnimplementedIpamServiceHandler) ReleaseChildPrefix(context.Context, *connect.Request[v1.ReleaseChildPrefixRequest]) (*connect.Response[v1.ReleaseChildPrefixResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.ReleaseChildPrefix is not implemented"))
 }
@@ -479,10 +1203,50 @@ func (UnimplementedIpamServiceHandler) AcquireIP(context.Context, *connect.Reque
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireIP is not implemented"))
 }
 
+func (UnimplementedIpamServiceHandler) AcquireIPs(context.Context, *connect.Request[v1.AcquireIPsRequest]) (*connect.Response[v1.AcquireIPsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireIPs is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) AcquireDualStack(context.Context, *connect.Request[v1.AcquireDualStackRequest]) (*connect.Response[v1.AcquireDualStackResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireDualStack is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) AcquireIPFromMAC(context.Context, *connect.Request[v1.AcquireIPFromMACRequest]) (*connect.Response[v1.AcquireIPFromMACResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireIPFromMAC is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) AcquireIPForKey(context.Context, *connect.Request[v1.AcquireIPForKeyRequest]) (*connect.Response[v1.AcquireIPForKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireIPForKey is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) AcquireIPRange(context.Context, *connect.Request[v1.AcquireIPRangeRequest]) (*connect.Response[v1.AcquireIPRangeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.AcquireIPRange is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) ReleaseIPRange(context.Context, *connect.Request[v1.ReleaseIPRangeRequest]) (*connect.Response[v1.ReleaseIPRangeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.ReleaseIPRange is not implemented"))
+}
+
 func (UnimplementedIpamServiceHandler) ReleaseIP(context.Context, *connect.Request[v1.ReleaseIPRequest]) (*connect.Response[v1.ReleaseIPResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.ReleaseIP is not implemented"))
 }
 
+func (UnimplementedIpamServiceHandler) GetIP(context.Context, *connect.Request[v1.GetIPRequest]) (*connect.Response[v1.GetIPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.GetIP is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) LookupIP(context.Context, *connect.Request[v1.LookupIPRequest]) (*connect.Response[v1.LookupIPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.LookupIP is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) RenewLease(context.Context, *connect.Request[v1.RenewLeaseRequest]) (*connect.Response[v1.RenewLeaseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.RenewLease is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) ListLeases(context.Context, *connect.Request[v1.ListLeasesRequest]) (*connect.Response[v1.ListLeasesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.ListLeases is not implemented"))
+}
+
 func (UnimplementedIpamServiceHandler) Dump(context.Context, *connect.Request[v1.DumpRequest]) (*connect.Response[v1.DumpResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.Dump is not implemented"))
 }
@@ -503,6 +1267,14 @@ func (UnimplementedIpamServiceHandler) DeleteNamespace(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.DeleteNamespace is not implemented"))
 }
 
+func (UnimplementedIpamServiceHandler) SetNamespaceQuota(context.Context, *connect.Request[v1.SetNamespaceQuotaRequest]) (*connect.Response[v1.SetNamespaceQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.SetNamespaceQuota is not implemented"))
+}
+
+func (UnimplementedIpamServiceHandler) GetNamespaceQuota(context.Context, *connect.Request[v1.GetNamespaceQuotaRequest]) (*connect.Response[v1.GetNamespaceQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.GetNamespaceQuota is not implemented"))
+}
+
 func (UnimplementedIpamServiceHandler) Version(context.Context, *connect.Request[v1.VersionRequest]) (*connect.Response[v1.VersionResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.IpamService.Version is not implemented"))
 }
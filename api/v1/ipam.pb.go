@@ -9,6 +9,8 @@ package apiv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,12 +23,265 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// AllocationStrategy defines in which order free ips of a prefix are acquired
+type AllocationStrategy int32
+
+const (
+	// ALLOCATION_STRATEGY_UNSPECIFIED uses the strategy of the prefix, first free if not configured
+	AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED AllocationStrategy = 0
+	// ALLOCATION_STRATEGY_FIRST_FREE acquires the lowest free ip
+	AllocationStrategy_ALLOCATION_STRATEGY_FIRST_FREE AllocationStrategy = 1
+	// ALLOCATION_STRATEGY_LAST_FREE acquires the highest free ip
+	AllocationStrategy_ALLOCATION_STRATEGY_LAST_FREE AllocationStrategy = 2
+	// ALLOCATION_STRATEGY_NEXT_AFTER_LAST acquires the next free ip after the last acquired one
+	AllocationStrategy_ALLOCATION_STRATEGY_NEXT_AFTER_LAST AllocationStrategy = 3
+	// ALLOCATION_STRATEGY_RANDOM acquires a random free ip
+	AllocationStrategy_ALLOCATION_STRATEGY_RANDOM AllocationStrategy = 4
+)
+
+// Enum value maps for AllocationStrategy.
+var (
+	AllocationStrategy_name = map[int32]string{
+		0: "ALLOCATION_STRATEGY_UNSPECIFIED",
+		1: "ALLOCATION_STRATEGY_FIRST_FREE",
+		2: "ALLOCATION_STRATEGY_LAST_FREE",
+		3: "ALLOCATION_STRATEGY_NEXT_AFTER_LAST",
+		4: "ALLOCATION_STRATEGY_RANDOM",
+	}
+	AllocationStrategy_value = map[string]int32{
+		"ALLOCATION_STRATEGY_UNSPECIFIED":     0,
+		"ALLOCATION_STRATEGY_FIRST_FREE":      1,
+		"ALLOCATION_STRATEGY_LAST_FREE":       2,
+		"ALLOCATION_STRATEGY_NEXT_AFTER_LAST": 3,
+		"ALLOCATION_STRATEGY_RANDOM":          4,
+	}
+)
+
+func (x AllocationStrategy) Enum() *AllocationStrategy {
+	p := new(AllocationStrategy)
+	*p = x
+	return p
+}
+
+func (x AllocationStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AllocationStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_ipam_proto_enumTypes[0].Descriptor()
+}
+
+func (AllocationStrategy) Type() protoreflect.EnumType {
+	return &file_api_v1_ipam_proto_enumTypes[0]
+}
+
+func (x AllocationStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AllocationStrategy.Descriptor instead.
+func (AllocationStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{0}
+}
+
+// ChildPrefixStrategy defines from which free block of a prefix child prefixes are acquired
+type ChildPrefixStrategy int32
+
+const (
+	// CHILD_PREFIX_STRATEGY_UNSPECIFIED uses the strategy of the prefix, best fit if not configured
+	ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED ChildPrefixStrategy = 0
+	// CHILD_PREFIX_STRATEGY_BEST_FIT acquires from the smallest free block the child prefix fits into
+	ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_BEST_FIT ChildPrefixStrategy = 1
+	// CHILD_PREFIX_STRATEGY_FIRST_FIT acquires from the lowest free block the child prefix fits into
+	ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_FIRST_FIT ChildPrefixStrategy = 2
+	// CHILD_PREFIX_STRATEGY_SPARSE bisects the prefix to place child prefixes as far away from each other as possible
+	ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_SPARSE ChildPrefixStrategy = 3
+)
+
+// Enum value maps for ChildPrefixStrategy.
+var (
+	ChildPrefixStrategy_name = map[int32]string{
+		0: "CHILD_PREFIX_STRATEGY_UNSPECIFIED",
+		1: "CHILD_PREFIX_STRATEGY_BEST_FIT",
+		2: "CHILD_PREFIX_STRATEGY_FIRST_FIT",
+		3: "CHILD_PREFIX_STRATEGY_SPARSE",
+	}
+	ChildPrefixStrategy_value = map[string]int32{
+		"CHILD_PREFIX_STRATEGY_UNSPECIFIED": 0,
+		"CHILD_PREFIX_STRATEGY_BEST_FIT":    1,
+		"CHILD_PREFIX_STRATEGY_FIRST_FIT":   2,
+		"CHILD_PREFIX_STRATEGY_SPARSE":      3,
+	}
+)
+
+func (x ChildPrefixStrategy) Enum() *ChildPrefixStrategy {
+	p := new(ChildPrefixStrategy)
+	*p = x
+	return p
+}
+
+func (x ChildPrefixStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChildPrefixStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_ipam_proto_enumTypes[1].Descriptor()
+}
+
+func (ChildPrefixStrategy) Type() protoreflect.EnumType {
+	return &file_api_v1_ipam_proto_enumTypes[1]
+}
+
+func (x ChildPrefixStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChildPrefixStrategy.Descriptor instead.
+func (ChildPrefixStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{1}
+}
+
+// PoolStrategy defines in which order the prefixes of a pool are tried
+type PoolStrategy int32
+
+const (
+	// POOL_STRATEGY_UNSPECIFIED tries the prefixes in their order in the pool
+	PoolStrategy_POOL_STRATEGY_UNSPECIFIED PoolStrategy = 0
+	// POOL_STRATEGY_FILL_FIRST tries the prefixes in their order in the pool
+	PoolStrategy_POOL_STRATEGY_FILL_FIRST PoolStrategy = 1
+	// POOL_STRATEGY_SPREAD tries the least utilized prefix first
+	PoolStrategy_POOL_STRATEGY_SPREAD PoolStrategy = 2
+)
+
+// Enum value maps for PoolStrategy.
+var (
+	PoolStrategy_name = map[int32]string{
+		0: "POOL_STRATEGY_UNSPECIFIED",
+		1: "POOL_STRATEGY_FILL_FIRST",
+		2: "POOL_STRATEGY_SPREAD",
+	}
+	PoolStrategy_value = map[string]int32{
+		"POOL_STRATEGY_UNSPECIFIED": 0,
+		"POOL_STRATEGY_FILL_FIRST":  1,
+		"POOL_STRATEGY_SPREAD":      2,
+	}
+)
+
+func (x PoolStrategy) Enum() *PoolStrategy {
+	p := new(PoolStrategy)
+	*p = x
+	return p
+}
+
+func (x PoolStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PoolStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_ipam_proto_enumTypes[2].Descriptor()
+}
+
+func (PoolStrategy) Type() protoreflect.EnumType {
+	return &file_api_v1_ipam_proto_enumTypes[2]
+}
+
+func (x PoolStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PoolStrategy.Descriptor instead.
+func (PoolStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{2}
+}
+
+// IPState is the allocation state of an ip in the deepest prefix containing it
+type IPState int32
+
+const (
+	IPState_IP_STATE_UNSPECIFIED IPState = 0
+	// IP_STATE_FREE is neither acquired nor reserved, excluded or quarantined
+	IPState_IP_STATE_FREE IPState = 1
+	// IP_STATE_ACQUIRED is acquired
+	IPState_IP_STATE_ACQUIRED IPState = 2
+	// IP_STATE_RESERVED is reserved and never acquired
+	IPState_IP_STATE_RESERVED IPState = 3
+	// IP_STATE_EXCLUDED is excluded and only acquired if forced
+	IPState_IP_STATE_EXCLUDED IPState = 4
+	// IP_STATE_QUARANTINED was released and is not acquired again until its quarantine is over
+	IPState_IP_STATE_QUARANTINED IPState = 5
+)
+
+// Enum value maps for IPState.
+var (
+	IPState_name = map[int32]string{
+		0: "IP_STATE_UNSPECIFIED",
+		1: "IP_STATE_FREE",
+		2: "IP_STATE_ACQUIRED",
+		3: "IP_STATE_RESERVED",
+		4: "IP_STATE_EXCLUDED",
+		5: "IP_STATE_QUARANTINED",
+	}
+	IPState_value = map[string]int32{
+		"IP_STATE_UNSPECIFIED": 0,
+		"IP_STATE_FREE":        1,
+		"IP_STATE_ACQUIRED":    2,
+		"IP_STATE_RESERVED":    3,
+		"IP_STATE_EXCLUDED":    4,
+		"IP_STATE_QUARANTINED": 5,
+	}
+)
+
+func (x IPState) Enum() *IPState {
+	p := new(IPState)
+	*p = x
+	return p
+}
+
+func (x IPState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IPState) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_ipam_proto_enumTypes[3].Descriptor()
+}
+
+func (IPState) Type() protoreflect.EnumType {
+	return &file_api_v1_ipam_proto_enumTypes[3]
+}
+
+func (x IPState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IPState.Descriptor instead.
+func (IPState) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{3}
+}
+
 type Prefix struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	ParentCidr    string                 `protobuf:"bytes,2,opt,name=parent_cidr,json=parentCidr,proto3" json:"parent_cidr,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Cidr       string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	ParentCidr string                 `protobuf:"bytes,2,opt,name=parent_cidr,json=parentCidr,proto3" json:"parent_cidr,omitempty"`
+	// Labels are user defined key value pairs which can be used to select prefixes
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Description is a free text description of the prefix
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// ReservedIps are the addresses and ranges of the prefix which are never acquired
+	ReservedIps []string `protobuf:"bytes,5,rep,name=reserved_ips,json=reservedIps,proto3" json:"reserved_ips,omitempty"`
+	// AllocationStrategy defines in which order ips of the prefix are acquired
+	AllocationStrategy AllocationStrategy `protobuf:"varint,6,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Exclusions are the addresses and ranges of the prefix which are only acquired if forced
+	Exclusions []string `protobuf:"bytes,7,rep,name=exclusions,proto3" json:"exclusions,omitempty"`
+	// Lease is set if this child prefix was acquired with a ttl
+	Lease *Lease `protobuf:"bytes,8,opt,name=lease,proto3" json:"lease,omitempty"`
+	// Quarantine is the duration released ips are not acquired again
+	Quarantine *durationpb.Duration `protobuf:"bytes,9,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// Policy restricts the child prefixes and ips acquired from the prefix
+	Policy *Policy `protobuf:"bytes,10,opt,name=policy,proto3" json:"policy,omitempty"`
+	// ChildPrefixStrategy defines from which free block child prefixes of the prefix are acquired
+	ChildPrefixStrategy ChildPrefixStrategy `protobuf:"varint,11,opt,name=child_prefix_strategy,json=childPrefixStrategy,proto3,enum=api.v1.ChildPrefixStrategy" json:"child_prefix_strategy,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Prefix) Reset() {
@@ -73,27 +328,99 @@ func (x *Prefix) GetParentCidr() string {
 	return ""
 }
 
-type CreatePrefixResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+func (x *Prefix) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Prefix) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Prefix) GetReservedIps() []string {
+	if x != nil {
+		return x.ReservedIps
+	}
+	return nil
+}
+
+func (x *Prefix) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *Prefix) GetExclusions() []string {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *Prefix) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+func (x *Prefix) GetQuarantine() *durationpb.Duration {
+	if x != nil {
+		return x.Quarantine
+	}
+	return nil
+}
+
+func (x *Prefix) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *Prefix) GetChildPrefixStrategy() ChildPrefixStrategy {
+	if x != nil {
+		return x.ChildPrefixStrategy
+	}
+	return ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED
+}
+
+// ReservedIPs configures which addresses of a prefix are never acquired.
+// If given, only the configured addresses are reserved, an empty message reserves nothing,
+// e.g. for /31 and /32 point-to-point links and loopback pools.
+// If not given, the first address and for IPv4 the broadcast address are reserved.
+type ReservedIPs struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First reserves the first n addresses of the prefix
+	First uint64 `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	// Last reserves the last n addresses of the prefix
+	Last uint64 `protobuf:"varint,2,opt,name=last,proto3" json:"last,omitempty"`
+	// Ranges reserves single addresses, ranges in the form "10.0.0.1-10.0.0.10" and cidrs
+	Ranges        []string `protobuf:"bytes,3,rep,name=ranges,proto3" json:"ranges,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePrefixResponse) Reset() {
-	*x = CreatePrefixResponse{}
+func (x *ReservedIPs) Reset() {
+	*x = ReservedIPs{}
 	mi := &file_api_v1_ipam_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePrefixResponse) String() string {
+func (x *ReservedIPs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePrefixResponse) ProtoMessage() {}
+func (*ReservedIPs) ProtoMessage() {}
 
-func (x *CreatePrefixResponse) ProtoReflect() protoreflect.Message {
+func (x *ReservedIPs) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_ipam_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -105,39 +432,57 @@ func (x *CreatePrefixResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePrefixResponse.ProtoReflect.Descriptor instead.
-func (*CreatePrefixResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReservedIPs.ProtoReflect.Descriptor instead.
+func (*ReservedIPs) Descriptor() ([]byte, []int) {
 	return file_api_v1_ipam_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreatePrefixResponse) GetPrefix() *Prefix {
+func (x *ReservedIPs) GetFirst() uint64 {
 	if x != nil {
-		return x.Prefix
+		return x.First
+	}
+	return 0
+}
+
+func (x *ReservedIPs) GetLast() uint64 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+func (x *ReservedIPs) GetRanges() []string {
+	if x != nil {
+		return x.Ranges
 	}
 	return nil
 }
 
-type DeletePrefixResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+// Exclusions configures which addresses of a prefix are skipped on acquisition, e.g. dhcp ranges.
+// Excluded addresses are only acquired if the acquisition is forced.
+// If given, the existing exclusions are replaced, an empty message removes all exclusions.
+type Exclusions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ranges excludes single addresses, ranges in the form "10.0.0.1-10.0.0.10" and cidrs
+	Ranges        []string `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletePrefixResponse) Reset() {
-	*x = DeletePrefixResponse{}
+func (x *Exclusions) Reset() {
+	*x = Exclusions{}
 	mi := &file_api_v1_ipam_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePrefixResponse) String() string {
+func (x *Exclusions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePrefixResponse) ProtoMessage() {}
+func (*Exclusions) ProtoMessage() {}
 
-func (x *DeletePrefixResponse) ProtoReflect() protoreflect.Message {
+func (x *Exclusions) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_ipam_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -149,39 +494,47 @@ func (x *DeletePrefixResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePrefixResponse.ProtoReflect.Descriptor instead.
-func (*DeletePrefixResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Exclusions.ProtoReflect.Descriptor instead.
+func (*Exclusions) Descriptor() ([]byte, []int) {
 	return file_api_v1_ipam_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *DeletePrefixResponse) GetPrefix() *Prefix {
+func (x *Exclusions) GetRanges() []string {
 	if x != nil {
-		return x.Prefix
+		return x.Ranges
 	}
 	return nil
 }
 
-type GetPrefixResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+// Policy restricts the allocations from a parent prefix, a zero value does not restrict anything.
+type Policy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MinChildLength is the smallest length of child prefixes acquired from the prefix
+	MinChildLength uint32 `protobuf:"varint,1,opt,name=min_child_length,json=minChildLength,proto3" json:"min_child_length,omitempty"`
+	// MaxChildLength is the biggest length of child prefixes acquired from the prefix
+	MaxChildLength uint32 `protobuf:"varint,2,opt,name=max_child_length,json=maxChildLength,proto3" json:"max_child_length,omitempty"`
+	// MaxChildPrefixes is the maximum number of child prefixes acquired from the prefix at the same time
+	MaxChildPrefixes uint64 `protobuf:"varint,3,opt,name=max_child_prefixes,json=maxChildPrefixes,proto3" json:"max_child_prefixes,omitempty"`
+	// DenyIps denies the acquisition of ips from the prefix
+	DenyIps       bool `protobuf:"varint,4,opt,name=deny_ips,json=denyIps,proto3" json:"deny_ips,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPrefixResponse) Reset() {
-	*x = GetPrefixResponse{}
+func (x *Policy) Reset() {
+	*x = Policy{}
 	mi := &file_api_v1_ipam_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPrefixResponse) String() string {
+func (x *Policy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPrefixResponse) ProtoMessage() {}
+func (*Policy) ProtoMessage() {}
 
-func (x *GetPrefixResponse) ProtoReflect() protoreflect.Message {
+func (x *Policy) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_ipam_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -193,39 +546,60 @@ func (x *GetPrefixResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPrefixResponse.ProtoReflect.Descriptor instead.
-func (*GetPrefixResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
 	return file_api_v1_ipam_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetPrefixResponse) GetPrefix() *Prefix {
+func (x *Policy) GetMinChildLength() uint32 {
 	if x != nil {
-		return x.Prefix
+		return x.MinChildLength
 	}
-	return nil
+	return 0
 }
 
-type AcquireChildPrefixResponse struct {
+func (x *Policy) GetMaxChildLength() uint32 {
+	if x != nil {
+		return x.MaxChildLength
+	}
+	return 0
+}
+
+func (x *Policy) GetMaxChildPrefixes() uint64 {
+	if x != nil {
+		return x.MaxChildPrefixes
+	}
+	return 0
+}
+
+func (x *Policy) GetDenyIps() bool {
+	if x != nil {
+		return x.DenyIps
+	}
+	return false
+}
+
+type CreatePrefixResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AcquireChildPrefixResponse) Reset() {
-	*x = AcquireChildPrefixResponse{}
+func (x *CreatePrefixResponse) Reset() {
+	*x = CreatePrefixResponse{}
 	mi := &file_api_v1_ipam_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcquireChildPrefixResponse) String() string {
+func (x *CreatePrefixResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcquireChildPrefixResponse) ProtoMessage() {}
+func (*CreatePrefixResponse) ProtoMessage() {}
 
-func (x *AcquireChildPrefixResponse) ProtoReflect() protoreflect.Message {
+func (x *CreatePrefixResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_ipam_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,40 +611,4236 @@ func (x *AcquireChildPrefixResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcquireChildPrefixResponse.ProtoReflect.Descriptor instead.
-func (*AcquireChildPrefixResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreatePrefixResponse.ProtoReflect.Descriptor instead.
+func (*CreatePrefixResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_ipam_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AcquireChildPrefixResponse) GetPrefix() *Prefix {
+func (x *CreatePrefixResponse) GetPrefix() *Prefix {
 	if x != nil {
 		return x.Prefix
 	}
 	return nil
 }
 
-type ReleaseChildPrefixResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type DeletePrefixResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// DeletedPrefixes are all deleted prefixes, descendants before their parents and the requested prefix last
+	DeletedPrefixes []*Prefix `protobuf:"bytes,2,rep,name=deleted_prefixes,json=deletedPrefixes,proto3" json:"deleted_prefixes,omitempty"`
+	// ReleasedIps are the released ips of all deleted prefixes which have annotations or a lease
+	ReleasedIps []*IP `protobuf:"bytes,3,rep,name=released_ips,json=releasedIps,proto3" json:"released_ips,omitempty"`
+	// ReleasedIpRanges are the acquired ips of all deleted prefixes as addresses and ranges by the cidr of their prefix
+	ReleasedIpRanges map[string]*IPRanges `protobuf:"bytes,4,rep,name=released_ip_ranges,json=releasedIpRanges,proto3" json:"released_ip_ranges,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ReleaseChildPrefixResponse) Reset() {
-	*x = ReleaseChildPrefixResponse{}
+func (x *DeletePrefixResponse) Reset() {
+	*x = DeletePrefixResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePrefixResponse) ProtoMessage() {}
+
+func (x *DeletePrefixResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_ipam_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePrefixResponse.ProtoReflect.Descriptor instead.
+func (*DeletePrefixResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeletePrefixResponse) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *DeletePrefixResponse) GetDeletedPrefixes() []*Prefix {
+	if x != nil {
+		return x.DeletedPrefixes
+	}
+	return nil
+}
+
+func (x *DeletePrefixResponse) GetReleasedIps() []*IP {
+	if x != nil {
+		return x.ReleasedIps
+	}
+	return nil
+}
+
+func (x *DeletePrefixResponse) GetReleasedIpRanges() map[string]*IPRanges {
+	if x != nil {
+		return x.ReleasedIpRanges
+	}
+	return nil
+}
+
+// IPRanges are addresses and ranges of ips
+type IPRanges struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ranges        []string               `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPRanges) Reset() {
+	*x = IPRanges{}
+	mi := &file_api_v1_ipam_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPRanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPRanges) ProtoMessage() {}
+
+func (x *IPRanges) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPRanges.ProtoReflect.Descriptor instead.
+func (*IPRanges) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IPRanges) GetRanges() []string {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+type UpdatePrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePrefixResponse) Reset() {
+	*x = UpdatePrefixResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePrefixResponse) ProtoMessage() {}
+
+func (x *UpdatePrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePrefixResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePrefixResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdatePrefixResponse) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+type GetPrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrefixResponse) Reset() {
+	*x = GetPrefixResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrefixResponse) ProtoMessage() {}
+
+func (x *GetPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrefixResponse.ProtoReflect.Descriptor instead.
+func (*GetPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPrefixResponse) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+type AcquireChildPrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireChildPrefixResponse) Reset() {
+	*x = AcquireChildPrefixResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireChildPrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireChildPrefixResponse) ProtoMessage() {}
+
+func (x *AcquireChildPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireChildPrefixResponse.ProtoReflect.Descriptor instead.
+func (*AcquireChildPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AcquireChildPrefixResponse) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+type AcquireChildPrefixesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefixes      []*Prefix              `protobuf:"bytes,1,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireChildPrefixesResponse) Reset() {
+	*x = AcquireChildPrefixesResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireChildPrefixesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireChildPrefixesResponse) ProtoMessage() {}
+
+func (x *AcquireChildPrefixesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireChildPrefixesResponse.ProtoReflect.Descriptor instead.
+func (*AcquireChildPrefixesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AcquireChildPrefixesResponse) GetPrefixes() []*Prefix {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+type ReleaseChildPrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseChildPrefixResponse) Reset() {
+	*x = ReleaseChildPrefixResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseChildPrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseChildPrefixResponse) ProtoMessage() {}
+
+func (x *ReleaseChildPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseChildPrefixResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseChildPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReleaseChildPrefixResponse) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+type CreatePrefixRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Cidr        string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Namespace   *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Description *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// ReservedIps configures the addresses which are never acquired
+	ReservedIps *ReservedIPs `protobuf:"bytes,5,opt,name=reserved_ips,json=reservedIps,proto3" json:"reserved_ips,omitempty"`
+	// AllocationStrategy defines in which order ips of the prefix are acquired
+	AllocationStrategy AllocationStrategy `protobuf:"varint,6,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Exclusions configures the addresses which are skipped on acquisition
+	Exclusions *Exclusions `protobuf:"bytes,7,opt,name=exclusions,proto3" json:"exclusions,omitempty"`
+	// Quarantine is the duration released ips are not acquired again
+	Quarantine *durationpb.Duration `protobuf:"bytes,8,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original prefix
+	IdempotencyKey *string `protobuf:"bytes,9,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	// Policy restricts the child prefixes and ips acquired from the prefix
+	Policy *Policy `protobuf:"bytes,10,opt,name=policy,proto3" json:"policy,omitempty"`
+	// ChildPrefixStrategy defines from which free block child prefixes of the prefix are acquired
+	ChildPrefixStrategy ChildPrefixStrategy `protobuf:"varint,11,opt,name=child_prefix_strategy,json=childPrefixStrategy,proto3,enum=api.v1.ChildPrefixStrategy" json:"child_prefix_strategy,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreatePrefixRequest) Reset() {
+	*x = CreatePrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePrefixRequest) ProtoMessage() {}
+
+func (x *CreatePrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePrefixRequest.ProtoReflect.Descriptor instead.
+func (*CreatePrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreatePrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *CreatePrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *CreatePrefixRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreatePrefixRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreatePrefixRequest) GetReservedIps() *ReservedIPs {
+	if x != nil {
+		return x.ReservedIps
+	}
+	return nil
+}
+
+func (x *CreatePrefixRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *CreatePrefixRequest) GetExclusions() *Exclusions {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *CreatePrefixRequest) GetQuarantine() *durationpb.Duration {
+	if x != nil {
+		return x.Quarantine
+	}
+	return nil
+}
+
+func (x *CreatePrefixRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreatePrefixRequest) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *CreatePrefixRequest) GetChildPrefixStrategy() ChildPrefixStrategy {
+	if x != nil {
+		return x.ChildPrefixStrategy
+	}
+	return ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED
+}
+
+type DeletePrefixRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Cidr      string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Namespace *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Recursive deletes the prefix even if it has ips, together with all its descendants
+	Recursive bool `protobuf:"varint,3,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	// DryRun only lists what would be deleted, nothing is changed
+	DryRun        bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePrefixRequest) Reset() {
+	*x = DeletePrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePrefixRequest) ProtoMessage() {}
+
+func (x *DeletePrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePrefixRequest.ProtoReflect.Descriptor instead.
+func (*DeletePrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeletePrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *DeletePrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DeletePrefixRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+func (x *DeletePrefixRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type UpdatePrefixRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Cidr      string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Namespace *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Labels replace the existing labels of the prefix, they are left untouched if empty
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Description replaces the existing description of the prefix if given
+	Description *string `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// ReservedIps replaces the existing reserved addresses of the prefix if given
+	ReservedIps *ReservedIPs `protobuf:"bytes,5,opt,name=reserved_ips,json=reservedIps,proto3" json:"reserved_ips,omitempty"`
+	// AllocationStrategy replaces the existing allocation strategy of the prefix if specified
+	AllocationStrategy AllocationStrategy `protobuf:"varint,6,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Exclusions replaces the existing exclusions of the prefix if given
+	Exclusions *Exclusions `protobuf:"bytes,7,opt,name=exclusions,proto3" json:"exclusions,omitempty"`
+	// Quarantine replaces the existing quarantine of the prefix if given, a duration of 0 disables it
+	Quarantine *durationpb.Duration `protobuf:"bytes,8,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// Policy replaces the existing policy of the prefix if given, an empty policy removes it
+	Policy *Policy `protobuf:"bytes,9,opt,name=policy,proto3" json:"policy,omitempty"`
+	// ChildPrefixStrategy replaces the existing child prefix strategy of the prefix if specified
+	ChildPrefixStrategy ChildPrefixStrategy `protobuf:"varint,10,opt,name=child_prefix_strategy,json=childPrefixStrategy,proto3,enum=api.v1.ChildPrefixStrategy" json:"child_prefix_strategy,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UpdatePrefixRequest) Reset() {
+	*x = UpdatePrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePrefixRequest) ProtoMessage() {}
+
+func (x *UpdatePrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePrefixRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdatePrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *UpdatePrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *UpdatePrefixRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdatePrefixRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdatePrefixRequest) GetReservedIps() *ReservedIPs {
+	if x != nil {
+		return x.ReservedIps
+	}
+	return nil
+}
+
+func (x *UpdatePrefixRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *UpdatePrefixRequest) GetExclusions() *Exclusions {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *UpdatePrefixRequest) GetQuarantine() *durationpb.Duration {
+	if x != nil {
+		return x.Quarantine
+	}
+	return nil
+}
+
+func (x *UpdatePrefixRequest) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *UpdatePrefixRequest) GetChildPrefixStrategy() ChildPrefixStrategy {
+	if x != nil {
+		return x.ChildPrefixStrategy
+	}
+	return ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED
+}
+
+type ResizePrefixRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Cidr  string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// Length is the new prefix length, a smaller length grows the prefix and a bigger length shrinks it
+	Length        uint32  `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	Namespace     *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResizePrefixRequest) Reset() {
+	*x = ResizePrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResizePrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResizePrefixRequest) ProtoMessage() {}
+
+func (x *ResizePrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResizePrefixRequest.ProtoReflect.Descriptor instead.
+func (*ResizePrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResizePrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *ResizePrefixRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *ResizePrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type ResizePrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResizePrefixResponse) Reset() {
+	*x = ResizePrefixResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResizePrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResizePrefixResponse) ProtoMessage() {}
+
+func (x *ResizePrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResizePrefixResponse.ProtoReflect.Descriptor instead.
+func (*ResizePrefixResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResizePrefixResponse) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+type SplitPrefixRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Cidr  string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// Length is the length of the parts, it must be greater than the length of the prefix
+	Length        uint32  `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	Namespace     *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitPrefixRequest) Reset() {
+	*x = SplitPrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitPrefixRequest) ProtoMessage() {}
+
+func (x *SplitPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitPrefixRequest.ProtoReflect.Descriptor instead.
+func (*SplitPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SplitPrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *SplitPrefixRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *SplitPrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type SplitPrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefixes      []*Prefix              `protobuf:"bytes,1,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitPrefixResponse) Reset() {
+	*x = SplitPrefixResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitPrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitPrefixResponse) ProtoMessage() {}
+
+func (x *SplitPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitPrefixResponse.ProtoReflect.Descriptor instead.
+func (*SplitPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SplitPrefixResponse) GetPrefixes() []*Prefix {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+type MergePrefixesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cidrs are the adjacent prefixes with the same parent which are merged into their supernet
+	Cidrs         []string `protobuf:"bytes,1,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+	Namespace     *string  `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePrefixesRequest) Reset() {
+	*x = MergePrefixesRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePrefixesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePrefixesRequest) ProtoMessage() {}
+
+func (x *MergePrefixesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePrefixesRequest.ProtoReflect.Descriptor instead.
+func (*MergePrefixesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MergePrefixesRequest) GetCidrs() []string {
+	if x != nil {
+		return x.Cidrs
+	}
+	return nil
+}
+
+func (x *MergePrefixesRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type MergePrefixesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePrefixesResponse) Reset() {
+	*x = MergePrefixesResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePrefixesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePrefixesResponse) ProtoMessage() {}
+
+func (x *MergePrefixesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePrefixesResponse.ProtoReflect.Descriptor instead.
+func (*MergePrefixesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MergePrefixesResponse) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+type GetPrefixRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrefixRequest) Reset() {
+	*x = GetPrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrefixRequest) ProtoMessage() {}
+
+func (x *GetPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrefixRequest.ProtoReflect.Descriptor instead.
+func (*GetPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *GetPrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type ListPrefixesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace *string                `protobuf:"bytes,1,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// LabelSelector is a kubernetes style label selector, e.g. "site=fra1,purpose=underlay"
+	LabelSelector *string `protobuf:"bytes,2,opt,name=label_selector,json=labelSelector,proto3,oneof" json:"label_selector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPrefixesRequest) Reset() {
+	*x = ListPrefixesRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPrefixesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrefixesRequest) ProtoMessage() {}
+
+func (x *ListPrefixesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrefixesRequest.ProtoReflect.Descriptor instead.
+func (*ListPrefixesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPrefixesRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *ListPrefixesRequest) GetLabelSelector() string {
+	if x != nil && x.LabelSelector != nil {
+		return *x.LabelSelector
+	}
+	return ""
+}
+
+type ListPrefixesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefixes      []*Prefix              `protobuf:"bytes,1,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPrefixesResponse) Reset() {
+	*x = ListPrefixesResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPrefixesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrefixesResponse) ProtoMessage() {}
+
+func (x *ListPrefixesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrefixesResponse.ProtoReflect.Descriptor instead.
+func (*ListPrefixesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListPrefixesResponse) GetPrefixes() []*Prefix {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+type PrefixUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrefixUsageRequest) Reset() {
+	*x = PrefixUsageRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrefixUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixUsageRequest) ProtoMessage() {}
+
+func (x *PrefixUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixUsageRequest.ProtoReflect.Descriptor instead.
+func (*PrefixUsageRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PrefixUsageRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *PrefixUsageRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type PrefixUsageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// AvailableIPs the number of available IPs if this is not a parent prefix
+	// No more than 2^31 available IPs are reported, see available_ips_exact for the exact number
+	AvailableIps uint64 `protobuf:"varint,1,opt,name=available_ips,json=availableIps,proto3" json:"available_ips,omitempty"`
+	// AcquiredIPs the number of acquired IPs if this is not a parent prefix
+	AcquiredIps uint64 `protobuf:"varint,2,opt,name=acquired_ips,json=acquiredIps,proto3" json:"acquired_ips,omitempty"`
+	// AvailableSmallestPrefixes is the count of available Prefixes with 2 countable Bits
+	// No more than 2^31 available Prefixes are reported, see available_smallest_prefixes_exact for the exact number
+	AvailableSmallestPrefixes uint64 `protobuf:"varint,3,opt,name=available_smallest_prefixes,json=availableSmallestPrefixes,proto3" json:"available_smallest_prefixes,omitempty"`
+	// AvailablePrefixes is a list of prefixes which are available
+	AvailablePrefixes []string `protobuf:"bytes,4,rep,name=available_prefixes,json=availablePrefixes,proto3" json:"available_prefixes,omitempty"`
+	// AcquiredPrefixes the number of acquired prefixes if this is a parent prefix
+	AcquiredPrefixes uint64 `protobuf:"varint,5,opt,name=acquired_prefixes,json=acquiredPrefixes,proto3" json:"acquired_prefixes,omitempty"`
+	// ReservedIPs the number of reserved IPs which are never acquired
+	// No more than 2^31 reserved IPs are reported, see reserved_ips_exact for the exact number
+	ReservedIps uint64 `protobuf:"varint,6,opt,name=reserved_ips,json=reservedIps,proto3" json:"reserved_ips,omitempty"`
+	// ExcludedIPs the number of excluded IPs which are only acquired if forced
+	// No more than 2^31 excluded IPs are reported, see excluded_ips_exact for the exact number
+	ExcludedIps uint64 `protobuf:"varint,7,opt,name=excluded_ips,json=excludedIps,proto3" json:"excluded_ips,omitempty"`
+	// QuarantinedIPs the number of released IPs which are not acquired again until their quarantine is over
+	QuarantinedIps uint64 `protobuf:"varint,8,opt,name=quarantined_ips,json=quarantinedIps,proto3" json:"quarantined_ips,omitempty"`
+	// AvailableIPsExact the exact number of available IPs as decimal string, which is not limited to 2^31
+	AvailableIpsExact string `protobuf:"bytes,9,opt,name=available_ips_exact,json=availableIpsExact,proto3" json:"available_ips_exact,omitempty"`
+	// ReservedIPsExact the exact number of reserved IPs as decimal string, which is not limited to 2^31
+	ReservedIpsExact string `protobuf:"bytes,10,opt,name=reserved_ips_exact,json=reservedIpsExact,proto3" json:"reserved_ips_exact,omitempty"`
+	// ExcludedIPsExact the exact number of excluded IPs as decimal string, which is not limited to 2^31
+	ExcludedIpsExact string `protobuf:"bytes,11,opt,name=excluded_ips_exact,json=excludedIpsExact,proto3" json:"excluded_ips_exact,omitempty"`
+	// AvailableSmallestPrefixesExact the exact count of available Prefixes with 2 countable Bits as decimal string, which is not limited to 2^31
+	AvailableSmallestPrefixesExact string `protobuf:"bytes,12,opt,name=available_smallest_prefixes_exact,json=availableSmallestPrefixesExact,proto3" json:"available_smallest_prefixes_exact,omitempty"`
+	// FreeIPs the exact number of IPs which can still be acquired as decimal string, 0 for parent prefixes
+	FreeIps string `protobuf:"bytes,13,opt,name=free_ips,json=freeIps,proto3" json:"free_ips,omitempty"`
+	// FreeIPsNotation the free IPs relative to the nearest power of two, e.g. "2^64 - 3"
+	FreeIpsNotation string `protobuf:"bytes,14,opt,name=free_ips_notation,json=freeIpsNotation,proto3" json:"free_ips_notation,omitempty"`
+	// AvailableIPsNotation the available IPs relative to the nearest power of two, e.g. "2^64"
+	AvailableIpsNotation string `protobuf:"bytes,15,opt,name=available_ips_notation,json=availableIpsNotation,proto3" json:"available_ips_notation,omitempty"`
+	// Utilization the percentage of acquired IPs of all IPs which are neither reserved nor excluded,
+	// for a parent prefix the percentage of its addresses covered by acquired child prefixes
+	Utilization   float64 `protobuf:"fixed64,16,opt,name=utilization,proto3" json:"utilization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrefixUsageResponse) Reset() {
+	*x = PrefixUsageResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrefixUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixUsageResponse) ProtoMessage() {}
+
+func (x *PrefixUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixUsageResponse.ProtoReflect.Descriptor instead.
+func (*PrefixUsageResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PrefixUsageResponse) GetAvailableIps() uint64 {
+	if x != nil {
+		return x.AvailableIps
+	}
+	return 0
+}
+
+func (x *PrefixUsageResponse) GetAcquiredIps() uint64 {
+	if x != nil {
+		return x.AcquiredIps
+	}
+	return 0
+}
+
+func (x *PrefixUsageResponse) GetAvailableSmallestPrefixes() uint64 {
+	if x != nil {
+		return x.AvailableSmallestPrefixes
+	}
+	return 0
+}
+
+func (x *PrefixUsageResponse) GetAvailablePrefixes() []string {
+	if x != nil {
+		return x.AvailablePrefixes
+	}
+	return nil
+}
+
+func (x *PrefixUsageResponse) GetAcquiredPrefixes() uint64 {
+	if x != nil {
+		return x.AcquiredPrefixes
+	}
+	return 0
+}
+
+func (x *PrefixUsageResponse) GetReservedIps() uint64 {
+	if x != nil {
+		return x.ReservedIps
+	}
+	return 0
+}
+
+func (x *PrefixUsageResponse) GetExcludedIps() uint64 {
+	if x != nil {
+		return x.ExcludedIps
+	}
+	return 0
+}
+
+func (x *PrefixUsageResponse) GetQuarantinedIps() uint64 {
+	if x != nil {
+		return x.QuarantinedIps
+	}
+	return 0
+}
+
+func (x *PrefixUsageResponse) GetAvailableIpsExact() string {
+	if x != nil {
+		return x.AvailableIpsExact
+	}
+	return ""
+}
+
+func (x *PrefixUsageResponse) GetReservedIpsExact() string {
+	if x != nil {
+		return x.ReservedIpsExact
+	}
+	return ""
+}
+
+func (x *PrefixUsageResponse) GetExcludedIpsExact() string {
+	if x != nil {
+		return x.ExcludedIpsExact
+	}
+	return ""
+}
+
+func (x *PrefixUsageResponse) GetAvailableSmallestPrefixesExact() string {
+	if x != nil {
+		return x.AvailableSmallestPrefixesExact
+	}
+	return ""
+}
+
+func (x *PrefixUsageResponse) GetFreeIps() string {
+	if x != nil {
+		return x.FreeIps
+	}
+	return ""
+}
+
+func (x *PrefixUsageResponse) GetFreeIpsNotation() string {
+	if x != nil {
+		return x.FreeIpsNotation
+	}
+	return ""
+}
+
+func (x *PrefixUsageResponse) GetAvailableIpsNotation() string {
+	if x != nil {
+		return x.AvailableIpsNotation
+	}
+	return ""
+}
+
+func (x *PrefixUsageResponse) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+type GetPrefixTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cidr of the root prefix, if empty the trees of all top level prefixes are returned
+	Cidr *string `protobuf:"bytes,1,opt,name=cidr,proto3,oneof" json:"cidr,omitempty"`
+	// Depth limits the number of child levels below the root, 0 returns all levels
+	Depth         uint32  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	Namespace     *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrefixTreeRequest) Reset() {
+	*x = GetPrefixTreeRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrefixTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrefixTreeRequest) ProtoMessage() {}
+
+func (x *GetPrefixTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrefixTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetPrefixTreeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetPrefixTreeRequest) GetCidr() string {
+	if x != nil && x.Cidr != nil {
+		return *x.Cidr
+	}
+	return ""
+}
+
+func (x *GetPrefixTreeRequest) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *GetPrefixTreeRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type GetPrefixTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*PrefixNode          `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPrefixTreeResponse) Reset() {
+	*x = GetPrefixTreeResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPrefixTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrefixTreeResponse) ProtoMessage() {}
+
+func (x *GetPrefixTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrefixTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetPrefixTreeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetPrefixTreeResponse) GetNodes() []*PrefixNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type PrefixNode struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Usage  *PrefixUsageResponse   `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	// Children are the child prefixes acquired from this prefix ordered by their network address
+	Children      []*PrefixNode `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrefixNode) Reset() {
+	*x = PrefixNode{}
+	mi := &file_api_v1_ipam_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrefixNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixNode) ProtoMessage() {}
+
+func (x *PrefixNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixNode.ProtoReflect.Descriptor instead.
+func (*PrefixNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PrefixNode) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *PrefixNode) GetUsage() *PrefixUsageResponse {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *PrefixNode) GetChildren() []*PrefixNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type FragmentationReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FragmentationReportRequest) Reset() {
+	*x = FragmentationReportRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FragmentationReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FragmentationReportRequest) ProtoMessage() {}
+
+func (x *FragmentationReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FragmentationReportRequest.ProtoReflect.Descriptor instead.
+func (*FragmentationReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FragmentationReportRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *FragmentationReportRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type FragmentationReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Cidr  string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// LargestFreeLength is the shortest length of a child prefix which can still be acquired, 0 if no child prefix can be acquired
+	LargestFreeLength uint32 `protobuf:"varint,2,opt,name=largest_free_length,json=largestFreeLength,proto3" json:"largest_free_length,omitempty"`
+	// FreeBlocks is the number of free blocks by their length, the free address space is split into the largest aligned blocks
+	FreeBlocks map[uint32]uint64 `protobuf:"bytes,3,rep,name=free_blocks,json=freeBlocks,proto3" json:"free_blocks,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// FreeAddresses the exact number of addresses which are neither part of an acquired child prefix nor excluded as decimal string
+	FreeAddresses string `protobuf:"bytes,4,opt,name=free_addresses,json=freeAddresses,proto3" json:"free_addresses,omitempty"`
+	// FreeAddressesNotation the free addresses relative to the nearest power of two, e.g. "2^64 - 3"
+	FreeAddressesNotation string `protobuf:"bytes,5,opt,name=free_addresses_notation,json=freeAddressesNotation,proto3" json:"free_addresses_notation,omitempty"`
+	// Score is 0 if all free addresses are in a single block and approaches 1 the more the free address space is scattered
+	Score         float64 `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FragmentationReportResponse) Reset() {
+	*x = FragmentationReportResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FragmentationReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FragmentationReportResponse) ProtoMessage() {}
+
+func (x *FragmentationReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FragmentationReportResponse.ProtoReflect.Descriptor instead.
+func (*FragmentationReportResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FragmentationReportResponse) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *FragmentationReportResponse) GetLargestFreeLength() uint32 {
+	if x != nil {
+		return x.LargestFreeLength
+	}
+	return 0
+}
+
+func (x *FragmentationReportResponse) GetFreeBlocks() map[uint32]uint64 {
+	if x != nil {
+		return x.FreeBlocks
+	}
+	return nil
+}
+
+func (x *FragmentationReportResponse) GetFreeAddresses() string {
+	if x != nil {
+		return x.FreeAddresses
+	}
+	return ""
+}
+
+func (x *FragmentationReportResponse) GetFreeAddressesNotation() string {
+	if x != nil {
+		return x.FreeAddressesNotation
+	}
+	return ""
+}
+
+func (x *FragmentationReportResponse) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type PlanDefragmentationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Cidr  string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// Length of the contiguous block to free up
+	Length        uint32  `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	Namespace     *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanDefragmentationRequest) Reset() {
+	*x = PlanDefragmentationRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanDefragmentationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanDefragmentationRequest) ProtoMessage() {}
+
+func (x *PlanDefragmentationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanDefragmentationRequest.ProtoReflect.Descriptor instead.
+func (*PlanDefragmentationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PlanDefragmentationRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *PlanDefragmentationRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *PlanDefragmentationRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type PlanDefragmentationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Block is the contiguous block which is free after all moves are done
+	Block string `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// Moves are the child prefixes to renumber, empty if the block is already free
+	Moves         []*ChildPrefixMove `protobuf:"bytes,2,rep,name=moves,proto3" json:"moves,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanDefragmentationResponse) Reset() {
+	*x = PlanDefragmentationResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanDefragmentationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanDefragmentationResponse) ProtoMessage() {}
+
+func (x *PlanDefragmentationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanDefragmentationResponse.ProtoReflect.Descriptor instead.
+func (*PlanDefragmentationResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PlanDefragmentationResponse) GetBlock() string {
+	if x != nil {
+		return x.Block
+	}
+	return ""
+}
+
+func (x *PlanDefragmentationResponse) GetMoves() []*ChildPrefixMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+// ChildPrefixMove proposes to renumber a child prefix to a free prefix of the same length
+type ChildPrefixMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChildPrefixMove) Reset() {
+	*x = ChildPrefixMove{}
+	mi := &file_api_v1_ipam_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChildPrefixMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChildPrefixMove) ProtoMessage() {}
+
+func (x *ChildPrefixMove) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChildPrefixMove.ProtoReflect.Descriptor instead.
+func (*ChildPrefixMove) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ChildPrefixMove) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ChildPrefixMove) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type AcquireChildPrefixRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Cidr        string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Length      uint32                 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	ChildCidr   *string                `protobuf:"bytes,3,opt,name=child_cidr,json=childCidr,proto3,oneof" json:"child_cidr,omitempty"`
+	Namespace   *string                `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Description *string                `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// ReservedIps configures the addresses of the child prefix which are never acquired
+	ReservedIps *ReservedIPs `protobuf:"bytes,7,opt,name=reserved_ips,json=reservedIps,proto3" json:"reserved_ips,omitempty"`
+	// AllocationStrategy defines in which order ips of the child prefix are acquired
+	AllocationStrategy AllocationStrategy `protobuf:"varint,8,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Exclusions configures the addresses of the child prefix which are skipped on acquisition
+	Exclusions *Exclusions `protobuf:"bytes,9,opt,name=exclusions,proto3" json:"exclusions,omitempty"`
+	// Ttl leases the child prefix, it is released after the ttl unless the lease is renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,10,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Quarantine is the duration released ips of the child prefix are not acquired again
+	Quarantine *durationpb.Duration `protobuf:"bytes,11,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original child prefix
+	IdempotencyKey *string `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	// Placement overrides the child prefix strategy of the parent prefix for this request
+	Placement     ChildPrefixStrategy `protobuf:"varint,13,opt,name=placement,proto3,enum=api.v1.ChildPrefixStrategy" json:"placement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireChildPrefixRequest) Reset() {
+	*x = AcquireChildPrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireChildPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireChildPrefixRequest) ProtoMessage() {}
+
+func (x *AcquireChildPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireChildPrefixRequest.ProtoReflect.Descriptor instead.
+func (*AcquireChildPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AcquireChildPrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *AcquireChildPrefixRequest) GetChildCidr() string {
+	if x != nil && x.ChildCidr != nil {
+		return *x.ChildCidr
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixRequest) GetReservedIps() *ReservedIPs {
+	if x != nil {
+		return x.ReservedIps
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *AcquireChildPrefixRequest) GetExclusions() *Exclusions {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixRequest) GetQuarantine() *durationpb.Duration {
+	if x != nil {
+		return x.Quarantine
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixRequest) GetPlacement() ChildPrefixStrategy {
+	if x != nil {
+		return x.Placement
+	}
+	return ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED
+}
+
+type AcquireChildPrefixesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Cidr   string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Length uint32                 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	// Count is the number of child prefixes to acquire, either all or none are acquired
+	Count       uint32            `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Namespace   *string           `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Description *string           `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// ReservedIps configures the addresses of the child prefixes which are never acquired
+	ReservedIps *ReservedIPs `protobuf:"bytes,7,opt,name=reserved_ips,json=reservedIps,proto3" json:"reserved_ips,omitempty"`
+	// AllocationStrategy defines in which order ips of the child prefixes are acquired
+	AllocationStrategy AllocationStrategy `protobuf:"varint,8,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Exclusions configures the addresses of the child prefixes which are skipped on acquisition
+	Exclusions *Exclusions `protobuf:"bytes,9,opt,name=exclusions,proto3" json:"exclusions,omitempty"`
+	// Ttl leases the child prefixes, they are released after the ttl unless the leases are renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,10,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Quarantine is the duration released ips of the child prefixes are not acquired again
+	Quarantine *durationpb.Duration `protobuf:"bytes,11,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original child prefixes
+	IdempotencyKey *string `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	// Placement overrides the child prefix strategy of the parent prefix for this request
+	Placement     ChildPrefixStrategy `protobuf:"varint,13,opt,name=placement,proto3,enum=api.v1.ChildPrefixStrategy" json:"placement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireChildPrefixesRequest) Reset() {
+	*x = AcquireChildPrefixesRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireChildPrefixesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireChildPrefixesRequest) ProtoMessage() {}
+
+func (x *AcquireChildPrefixesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireChildPrefixesRequest.ProtoReflect.Descriptor instead.
+func (*AcquireChildPrefixesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AcquireChildPrefixesRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixesRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *AcquireChildPrefixesRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AcquireChildPrefixesRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixesRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixesRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixesRequest) GetReservedIps() *ReservedIPs {
+	if x != nil {
+		return x.ReservedIps
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixesRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *AcquireChildPrefixesRequest) GetExclusions() *Exclusions {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixesRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixesRequest) GetQuarantine() *durationpb.Duration {
+	if x != nil {
+		return x.Quarantine
+	}
+	return nil
+}
+
+func (x *AcquireChildPrefixesRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AcquireChildPrefixesRequest) GetPlacement() ChildPrefixStrategy {
+	if x != nil {
+		return x.Placement
+	}
+	return ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED
+}
+
+type ReleaseChildPrefixRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseChildPrefixRequest) Reset() {
+	*x = ReleaseChildPrefixRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseChildPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseChildPrefixRequest) ProtoMessage() {}
+
+func (x *ReleaseChildPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseChildPrefixRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseChildPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReleaseChildPrefixRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *ReleaseChildPrefixRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type IP struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Ip           string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	ParentPrefix string                 `protobuf:"bytes,2,opt,name=parent_prefix,json=parentPrefix,proto3" json:"parent_prefix,omitempty"`
+	// Annotations carry arbitrary metadata like owner, hostname or description of this ip
+	Annotations map[string]string `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Lease is set if this ip was acquired with a ttl
+	Lease         *Lease `protobuf:"bytes,4,opt,name=lease,proto3" json:"lease,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IP) Reset() {
+	*x = IP{}
+	mi := &file_api_v1_ipam_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IP) ProtoMessage() {}
+
+func (x *IP) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IP.ProtoReflect.Descriptor instead.
+func (*IP) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *IP) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *IP) GetParentPrefix() string {
+	if x != nil {
+		return x.ParentPrefix
+	}
+	return ""
+}
+
+func (x *IP) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *IP) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+// Lease limits the lifetime of an acquired ip or child prefix
+type Lease struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentPrefix string                 `protobuf:"bytes,2,opt,name=parent_prefix,json=parentPrefix,proto3" json:"parent_prefix,omitempty"`
+	// Ip is the leased ip, empty for child prefix leases
+	Ip string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	// Cidr is the leased child prefix, empty for ip leases
+	Cidr string `protobuf:"bytes,4,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// Expires is the time after which the ip or child prefix is released unless the lease is renewed
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lease) Reset() {
+	*x = Lease{}
+	mi := &file_api_v1_ipam_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lease) ProtoMessage() {}
+
+func (x *Lease) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lease.ProtoReflect.Descriptor instead.
+func (*Lease) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Lease) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Lease) GetParentPrefix() string {
+	if x != nil {
+		return x.ParentPrefix
+	}
+	return ""
+}
+
+func (x *Lease) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Lease) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *Lease) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+type AcquireIPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireIPResponse) Reset() {
+	*x = AcquireIPResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPResponse) ProtoMessage() {}
+
+func (x *AcquireIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPResponse.ProtoReflect.Descriptor instead.
+func (*AcquireIPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AcquireIPResponse) GetIp() *IP {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+func (x *AcquireIPResponse) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type ReleaseIPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseIPResponse) Reset() {
+	*x = ReleaseIPResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseIPResponse) ProtoMessage() {}
+
+func (x *ReleaseIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseIPResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseIPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReleaseIPResponse) GetIp() *IP {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+type AcquireIPRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	PrefixCidr string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	// Ip is acquired instead of the next free ip, even if it is quarantined
+	Ip        *string `protobuf:"bytes,2,opt,name=ip,proto3,oneof" json:"ip,omitempty"`
+	Namespace *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Annotations are stored with the acquired ip
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// AllocationStrategy overrides the allocation strategy of the prefix for this request
+	AllocationStrategy AllocationStrategy `protobuf:"varint,5,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Force acquires the given ip even if it is excluded in the prefix
+	Force bool `protobuf:"varint,6,opt,name=force,proto3" json:"force,omitempty"`
+	// Ttl leases the ip, it is released after the ttl unless the lease is renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original ip
+	IdempotencyKey *string `protobuf:"bytes,8,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AcquireIPRequest) Reset() {
+	*x = AcquireIPRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPRequest) ProtoMessage() {}
+
+func (x *AcquireIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPRequest.ProtoReflect.Descriptor instead.
+func (*AcquireIPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AcquireIPRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *AcquireIPRequest) GetIp() string {
+	if x != nil && x.Ip != nil {
+		return *x.Ip
+	}
+	return ""
+}
+
+func (x *AcquireIPRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireIPRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *AcquireIPRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *AcquireIPRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *AcquireIPRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireIPRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+type AcquireIPsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	PrefixCidr string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	// Count is the number of ips to acquire, either all or none are acquired
+	Count     uint32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Namespace *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Annotations are stored with every acquired ip
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// AllocationStrategy overrides the allocation strategy of the prefix for this request
+	AllocationStrategy AllocationStrategy `protobuf:"varint,5,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Ttl leases the ips, they are released after the ttl unless the leases are renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original ips
+	IdempotencyKey *string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AcquireIPsRequest) Reset() {
+	*x = AcquireIPsRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPsRequest) ProtoMessage() {}
+
+func (x *AcquireIPsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPsRequest.ProtoReflect.Descriptor instead.
+func (*AcquireIPsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AcquireIPsRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *AcquireIPsRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AcquireIPsRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireIPsRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *AcquireIPsRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *AcquireIPsRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireIPsRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+type AcquireIPsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ips           []*IP                  `protobuf:"bytes,1,rep,name=ips,proto3" json:"ips,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireIPsResponse) Reset() {
+	*x = AcquireIPsResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPsResponse) ProtoMessage() {}
+
+func (x *AcquireIPsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPsResponse.ProtoReflect.Descriptor instead.
+func (*AcquireIPsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AcquireIPsResponse) GetIps() []*IP {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+type AcquireDualStackRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ipv4PrefixCidr string                 `protobuf:"bytes,1,opt,name=ipv4_prefix_cidr,json=ipv4PrefixCidr,proto3" json:"ipv4_prefix_cidr,omitempty"`
+	Ipv6PrefixCidr string                 `protobuf:"bytes,2,opt,name=ipv6_prefix_cidr,json=ipv6PrefixCidr,proto3" json:"ipv6_prefix_cidr,omitempty"`
+	Namespace      *string                `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Annotations are stored with both acquired ips
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// AllocationStrategy overrides the allocation strategy of the prefixes for this request
+	AllocationStrategy AllocationStrategy `protobuf:"varint,5,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Ttl leases both ips, they are released after the ttl unless the leases are renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original ips
+	IdempotencyKey *string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	// EmbedIpv4 derives the ipv6 address from the ipv4 address, which is embedded into the last bits of the ipv6 prefix
+	EmbedIpv4     bool `protobuf:"varint,8,opt,name=embed_ipv4,json=embedIpv4,proto3" json:"embed_ipv4,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireDualStackRequest) Reset() {
+	*x = AcquireDualStackRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireDualStackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireDualStackRequest) ProtoMessage() {}
+
+func (x *AcquireDualStackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireDualStackRequest.ProtoReflect.Descriptor instead.
+func (*AcquireDualStackRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AcquireDualStackRequest) GetIpv4PrefixCidr() string {
+	if x != nil {
+		return x.Ipv4PrefixCidr
+	}
+	return ""
+}
+
+func (x *AcquireDualStackRequest) GetIpv6PrefixCidr() string {
+	if x != nil {
+		return x.Ipv6PrefixCidr
+	}
+	return ""
+}
+
+func (x *AcquireDualStackRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireDualStackRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *AcquireDualStackRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *AcquireDualStackRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireDualStackRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AcquireDualStackRequest) GetEmbedIpv4() bool {
+	if x != nil {
+		return x.EmbedIpv4
+	}
+	return false
+}
+
+type AcquireDualStackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ipv4          *IP                    `protobuf:"bytes,1,opt,name=ipv4,proto3" json:"ipv4,omitempty"`
+	Ipv6          *IP                    `protobuf:"bytes,2,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireDualStackResponse) Reset() {
+	*x = AcquireDualStackResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireDualStackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireDualStackResponse) ProtoMessage() {}
+
+func (x *AcquireDualStackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireDualStackResponse.ProtoReflect.Descriptor instead.
+func (*AcquireDualStackResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AcquireDualStackResponse) GetIpv4() *IP {
+	if x != nil {
+		return x.Ipv4
+	}
+	return nil
+}
+
+func (x *AcquireDualStackResponse) GetIpv6() *IP {
+	if x != nil {
+		return x.Ipv6
+	}
+	return nil
+}
+
+type AcquireIPFromMACRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// PrefixCidr is the ipv6 prefix, it must not be longer than 64 bits
+	PrefixCidr string `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	// Mac is the eui-48 or eui-64 address the modified eui-64 interface identifier is computed from
+	Mac       string  `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	Namespace *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Annotations are stored with the acquired ip
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Ttl leases the ip, it is released after the ttl unless the lease is renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original ip
+	IdempotencyKey *string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AcquireIPFromMACRequest) Reset() {
+	*x = AcquireIPFromMACRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPFromMACRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPFromMACRequest) ProtoMessage() {}
+
+func (x *AcquireIPFromMACRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPFromMACRequest.ProtoReflect.Descriptor instead.
+func (*AcquireIPFromMACRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AcquireIPFromMACRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *AcquireIPFromMACRequest) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *AcquireIPFromMACRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireIPFromMACRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *AcquireIPFromMACRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireIPFromMACRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+type AcquireIPFromMACResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireIPFromMACResponse) Reset() {
+	*x = AcquireIPFromMACResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPFromMACResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPFromMACResponse) ProtoMessage() {}
+
+func (x *AcquireIPFromMACResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPFromMACResponse.ProtoReflect.Descriptor instead.
+func (*AcquireIPFromMACResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AcquireIPFromMACResponse) GetIp() *IP {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+type AcquireIPForKeyRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	PrefixCidr string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	// Key is hashed to the acquired ip and stored with it, a repeated request with the same key returns this ip
+	Key       string  `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Namespace *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Annotations are stored with the acquired ip
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Ttl leases the ip, it is released after the ttl unless the lease is renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original ip
+	IdempotencyKey *string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AcquireIPForKeyRequest) Reset() {
+	*x = AcquireIPForKeyRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPForKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPForKeyRequest) ProtoMessage() {}
+
+func (x *AcquireIPForKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPForKeyRequest.ProtoReflect.Descriptor instead.
+func (*AcquireIPForKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AcquireIPForKeyRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *AcquireIPForKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AcquireIPForKeyRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireIPForKeyRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *AcquireIPForKeyRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireIPForKeyRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+type AcquireIPForKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireIPForKeyResponse) Reset() {
+	*x = AcquireIPForKeyResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPForKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPForKeyResponse) ProtoMessage() {}
+
+func (x *AcquireIPForKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPForKeyResponse.ProtoReflect.Descriptor instead.
+func (*AcquireIPForKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AcquireIPForKeyResponse) GetIp() *IP {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+// IPRange is a range of consecutive ips acquired together
+type IPRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	ParentPrefix  string                 `protobuf:"bytes,3,opt,name=parent_prefix,json=parentPrefix,proto3" json:"parent_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPRange) Reset() {
+	*x = IPRange{}
+	mi := &file_api_v1_ipam_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPRange) ProtoMessage() {}
+
+func (x *IPRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPRange.ProtoReflect.Descriptor instead.
+func (*IPRange) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *IPRange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *IPRange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *IPRange) GetParentPrefix() string {
+	if x != nil {
+		return x.ParentPrefix
+	}
+	return ""
+}
+
+type AcquireIPRangeRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	PrefixCidr string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	// Count is the number of consecutive ips to acquire
+	Count         uint32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Namespace     *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireIPRangeRequest) Reset() {
+	*x = AcquireIPRangeRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPRangeRequest) ProtoMessage() {}
+
+func (x *AcquireIPRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPRangeRequest.ProtoReflect.Descriptor instead.
+func (*AcquireIPRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AcquireIPRangeRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *AcquireIPRangeRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AcquireIPRangeRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type AcquireIPRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IpRange       *IPRange               `protobuf:"bytes,1,opt,name=ip_range,json=ipRange,proto3" json:"ip_range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireIPRangeResponse) Reset() {
+	*x = AcquireIPRangeResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireIPRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireIPRangeResponse) ProtoMessage() {}
+
+func (x *AcquireIPRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireIPRangeResponse.ProtoReflect.Descriptor instead.
+func (*AcquireIPRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AcquireIPRangeResponse) GetIpRange() *IPRange {
+	if x != nil {
+		return x.IpRange
+	}
+	return nil
+}
+
+type ReleaseIPRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrefixCidr    string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Namespace     *string                `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseIPRangeRequest) Reset() {
+	*x = ReleaseIPRangeRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseIPRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseIPRangeRequest) ProtoMessage() {}
+
+func (x *ReleaseIPRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseIPRangeRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseIPRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ReleaseIPRangeRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *ReleaseIPRangeRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ReleaseIPRangeRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ReleaseIPRangeRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type ReleaseIPRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IpRange       *IPRange               `protobuf:"bytes,1,opt,name=ip_range,json=ipRange,proto3" json:"ip_range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseIPRangeResponse) Reset() {
+	*x = ReleaseIPRangeResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseIPRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseIPRangeResponse) ProtoMessage() {}
+
+func (x *ReleaseIPRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseIPRangeResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseIPRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ReleaseIPRangeResponse) GetIpRange() *IPRange {
+	if x != nil {
+		return x.IpRange
+	}
+	return nil
+}
+
+type ReleaseIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrefixCidr    string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Namespace     *string                `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseIPRequest) Reset() {
+	*x = ReleaseIPRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseIPRequest) ProtoMessage() {}
+
+func (x *ReleaseIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseIPRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseIPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ReleaseIPRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ReleaseIPRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type GetIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrefixCidr    string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Namespace     *string                `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIPRequest) Reset() {
+	*x = GetIPRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIPRequest) ProtoMessage() {}
+
+func (x *GetIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIPRequest.ProtoReflect.Descriptor instead.
+func (*GetIPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetIPRequest) GetPrefixCidr() string {
+	if x != nil {
+		return x.PrefixCidr
+	}
+	return ""
+}
+
+func (x *GetIPRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *GetIPRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type GetIPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIPResponse) Reset() {
+	*x = GetIPResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIPResponse) ProtoMessage() {}
+
+func (x *GetIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIPResponse.ProtoReflect.Descriptor instead.
+func (*GetIPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetIPResponse) GetIp() *IP {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+type LookupIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupIPRequest) Reset() {
+	*x = LookupIPRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupIPRequest) ProtoMessage() {}
+
+func (x *LookupIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupIPRequest.ProtoReflect.Descriptor instead.
+func (*LookupIPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *LookupIPRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LookupIPRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type LookupIPResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ip in the deepest prefix, annotations and lease are set if it is acquired
+	Ip *IP `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	// Prefixes containing the ip, from the top level prefix down to the deepest one
+	Prefixes      []*Prefix `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	State         IPState   `protobuf:"varint,3,opt,name=state,proto3,enum=api.v1.IPState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupIPResponse) Reset() {
+	*x = LookupIPResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupIPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupIPResponse) ProtoMessage() {}
+
+func (x *LookupIPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupIPResponse.ProtoReflect.Descriptor instead.
+func (*LookupIPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *LookupIPResponse) GetIp() *IP {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+func (x *LookupIPResponse) GetPrefixes() []*Prefix {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+func (x *LookupIPResponse) GetState() IPState {
+	if x != nil {
+		return x.State
+	}
+	return IPState_IP_STATE_UNSPECIFIED
+}
+
+type RenewLeaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Ttl is the new lifetime of the lease starting now
+	Ttl           *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Namespace     *string              `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewLeaseRequest) Reset() {
+	*x = RenewLeaseRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewLeaseRequest) ProtoMessage() {}
+
+func (x *RenewLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewLeaseRequest.ProtoReflect.Descriptor instead.
+func (*RenewLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RenewLeaseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RenewLeaseRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *RenewLeaseRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type RenewLeaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lease         *Lease                 `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewLeaseResponse) Reset() {
+	*x = RenewLeaseResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewLeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewLeaseResponse) ProtoMessage() {}
+
+func (x *RenewLeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewLeaseResponse.ProtoReflect.Descriptor instead.
+func (*RenewLeaseResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RenewLeaseResponse) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+type ListLeasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     *string                `protobuf:"bytes,1,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLeasesRequest) Reset() {
+	*x = ListLeasesRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeasesRequest) ProtoMessage() {}
+
+func (x *ListLeasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeasesRequest.ProtoReflect.Descriptor instead.
+func (*ListLeasesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListLeasesRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type ListLeasesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Leases ordered by their expiry
+	Leases        []*Lease `protobuf:"bytes,1,rep,name=leases,proto3" json:"leases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLeasesResponse) Reset() {
+	*x = ListLeasesResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeasesResponse) ProtoMessage() {}
+
+func (x *ListLeasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeasesResponse.ProtoReflect.Descriptor instead.
+func (*ListLeasesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListLeasesResponse) GetLeases() []*Lease {
+	if x != nil {
+		return x.Leases
+	}
+	return nil
+}
+
+type DumpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     *string                `protobuf:"bytes,1,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpRequest) Reset() {
+	*x = DumpRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpRequest) ProtoMessage() {}
+
+func (x *DumpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpRequest.ProtoReflect.Descriptor instead.
+func (*DumpRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DumpRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type DumpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dump          string                 `protobuf:"bytes,1,opt,name=dump,proto3" json:"dump,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpResponse) Reset() {
+	*x = DumpResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpResponse) ProtoMessage() {}
+
+func (x *DumpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpResponse.ProtoReflect.Descriptor instead.
+func (*DumpResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DumpResponse) GetDump() string {
+	if x != nil {
+		return x.Dump
+	}
+	return ""
+}
+
+type LoadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dump          string                 `protobuf:"bytes,1,opt,name=dump,proto3" json:"dump,omitempty"`
+	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadRequest) Reset() {
+	*x = LoadRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadRequest) ProtoMessage() {}
+
+func (x *LoadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadRequest.ProtoReflect.Descriptor instead.
+func (*LoadRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *LoadRequest) GetDump() string {
+	if x != nil {
+		return x.Dump
+	}
+	return ""
+}
+
+func (x *LoadRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type LoadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadResponse) Reset() {
+	*x = LoadResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadResponse) ProtoMessage() {}
+
+func (x *LoadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadResponse.ProtoReflect.Descriptor instead.
+func (*LoadResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{67}
+}
+
+// Pool is a named group of prefixes, ips and child prefixes are acquired from any of them
+type Pool struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Prefixes are the cidrs of the members of the pool
+	Prefixes      []string     `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Strategy      PoolStrategy `protobuf:"varint,3,opt,name=strategy,proto3,enum=api.v1.PoolStrategy" json:"strategy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pool) Reset() {
+	*x = Pool{}
+	mi := &file_api_v1_ipam_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pool) ProtoMessage() {}
+
+func (x *Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pool.ProtoReflect.Descriptor instead.
+func (*Pool) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Pool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pool) GetPrefixes() []string {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+func (x *Pool) GetStrategy() PoolStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return PoolStrategy_POOL_STRATEGY_UNSPECIFIED
+}
+
+type CreatePoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Prefixes      []string               `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Strategy      PoolStrategy           `protobuf:"varint,3,opt,name=strategy,proto3,enum=api.v1.PoolStrategy" json:"strategy,omitempty"`
+	Namespace     *string                `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePoolRequest) Reset() {
+	*x = CreatePoolRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePoolRequest) ProtoMessage() {}
+
+func (x *CreatePoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePoolRequest.ProtoReflect.Descriptor instead.
+func (*CreatePoolRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreatePoolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePoolRequest) GetPrefixes() []string {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+func (x *CreatePoolRequest) GetStrategy() PoolStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return PoolStrategy_POOL_STRATEGY_UNSPECIFIED
+}
+
+func (x *CreatePoolRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type CreatePoolResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pool          *Pool                  `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePoolResponse) Reset() {
+	*x = CreatePoolResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePoolResponse) ProtoMessage() {}
+
+func (x *CreatePoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePoolResponse.ProtoReflect.Descriptor instead.
+func (*CreatePoolResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CreatePoolResponse) GetPool() *Pool {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+type UpdatePoolRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Prefixes replace all members of the pool
+	Prefixes      []string     `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Strategy      PoolStrategy `protobuf:"varint,3,opt,name=strategy,proto3,enum=api.v1.PoolStrategy" json:"strategy,omitempty"`
+	Namespace     *string      `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePoolRequest) Reset() {
+	*x = UpdatePoolRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseChildPrefixResponse) String() string {
+func (x *UpdatePoolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseChildPrefixResponse) ProtoMessage() {}
+func (*UpdatePoolRequest) ProtoMessage() {}
 
-func (x *ReleaseChildPrefixResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[5]
+func (x *UpdatePoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,41 +4851,61 @@ func (x *ReleaseChildPrefixResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseChildPrefixResponse.ProtoReflect.Descriptor instead.
-func (*ReleaseChildPrefixResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UpdatePoolRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePoolRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *ReleaseChildPrefixResponse) GetPrefix() *Prefix {
+func (x *UpdatePoolRequest) GetName() string {
 	if x != nil {
-		return x.Prefix
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePoolRequest) GetPrefixes() []string {
+	if x != nil {
+		return x.Prefixes
 	}
 	return nil
 }
 
-type CreatePrefixRequest struct {
+func (x *UpdatePoolRequest) GetStrategy() PoolStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return PoolStrategy_POOL_STRATEGY_UNSPECIFIED
+}
+
+func (x *UpdatePoolRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type UpdatePoolResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Pool          *Pool                  `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePrefixRequest) Reset() {
-	*x = CreatePrefixRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[6]
+func (x *UpdatePoolResponse) Reset() {
+	*x = UpdatePoolResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePrefixRequest) String() string {
+func (x *UpdatePoolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePrefixRequest) ProtoMessage() {}
+func (*UpdatePoolResponse) ProtoMessage() {}
 
-func (x *CreatePrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[6]
+func (x *UpdatePoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,48 +4916,41 @@ func (x *CreatePrefixRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePrefixRequest.ProtoReflect.Descriptor instead.
-func (*CreatePrefixRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UpdatePoolResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePoolResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *CreatePrefixRequest) GetCidr() string {
+func (x *UpdatePoolResponse) GetPool() *Pool {
 	if x != nil {
-		return x.Cidr
-	}
-	return ""
-}
-
-func (x *CreatePrefixRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+		return x.Pool
 	}
-	return ""
+	return nil
 }
 
-type DeletePrefixRequest struct {
+type GetPoolRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletePrefixRequest) Reset() {
-	*x = DeletePrefixRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[7]
+func (x *GetPoolRequest) Reset() {
+	*x = GetPoolRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePrefixRequest) String() string {
+func (x *GetPoolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePrefixRequest) ProtoMessage() {}
+func (*GetPoolRequest) ProtoMessage() {}
 
-func (x *DeletePrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[7]
+func (x *GetPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -378,48 +4961,47 @@ func (x *DeletePrefixRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePrefixRequest.ProtoReflect.Descriptor instead.
-func (*DeletePrefixRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetPoolRequest.ProtoReflect.Descriptor instead.
+func (*GetPoolRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *DeletePrefixRequest) GetCidr() string {
+func (x *GetPoolRequest) GetName() string {
 	if x != nil {
-		return x.Cidr
+		return x.Name
 	}
 	return ""
 }
 
-func (x *DeletePrefixRequest) GetNamespace() string {
+func (x *GetPoolRequest) GetNamespace() string {
 	if x != nil && x.Namespace != nil {
 		return *x.Namespace
 	}
 	return ""
 }
 
-type GetPrefixRequest struct {
+type GetPoolResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Pool          *Pool                  `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPrefixRequest) Reset() {
-	*x = GetPrefixRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[8]
+func (x *GetPoolResponse) Reset() {
+	*x = GetPoolResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPrefixRequest) String() string {
+func (x *GetPoolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPrefixRequest) ProtoMessage() {}
+func (*GetPoolResponse) ProtoMessage() {}
 
-func (x *GetPrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[8]
+func (x *GetPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,47 +5012,40 @@ func (x *GetPrefixRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPrefixRequest.ProtoReflect.Descriptor instead.
-func (*GetPrefixRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetPoolResponse.ProtoReflect.Descriptor instead.
+func (*GetPoolResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *GetPrefixRequest) GetCidr() string {
+func (x *GetPoolResponse) GetPool() *Pool {
 	if x != nil {
-		return x.Cidr
-	}
-	return ""
-}
-
-func (x *GetPrefixRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+		return x.Pool
 	}
-	return ""
+	return nil
 }
 
-type ListPrefixesRequest struct {
+type ListPoolsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Namespace     *string                `protobuf:"bytes,1,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPrefixesRequest) Reset() {
-	*x = ListPrefixesRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[9]
+func (x *ListPoolsRequest) Reset() {
+	*x = ListPoolsRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPrefixesRequest) String() string {
+func (x *ListPoolsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPrefixesRequest) ProtoMessage() {}
+func (*ListPoolsRequest) ProtoMessage() {}
 
-func (x *ListPrefixesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[9]
+func (x *ListPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,40 +5056,40 @@ func (x *ListPrefixesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPrefixesRequest.ProtoReflect.Descriptor instead.
-func (*ListPrefixesRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListPoolsRequest.ProtoReflect.Descriptor instead.
+func (*ListPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *ListPrefixesRequest) GetNamespace() string {
+func (x *ListPoolsRequest) GetNamespace() string {
 	if x != nil && x.Namespace != nil {
 		return *x.Namespace
 	}
 	return ""
 }
 
-type ListPrefixesResponse struct {
+type ListPoolsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prefixes      []*Prefix              `protobuf:"bytes,1,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Pools         []*Pool                `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPrefixesResponse) Reset() {
-	*x = ListPrefixesResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[10]
+func (x *ListPoolsResponse) Reset() {
+	*x = ListPoolsResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPrefixesResponse) String() string {
+func (x *ListPoolsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPrefixesResponse) ProtoMessage() {}
+func (*ListPoolsResponse) ProtoMessage() {}
 
-func (x *ListPrefixesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[10]
+func (x *ListPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,41 +5100,41 @@ func (x *ListPrefixesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPrefixesResponse.ProtoReflect.Descriptor instead.
-func (*ListPrefixesResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListPoolsResponse.ProtoReflect.Descriptor instead.
+func (*ListPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *ListPrefixesResponse) GetPrefixes() []*Prefix {
+func (x *ListPoolsResponse) GetPools() []*Pool {
 	if x != nil {
-		return x.Prefixes
+		return x.Pools
 	}
 	return nil
 }
 
-type PrefixUsageRequest struct {
+type DeletePoolRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PrefixUsageRequest) Reset() {
-	*x = PrefixUsageRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[11]
+func (x *DeletePoolRequest) Reset() {
+	*x = DeletePoolRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PrefixUsageRequest) String() string {
+func (x *DeletePoolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrefixUsageRequest) ProtoMessage() {}
+func (*DeletePoolRequest) ProtoMessage() {}
 
-func (x *PrefixUsageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[11]
+func (x *DeletePoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,58 +5145,46 @@ func (x *PrefixUsageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrefixUsageRequest.ProtoReflect.Descriptor instead.
-func (*PrefixUsageRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DeletePoolRequest.ProtoReflect.Descriptor instead.
+func (*DeletePoolRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *PrefixUsageRequest) GetCidr() string {
+func (x *DeletePoolRequest) GetName() string {
 	if x != nil {
-		return x.Cidr
+		return x.Name
 	}
 	return ""
 }
 
-func (x *PrefixUsageRequest) GetNamespace() string {
+func (x *DeletePoolRequest) GetNamespace() string {
 	if x != nil && x.Namespace != nil {
 		return *x.Namespace
 	}
 	return ""
 }
 
-type PrefixUsageResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// AvailableIPs the number of available IPs if this is not a parent prefix
-	// No more than 2^31 available IPs are reported
-	AvailableIps uint64 `protobuf:"varint,1,opt,name=available_ips,json=availableIps,proto3" json:"available_ips,omitempty"`
-	// AcquiredIPs the number of acquired IPs if this is not a parent prefix
-	AcquiredIps uint64 `protobuf:"varint,2,opt,name=acquired_ips,json=acquiredIps,proto3" json:"acquired_ips,omitempty"`
-	// AvailableSmallestPrefixes is the count of available Prefixes with 2 countable Bits
-	// No more than 2^31 available Prefixes are reported
-	AvailableSmallestPrefixes uint64 `protobuf:"varint,3,opt,name=available_smallest_prefixes,json=availableSmallestPrefixes,proto3" json:"available_smallest_prefixes,omitempty"`
-	// AvailablePrefixes is a list of prefixes which are available
-	AvailablePrefixes []string `protobuf:"bytes,4,rep,name=available_prefixes,json=availablePrefixes,proto3" json:"available_prefixes,omitempty"`
-	// AcquiredPrefixes the number of acquired prefixes if this is a parent prefix
-	AcquiredPrefixes uint64 `protobuf:"varint,5,opt,name=acquired_prefixes,json=acquiredPrefixes,proto3" json:"acquired_prefixes,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type DeletePoolResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PrefixUsageResponse) Reset() {
-	*x = PrefixUsageResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[12]
+func (x *DeletePoolResponse) Reset() {
+	*x = DeletePoolResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PrefixUsageResponse) String() string {
+func (x *DeletePoolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrefixUsageResponse) ProtoMessage() {}
+func (*DeletePoolResponse) ProtoMessage() {}
 
-func (x *PrefixUsageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[12]
+func (x *DeletePoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,71 +5195,42 @@ func (x *PrefixUsageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrefixUsageResponse.ProtoReflect.Descriptor instead.
-func (*PrefixUsageResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *PrefixUsageResponse) GetAvailableIps() uint64 {
-	if x != nil {
-		return x.AvailableIps
-	}
-	return 0
-}
-
-func (x *PrefixUsageResponse) GetAcquiredIps() uint64 {
-	if x != nil {
-		return x.AcquiredIps
-	}
-	return 0
-}
-
-func (x *PrefixUsageResponse) GetAvailableSmallestPrefixes() uint64 {
-	if x != nil {
-		return x.AvailableSmallestPrefixes
-	}
-	return 0
-}
-
-func (x *PrefixUsageResponse) GetAvailablePrefixes() []string {
-	if x != nil {
-		return x.AvailablePrefixes
-	}
-	return nil
-}
-
-func (x *PrefixUsageResponse) GetAcquiredPrefixes() uint64 {
-	if x != nil {
-		return x.AcquiredPrefixes
-	}
-	return 0
+// Deprecated: Use DeletePoolResponse.ProtoReflect.Descriptor instead.
+func (*DeletePoolResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{78}
 }
 
-type AcquireChildPrefixRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Length        uint32                 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
-	ChildCidr     *string                `protobuf:"bytes,3,opt,name=child_cidr,json=childCidr,proto3,oneof" json:"child_cidr,omitempty"`
-	Namespace     *string                `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type AcquireIPFromPoolRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Pool      string                 `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	Namespace *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// Annotations are stored with the acquired ip
+	Annotations map[string]string `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// AllocationStrategy overrides the allocation strategy of the prefixes for this request
+	AllocationStrategy AllocationStrategy `protobuf:"varint,4,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Ttl leases the ip, it is released after the ttl unless the lease is renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original ip
+	IdempotencyKey *string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *AcquireChildPrefixRequest) Reset() {
-	*x = AcquireChildPrefixRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[13]
+func (x *AcquireIPFromPoolRequest) Reset() {
+	*x = AcquireIPFromPoolRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcquireChildPrefixRequest) String() string {
+func (x *AcquireIPFromPoolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcquireChildPrefixRequest) ProtoMessage() {}
+func (*AcquireIPFromPoolRequest) ProtoMessage() {}
 
-func (x *AcquireChildPrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[13]
+func (x *AcquireIPFromPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,62 +5241,75 @@ func (x *AcquireChildPrefixRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcquireChildPrefixRequest.ProtoReflect.Descriptor instead.
-func (*AcquireChildPrefixRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use AcquireIPFromPoolRequest.ProtoReflect.Descriptor instead.
+func (*AcquireIPFromPoolRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *AcquireChildPrefixRequest) GetCidr() string {
+func (x *AcquireIPFromPoolRequest) GetPool() string {
 	if x != nil {
-		return x.Cidr
+		return x.Pool
 	}
 	return ""
 }
 
-func (x *AcquireChildPrefixRequest) GetLength() uint32 {
+func (x *AcquireIPFromPoolRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *AcquireIPFromPoolRequest) GetAnnotations() map[string]string {
 	if x != nil {
-		return x.Length
+		return x.Annotations
 	}
-	return 0
+	return nil
 }
 
-func (x *AcquireChildPrefixRequest) GetChildCidr() string {
-	if x != nil && x.ChildCidr != nil {
-		return *x.ChildCidr
+func (x *AcquireIPFromPoolRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
 	}
-	return ""
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
 }
 
-func (x *AcquireChildPrefixRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *AcquireIPFromPoolRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireIPFromPoolRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
 	}
 	return ""
 }
 
-type ReleaseChildPrefixRequest struct {
+type AcquireIPFromPoolResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseChildPrefixRequest) Reset() {
-	*x = ReleaseChildPrefixRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[14]
+func (x *AcquireIPFromPoolResponse) Reset() {
+	*x = AcquireIPFromPoolResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseChildPrefixRequest) String() string {
+func (x *AcquireIPFromPoolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseChildPrefixRequest) ProtoMessage() {}
+func (*AcquireIPFromPoolResponse) ProtoMessage() {}
 
-func (x *ReleaseChildPrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[14]
+func (x *AcquireIPFromPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,49 +5319,59 @@ func (x *ReleaseChildPrefixRequest) ProtoReflect() protoreflect.Message {
 	}
 	return mi.MessageOf(x)
 }
-
-// Deprecated: Use ReleaseChildPrefixRequest.ProtoReflect.Descriptor instead.
-func (*ReleaseChildPrefixRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *ReleaseChildPrefixRequest) GetCidr() string {
-	if x != nil {
-		return x.Cidr
-	}
-	return ""
+
+// Deprecated: Use AcquireIPFromPoolResponse.ProtoReflect.Descriptor instead.
+func (*AcquireIPFromPoolResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *ReleaseChildPrefixRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *AcquireIPFromPoolResponse) GetIp() *IP {
+	if x != nil {
+		return x.Ip
 	}
-	return ""
+	return nil
 }
 
-type IP struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
-	ParentPrefix  string                 `protobuf:"bytes,2,opt,name=parent_prefix,json=parentPrefix,proto3" json:"parent_prefix,omitempty"`
+type AcquireChildPrefixFromPoolRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Pool        string                 `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	Length      uint32                 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	Namespace   *string                `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Description *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// ReservedIps configures the addresses of the child prefix which are never acquired
+	ReservedIps *ReservedIPs `protobuf:"bytes,6,opt,name=reserved_ips,json=reservedIps,proto3" json:"reserved_ips,omitempty"`
+	// AllocationStrategy defines in which order ips of the child prefix are acquired
+	AllocationStrategy AllocationStrategy `protobuf:"varint,7,opt,name=allocation_strategy,json=allocationStrategy,proto3,enum=api.v1.AllocationStrategy" json:"allocation_strategy,omitempty"`
+	// Exclusions configures the addresses of the child prefix which are skipped on acquisition
+	Exclusions *Exclusions `protobuf:"bytes,8,opt,name=exclusions,proto3" json:"exclusions,omitempty"`
+	// Ttl leases the child prefix, it is released after the ttl unless the lease is renewed
+	Ttl *durationpb.Duration `protobuf:"bytes,9,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Quarantine is the duration released ips of the child prefix are not acquired again
+	Quarantine *durationpb.Duration `protobuf:"bytes,10,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// IdempotencyKey makes the request idempotent, a repeated request with the same key returns the original child prefix
+	IdempotencyKey *string `protobuf:"bytes,11,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	// Placement overrides the child prefix strategy of the prefixes of the pool for this request
+	Placement     ChildPrefixStrategy `protobuf:"varint,12,opt,name=placement,proto3,enum=api.v1.ChildPrefixStrategy" json:"placement,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IP) Reset() {
-	*x = IP{}
-	mi := &file_api_v1_ipam_proto_msgTypes[15]
+func (x *AcquireChildPrefixFromPoolRequest) Reset() {
+	*x = AcquireChildPrefixFromPoolRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IP) String() string {
+func (x *AcquireChildPrefixFromPoolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IP) ProtoMessage() {}
+func (*AcquireChildPrefixFromPoolRequest) ProtoMessage() {}
 
-func (x *IP) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[15]
+func (x *AcquireChildPrefixFromPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -825,99 +5382,117 @@ func (x *IP) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IP.ProtoReflect.Descriptor instead.
-func (*IP) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use AcquireChildPrefixFromPoolRequest.ProtoReflect.Descriptor instead.
+func (*AcquireChildPrefixFromPoolRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *IP) GetIp() string {
+func (x *AcquireChildPrefixFromPoolRequest) GetPool() string {
 	if x != nil {
-		return x.Ip
+		return x.Pool
 	}
 	return ""
 }
 
-func (x *IP) GetParentPrefix() string {
+func (x *AcquireChildPrefixFromPoolRequest) GetLength() uint32 {
 	if x != nil {
-		return x.ParentPrefix
+		return x.Length
+	}
+	return 0
+}
+
+func (x *AcquireChildPrefixFromPoolRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
 	}
 	return ""
 }
 
-type AcquireIPResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
-	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AcquireChildPrefixFromPoolRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
 }
 
-func (x *AcquireIPResponse) Reset() {
-	*x = AcquireIPResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AcquireChildPrefixFromPoolRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
 }
 
-func (x *AcquireIPResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AcquireChildPrefixFromPoolRequest) GetReservedIps() *ReservedIPs {
+	if x != nil {
+		return x.ReservedIps
+	}
+	return nil
 }
 
-func (*AcquireIPResponse) ProtoMessage() {}
+func (x *AcquireChildPrefixFromPoolRequest) GetAllocationStrategy() AllocationStrategy {
+	if x != nil {
+		return x.AllocationStrategy
+	}
+	return AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
 
-func (x *AcquireIPResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[16]
+func (x *AcquireChildPrefixFromPoolRequest) GetExclusions() *Exclusions {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Exclusions
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use AcquireIPResponse.ProtoReflect.Descriptor instead.
-func (*AcquireIPResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{16}
+func (x *AcquireChildPrefixFromPoolRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
 }
 
-func (x *AcquireIPResponse) GetIp() *IP {
+func (x *AcquireChildPrefixFromPoolRequest) GetQuarantine() *durationpb.Duration {
 	if x != nil {
-		return x.Ip
+		return x.Quarantine
 	}
 	return nil
 }
 
-func (x *AcquireIPResponse) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *AcquireChildPrefixFromPoolRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
 	}
 	return ""
 }
 
-type ReleaseIPResponse struct {
+func (x *AcquireChildPrefixFromPoolRequest) GetPlacement() ChildPrefixStrategy {
+	if x != nil {
+		return x.Placement
+	}
+	return ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED
+}
+
+type AcquireChildPrefixFromPoolResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ip            *IP                    `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Prefix        *Prefix                `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseIPResponse) Reset() {
-	*x = ReleaseIPResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[17]
+func (x *AcquireChildPrefixFromPoolResponse) Reset() {
+	*x = AcquireChildPrefixFromPoolResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseIPResponse) String() string {
+func (x *AcquireChildPrefixFromPoolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseIPResponse) ProtoMessage() {}
+func (*AcquireChildPrefixFromPoolResponse) ProtoMessage() {}
 
-func (x *ReleaseIPResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[17]
+func (x *AcquireChildPrefixFromPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -928,42 +5503,40 @@ func (x *ReleaseIPResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseIPResponse.ProtoReflect.Descriptor instead.
-func (*ReleaseIPResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use AcquireChildPrefixFromPoolResponse.ProtoReflect.Descriptor instead.
+func (*AcquireChildPrefixFromPoolResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *ReleaseIPResponse) GetIp() *IP {
+func (x *AcquireChildPrefixFromPoolResponse) GetPrefix() *Prefix {
 	if x != nil {
-		return x.Ip
+		return x.Prefix
 	}
 	return nil
 }
 
-type AcquireIPRequest struct {
+type CreateNamespaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PrefixCidr    string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
-	Ip            *string                `protobuf:"bytes,2,opt,name=ip,proto3,oneof" json:"ip,omitempty"`
-	Namespace     *string                `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AcquireIPRequest) Reset() {
-	*x = AcquireIPRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[18]
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcquireIPRequest) String() string {
+func (x *CreateNamespaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcquireIPRequest) ProtoMessage() {}
+func (*CreateNamespaceRequest) ProtoMessage() {}
 
-func (x *AcquireIPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[18]
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -974,56 +5547,39 @@ func (x *AcquireIPRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcquireIPRequest.ProtoReflect.Descriptor instead.
-func (*AcquireIPRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *AcquireIPRequest) GetPrefixCidr() string {
+func (x *CreateNamespaceRequest) GetNamespace() string {
 	if x != nil {
-		return x.PrefixCidr
-	}
-	return ""
-}
-
-func (x *AcquireIPRequest) GetIp() string {
-	if x != nil && x.Ip != nil {
-		return *x.Ip
-	}
-	return ""
-}
-
-func (x *AcquireIPRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+		return x.Namespace
 	}
 	return ""
 }
 
-type ReleaseIPRequest struct {
+type CreateNamespaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PrefixCidr    string                 `protobuf:"bytes,1,opt,name=prefix_cidr,json=prefixCidr,proto3" json:"prefix_cidr,omitempty"`
-	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
-	Namespace     *string                `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseIPRequest) Reset() {
-	*x = ReleaseIPRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[19]
+func (x *CreateNamespaceResponse) Reset() {
+	*x = CreateNamespaceResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseIPRequest) String() string {
+func (x *CreateNamespaceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseIPRequest) ProtoMessage() {}
+func (*CreateNamespaceResponse) ProtoMessage() {}
 
-func (x *ReleaseIPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[19]
+func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,54 +5590,32 @@ func (x *ReleaseIPRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseIPRequest.ProtoReflect.Descriptor instead.
-func (*ReleaseIPRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *ReleaseIPRequest) GetPrefixCidr() string {
-	if x != nil {
-		return x.PrefixCidr
-	}
-	return ""
-}
-
-func (x *ReleaseIPRequest) GetIp() string {
-	if x != nil {
-		return x.Ip
-	}
-	return ""
-}
-
-func (x *ReleaseIPRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
-	}
-	return ""
+// Deprecated: Use CreateNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{84}
 }
 
-type DumpRequest struct {
+type ListNamespacesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     *string                `protobuf:"bytes,1,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpRequest) Reset() {
-	*x = DumpRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[20]
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpRequest) String() string {
+func (x *ListNamespacesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpRequest) ProtoMessage() {}
+func (*ListNamespacesRequest) ProtoMessage() {}
 
-func (x *DumpRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[20]
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1092,40 +5626,33 @@ func (x *DumpRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpRequest.ProtoReflect.Descriptor instead.
-func (*DumpRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *DumpRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
-	}
-	return ""
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{85}
 }
 
-type DumpResponse struct {
+type ListNamespacesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dump          string                 `protobuf:"bytes,1,opt,name=dump,proto3" json:"dump,omitempty"`
+	Namespace     []string               `protobuf:"bytes,1,rep,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpResponse) Reset() {
-	*x = DumpResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[21]
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpResponse) String() string {
+func (x *ListNamespacesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpResponse) ProtoMessage() {}
+func (*ListNamespacesResponse) ProtoMessage() {}
 
-func (x *DumpResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[21]
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1136,41 +5663,40 @@ func (x *DumpResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpResponse.ProtoReflect.Descriptor instead.
-func (*DumpResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *DumpResponse) GetDump() string {
+func (x *ListNamespacesResponse) GetNamespace() []string {
 	if x != nil {
-		return x.Dump
+		return x.Namespace
 	}
-	return ""
+	return nil
 }
 
-type LoadRequest struct {
+type DeleteNamespaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dump          string                 `protobuf:"bytes,1,opt,name=dump,proto3" json:"dump,omitempty"`
-	Namespace     *string                `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoadRequest) Reset() {
-	*x = LoadRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[22]
+func (x *DeleteNamespaceRequest) Reset() {
+	*x = DeleteNamespaceRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoadRequest) String() string {
+func (x *DeleteNamespaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadRequest) ProtoMessage() {}
+func (*DeleteNamespaceRequest) ProtoMessage() {}
 
-func (x *LoadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[22]
+func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1181,46 +5707,39 @@ func (x *LoadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadRequest.ProtoReflect.Descriptor instead.
-func (*LoadRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *LoadRequest) GetDump() string {
-	if x != nil {
-		return x.Dump
-	}
-	return ""
+// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *LoadRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *DeleteNamespaceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
 	}
 	return ""
 }
 
-type LoadResponse struct {
+type DeleteNamespaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoadResponse) Reset() {
-	*x = LoadResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[23]
+func (x *DeleteNamespaceResponse) Reset() {
+	*x = DeleteNamespaceResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoadResponse) String() string {
+func (x *DeleteNamespaceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadResponse) ProtoMessage() {}
+func (*DeleteNamespaceResponse) ProtoMessage() {}
 
-func (x *LoadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[23]
+func (x *DeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1231,33 +5750,39 @@ func (x *LoadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadResponse.ProtoReflect.Descriptor instead.
-func (*LoadResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{23}
-}
-
-type CreateNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Deprecated: Use DeleteNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *CreateNamespaceRequest) Reset() {
-	*x = CreateNamespaceRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[24]
+// Quota limits the allocations of a namespace, zero values do not limit anything
+type Quota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MaxPrefixes is the maximum number of top level prefixes
+	MaxPrefixes uint64 `protobuf:"varint,1,opt,name=max_prefixes,json=maxPrefixes,proto3" json:"max_prefixes,omitempty"`
+	// MaxIps is the maximum number of acquired ips in all prefixes
+	MaxIps uint64 `protobuf:"varint,2,opt,name=max_ips,json=maxIps,proto3" json:"max_ips,omitempty"`
+	// MaxAddressSpace is the maximum number of addresses of all top level prefixes as decimal number
+	MaxAddressSpace string `protobuf:"bytes,3,opt,name=max_address_space,json=maxAddressSpace,proto3" json:"max_address_space,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_api_v1_ipam_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateNamespaceRequest) String() string {
+func (x *Quota) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateNamespaceRequest) ProtoMessage() {}
+func (*Quota) ProtoMessage() {}
 
-func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[24]
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1268,39 +5793,56 @@ func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *CreateNamespaceRequest) GetNamespace() string {
+func (x *Quota) GetMaxPrefixes() uint64 {
 	if x != nil {
-		return x.Namespace
+		return x.MaxPrefixes
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxIps() uint64 {
+	if x != nil {
+		return x.MaxIps
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxAddressSpace() string {
+	if x != nil {
+		return x.MaxAddressSpace
 	}
 	return ""
 }
 
-type CreateNamespaceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type SetNamespaceQuotaRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Quota replaces the quota of the namespace, an empty quota removes all limits
+	Quota         *Quota `protobuf:"bytes,2,opt,name=quota,proto3" json:"quota,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateNamespaceResponse) Reset() {
-	*x = CreateNamespaceResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[25]
+func (x *SetNamespaceQuotaRequest) Reset() {
+	*x = SetNamespaceQuotaRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateNamespaceResponse) String() string {
+func (x *SetNamespaceQuotaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateNamespaceResponse) ProtoMessage() {}
+func (*SetNamespaceQuotaRequest) ProtoMessage() {}
 
-func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[25]
+func (x *SetNamespaceQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1311,32 +5853,46 @@ func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateNamespaceResponse.ProtoReflect.Descriptor instead.
-func (*CreateNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use SetNamespaceQuotaRequest.ProtoReflect.Descriptor instead.
+func (*SetNamespaceQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{90}
 }
 
-type ListNamespacesRequest struct {
+func (x *SetNamespaceQuotaRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SetNamespaceQuotaRequest) GetQuota() *Quota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+type SetNamespaceQuotaResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNamespacesRequest) Reset() {
-	*x = ListNamespacesRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[26]
+func (x *SetNamespaceQuotaResponse) Reset() {
+	*x = SetNamespaceQuotaResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNamespacesRequest) String() string {
+func (x *SetNamespaceQuotaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNamespacesRequest) ProtoMessage() {}
+func (*SetNamespaceQuotaResponse) ProtoMessage() {}
 
-func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[26]
+func (x *SetNamespaceQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1347,33 +5903,33 @@ func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
-func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use SetNamespaceQuotaResponse.ProtoReflect.Descriptor instead.
+func (*SetNamespaceQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{91}
 }
 
-type ListNamespacesResponse struct {
+type GetNamespaceQuotaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     []string               `protobuf:"bytes,1,rep,name=namespace,proto3" json:"namespace,omitempty"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNamespacesResponse) Reset() {
-	*x = ListNamespacesResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[27]
+func (x *GetNamespaceQuotaRequest) Reset() {
+	*x = GetNamespaceQuotaRequest{}
+	mi := &file_api_v1_ipam_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNamespacesResponse) String() string {
+func (x *GetNamespaceQuotaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNamespacesResponse) ProtoMessage() {}
+func (*GetNamespaceQuotaRequest) ProtoMessage() {}
 
-func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[27]
+func (x *GetNamespaceQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,40 +5940,46 @@ func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
-func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use GetNamespaceQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetNamespaceQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *ListNamespacesResponse) GetNamespace() []string {
+func (x *GetNamespaceQuotaRequest) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
-	return nil
+	return ""
 }
 
-type DeleteNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+type GetNamespaceQuotaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Quota *Quota                 `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	// Prefixes is the number of top level prefixes
+	Prefixes uint64 `protobuf:"varint,2,opt,name=prefixes,proto3" json:"prefixes,omitempty"`
+	// Ips is the number of acquired ips in all prefixes
+	Ips uint64 `protobuf:"varint,3,opt,name=ips,proto3" json:"ips,omitempty"`
+	// AddressSpace is the number of addresses of all top level prefixes as decimal number
+	AddressSpace  string `protobuf:"bytes,4,opt,name=address_space,json=addressSpace,proto3" json:"address_space,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteNamespaceRequest) Reset() {
-	*x = DeleteNamespaceRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[28]
+func (x *GetNamespaceQuotaResponse) Reset() {
+	*x = GetNamespaceQuotaResponse{}
+	mi := &file_api_v1_ipam_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNamespaceRequest) String() string {
+func (x *GetNamespaceQuotaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNamespaceRequest) ProtoMessage() {}
+func (*GetNamespaceQuotaResponse) ProtoMessage() {}
 
-func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[28]
+func (x *GetNamespaceQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_ipam_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1428,52 +5990,37 @@ func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use GetNamespaceQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetNamespaceQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *DeleteNamespaceRequest) GetNamespace() string {
+func (x *GetNamespaceQuotaResponse) GetQuota() *Quota {
 	if x != nil {
-		return x.Namespace
+		return x.Quota
 	}
-	return ""
-}
-
-type DeleteNamespaceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteNamespaceResponse) Reset() {
-	*x = DeleteNamespaceResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[29]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return nil
 }
 
-func (x *DeleteNamespaceResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetNamespaceQuotaResponse) GetPrefixes() uint64 {
+	if x != nil {
+		return x.Prefixes
+	}
+	return 0
 }
 
-func (*DeleteNamespaceResponse) ProtoMessage() {}
-
-func (x *DeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[29]
+func (x *GetNamespaceQuotaResponse) GetIps() uint64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Ips
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use DeleteNamespaceResponse.ProtoReflect.Descriptor instead.
-func (*DeleteNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{29}
+func (x *GetNamespaceQuotaResponse) GetAddressSpace() string {
+	if x != nil {
+		return x.AddressSpace
+	}
+	return ""
 }
 
 type VersionRequest struct {
@@ -1484,7 +6031,7 @@ type VersionRequest struct {
 
 func (x *VersionRequest) Reset() {
 	*x = VersionRequest{}
-	mi := &file_api_v1_ipam_proto_msgTypes[30]
+	mi := &file_api_v1_ipam_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1496,7 +6043,7 @@ func (x *VersionRequest) String() string {
 func (*VersionRequest) ProtoMessage() {}
 
 func (x *VersionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[30]
+	mi := &file_api_v1_ipam_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1509,7 +6056,7 @@ func (x *VersionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VersionRequest.ProtoReflect.Descriptor instead.
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{30}
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{94}
 }
 
 type VersionResponse struct {
@@ -1524,7 +6071,7 @@ type VersionResponse struct {
 
 func (x *VersionResponse) Reset() {
 	*x = VersionResponse{}
-	mi := &file_api_v1_ipam_proto_msgTypes[31]
+	mi := &file_api_v1_ipam_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1536,7 +6083,7 @@ func (x *VersionResponse) String() string {
 func (*VersionResponse) ProtoMessage() {}
 
 func (x *VersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_ipam_proto_msgTypes[31]
+	mi := &file_api_v1_ipam_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1549,7 +6096,7 @@ func (x *VersionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_ipam_proto_rawDescGZIP(), []int{31}
+	return file_api_v1_ipam_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *VersionResponse) GetVersion() string {
@@ -1584,70 +6131,290 @@ var File_api_v1_ipam_proto protoreflect.FileDescriptor
 
 const file_api_v1_ipam_proto_rawDesc = "" +
 	"\n" +
-	"\x11api/v1/ipam.proto\x12\x06api.v1\"=\n" +
+	"\x11api/v1/ipam.proto\x12\x06api.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb7\x04\n" +
 	"\x06Prefix\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x1f\n" +
 	"\vparent_cidr\x18\x02 \x01(\tR\n" +
-	"parentCidr\">\n" +
+	"parentCidr\x122\n" +
+	"\x06labels\x18\x03 \x03(\v2\x1a.api.v1.Prefix.LabelsEntryR\x06labels\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12!\n" +
+	"\freserved_ips\x18\x05 \x03(\tR\vreservedIps\x12K\n" +
+	"\x13allocation_strategy\x18\x06 \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x12\x1e\n" +
+	"\n" +
+	"exclusions\x18\a \x03(\tR\n" +
+	"exclusions\x12#\n" +
+	"\x05lease\x18\b \x01(\v2\r.api.v1.LeaseR\x05lease\x129\n" +
+	"\n" +
+	"quarantine\x18\t \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"quarantine\x12&\n" +
+	"\x06policy\x18\n" +
+	" \x01(\v2\x0e.api.v1.PolicyR\x06policy\x12O\n" +
+	"\x15child_prefix_strategy\x18\v \x01(\x0e2\x1b.api.v1.ChildPrefixStrategyR\x13childPrefixStrategy\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"O\n" +
+	"\vReservedIPs\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x04R\x05first\x12\x12\n" +
+	"\x04last\x18\x02 \x01(\x04R\x04last\x12\x16\n" +
+	"\x06ranges\x18\x03 \x03(\tR\x06ranges\"$\n" +
+	"\n" +
+	"Exclusions\x12\x16\n" +
+	"\x06ranges\x18\x01 \x03(\tR\x06ranges\"\xa5\x01\n" +
+	"\x06Policy\x12(\n" +
+	"\x10min_child_length\x18\x01 \x01(\rR\x0eminChildLength\x12(\n" +
+	"\x10max_child_length\x18\x02 \x01(\rR\x0emaxChildLength\x12,\n" +
+	"\x12max_child_prefixes\x18\x03 \x01(\x04R\x10maxChildPrefixes\x12\x19\n" +
+	"\bdeny_ips\x18\x04 \x01(\bR\adenyIps\">\n" +
 	"\x14CreatePrefixResponse\x12&\n" +
-	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\">\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"\xe1\x02\n" +
 	"\x14DeletePrefixResponse\x12&\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\x129\n" +
+	"\x10deleted_prefixes\x18\x02 \x03(\v2\x0e.api.v1.PrefixR\x0fdeletedPrefixes\x12-\n" +
+	"\freleased_ips\x18\x03 \x03(\v2\n" +
+	".api.v1.IPR\vreleasedIps\x12`\n" +
+	"\x12released_ip_ranges\x18\x04 \x03(\v22.api.v1.DeletePrefixResponse.ReleasedIpRangesEntryR\x10releasedIpRanges\x1aU\n" +
+	"\x15ReleasedIpRangesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.api.v1.IPRangesR\x05value:\x028\x01\"\"\n" +
+	"\bIPRanges\x12\x16\n" +
+	"\x06ranges\x18\x01 \x03(\tR\x06ranges\">\n" +
+	"\x14UpdatePrefixResponse\x12&\n" +
 	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\";\n" +
 	"\x11GetPrefixResponse\x12&\n" +
 	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"D\n" +
 	"\x1aAcquireChildPrefixResponse\x12&\n" +
-	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"D\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"J\n" +
+	"\x1cAcquireChildPrefixesResponse\x12*\n" +
+	"\bprefixes\x18\x01 \x03(\v2\x0e.api.v1.PrefixR\bprefixes\"D\n" +
 	"\x1aReleaseChildPrefixResponse\x12&\n" +
-	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"Z\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"\xbc\x05\n" +
 	"\x13CreatePrefixRequest\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12!\n" +
-	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12?\n" +
+	"\x06labels\x18\x03 \x03(\v2'.api.v1.CreatePrefixRequest.LabelsEntryR\x06labels\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x126\n" +
+	"\freserved_ips\x18\x05 \x01(\v2\x13.api.v1.ReservedIPsR\vreservedIps\x12K\n" +
+	"\x13allocation_strategy\x18\x06 \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x122\n" +
+	"\n" +
+	"exclusions\x18\a \x01(\v2\x12.api.v1.ExclusionsR\n" +
+	"exclusions\x129\n" +
 	"\n" +
-	"_namespace\"Z\n" +
+	"quarantine\x18\b \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"quarantine\x12,\n" +
+	"\x0fidempotency_key\x18\t \x01(\tH\x02R\x0eidempotencyKey\x88\x01\x01\x12&\n" +
+	"\x06policy\x18\n" +
+	" \x01(\v2\x0e.api.v1.PolicyR\x06policy\x12O\n" +
+	"\x15child_prefix_strategy\x18\v \x01(\x0e2\x1b.api.v1.ChildPrefixStrategyR\x13childPrefixStrategy\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x0e\n" +
+	"\f_descriptionB\x12\n" +
+	"\x10_idempotency_key\"\x91\x01\n" +
 	"\x13DeletePrefixRequest\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12\x1c\n" +
+	"\trecursive\x18\x03 \x01(\bR\trecursive\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRunB\f\n" +
+	"\n" +
+	"_namespace\"\xfa\x04\n" +
+	"\x13UpdatePrefixRequest\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12?\n" +
+	"\x06labels\x18\x03 \x03(\v2'.api.v1.UpdatePrefixRequest.LabelsEntryR\x06labels\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x126\n" +
+	"\freserved_ips\x18\x05 \x01(\v2\x13.api.v1.ReservedIPsR\vreservedIps\x12K\n" +
+	"\x13allocation_strategy\x18\x06 \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x122\n" +
+	"\n" +
+	"exclusions\x18\a \x01(\v2\x12.api.v1.ExclusionsR\n" +
+	"exclusions\x129\n" +
+	"\n" +
+	"quarantine\x18\b \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"quarantine\x12&\n" +
+	"\x06policy\x18\t \x01(\v2\x0e.api.v1.PolicyR\x06policy\x12O\n" +
+	"\x15child_prefix_strategy\x18\n" +
+	" \x01(\x0e2\x1b.api.v1.ChildPrefixStrategyR\x13childPrefixStrategy\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x0e\n" +
+	"\f_description\"r\n" +
+	"\x13ResizePrefixRequest\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\rR\x06length\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\">\n" +
+	"\x14ResizePrefixResponse\x12&\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"q\n" +
+	"\x12SplitPrefixRequest\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\rR\x06length\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"A\n" +
+	"\x13SplitPrefixResponse\x12*\n" +
+	"\bprefixes\x18\x01 \x03(\v2\x0e.api.v1.PrefixR\bprefixes\"]\n" +
+	"\x14MergePrefixesRequest\x12\x14\n" +
+	"\x05cidrs\x18\x01 \x03(\tR\x05cidrs\x12!\n" +
 	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\"W\n" +
+	"_namespace\"?\n" +
+	"\x15MergePrefixesResponse\x12&\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"W\n" +
 	"\x10GetPrefixRequest\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12!\n" +
 	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\"F\n" +
+	"_namespace\"\x85\x01\n" +
 	"\x13ListPrefixesRequest\x12!\n" +
-	"\tnamespace\x18\x01 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\tnamespace\x18\x01 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12*\n" +
+	"\x0elabel_selector\x18\x02 \x01(\tH\x01R\rlabelSelector\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\"B\n" +
+	"_namespaceB\x11\n" +
+	"\x0f_label_selector\"B\n" +
 	"\x14ListPrefixesResponse\x12*\n" +
 	"\bprefixes\x18\x01 \x03(\v2\x0e.api.v1.PrefixR\bprefixes\"Y\n" +
 	"\x12PrefixUsageRequest\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12!\n" +
 	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\"\xf9\x01\n" +
+	"_namespace\"\xde\x05\n" +
 	"\x13PrefixUsageResponse\x12#\n" +
 	"\ravailable_ips\x18\x01 \x01(\x04R\favailableIps\x12!\n" +
 	"\facquired_ips\x18\x02 \x01(\x04R\vacquiredIps\x12>\n" +
 	"\x1bavailable_smallest_prefixes\x18\x03 \x01(\x04R\x19availableSmallestPrefixes\x12-\n" +
 	"\x12available_prefixes\x18\x04 \x03(\tR\x11availablePrefixes\x12+\n" +
-	"\x11acquired_prefixes\x18\x05 \x01(\x04R\x10acquiredPrefixes\"\xab\x01\n" +
+	"\x11acquired_prefixes\x18\x05 \x01(\x04R\x10acquiredPrefixes\x12!\n" +
+	"\freserved_ips\x18\x06 \x01(\x04R\vreservedIps\x12!\n" +
+	"\fexcluded_ips\x18\a \x01(\x04R\vexcludedIps\x12'\n" +
+	"\x0fquarantined_ips\x18\b \x01(\x04R\x0equarantinedIps\x12.\n" +
+	"\x13available_ips_exact\x18\t \x01(\tR\x11availableIpsExact\x12,\n" +
+	"\x12reserved_ips_exact\x18\n" +
+	" \x01(\tR\x10reservedIpsExact\x12,\n" +
+	"\x12excluded_ips_exact\x18\v \x01(\tR\x10excludedIpsExact\x12I\n" +
+	"!available_smallest_prefixes_exact\x18\f \x01(\tR\x1eavailableSmallestPrefixesExact\x12\x19\n" +
+	"\bfree_ips\x18\r \x01(\tR\afreeIps\x12*\n" +
+	"\x11free_ips_notation\x18\x0e \x01(\tR\x0ffreeIpsNotation\x124\n" +
+	"\x16available_ips_notation\x18\x0f \x01(\tR\x14availableIpsNotation\x12 \n" +
+	"\vutilization\x18\x10 \x01(\x01R\vutilization\"\x7f\n" +
+	"\x14GetPrefixTreeRequest\x12\x17\n" +
+	"\x04cidr\x18\x01 \x01(\tH\x00R\x04cidr\x88\x01\x01\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\rR\x05depth\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x01R\tnamespace\x88\x01\x01B\a\n" +
+	"\x05_cidrB\f\n" +
+	"\n" +
+	"_namespace\"A\n" +
+	"\x15GetPrefixTreeResponse\x12(\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x12.api.v1.PrefixNodeR\x05nodes\"\x97\x01\n" +
+	"\n" +
+	"PrefixNode\x12&\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\x121\n" +
+	"\x05usage\x18\x02 \x01(\v2\x1b.api.v1.PrefixUsageResponseR\x05usage\x12.\n" +
+	"\bchildren\x18\x03 \x03(\v2\x12.api.v1.PrefixNodeR\bchildren\"a\n" +
+	"\x1aFragmentationReportRequest\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"\xeb\x02\n" +
+	"\x1bFragmentationReportResponse\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12.\n" +
+	"\x13largest_free_length\x18\x02 \x01(\rR\x11largestFreeLength\x12T\n" +
+	"\vfree_blocks\x18\x03 \x03(\v23.api.v1.FragmentationReportResponse.FreeBlocksEntryR\n" +
+	"freeBlocks\x12%\n" +
+	"\x0efree_addresses\x18\x04 \x01(\tR\rfreeAddresses\x126\n" +
+	"\x17free_addresses_notation\x18\x05 \x01(\tR\x15freeAddressesNotation\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x1a=\n" +
+	"\x0fFreeBlocksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"y\n" +
+	"\x1aPlanDefragmentationRequest\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\rR\x06length\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"b\n" +
+	"\x1bPlanDefragmentationResponse\x12\x14\n" +
+	"\x05block\x18\x01 \x01(\tR\x05block\x12-\n" +
+	"\x05moves\x18\x02 \x03(\v2\x17.api.v1.ChildPrefixMoveR\x05moves\"5\n" +
+	"\x0fChildPrefixMove\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\x82\x06\n" +
 	"\x19AcquireChildPrefixRequest\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x16\n" +
 	"\x06length\x18\x02 \x01(\rR\x06length\x12\"\n" +
 	"\n" +
 	"child_cidr\x18\x03 \x01(\tH\x00R\tchildCidr\x88\x01\x01\x12!\n" +
-	"\tnamespace\x18\x04 \x01(\tH\x01R\tnamespace\x88\x01\x01B\r\n" +
+	"\tnamespace\x18\x04 \x01(\tH\x01R\tnamespace\x88\x01\x01\x12E\n" +
+	"\x06labels\x18\x05 \x03(\v2-.api.v1.AcquireChildPrefixRequest.LabelsEntryR\x06labels\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x02R\vdescription\x88\x01\x01\x126\n" +
+	"\freserved_ips\x18\a \x01(\v2\x13.api.v1.ReservedIPsR\vreservedIps\x12K\n" +
+	"\x13allocation_strategy\x18\b \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x122\n" +
+	"\n" +
+	"exclusions\x18\t \x01(\v2\x12.api.v1.ExclusionsR\n" +
+	"exclusions\x12+\n" +
+	"\x03ttl\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x129\n" +
+	"\n" +
+	"quarantine\x18\v \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"quarantine\x12,\n" +
+	"\x0fidempotency_key\x18\f \x01(\tH\x03R\x0eidempotencyKey\x88\x01\x01\x129\n" +
+	"\tplacement\x18\r \x01(\x0e2\x1b.api.v1.ChildPrefixStrategyR\tplacement\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
 	"\v_child_cidrB\f\n" +
 	"\n" +
-	"_namespace\"`\n" +
+	"_namespaceB\x0e\n" +
+	"\f_descriptionB\x12\n" +
+	"\x10_idempotency_key\"\xe9\x05\n" +
+	"\x1bAcquireChildPrefixesRequest\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\rR\x06length\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\rR\x05count\x12!\n" +
+	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12G\n" +
+	"\x06labels\x18\x05 \x03(\v2/.api.v1.AcquireChildPrefixesRequest.LabelsEntryR\x06labels\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x01R\vdescription\x88\x01\x01\x126\n" +
+	"\freserved_ips\x18\a \x01(\v2\x13.api.v1.ReservedIPsR\vreservedIps\x12K\n" +
+	"\x13allocation_strategy\x18\b \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x122\n" +
+	"\n" +
+	"exclusions\x18\t \x01(\v2\x12.api.v1.ExclusionsR\n" +
+	"exclusions\x12+\n" +
+	"\x03ttl\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x129\n" +
+	"\n" +
+	"quarantine\x18\v \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"quarantine\x12,\n" +
+	"\x0fidempotency_key\x18\f \x01(\tH\x02R\x0eidempotencyKey\x88\x01\x01\x129\n" +
+	"\tplacement\x18\r \x01(\x0e2\x1b.api.v1.ChildPrefixStrategyR\tplacement\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x0e\n" +
+	"\f_descriptionB\x12\n" +
+	"\x10_idempotency_key\"`\n" +
 	"\x19ReleaseChildPrefixRequest\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12!\n" +
 	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\"9\n" +
+	"_namespace\"\xdd\x01\n" +
 	"\x02IP\x12\x0e\n" +
 	"\x02ip\x18\x01 \x01(\tR\x02ip\x12#\n" +
-	"\rparent_prefix\x18\x02 \x01(\tR\fparentPrefix\"`\n" +
+	"\rparent_prefix\x18\x02 \x01(\tR\fparentPrefix\x12=\n" +
+	"\vannotations\x18\x03 \x03(\v2\x1b.api.v1.IP.AnnotationsEntryR\vannotations\x12#\n" +
+	"\x05lease\x18\x04 \x01(\v2\r.api.v1.LeaseR\x05lease\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x96\x01\n" +
+	"\x05Lease\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rparent_prefix\x18\x02 \x01(\tR\fparentPrefix\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x12\n" +
+	"\x04cidr\x18\x04 \x01(\tR\x04cidr\x124\n" +
+	"\aexpires\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aexpires\"`\n" +
 	"\x11AcquireIPResponse\x12\x1a\n" +
 	"\x02ip\x18\x01 \x01(\v2\n" +
 	".api.v1.IPR\x02ip\x12!\n" +
@@ -1656,22 +6423,161 @@ const file_api_v1_ipam_proto_rawDesc = "" +
 	"_namespace\"/\n" +
 	"\x11ReleaseIPResponse\x12\x1a\n" +
 	"\x02ip\x18\x01 \x01(\v2\n" +
-	".api.v1.IPR\x02ip\"\x80\x01\n" +
+	".api.v1.IPR\x02ip\"\xdf\x03\n" +
 	"\x10AcquireIPRequest\x12\x1f\n" +
 	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
 	"prefixCidr\x12\x13\n" +
 	"\x02ip\x18\x02 \x01(\tH\x00R\x02ip\x88\x01\x01\x12!\n" +
-	"\tnamespace\x18\x03 \x01(\tH\x01R\tnamespace\x88\x01\x01B\x05\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x01R\tnamespace\x88\x01\x01\x12K\n" +
+	"\vannotations\x18\x04 \x03(\v2).api.v1.AcquireIPRequest.AnnotationsEntryR\vannotations\x12K\n" +
+	"\x13allocation_strategy\x18\x05 \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x12\x14\n" +
+	"\x05force\x18\x06 \x01(\bR\x05force\x12+\n" +
+	"\x03ttl\x18\a \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12,\n" +
+	"\x0fidempotency_key\x18\b \x01(\tH\x02R\x0eidempotencyKey\x88\x01\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x05\n" +
 	"\x03_ipB\f\n" +
 	"\n" +
-	"_namespace\"t\n" +
+	"_namespaceB\x12\n" +
+	"\x10_idempotency_key\"\xc5\x03\n" +
+	"\x11AcquireIPsRequest\x12\x1f\n" +
+	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
+	"prefixCidr\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12L\n" +
+	"\vannotations\x18\x04 \x03(\v2*.api.v1.AcquireIPsRequest.AnnotationsEntryR\vannotations\x12K\n" +
+	"\x13allocation_strategy\x18\x05 \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x12+\n" +
+	"\x03ttl\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12,\n" +
+	"\x0fidempotency_key\x18\a \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x12\n" +
+	"\x10_idempotency_key\"2\n" +
+	"\x12AcquireIPsResponse\x12\x1c\n" +
+	"\x03ips\x18\x01 \x03(\v2\n" +
+	".api.v1.IPR\x03ips\"\x8d\x04\n" +
+	"\x17AcquireDualStackRequest\x12(\n" +
+	"\x10ipv4_prefix_cidr\x18\x01 \x01(\tR\x0eipv4PrefixCidr\x12(\n" +
+	"\x10ipv6_prefix_cidr\x18\x02 \x01(\tR\x0eipv6PrefixCidr\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12R\n" +
+	"\vannotations\x18\x04 \x03(\v20.api.v1.AcquireDualStackRequest.AnnotationsEntryR\vannotations\x12K\n" +
+	"\x13allocation_strategy\x18\x05 \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x12+\n" +
+	"\x03ttl\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12,\n" +
+	"\x0fidempotency_key\x18\a \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"embed_ipv4\x18\b \x01(\bR\tembedIpv4\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x12\n" +
+	"\x10_idempotency_key\"Z\n" +
+	"\x18AcquireDualStackResponse\x12\x1e\n" +
+	"\x04ipv4\x18\x01 \x01(\v2\n" +
+	".api.v1.IPR\x04ipv4\x12\x1e\n" +
+	"\x04ipv6\x18\x02 \x01(\v2\n" +
+	".api.v1.IPR\x04ipv6\"\x80\x03\n" +
+	"\x17AcquireIPFromMACRequest\x12\x1f\n" +
+	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
+	"prefixCidr\x12\x10\n" +
+	"\x03mac\x18\x02 \x01(\tR\x03mac\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12R\n" +
+	"\vannotations\x18\x04 \x03(\v20.api.v1.AcquireIPFromMACRequest.AnnotationsEntryR\vannotations\x12+\n" +
+	"\x03ttl\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12,\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x12\n" +
+	"\x10_idempotency_key\"6\n" +
+	"\x18AcquireIPFromMACResponse\x12\x1a\n" +
+	"\x02ip\x18\x01 \x01(\v2\n" +
+	".api.v1.IPR\x02ip\"\xfe\x02\n" +
+	"\x16AcquireIPForKeyRequest\x12\x1f\n" +
+	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
+	"prefixCidr\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12Q\n" +
+	"\vannotations\x18\x04 \x03(\v2/.api.v1.AcquireIPForKeyRequest.AnnotationsEntryR\vannotations\x12+\n" +
+	"\x03ttl\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12,\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x12\n" +
+	"\x10_idempotency_key\"5\n" +
+	"\x17AcquireIPForKeyResponse\x12\x1a\n" +
+	"\x02ip\x18\x01 \x01(\v2\n" +
+	".api.v1.IPR\x02ip\"R\n" +
+	"\aIPRange\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12#\n" +
+	"\rparent_prefix\x18\x03 \x01(\tR\fparentPrefix\"\x7f\n" +
+	"\x15AcquireIPRangeRequest\x12\x1f\n" +
+	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
+	"prefixCidr\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"D\n" +
+	"\x16AcquireIPRangeResponse\x12*\n" +
+	"\bip_range\x18\x01 \x01(\v2\x0f.api.v1.IPRangeR\aipRange\"\x8d\x01\n" +
+	"\x15ReleaseIPRangeRequest\x12\x1f\n" +
+	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
+	"prefixCidr\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12!\n" +
+	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"D\n" +
+	"\x16ReleaseIPRangeResponse\x12*\n" +
+	"\bip_range\x18\x01 \x01(\v2\x0f.api.v1.IPRangeR\aipRange\"t\n" +
 	"\x10ReleaseIPRequest\x12\x1f\n" +
 	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
 	"prefixCidr\x12\x0e\n" +
 	"\x02ip\x18\x02 \x01(\tR\x02ip\x12!\n" +
 	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\">\n" +
+	"_namespace\"p\n" +
+	"\fGetIPRequest\x12\x1f\n" +
+	"\vprefix_cidr\x18\x01 \x01(\tR\n" +
+	"prefixCidr\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"+\n" +
+	"\rGetIPResponse\x12\x1a\n" +
+	"\x02ip\x18\x01 \x01(\v2\n" +
+	".api.v1.IPR\x02ip\"R\n" +
+	"\x0fLookupIPRequest\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"\x81\x01\n" +
+	"\x10LookupIPResponse\x12\x1a\n" +
+	"\x02ip\x18\x01 \x01(\v2\n" +
+	".api.v1.IPR\x02ip\x12*\n" +
+	"\bprefixes\x18\x02 \x03(\v2\x0e.api.v1.PrefixR\bprefixes\x12%\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x0f.api.v1.IPStateR\x05state\"\x81\x01\n" +
+	"\x11RenewLeaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"9\n" +
+	"\x12RenewLeaseResponse\x12#\n" +
+	"\x05lease\x18\x01 \x01(\v2\r.api.v1.LeaseR\x05lease\"D\n" +
+	"\x11ListLeasesRequest\x12!\n" +
+	"\tnamespace\x18\x01 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\";\n" +
+	"\x12ListLeasesResponse\x12%\n" +
+	"\x06leases\x18\x01 \x03(\v2\r.api.v1.LeaseR\x06leases\">\n" +
 	"\vDumpRequest\x12!\n" +
 	"\tnamespace\x18\x01 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
@@ -1683,7 +6589,91 @@ const file_api_v1_ipam_proto_rawDesc = "" +
 	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
 	"_namespace\"\x0e\n" +
-	"\fLoadResponse\"6\n" +
+	"\fLoadResponse\"h\n" +
+	"\x04Pool\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprefixes\x18\x02 \x03(\tR\bprefixes\x120\n" +
+	"\bstrategy\x18\x03 \x01(\x0e2\x14.api.v1.PoolStrategyR\bstrategy\"\xa6\x01\n" +
+	"\x11CreatePoolRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprefixes\x18\x02 \x03(\tR\bprefixes\x120\n" +
+	"\bstrategy\x18\x03 \x01(\x0e2\x14.api.v1.PoolStrategyR\bstrategy\x12!\n" +
+	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"6\n" +
+	"\x12CreatePoolResponse\x12 \n" +
+	"\x04pool\x18\x01 \x01(\v2\f.api.v1.PoolR\x04pool\"\xa6\x01\n" +
+	"\x11UpdatePoolRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprefixes\x18\x02 \x03(\tR\bprefixes\x120\n" +
+	"\bstrategy\x18\x03 \x01(\x0e2\x14.api.v1.PoolStrategyR\bstrategy\x12!\n" +
+	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"6\n" +
+	"\x12UpdatePoolResponse\x12 \n" +
+	"\x04pool\x18\x01 \x01(\v2\f.api.v1.PoolR\x04pool\"U\n" +
+	"\x0eGetPoolRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"3\n" +
+	"\x0fGetPoolResponse\x12 \n" +
+	"\x04pool\x18\x01 \x01(\v2\f.api.v1.PoolR\x04pool\"C\n" +
+	"\x10ListPoolsRequest\x12!\n" +
+	"\tnamespace\x18\x01 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"7\n" +
+	"\x11ListPoolsResponse\x12\"\n" +
+	"\x05pools\x18\x01 \x03(\v2\f.api.v1.PoolR\x05pools\"X\n" +
+	"\x11DeletePoolRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"\x14\n" +
+	"\x12DeletePoolResponse\"\xb0\x03\n" +
+	"\x18AcquireIPFromPoolRequest\x12\x12\n" +
+	"\x04pool\x18\x01 \x01(\tR\x04pool\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12S\n" +
+	"\vannotations\x18\x03 \x03(\v21.api.v1.AcquireIPFromPoolRequest.AnnotationsEntryR\vannotations\x12K\n" +
+	"\x13allocation_strategy\x18\x04 \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x12+\n" +
+	"\x03ttl\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12,\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tH\x01R\x0eidempotencyKey\x88\x01\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x12\n" +
+	"\x10_idempotency_key\"7\n" +
+	"\x19AcquireIPFromPoolResponse\x12\x1a\n" +
+	"\x02ip\x18\x01 \x01(\v2\n" +
+	".api.v1.IPR\x02ip\"\xdf\x05\n" +
+	"!AcquireChildPrefixFromPoolRequest\x12\x12\n" +
+	"\x04pool\x18\x01 \x01(\tR\x04pool\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\rR\x06length\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12M\n" +
+	"\x06labels\x18\x04 \x03(\v25.api.v1.AcquireChildPrefixFromPoolRequest.LabelsEntryR\x06labels\x12%\n" +
+	"\vdescription\x18\x05 \x01(\tH\x01R\vdescription\x88\x01\x01\x126\n" +
+	"\freserved_ips\x18\x06 \x01(\v2\x13.api.v1.ReservedIPsR\vreservedIps\x12K\n" +
+	"\x13allocation_strategy\x18\a \x01(\x0e2\x1a.api.v1.AllocationStrategyR\x12allocationStrategy\x122\n" +
+	"\n" +
+	"exclusions\x18\b \x01(\v2\x12.api.v1.ExclusionsR\n" +
+	"exclusions\x12+\n" +
+	"\x03ttl\x18\t \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x129\n" +
+	"\n" +
+	"quarantine\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"quarantine\x12,\n" +
+	"\x0fidempotency_key\x18\v \x01(\tH\x02R\x0eidempotencyKey\x88\x01\x01\x129\n" +
+	"\tplacement\x18\f \x01(\x0e2\x1b.api.v1.ChildPrefixStrategyR\tplacement\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_namespaceB\x0e\n" +
+	"\f_descriptionB\x12\n" +
+	"\x10_idempotency_key\"L\n" +
+	"\"AcquireChildPrefixFromPoolResponse\x12&\n" +
+	"\x06prefix\x18\x01 \x01(\v2\x0e.api.v1.PrefixR\x06prefix\"6\n" +
 	"\x16CreateNamespaceRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x19\n" +
 	"\x17CreateNamespaceResponse\"\x17\n" +
@@ -1692,29 +6682,99 @@ const file_api_v1_ipam_proto_rawDesc = "" +
 	"\tnamespace\x18\x01 \x03(\tR\tnamespace\"6\n" +
 	"\x16DeleteNamespaceRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x19\n" +
-	"\x17DeleteNamespaceResponse\"\x10\n" +
+	"\x17DeleteNamespaceResponse\"o\n" +
+	"\x05Quota\x12!\n" +
+	"\fmax_prefixes\x18\x01 \x01(\x04R\vmaxPrefixes\x12\x17\n" +
+	"\amax_ips\x18\x02 \x01(\x04R\x06maxIps\x12*\n" +
+	"\x11max_address_space\x18\x03 \x01(\tR\x0fmaxAddressSpace\"]\n" +
+	"\x18SetNamespaceQuotaRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12#\n" +
+	"\x05quota\x18\x02 \x01(\v2\r.api.v1.QuotaR\x05quota\"\x1b\n" +
+	"\x19SetNamespaceQuotaResponse\"8\n" +
+	"\x18GetNamespaceQuotaRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x93\x01\n" +
+	"\x19GetNamespaceQuotaResponse\x12#\n" +
+	"\x05quota\x18\x01 \x01(\v2\r.api.v1.QuotaR\x05quota\x12\x1a\n" +
+	"\bprefixes\x18\x02 \x01(\x04R\bprefixes\x12\x10\n" +
+	"\x03ips\x18\x03 \x01(\x04R\x03ips\x12#\n" +
+	"\raddress_space\x18\x04 \x01(\tR\faddressSpace\"\x10\n" +
 	"\x0eVersionRequest\"\x81\x01\n" +
 	"\x0fVersionResponse\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1a\n" +
 	"\brevision\x18\x02 \x01(\tR\brevision\x12\x19\n" +
 	"\bgit_sha1\x18\x03 \x01(\tR\agitSha1\x12\x1d\n" +
 	"\n" +
-	"build_date\x18\x04 \x01(\tR\tbuildDate2\xd1\b\n" +
+	"build_date\x18\x04 \x01(\tR\tbuildDate*\xc9\x01\n" +
+	"\x12AllocationStrategy\x12#\n" +
+	"\x1fALLOCATION_STRATEGY_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eALLOCATION_STRATEGY_FIRST_FREE\x10\x01\x12!\n" +
+	"\x1dALLOCATION_STRATEGY_LAST_FREE\x10\x02\x12'\n" +
+	"#ALLOCATION_STRATEGY_NEXT_AFTER_LAST\x10\x03\x12\x1e\n" +
+	"\x1aALLOCATION_STRATEGY_RANDOM\x10\x04*\xa7\x01\n" +
+	"\x13ChildPrefixStrategy\x12%\n" +
+	"!CHILD_PREFIX_STRATEGY_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eCHILD_PREFIX_STRATEGY_BEST_FIT\x10\x01\x12#\n" +
+	"\x1fCHILD_PREFIX_STRATEGY_FIRST_FIT\x10\x02\x12 \n" +
+	"\x1cCHILD_PREFIX_STRATEGY_SPARSE\x10\x03*e\n" +
+	"\fPoolStrategy\x12\x1d\n" +
+	"\x19POOL_STRATEGY_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18POOL_STRATEGY_FILL_FIRST\x10\x01\x12\x18\n" +
+	"\x14POOL_STRATEGY_SPREAD\x10\x02*\x95\x01\n" +
+	"\aIPState\x12\x18\n" +
+	"\x14IP_STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rIP_STATE_FREE\x10\x01\x12\x15\n" +
+	"\x11IP_STATE_ACQUIRED\x10\x02\x12\x15\n" +
+	"\x11IP_STATE_RESERVED\x10\x03\x12\x15\n" +
+	"\x11IP_STATE_EXCLUDED\x10\x04\x12\x18\n" +
+	"\x14IP_STATE_QUARANTINED\x10\x052\xa6\x19\n" +
 	"\vIpamService\x12I\n" +
 	"\fCreatePrefix\x12\x1b.api.v1.CreatePrefixRequest\x1a\x1c.api.v1.CreatePrefixResponse\x12I\n" +
-	"\fDeletePrefix\x12\x1b.api.v1.DeletePrefixRequest\x1a\x1c.api.v1.DeletePrefixResponse\x12@\n" +
+	"\fDeletePrefix\x12\x1b.api.v1.DeletePrefixRequest\x1a\x1c.api.v1.DeletePrefixResponse\x12I\n" +
+	"\fUpdatePrefix\x12\x1b.api.v1.UpdatePrefixRequest\x1a\x1c.api.v1.UpdatePrefixResponse\x12I\n" +
+	"\fResizePrefix\x12\x1b.api.v1.ResizePrefixRequest\x1a\x1c.api.v1.ResizePrefixResponse\x12F\n" +
+	"\vSplitPrefix\x12\x1a.api.v1.SplitPrefixRequest\x1a\x1b.api.v1.SplitPrefixResponse\x12L\n" +
+	"\rMergePrefixes\x12\x1c.api.v1.MergePrefixesRequest\x1a\x1d.api.v1.MergePrefixesResponse\x12@\n" +
 	"\tGetPrefix\x12\x18.api.v1.GetPrefixRequest\x1a\x19.api.v1.GetPrefixResponse\x12I\n" +
 	"\fListPrefixes\x12\x1b.api.v1.ListPrefixesRequest\x1a\x1c.api.v1.ListPrefixesResponse\x12F\n" +
-	"\vPrefixUsage\x12\x1a.api.v1.PrefixUsageRequest\x1a\x1b.api.v1.PrefixUsageResponse\x12[\n" +
-	"\x12AcquireChildPrefix\x12!.api.v1.AcquireChildPrefixRequest\x1a\".api.v1.AcquireChildPrefixResponse\x12[\n" +
+	"\vPrefixUsage\x12\x1a.api.v1.PrefixUsageRequest\x1a\x1b.api.v1.PrefixUsageResponse\x12L\n" +
+	"\rGetPrefixTree\x12\x1c.api.v1.GetPrefixTreeRequest\x1a\x1d.api.v1.GetPrefixTreeResponse\x12^\n" +
+	"\x13FragmentationReport\x12\".api.v1.FragmentationReportRequest\x1a#.api.v1.FragmentationReportResponse\x12^\n" +
+	"\x13PlanDefragmentation\x12\".api.v1.PlanDefragmentationRequest\x1a#.api.v1.PlanDefragmentationResponse\x12C\n" +
+	"\n" +
+	"CreatePool\x12\x19.api.v1.CreatePoolRequest\x1a\x1a.api.v1.CreatePoolResponse\x12C\n" +
+	"\n" +
+	"UpdatePool\x12\x19.api.v1.UpdatePoolRequest\x1a\x1a.api.v1.UpdatePoolResponse\x12:\n" +
+	"\aGetPool\x12\x16.api.v1.GetPoolRequest\x1a\x17.api.v1.GetPoolResponse\x12@\n" +
+	"\tListPools\x12\x18.api.v1.ListPoolsRequest\x1a\x19.api.v1.ListPoolsResponse\x12C\n" +
+	"\n" +
+	"DeletePool\x12\x19.api.v1.DeletePoolRequest\x1a\x1a.api.v1.DeletePoolResponse\x12X\n" +
+	"\x11AcquireIPFromPool\x12 .api.v1.AcquireIPFromPoolRequest\x1a!.api.v1.AcquireIPFromPoolResponse\x12s\n" +
+	"\x1aAcquireChildPrefixFromPool\x12).api.v1.AcquireChildPrefixFromPoolRequest\x1a*.api.v1.AcquireChildPrefixFromPoolResponse\x12[\n" +
+	"\x12AcquireChildPrefix\x12!.api.v1.AcquireChildPrefixRequest\x1a\".api.v1.AcquireChildPrefixResponse\x12a\n" +
+	"\x14AcquireChildPrefixes\x12#.api.v1.AcquireChildPrefixesRequest\x1a$.api.v1.AcquireChildPrefixesResponse\x12[\n" +
 	"\x12ReleaseChildPrefix\x12!.api.v1.ReleaseChildPrefixRequest\x1a\".api.v1.ReleaseChildPrefixResponse\x12@\n" +
-	"\tAcquireIP\x12\x18.api.v1.AcquireIPRequest\x1a\x19.api.v1.AcquireIPResponse\x12@\n" +
-	"\tReleaseIP\x12\x18.api.v1.ReleaseIPRequest\x1a\x19.api.v1.ReleaseIPResponse\x121\n" +
+	"\tAcquireIP\x12\x18.api.v1.AcquireIPRequest\x1a\x19.api.v1.AcquireIPResponse\x12C\n" +
+	"\n" +
+	"AcquireIPs\x12\x19.api.v1.AcquireIPsRequest\x1a\x1a.api.v1.AcquireIPsResponse\x12U\n" +
+	"\x10AcquireDualStack\x12\x1f.api.v1.AcquireDualStackRequest\x1a .api.v1.AcquireDualStackResponse\x12U\n" +
+	"\x10AcquireIPFromMAC\x12\x1f.api.v1.AcquireIPFromMACRequest\x1a .api.v1.AcquireIPFromMACResponse\x12R\n" +
+	"\x0fAcquireIPForKey\x12\x1e.api.v1.AcquireIPForKeyRequest\x1a\x1f.api.v1.AcquireIPForKeyResponse\x12O\n" +
+	"\x0eAcquireIPRange\x12\x1d.api.v1.AcquireIPRangeRequest\x1a\x1e.api.v1.AcquireIPRangeResponse\x12O\n" +
+	"\x0eReleaseIPRange\x12\x1d.api.v1.ReleaseIPRangeRequest\x1a\x1e.api.v1.ReleaseIPRangeResponse\x12@\n" +
+	"\tReleaseIP\x12\x18.api.v1.ReleaseIPRequest\x1a\x19.api.v1.ReleaseIPResponse\x124\n" +
+	"\x05GetIP\x12\x14.api.v1.GetIPRequest\x1a\x15.api.v1.GetIPResponse\x12=\n" +
+	"\bLookupIP\x12\x17.api.v1.LookupIPRequest\x1a\x18.api.v1.LookupIPResponse\x12C\n" +
+	"\n" +
+	"RenewLease\x12\x19.api.v1.RenewLeaseRequest\x1a\x1a.api.v1.RenewLeaseResponse\x12C\n" +
+	"\n" +
+	"ListLeases\x12\x19.api.v1.ListLeasesRequest\x1a\x1a.api.v1.ListLeasesResponse\x121\n" +
 	"\x04Dump\x12\x13.api.v1.DumpRequest\x1a\x14.api.v1.DumpResponse\x121\n" +
 	"\x04Load\x12\x13.api.v1.LoadRequest\x1a\x14.api.v1.LoadResponse\x12R\n" +
 	"\x0fCreateNamespace\x12\x1e.api.v1.CreateNamespaceRequest\x1a\x1f.api.v1.CreateNamespaceResponse\x12O\n" +
 	"\x0eListNamespaces\x12\x1d.api.v1.ListNamespacesRequest\x1a\x1e.api.v1.ListNamespacesResponse\x12R\n" +
-	"\x0fDeleteNamespace\x12\x1e.api.v1.DeleteNamespaceRequest\x1a\x1f.api.v1.DeleteNamespaceResponse\x12:\n" +
+	"\x0fDeleteNamespace\x12\x1e.api.v1.DeleteNamespaceRequest\x1a\x1f.api.v1.DeleteNamespaceResponse\x12X\n" +
+	"\x11SetNamespaceQuota\x12 .api.v1.SetNamespaceQuotaRequest\x1a!.api.v1.SetNamespaceQuotaResponse\x12X\n" +
+	"\x11GetNamespaceQuota\x12 .api.v1.GetNamespaceQuotaRequest\x1a!.api.v1.GetNamespaceQuotaResponse\x12:\n" +
 	"\aVersion\x12\x16.api.v1.VersionRequest\x1a\x17.api.v1.VersionResponseB}\n" +
 	"\n" +
 	"com.api.v1B\tIpamProtoP\x01Z+github.com/metal-stack/go-ipam/api/v1;apiv1\xa2\x02\x03AXX\xaa\x02\x06Api.V1\xca\x02\x06Api\\V1\xe2\x02\x12Api\\V1\\GPBMetadata\xea\x02\aApi::V1b\x06proto3"
@@ -1731,85 +6791,325 @@ func file_api_v1_ipam_proto_rawDescGZIP() []byte {
 	return file_api_v1_ipam_proto_rawDescData
 }
 
-var file_api_v1_ipam_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_api_v1_ipam_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_v1_ipam_proto_msgTypes = make([]protoimpl.MessageInfo, 111)
 var file_api_v1_ipam_proto_goTypes = []any{
-	(*Prefix)(nil),                     // 0: api.v1.Prefix
-	(*CreatePrefixResponse)(nil),       // 1: api.v1.CreatePrefixResponse
-	(*DeletePrefixResponse)(nil),       // 2: api.v1.DeletePrefixResponse
-	(*GetPrefixResponse)(nil),          // 3: api.v1.GetPrefixResponse
-	(*AcquireChildPrefixResponse)(nil), // 4: api.v1.AcquireChildPrefixResponse
-	(*ReleaseChildPrefixResponse)(nil), // 5: api.v1.ReleaseChildPrefixResponse
-	(*CreatePrefixRequest)(nil),        // 6: api.v1.CreatePrefixRequest
-	(*DeletePrefixRequest)(nil),        // 7: api.v1.DeletePrefixRequest
-	(*GetPrefixRequest)(nil),           // 8: api.v1.GetPrefixRequest
-	(*ListPrefixesRequest)(nil),        // 9: api.v1.ListPrefixesRequest
-	(*ListPrefixesResponse)(nil),       // 10: api.v1.ListPrefixesResponse
-	(*PrefixUsageRequest)(nil),         // 11: api.v1.PrefixUsageRequest
-	(*PrefixUsageResponse)(nil),        // 12: api.v1.PrefixUsageResponse
-	(*AcquireChildPrefixRequest)(nil),  // 13: api.v1.AcquireChildPrefixRequest
-	(*ReleaseChildPrefixRequest)(nil),  // 14: api.v1.ReleaseChildPrefixRequest
-	(*IP)(nil),                         // 15: api.v1.IP
-	(*AcquireIPResponse)(nil),          // 16: api.v1.AcquireIPResponse
-	(*ReleaseIPResponse)(nil),          // 17: api.v1.ReleaseIPResponse
-	(*AcquireIPRequest)(nil),           // 18: api.v1.AcquireIPRequest
-	(*ReleaseIPRequest)(nil),           // 19: api.v1.ReleaseIPRequest
-	(*DumpRequest)(nil),                // 20: api.v1.DumpRequest
-	(*DumpResponse)(nil),               // 21: api.v1.DumpResponse
-	(*LoadRequest)(nil),                // 22: api.v1.LoadRequest
-	(*LoadResponse)(nil),               // 23: api.v1.LoadResponse
-	(*CreateNamespaceRequest)(nil),     // 24: api.v1.CreateNamespaceRequest
-	(*CreateNamespaceResponse)(nil),    // 25: api.v1.CreateNamespaceResponse
-	(*ListNamespacesRequest)(nil),      // 26: api.v1.ListNamespacesRequest
-	(*ListNamespacesResponse)(nil),     // 27: api.v1.ListNamespacesResponse
-	(*DeleteNamespaceRequest)(nil),     // 28: api.v1.DeleteNamespaceRequest
-	(*DeleteNamespaceResponse)(nil),    // 29: api.v1.DeleteNamespaceResponse
-	(*VersionRequest)(nil),             // 30: api.v1.VersionRequest
-	(*VersionResponse)(nil),            // 31: api.v1.VersionResponse
+	(AllocationStrategy)(0),                    // 0: api.v1.AllocationStrategy
+	(ChildPrefixStrategy)(0),                   // 1: api.v1.ChildPrefixStrategy
+	(PoolStrategy)(0),                          // 2: api.v1.PoolStrategy
+	(IPState)(0),                               // 3: api.v1.IPState
+	(*Prefix)(nil),                             // 4: api.v1.Prefix
+	(*ReservedIPs)(nil),                        // 5: api.v1.ReservedIPs
+	(*Exclusions)(nil),                         // 6: api.v1.Exclusions
+	(*Policy)(nil),                             // 7: api.v1.Policy
+	(*CreatePrefixResponse)(nil),               // 8: api.v1.CreatePrefixResponse
+	(*DeletePrefixResponse)(nil),               // 9: api.v1.DeletePrefixResponse
+	(*IPRanges)(nil),                           // 10: api.v1.IPRanges
+	(*UpdatePrefixResponse)(nil),               // 11: api.v1.UpdatePrefixResponse
+	(*GetPrefixResponse)(nil),                  // 12: api.v1.GetPrefixResponse
+	(*AcquireChildPrefixResponse)(nil),         // 13: api.v1.AcquireChildPrefixResponse
+	(*AcquireChildPrefixesResponse)(nil),       // 14: api.v1.AcquireChildPrefixesResponse
+	(*ReleaseChildPrefixResponse)(nil),         // 15: api.v1.ReleaseChildPrefixResponse
+	(*CreatePrefixRequest)(nil),                // 16: api.v1.CreatePrefixRequest
+	(*DeletePrefixRequest)(nil),                // 17: api.v1.DeletePrefixRequest
+	(*UpdatePrefixRequest)(nil),                // 18: api.v1.UpdatePrefixRequest
+	(*ResizePrefixRequest)(nil),                // 19: api.v1.ResizePrefixRequest
+	(*ResizePrefixResponse)(nil),               // 20: api.v1.ResizePrefixResponse
+	(*SplitPrefixRequest)(nil),                 // 21: api.v1.SplitPrefixRequest
+	(*SplitPrefixResponse)(nil),                // 22: api.v1.SplitPrefixResponse
+	(*MergePrefixesRequest)(nil),               // 23: api.v1.MergePrefixesRequest
+	(*MergePrefixesResponse)(nil),              // 24: api.v1.MergePrefixesResponse
+	(*GetPrefixRequest)(nil),                   // 25: api.v1.GetPrefixRequest
+	(*ListPrefixesRequest)(nil),                // 26: api.v1.ListPrefixesRequest
+	(*ListPrefixesResponse)(nil),               // 27: api.v1.ListPrefixesResponse
+	(*PrefixUsageRequest)(nil),                 // 28: api.v1.PrefixUsageRequest
+	(*PrefixUsageResponse)(nil),                // 29: api.v1.PrefixUsageResponse
+	(*GetPrefixTreeRequest)(nil),               // 30: api.v1.GetPrefixTreeRequest
+	(*GetPrefixTreeResponse)(nil),              // 31: api.v1.GetPrefixTreeResponse
+	(*PrefixNode)(nil),                         // 32: api.v1.PrefixNode
+	(*FragmentationReportRequest)(nil),         // 33: api.v1.FragmentationReportRequest
+	(*FragmentationReportResponse)(nil),        // 34: api.v1.FragmentationReportResponse
+	(*PlanDefragmentationRequest)(nil),         // 35: api.v1.PlanDefragmentationRequest
+	(*PlanDefragmentationResponse)(nil),        // 36: api.v1.PlanDefragmentationResponse
+	(*ChildPrefixMove)(nil),                    // 37: api.v1.ChildPrefixMove
+	(*AcquireChildPrefixRequest)(nil),          // 38: api.v1.AcquireChildPrefixRequest
+	(*AcquireChildPrefixesRequest)(nil),        // 39: api.v1.AcquireChildPrefixesRequest
+	(*ReleaseChildPrefixRequest)(nil),          // 40: api.v1.ReleaseChildPrefixRequest
+	(*IP)(nil),                                 // 41: api.v1.IP
+	(*Lease)(nil),                              // 42: api.v1.Lease
+	(*AcquireIPResponse)(nil),                  // 43: api.v1.AcquireIPResponse
+	(*ReleaseIPResponse)(nil),                  // 44: api.v1.ReleaseIPResponse
+	(*AcquireIPRequest)(nil),                   // 45: api.v1.AcquireIPRequest
+	(*AcquireIPsRequest)(nil),                  // 46: api.v1.AcquireIPsRequest
+	(*AcquireIPsResponse)(nil),                 // 47: api.v1.AcquireIPsResponse
+	(*AcquireDualStackRequest)(nil),            // 48: api.v1.AcquireDualStackRequest
+	(*AcquireDualStackResponse)(nil),           // 49: api.v1.AcquireDualStackResponse
+	(*AcquireIPFromMACRequest)(nil),            // 50: api.v1.AcquireIPFromMACRequest
+	(*AcquireIPFromMACResponse)(nil),           // 51: api.v1.AcquireIPFromMACResponse
+	(*AcquireIPForKeyRequest)(nil),             // 52: api.v1.AcquireIPForKeyRequest
+	(*AcquireIPForKeyResponse)(nil),            // 53: api.v1.AcquireIPForKeyResponse
+	(*IPRange)(nil),                            // 54: api.v1.IPRange
+	(*AcquireIPRangeRequest)(nil),              // 55: api.v1.AcquireIPRangeRequest
+	(*AcquireIPRangeResponse)(nil),             // 56: api.v1.AcquireIPRangeResponse
+	(*ReleaseIPRangeRequest)(nil),              // 57: api.v1.ReleaseIPRangeRequest
+	(*ReleaseIPRangeResponse)(nil),             // 58: api.v1.ReleaseIPRangeResponse
+	(*ReleaseIPRequest)(nil),                   // 59: api.v1.ReleaseIPRequest
+	(*GetIPRequest)(nil),                       // 60: api.v1.GetIPRequest
+	(*GetIPResponse)(nil),                      // 61: api.v1.GetIPResponse
+	(*LookupIPRequest)(nil),                    // 62: api.v1.LookupIPRequest
+	(*LookupIPResponse)(nil),                   // 63: api.v1.LookupIPResponse
+	(*RenewLeaseRequest)(nil),                  // 64: api.v1.RenewLeaseRequest
+	(*RenewLeaseResponse)(nil),                 // 65: api.v1.RenewLeaseResponse
+	(*ListLeasesRequest)(nil),                  // 66: api.v1.ListLeasesRequest
+	(*ListLeasesResponse)(nil),                 // 67: api.v1.ListLeasesResponse
+	(*DumpRequest)(nil),                        // 68: api.v1.DumpRequest
+	(*DumpResponse)(nil),                       // 69: api.v1.DumpResponse
+	(*LoadRequest)(nil),                        // 70: api.v1.LoadRequest
+	(*LoadResponse)(nil),                       // 71: api.v1.LoadResponse
+	(*Pool)(nil),                               // 72: api.v1.Pool
+	(*CreatePoolRequest)(nil),                  // 73: api.v1.CreatePoolRequest
+	(*CreatePoolResponse)(nil),                 // 74: api.v1.CreatePoolResponse
+	(*UpdatePoolRequest)(nil),                  // 75: api.v1.UpdatePoolRequest
+	(*UpdatePoolResponse)(nil),                 // 76: api.v1.UpdatePoolResponse
+	(*GetPoolRequest)(nil),                     // 77: api.v1.GetPoolRequest
+	(*GetPoolResponse)(nil),                    // 78: api.v1.GetPoolResponse
+	(*ListPoolsRequest)(nil),                   // 79: api.v1.ListPoolsRequest
+	(*ListPoolsResponse)(nil),                  // 80: api.v1.ListPoolsResponse
+	(*DeletePoolRequest)(nil),                  // 81: api.v1.DeletePoolRequest
+	(*DeletePoolResponse)(nil),                 // 82: api.v1.DeletePoolResponse
+	(*AcquireIPFromPoolRequest)(nil),           // 83: api.v1.AcquireIPFromPoolRequest
+	(*AcquireIPFromPoolResponse)(nil),          // 84: api.v1.AcquireIPFromPoolResponse
+	(*AcquireChildPrefixFromPoolRequest)(nil),  // 85: api.v1.AcquireChildPrefixFromPoolRequest
+	(*AcquireChildPrefixFromPoolResponse)(nil), // 86: api.v1.AcquireChildPrefixFromPoolResponse
+	(*CreateNamespaceRequest)(nil),             // 87: api.v1.CreateNamespaceRequest
+	(*CreateNamespaceResponse)(nil),            // 88: api.v1.CreateNamespaceResponse
+	(*ListNamespacesRequest)(nil),              // 89: api.v1.ListNamespacesRequest
+	(*ListNamespacesResponse)(nil),             // 90: api.v1.ListNamespacesResponse
+	(*DeleteNamespaceRequest)(nil),             // 91: api.v1.DeleteNamespaceRequest
+	(*DeleteNamespaceResponse)(nil),            // 92: api.v1.DeleteNamespaceResponse
+	(*Quota)(nil),                              // 93: api.v1.Quota
+	(*SetNamespaceQuotaRequest)(nil),           // 94: api.v1.SetNamespaceQuotaRequest
+	(*SetNamespaceQuotaResponse)(nil),          // 95: api.v1.SetNamespaceQuotaResponse
+	(*GetNamespaceQuotaRequest)(nil),           // 96: api.v1.GetNamespaceQuotaRequest
+	(*GetNamespaceQuotaResponse)(nil),          // 97: api.v1.GetNamespaceQuotaResponse
+	(*VersionRequest)(nil),                     // 98: api.v1.VersionRequest
+	(*VersionResponse)(nil),                    // 99: api.v1.VersionResponse
+	nil,                                        // 100: api.v1.Prefix.LabelsEntry
+	nil,                                        // 101: api.v1.DeletePrefixResponse.ReleasedIpRangesEntry
+	nil,                                        // 102: api.v1.CreatePrefixRequest.LabelsEntry
+	nil,                                        // 103: api.v1.UpdatePrefixRequest.LabelsEntry
+	nil,                                        // 104: api.v1.FragmentationReportResponse.FreeBlocksEntry
+	nil,                                        // 105: api.v1.AcquireChildPrefixRequest.LabelsEntry
+	nil,                                        // 106: api.v1.AcquireChildPrefixesRequest.LabelsEntry
+	nil,                                        // 107: api.v1.IP.AnnotationsEntry
+	nil,                                        // 108: api.v1.AcquireIPRequest.AnnotationsEntry
+	nil,                                        // 109: api.v1.AcquireIPsRequest.AnnotationsEntry
+	nil,                                        // 110: api.v1.AcquireDualStackRequest.AnnotationsEntry
+	nil,                                        // 111: api.v1.AcquireIPFromMACRequest.AnnotationsEntry
+	nil,                                        // 112: api.v1.AcquireIPForKeyRequest.AnnotationsEntry
+	nil,                                        // 113: api.v1.AcquireIPFromPoolRequest.AnnotationsEntry
+	nil,                                        // 114: api.v1.AcquireChildPrefixFromPoolRequest.LabelsEntry
+	(*durationpb.Duration)(nil),                // 115: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),              // 116: google.protobuf.Timestamp
 }
 var file_api_v1_ipam_proto_depIdxs = []int32{
-	0,  // 0: api.v1.CreatePrefixResponse.prefix:type_name -> api.v1.Prefix
-	0,  // 1: api.v1.DeletePrefixResponse.prefix:type_name -> api.v1.Prefix
-	0,  // 2: api.v1.GetPrefixResponse.prefix:type_name -> api.v1.Prefix
-	0,  // 3: api.v1.AcquireChildPrefixResponse.prefix:type_name -> api.v1.Prefix
-	0,  // 4: api.v1.ReleaseChildPrefixResponse.prefix:type_name -> api.v1.Prefix
-	0,  // 5: api.v1.ListPrefixesResponse.prefixes:type_name -> api.v1.Prefix
-	15, // 6: api.v1.AcquireIPResponse.ip:type_name -> api.v1.IP
-	15, // 7: api.v1.ReleaseIPResponse.ip:type_name -> api.v1.IP
-	6,  // 8: api.v1.IpamService.CreatePrefix:input_type -> api.v1.CreatePrefixRequest
-	7,  // 9: api.v1.IpamService.DeletePrefix:input_type -> api.v1.DeletePrefixRequest
-	8,  // 10: api.v1.IpamService.GetPrefix:input_type -> api.v1.GetPrefixRequest
-	9,  // 11: api.v1.IpamService.ListPrefixes:input_type -> api.v1.ListPrefixesRequest
-	11, // 12: api.v1.IpamService.PrefixUsage:input_type -> api.v1.PrefixUsageRequest
-	13, // 13: api.v1.IpamService.AcquireChildPrefix:input_type -> api.v1.AcquireChildPrefixRequest
-	14, // 14: api.v1.IpamService.ReleaseChildPrefix:input_type -> api.v1.ReleaseChildPrefixRequest
-	18, // 15: api.v1.IpamService.AcquireIP:input_type -> api.v1.AcquireIPRequest
-	19, // 16: api.v1.IpamService.ReleaseIP:input_type -> api.v1.ReleaseIPRequest
-	20, // 17: api.v1.IpamService.Dump:input_type -> api.v1.DumpRequest
-	22, // 18: api.v1.IpamService.Load:input_type -> api.v1.LoadRequest
-	24, // 19: api.v1.IpamService.CreateNamespace:input_type -> api.v1.CreateNamespaceRequest
-	26, // 20: api.v1.IpamService.ListNamespaces:input_type -> api.v1.ListNamespacesRequest
-	28, // 21: api.v1.IpamService.DeleteNamespace:input_type -> api.v1.DeleteNamespaceRequest
-	30, // 22: api.v1.IpamService.Version:input_type -> api.v1.VersionRequest
-	1,  // 23: api.v1.IpamService.CreatePrefix:output_type -> api.v1.CreatePrefixResponse
-	2,  // 24: api.v1.IpamService.DeletePrefix:output_type -> api.v1.DeletePrefixResponse
-	3,  // 25: api.v1.IpamService.GetPrefix:output_type -> api.v1.GetPrefixResponse
-	10, // 26: api.v1.IpamService.ListPrefixes:output_type -> api.v1.ListPrefixesResponse
-	12, // 27: api.v1.IpamService.PrefixUsage:output_type -> api.v1.PrefixUsageResponse
-	4,  // 28: api.v1.IpamService.AcquireChildPrefix:output_type -> api.v1.AcquireChildPrefixResponse
-	5,  // 29: api.v1.IpamService.ReleaseChildPrefix:output_type -> api.v1.ReleaseChildPrefixResponse
-	16, // 30: api.v1.IpamService.AcquireIP:output_type -> api.v1.AcquireIPResponse
-	17, // 31: api.v1.IpamService.ReleaseIP:output_type -> api.v1.ReleaseIPResponse
-	21, // 32: api.v1.IpamService.Dump:output_type -> api.v1.DumpResponse
-	23, // 33: api.v1.IpamService.Load:output_type -> api.v1.LoadResponse
-	25, // 34: api.v1.IpamService.CreateNamespace:output_type -> api.v1.CreateNamespaceResponse
-	27, // 35: api.v1.IpamService.ListNamespaces:output_type -> api.v1.ListNamespacesResponse
-	29, // 36: api.v1.IpamService.DeleteNamespace:output_type -> api.v1.DeleteNamespaceResponse
-	31, // 37: api.v1.IpamService.Version:output_type -> api.v1.VersionResponse
-	23, // [23:38] is the sub-list for method output_type
-	8,  // [8:23] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	100, // 0: api.v1.Prefix.labels:type_name -> api.v1.Prefix.LabelsEntry
+	0,   // 1: api.v1.Prefix.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	42,  // 2: api.v1.Prefix.lease:type_name -> api.v1.Lease
+	115, // 3: api.v1.Prefix.quarantine:type_name -> google.protobuf.Duration
+	7,   // 4: api.v1.Prefix.policy:type_name -> api.v1.Policy
+	1,   // 5: api.v1.Prefix.child_prefix_strategy:type_name -> api.v1.ChildPrefixStrategy
+	4,   // 6: api.v1.CreatePrefixResponse.prefix:type_name -> api.v1.Prefix
+	4,   // 7: api.v1.DeletePrefixResponse.prefix:type_name -> api.v1.Prefix
+	4,   // 8: api.v1.DeletePrefixResponse.deleted_prefixes:type_name -> api.v1.Prefix
+	41,  // 9: api.v1.DeletePrefixResponse.released_ips:type_name -> api.v1.IP
+	101, // 10: api.v1.DeletePrefixResponse.released_ip_ranges:type_name -> api.v1.DeletePrefixResponse.ReleasedIpRangesEntry
+	4,   // 11: api.v1.UpdatePrefixResponse.prefix:type_name -> api.v1.Prefix
+	4,   // 12: api.v1.GetPrefixResponse.prefix:type_name -> api.v1.Prefix
+	4,   // 13: api.v1.AcquireChildPrefixResponse.prefix:type_name -> api.v1.Prefix
+	4,   // 14: api.v1.AcquireChildPrefixesResponse.prefixes:type_name -> api.v1.Prefix
+	4,   // 15: api.v1.ReleaseChildPrefixResponse.prefix:type_name -> api.v1.Prefix
+	102, // 16: api.v1.CreatePrefixRequest.labels:type_name -> api.v1.CreatePrefixRequest.LabelsEntry
+	5,   // 17: api.v1.CreatePrefixRequest.reserved_ips:type_name -> api.v1.ReservedIPs
+	0,   // 18: api.v1.CreatePrefixRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	6,   // 19: api.v1.CreatePrefixRequest.exclusions:type_name -> api.v1.Exclusions
+	115, // 20: api.v1.CreatePrefixRequest.quarantine:type_name -> google.protobuf.Duration
+	7,   // 21: api.v1.CreatePrefixRequest.policy:type_name -> api.v1.Policy
+	1,   // 22: api.v1.CreatePrefixRequest.child_prefix_strategy:type_name -> api.v1.ChildPrefixStrategy
+	103, // 23: api.v1.UpdatePrefixRequest.labels:type_name -> api.v1.UpdatePrefixRequest.LabelsEntry
+	5,   // 24: api.v1.UpdatePrefixRequest.reserved_ips:type_name -> api.v1.ReservedIPs
+	0,   // 25: api.v1.UpdatePrefixRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	6,   // 26: api.v1.UpdatePrefixRequest.exclusions:type_name -> api.v1.Exclusions
+	115, // 27: api.v1.UpdatePrefixRequest.quarantine:type_name -> google.protobuf.Duration
+	7,   // 28: api.v1.UpdatePrefixRequest.policy:type_name -> api.v1.Policy
+	1,   // 29: api.v1.UpdatePrefixRequest.child_prefix_strategy:type_name -> api.v1.ChildPrefixStrategy
+	4,   // 30: api.v1.ResizePrefixResponse.prefix:type_name -> api.v1.Prefix
+	4,   // 31: api.v1.SplitPrefixResponse.prefixes:type_name -> api.v1.Prefix
+	4,   // 32: api.v1.MergePrefixesResponse.prefix:type_name -> api.v1.Prefix
+	4,   // 33: api.v1.ListPrefixesResponse.prefixes:type_name -> api.v1.Prefix
+	32,  // 34: api.v1.GetPrefixTreeResponse.nodes:type_name -> api.v1.PrefixNode
+	4,   // 35: api.v1.PrefixNode.prefix:type_name -> api.v1.Prefix
+	29,  // 36: api.v1.PrefixNode.usage:type_name -> api.v1.PrefixUsageResponse
+	32,  // 37: api.v1.PrefixNode.children:type_name -> api.v1.PrefixNode
+	104, // 38: api.v1.FragmentationReportResponse.free_blocks:type_name -> api.v1.FragmentationReportResponse.FreeBlocksEntry
+	37,  // 39: api.v1.PlanDefragmentationResponse.moves:type_name -> api.v1.ChildPrefixMove
+	105, // 40: api.v1.AcquireChildPrefixRequest.labels:type_name -> api.v1.AcquireChildPrefixRequest.LabelsEntry
+	5,   // 41: api.v1.AcquireChildPrefixRequest.reserved_ips:type_name -> api.v1.ReservedIPs
+	0,   // 42: api.v1.AcquireChildPrefixRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	6,   // 43: api.v1.AcquireChildPrefixRequest.exclusions:type_name -> api.v1.Exclusions
+	115, // 44: api.v1.AcquireChildPrefixRequest.ttl:type_name -> google.protobuf.Duration
+	115, // 45: api.v1.AcquireChildPrefixRequest.quarantine:type_name -> google.protobuf.Duration
+	1,   // 46: api.v1.AcquireChildPrefixRequest.placement:type_name -> api.v1.ChildPrefixStrategy
+	106, // 47: api.v1.AcquireChildPrefixesRequest.labels:type_name -> api.v1.AcquireChildPrefixesRequest.LabelsEntry
+	5,   // 48: api.v1.AcquireChildPrefixesRequest.reserved_ips:type_name -> api.v1.ReservedIPs
+	0,   // 49: api.v1.AcquireChildPrefixesRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	6,   // 50: api.v1.AcquireChildPrefixesRequest.exclusions:type_name -> api.v1.Exclusions
+	115, // 51: api.v1.AcquireChildPrefixesRequest.ttl:type_name -> google.protobuf.Duration
+	115, // 52: api.v1.AcquireChildPrefixesRequest.quarantine:type_name -> google.protobuf.Duration
+	1,   // 53: api.v1.AcquireChildPrefixesRequest.placement:type_name -> api.v1.ChildPrefixStrategy
+	107, // 54: api.v1.IP.annotations:type_name -> api.v1.IP.AnnotationsEntry
+	42,  // 55: api.v1.IP.lease:type_name -> api.v1.Lease
+	116, // 56: api.v1.Lease.expires:type_name -> google.protobuf.Timestamp
+	41,  // 57: api.v1.AcquireIPResponse.ip:type_name -> api.v1.IP
+	41,  // 58: api.v1.ReleaseIPResponse.ip:type_name -> api.v1.IP
+	108, // 59: api.v1.AcquireIPRequest.annotations:type_name -> api.v1.AcquireIPRequest.AnnotationsEntry
+	0,   // 60: api.v1.AcquireIPRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	115, // 61: api.v1.AcquireIPRequest.ttl:type_name -> google.protobuf.Duration
+	109, // 62: api.v1.AcquireIPsRequest.annotations:type_name -> api.v1.AcquireIPsRequest.AnnotationsEntry
+	0,   // 63: api.v1.AcquireIPsRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	115, // 64: api.v1.AcquireIPsRequest.ttl:type_name -> google.protobuf.Duration
+	41,  // 65: api.v1.AcquireIPsResponse.ips:type_name -> api.v1.IP
+	110, // 66: api.v1.AcquireDualStackRequest.annotations:type_name -> api.v1.AcquireDualStackRequest.AnnotationsEntry
+	0,   // 67: api.v1.AcquireDualStackRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	115, // 68: api.v1.AcquireDualStackRequest.ttl:type_name -> google.protobuf.Duration
+	41,  // 69: api.v1.AcquireDualStackResponse.ipv4:type_name -> api.v1.IP
+	41,  // 70: api.v1.AcquireDualStackResponse.ipv6:type_name -> api.v1.IP
+	111, // 71: api.v1.AcquireIPFromMACRequest.annotations:type_name -> api.v1.AcquireIPFromMACRequest.AnnotationsEntry
+	115, // 72: api.v1.AcquireIPFromMACRequest.ttl:type_name -> google.protobuf.Duration
+	41,  // 73: api.v1.AcquireIPFromMACResponse.ip:type_name -> api.v1.IP
+	112, // 74: api.v1.AcquireIPForKeyRequest.annotations:type_name -> api.v1.AcquireIPForKeyRequest.AnnotationsEntry
+	115, // 75: api.v1.AcquireIPForKeyRequest.ttl:type_name -> google.protobuf.Duration
+	41,  // 76: api.v1.AcquireIPForKeyResponse.ip:type_name -> api.v1.IP
+	54,  // 77: api.v1.AcquireIPRangeResponse.ip_range:type_name -> api.v1.IPRange
+	54,  // 78: api.v1.ReleaseIPRangeResponse.ip_range:type_name -> api.v1.IPRange
+	41,  // 79: api.v1.GetIPResponse.ip:type_name -> api.v1.IP
+	41,  // 80: api.v1.LookupIPResponse.ip:type_name -> api.v1.IP
+	4,   // 81: api.v1.LookupIPResponse.prefixes:type_name -> api.v1.Prefix
+	3,   // 82: api.v1.LookupIPResponse.state:type_name -> api.v1.IPState
+	115, // 83: api.v1.RenewLeaseRequest.ttl:type_name -> google.protobuf.Duration
+	42,  // 84: api.v1.RenewLeaseResponse.lease:type_name -> api.v1.Lease
+	42,  // 85: api.v1.ListLeasesResponse.leases:type_name -> api.v1.Lease
+	2,   // 86: api.v1.Pool.strategy:type_name -> api.v1.PoolStrategy
+	2,   // 87: api.v1.CreatePoolRequest.strategy:type_name -> api.v1.PoolStrategy
+	72,  // 88: api.v1.CreatePoolResponse.pool:type_name -> api.v1.Pool
+	2,   // 89: api.v1.UpdatePoolRequest.strategy:type_name -> api.v1.PoolStrategy
+	72,  // 90: api.v1.UpdatePoolResponse.pool:type_name -> api.v1.Pool
+	72,  // 91: api.v1.GetPoolResponse.pool:type_name -> api.v1.Pool
+	72,  // 92: api.v1.ListPoolsResponse.pools:type_name -> api.v1.Pool
+	113, // 93: api.v1.AcquireIPFromPoolRequest.annotations:type_name -> api.v1.AcquireIPFromPoolRequest.AnnotationsEntry
+	0,   // 94: api.v1.AcquireIPFromPoolRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	115, // 95: api.v1.AcquireIPFromPoolRequest.ttl:type_name -> google.protobuf.Duration
+	41,  // 96: api.v1.AcquireIPFromPoolResponse.ip:type_name -> api.v1.IP
+	114, // 97: api.v1.AcquireChildPrefixFromPoolRequest.labels:type_name -> api.v1.AcquireChildPrefixFromPoolRequest.LabelsEntry
+	5,   // 98: api.v1.AcquireChildPrefixFromPoolRequest.reserved_ips:type_name -> api.v1.ReservedIPs
+	0,   // 99: api.v1.AcquireChildPrefixFromPoolRequest.allocation_strategy:type_name -> api.v1.AllocationStrategy
+	6,   // 100: api.v1.AcquireChildPrefixFromPoolRequest.exclusions:type_name -> api.v1.Exclusions
+	115, // 101: api.v1.AcquireChildPrefixFromPoolRequest.ttl:type_name -> google.protobuf.Duration
+	115, // 102: api.v1.AcquireChildPrefixFromPoolRequest.quarantine:type_name -> google.protobuf.Duration
+	1,   // 103: api.v1.AcquireChildPrefixFromPoolRequest.placement:type_name -> api.v1.ChildPrefixStrategy
+	4,   // 104: api.v1.AcquireChildPrefixFromPoolResponse.prefix:type_name -> api.v1.Prefix
+	93,  // 105: api.v1.SetNamespaceQuotaRequest.quota:type_name -> api.v1.Quota
+	93,  // 106: api.v1.GetNamespaceQuotaResponse.quota:type_name -> api.v1.Quota
+	10,  // 107: api.v1.DeletePrefixResponse.ReleasedIpRangesEntry.value:type_name -> api.v1.IPRanges
+	16,  // 108: api.v1.IpamService.CreatePrefix:input_type -> api.v1.CreatePrefixRequest
+	17,  // 109: api.v1.IpamService.DeletePrefix:input_type -> api.v1.DeletePrefixRequest
+	18,  // 110: api.v1.IpamService.UpdatePrefix:input_type -> api.v1.UpdatePrefixRequest
+	19,  // 111: api.v1.IpamService.ResizePrefix:input_type -> api.v1.ResizePrefixRequest
+	21,  // 112: api.v1.IpamService.SplitPrefix:input_type -> api.v1.SplitPrefixRequest
+	23,  // 113: api.v1.IpamService.MergePrefixes:input_type -> api.v1.MergePrefixesRequest
+	25,  // 114: api.v1.IpamService.GetPrefix:input_type -> api.v1.GetPrefixRequest
+	26,  // 115: api.v1.IpamService.ListPrefixes:input_type -> api.v1.ListPrefixesRequest
+	28,  // 116: api.v1.IpamService.PrefixUsage:input_type -> api.v1.PrefixUsageRequest
+	30,  // 117: api.v1.IpamService.GetPrefixTree:input_type -> api.v1.GetPrefixTreeRequest
+	33,  // 118: api.v1.IpamService.FragmentationReport:input_type -> api.v1.FragmentationReportRequest
+	35,  // 119: api.v1.IpamService.PlanDefragmentation:input_type -> api.v1.PlanDefragmentationRequest
+	73,  // 120: api.v1.IpamService.CreatePool:input_type -> api.v1.CreatePoolRequest
+	75,  // 121: api.v1.IpamService.UpdatePool:input_type -> api.v1.UpdatePoolRequest
+	77,  // 122: api.v1.IpamService.GetPool:input_type -> api.v1.GetPoolRequest
+	79,  // 123: api.v1.IpamService.ListPools:input_type -> api.v1.ListPoolsRequest
+	81,  // 124: api.v1.IpamService.DeletePool:input_type -> api.v1.DeletePoolRequest
+	83,  // 125: api.v1.IpamService.AcquireIPFromPool:input_type -> api.v1.AcquireIPFromPoolRequest
+	85,  // 126: api.v1.IpamService.AcquireChildPrefixFromPool:input_type -> api.v1.AcquireChildPrefixFromPoolRequest
+	38,  // 127: api.v1.IpamService.AcquireChildPrefix:input_type -> api.v1.AcquireChildPrefixRequest
+	39,  // 128: api.v1.IpamService.AcquireChildPrefixes:input_type -> api.v1.AcquireChildPrefixesRequest
+	40,  // 129: api.v1.IpamService.ReleaseChildPrefix:input_type -> api.v1.ReleaseChildPrefixRequest
+	45,  // 130: api.v1.IpamService.AcquireIP:input_type -> api.v1.AcquireIPRequest
+	46,  // 131: api.v1.IpamService.AcquireIPs:input_type -> api.v1.AcquireIPsRequest
+	48,  // 132: api.v1.IpamService.AcquireDualStack:input_type -> api.v1.AcquireDualStackRequest
+	50,  // 133: api.v1.IpamService.AcquireIPFromMAC:input_type -> api.v1.AcquireIPFromMACRequest
+	52,  // 134: api.v1.IpamService.AcquireIPForKey:input_type -> api.v1.AcquireIPForKeyRequest
+	55,  // 135: api.v1.IpamService.AcquireIPRange:input_type -> api.v1.AcquireIPRangeRequest
+	57,  // 136: api.v1.IpamService.ReleaseIPRange:input_type -> api.v1.ReleaseIPRangeRequest
+	59,  // 137: api.v1.IpamService.ReleaseIP:input_type -> api.v1.ReleaseIPRequest
+	60,  // 138: api.v1.IpamService.GetIP:input_type -> api.v1.GetIPRequest
+	62,  // 139: api.v1.IpamService.LookupIP:input_type -> api.v1.LookupIPRequest
+	64,  // 140: api.v1.IpamService.RenewLease:input_type -> api.v1.RenewLeaseRequest
+	66,  // 141: api.v1.IpamService.ListLeases:input_type -> api.v1.ListLeasesRequest
+	68,  // 142: api.v1.IpamService.Dump:input_type -> api.v1.DumpRequest
+	70,  // 143: api.v1.IpamService.Load:input_type -> api.v1.LoadRequest
+	87,  // 144: api.v1.IpamService.CreateNamespace:input_type -> api.v1.CreateNamespaceRequest
+	89,  // 145: api.v1.IpamService.ListNamespaces:input_type -> api.v1.ListNamespacesRequest
+	91,  // 146: api.v1.IpamService.DeleteNamespace:input_type -> api.v1.DeleteNamespaceRequest
+	94,  // 147: api.v1.IpamService.SetNamespaceQuota:input_type -> api.v1.SetNamespaceQuotaRequest
+	96,  // 148: api.v1.IpamService.GetNamespaceQuota:input_type -> api.v1.GetNamespaceQuotaRequest
+	98,  // 149: api.v1.IpamService.Version:input_type -> api.v1.VersionRequest
+	8,   // 150: api.v1.IpamService.CreatePrefix:output_type -> api.v1.CreatePrefixResponse
+	9,   // 151: api.v1.IpamService.DeletePrefix:output_type -> api.v1.DeletePrefixResponse
+	11,  // 152: api.v1.IpamService.UpdatePrefix:output_type -> api.v1.UpdatePrefixResponse
+	20,  // 153: api.v1.IpamService.ResizePrefix:output_type -> api.v1.ResizePrefixResponse
+	22,  // 154: api.v1.IpamService.SplitPrefix:output_type -> api.v1.SplitPrefixResponse
+	24,  // 155: api.v1.IpamService.MergePrefixes:output_type -> api.v1.MergePrefixesResponse
+	12,  // 156: api.v1.IpamService.GetPrefix:output_type -> api.v1.GetPrefixResponse
+	27,  // 157: api.v1.IpamService.ListPrefixes:output_type -> api.v1.ListPrefixesResponse
+	29,  // 158: api.v1.IpamService.PrefixUsage:output_type -> api.v1.PrefixUsageResponse
+	31,  // 159: api.v1.IpamService.GetPrefixTree:output_type -> api.v1.GetPrefixTreeResponse
+	34,  // 160: api.v1.IpamService.FragmentationReport:output_type -> api.v1.FragmentationReportResponse
+	36,  // 161: api.v1.IpamService.PlanDefragmentation:output_type -> api.v1.PlanDefragmentationResponse
+	74,  // 162: api.v1.IpamService.CreatePool:output_type -> api.v1.CreatePoolResponse
+	76,  // 163: api.v1.IpamService.UpdatePool:output_type -> api.v1.UpdatePoolResponse
+	78,  // 164: api.v1.IpamService.GetPool:output_type -> api.v1.GetPoolResponse
+	80,  // 165: api.v1.IpamService.ListPools:output_type -> api.v1.ListPoolsResponse
+	82,  // 166: api.v1.IpamService.DeletePool:output_type -> api.v1.DeletePoolResponse
+	84,  // 167: api.v1.IpamService.AcquireIPFromPool:output_type -> api.v1.AcquireIPFromPoolResponse
+	86,  // 168: api.v1.IpamService.AcquireChildPrefixFromPool:output_type -> api.v1.AcquireChildPrefixFromPoolResponse
+	13,  // 169: api.v1.IpamService.AcquireChildPrefix:output_type -> api.v1.AcquireChildPrefixResponse
+	14,  // 170: api.v1.IpamService.AcquireChildPrefixes:output_type -> api.v1.AcquireChildPrefixesResponse
+	15,  // 171: api.v1.IpamService.ReleaseChildPrefix:output_type -> api.v1.ReleaseChildPrefixResponse
+	43,  // 172: api.v1.IpamService.AcquireIP:output_type -> api.v1.AcquireIPResponse
+	47,  // 173: api.v1.IpamService.AcquireIPs:output_type -> api.v1.AcquireIPsResponse
+	49,  // 174: api.v1.IpamService.AcquireDualStack:output_type -> api.v1.AcquireDualStackResponse
+	51,  // 175: api.v1.IpamService.AcquireIPFromMAC:output_type -> api.v1.AcquireIPFromMACResponse
+	53,  // 176: api.v1.IpamService.AcquireIPForKey:output_type -> api.v1.AcquireIPForKeyResponse
+	56,  // 177: api.v1.IpamService.AcquireIPRange:output_type -> api.v1.AcquireIPRangeResponse
+	58,  // 178: api.v1.IpamService.ReleaseIPRange:output_type -> api.v1.ReleaseIPRangeResponse
+	44,  // 179: api.v1.IpamService.ReleaseIP:output_type -> api.v1.ReleaseIPResponse
+	61,  // 180: api.v1.IpamService.GetIP:output_type -> api.v1.GetIPResponse
+	63,  // 181: api.v1.IpamService.LookupIP:output_type -> api.v1.LookupIPResponse
+	65,  // 182: api.v1.IpamService.RenewLease:output_type -> api.v1.RenewLeaseResponse
+	67,  // 183: api.v1.IpamService.ListLeases:output_type -> api.v1.ListLeasesResponse
+	69,  // 184: api.v1.IpamService.Dump:output_type -> api.v1.DumpResponse
+	71,  // 185: api.v1.IpamService.Load:output_type -> api.v1.LoadResponse
+	88,  // 186: api.v1.IpamService.CreateNamespace:output_type -> api.v1.CreateNamespaceResponse
+	90,  // 187: api.v1.IpamService.ListNamespaces:output_type -> api.v1.ListNamespacesResponse
+	92,  // 188: api.v1.IpamService.DeleteNamespace:output_type -> api.v1.DeleteNamespaceResponse
+	95,  // 189: api.v1.IpamService.SetNamespaceQuota:output_type -> api.v1.SetNamespaceQuotaResponse
+	97,  // 190: api.v1.IpamService.GetNamespaceQuota:output_type -> api.v1.GetNamespaceQuotaResponse
+	99,  // 191: api.v1.IpamService.Version:output_type -> api.v1.VersionResponse
+	150, // [150:192] is the sub-list for method output_type
+	108, // [108:150] is the sub-list for method input_type
+	108, // [108:108] is the sub-list for extension type_name
+	108, // [108:108] is the sub-list for extension extendee
+	0,   // [0:108] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_ipam_proto_init() }
@@ -1817,30 +7117,56 @@ func file_api_v1_ipam_proto_init() {
 	if File_api_v1_ipam_proto != nil {
 		return
 	}
-	file_api_v1_ipam_proto_msgTypes[6].OneofWrappers = []any{}
-	file_api_v1_ipam_proto_msgTypes[7].OneofWrappers = []any{}
-	file_api_v1_ipam_proto_msgTypes[8].OneofWrappers = []any{}
-	file_api_v1_ipam_proto_msgTypes[9].OneofWrappers = []any{}
-	file_api_v1_ipam_proto_msgTypes[11].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[12].OneofWrappers = []any{}
 	file_api_v1_ipam_proto_msgTypes[13].OneofWrappers = []any{}
 	file_api_v1_ipam_proto_msgTypes[14].OneofWrappers = []any{}
-	file_api_v1_ipam_proto_msgTypes[16].OneofWrappers = []any{}
-	file_api_v1_ipam_proto_msgTypes[18].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[15].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[17].OneofWrappers = []any{}
 	file_api_v1_ipam_proto_msgTypes[19].OneofWrappers = []any{}
-	file_api_v1_ipam_proto_msgTypes[20].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[21].OneofWrappers = []any{}
 	file_api_v1_ipam_proto_msgTypes[22].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[24].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[26].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[29].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[31].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[34].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[35].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[36].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[39].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[41].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[42].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[44].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[46].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[48].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[51].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[53].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[55].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[56].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[58].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[60].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[62].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[64].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[66].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[69].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[71].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[73].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[75].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[77].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[79].OneofWrappers = []any{}
+	file_api_v1_ipam_proto_msgTypes[81].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_ipam_proto_rawDesc), len(file_api_v1_ipam_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   32,
+			NumEnums:      4,
+			NumMessages:   111,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_ipam_proto_goTypes,
 		DependencyIndexes: file_api_v1_ipam_proto_depIdxs,
+		EnumInfos:         file_api_v1_ipam_proto_enumTypes,
 		MessageInfos:      file_api_v1_ipam_proto_msgTypes,
 	}.Build()
 	File_api_v1_ipam_proto = out.File
@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	compress "github.com/klauspost/connect-compress/v2"
@@ -12,6 +16,7 @@ import (
 	"github.com/metal-stack/go-ipam/api/v1/apiv1connect"
 	"github.com/metal-stack/v"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func main() {
@@ -37,15 +42,47 @@ func main() {
 					{
 						Name:  "create",
 						Usage: "create a prefix",
-						Flags: []cli.Flag{
+						Flags: append([]cli.Flag{
 							&cli.StringFlag{
 								Name: "cidr",
 							},
-						},
+							&cli.StringSliceFlag{
+								Name:  "label",
+								Usage: "label of the prefix in the form key=value, can be given multiple times",
+							},
+							&cli.StringFlag{
+								Name: "description",
+							},
+							strategyFlag(),
+							childStrategyFlag(),
+							quarantineFlag(),
+							idempotencyKeyFlag(),
+						}, slices.Concat(reservedIPsFlags(), exclusionsFlags(), policyFlags())...),
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
+							labels, err := parseKeyValues(ctx.StringSlice("label"))
+							if err != nil {
+								return err
+							}
+							strategy, err := allocationStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							childStrategy, err := childPrefixStrategy(ctx, "child-strategy")
+							if err != nil {
+								return err
+							}
 							result, err := c.CreatePrefix(context.Background(), connect.NewRequest(&v1.CreatePrefixRequest{
-								Cidr: ctx.String("cidr"),
+								Cidr:                ctx.String("cidr"),
+								Labels:              labels,
+								Description:         optionalString(ctx, "description"),
+								ReservedIps:         reservedIPs(ctx),
+								Exclusions:          exclusions(ctx),
+								Quarantine:          quarantine(ctx),
+								AllocationStrategy:  strategy,
+								IdempotencyKey:      optionalString(ctx, "idempotency-key"),
+								Policy:              policy(ctx),
+								ChildPrefixStrategy: childStrategy,
 							}))
 
 							if err != nil {
@@ -58,19 +95,81 @@ func main() {
 					{
 						Name:  "acquire",
 						Usage: "acquire a child prefix",
-						Flags: []cli.Flag{
+						Flags: append([]cli.Flag{
 							&cli.StringFlag{
 								Name: "parent",
 							},
 							&cli.UintFlag{
 								Name: "length",
 							},
-						},
+							&cli.UintFlag{
+								Name:  "count",
+								Value: 1,
+								Usage: "number of child prefixes to acquire, either all or none are acquired",
+							},
+							ttlFlag(),
+							&cli.StringSliceFlag{
+								Name:  "label",
+								Usage: "label of the child prefix in the form key=value, can be given multiple times",
+							},
+							&cli.StringFlag{
+								Name: "description",
+							},
+							strategyFlag(),
+							placementFlag(),
+							quarantineFlag(),
+							idempotencyKeyFlag(),
+						}, slices.Concat(reservedIPsFlags(), exclusionsFlags())...),
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
+							labels, err := parseKeyValues(ctx.StringSlice("label"))
+							if err != nil {
+								return err
+							}
+							strategy, err := allocationStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							placement, err := childPrefixStrategy(ctx, "placement")
+							if err != nil {
+								return err
+							}
+							if ctx.Uint("count") != 1 {
+								result, err := c.AcquireChildPrefixes(context.Background(), connect.NewRequest(&v1.AcquireChildPrefixesRequest{
+									Cidr:               ctx.String("parent"),
+									Length:             uint32(ctx.Uint("length")), // nolint:gosec
+									Count:              uint32(ctx.Uint("count")),  // nolint:gosec
+									Labels:             labels,
+									Description:        optionalString(ctx, "description"),
+									ReservedIps:        reservedIPs(ctx),
+									Exclusions:         exclusions(ctx),
+									Quarantine:         quarantine(ctx),
+									AllocationStrategy: strategy,
+									Ttl:                ttl(ctx),
+									IdempotencyKey:     optionalString(ctx, "idempotency-key"),
+									Placement:          placement,
+								}))
+
+								if err != nil {
+									return err
+								}
+								for _, p := range result.Msg.GetPrefixes() {
+									fmt.Printf("child prefix:%q from %q created\n", p.GetCidr(), p.GetParentCidr())
+								}
+								return nil
+							}
 							result, err := c.AcquireChildPrefix(context.Background(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
-								Cidr:   ctx.String("parent"),
-								Length: uint32(ctx.Uint("length")), // nolint:gosec
+								Cidr:               ctx.String("parent"),
+								Length:             uint32(ctx.Uint("length")), // nolint:gosec
+								Labels:             labels,
+								Description:        optionalString(ctx, "description"),
+								ReservedIps:        reservedIPs(ctx),
+								Exclusions:         exclusions(ctx),
+								Quarantine:         quarantine(ctx),
+								AllocationStrategy: strategy,
+								Ttl:                ttl(ctx),
+								IdempotencyKey:     optionalString(ctx, "idempotency-key"),
+								Placement:          placement,
 							}))
 
 							if err != nil {
@@ -107,16 +206,237 @@ func main() {
 					{
 						Name:  "list",
 						Usage: "list all prefixes",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "selector",
+								Usage: "only list prefixes matching this label selector, e.g. site=fra1,purpose=underlay",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.ListPrefixes(context.Background(), connect.NewRequest(&v1.ListPrefixesRequest{
+								LabelSelector: optionalString(ctx, "selector"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							for _, p := range result.Msg.GetPrefixes() {
+								fmt.Printf("Prefix:%q parent:%q labels:%v description:%q\n", p.GetCidr(), p.GetParentCidr(), p.GetLabels(), p.GetDescription())
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "tree",
+						Usage: "show the parent/child hierarchy of prefixes with their usage",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "cidr",
+								Usage: "root prefix of the tree, if not given the trees of all top level prefixes are shown",
+							},
+							&cli.UintFlag{
+								Name:  "depth",
+								Usage: "number of child levels to show below the root, 0 shows all levels",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.GetPrefixTree(context.Background(), connect.NewRequest(&v1.GetPrefixTreeRequest{
+								Cidr:  optionalString(ctx, "cidr"),
+								Depth: uint32(ctx.Uint("depth")), // nolint:gosec
+							}))
+
+							if err != nil {
+								return err
+							}
+							printPrefixNodes(result.Msg.GetNodes(), "", true)
+							return nil
+						},
+					},
+					{
+						Name:  "fragmentation",
+						Usage: "show how the free address space of a parent prefix is split into blocks",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "cidr",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.FragmentationReport(context.Background(), connect.NewRequest(&v1.FragmentationReportRequest{
+								Cidr: ctx.String("cidr"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							r := result.Msg
+							fmt.Printf("Prefix:%q largest free length:%d free addresses:%s score:%.2f\n", r.GetCidr(), r.GetLargestFreeLength(), r.GetFreeAddressesNotation(), r.GetScore())
+							lengths := slices.Sorted(maps.Keys(r.GetFreeBlocks()))
+							for _, length := range lengths {
+								fmt.Printf("  /%d free blocks:%d\n", length, r.GetFreeBlocks()[length])
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "plan-defragmentation",
+						Usage: "propose the child prefixes to move to free up a contiguous block, nothing is moved",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "cidr",
+							},
+							&cli.UintFlag{
+								Name:  "length",
+								Usage: "length of the contiguous block to free up",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.PlanDefragmentation(context.Background(), connect.NewRequest(&v1.PlanDefragmentationRequest{
+								Cidr:   ctx.String("cidr"),
+								Length: uint32(ctx.Uint("length")), // nolint:gosec
+							}))
+
+							if err != nil {
+								return err
+							}
+							if len(result.Msg.GetMoves()) == 0 {
+								fmt.Printf("block:%q is already free\n", result.Msg.GetBlock())
+								return nil
+							}
+							fmt.Printf("block:%q is free after %d moves\n", result.Msg.GetBlock(), len(result.Msg.GetMoves()))
+							for _, m := range result.Msg.GetMoves() {
+								fmt.Printf("  move child prefix:%q to %q\n", m.GetFrom(), m.GetTo())
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "update",
+						Usage: "update labels, description, reserved ips, exclusions, quarantine, allocation strategy, child strategy and policy of a prefix",
+						Flags: append([]cli.Flag{
+							&cli.StringFlag{
+								Name: "cidr",
+							},
+							&cli.StringSliceFlag{
+								Name:  "label",
+								Usage: "label of the prefix in the form key=value, can be given multiple times, replaces all existing labels",
+							},
+							&cli.StringFlag{
+								Name: "description",
+							},
+							strategyFlag(),
+							childStrategyFlag(),
+							quarantineFlag(),
+						}, slices.Concat(reservedIPsFlags(), exclusionsFlags(), policyFlags())...),
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							labels, err := parseKeyValues(ctx.StringSlice("label"))
+							if err != nil {
+								return err
+							}
+							strategy, err := allocationStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							childStrategy, err := childPrefixStrategy(ctx, "child-strategy")
+							if err != nil {
+								return err
+							}
+							result, err := c.UpdatePrefix(context.Background(), connect.NewRequest(&v1.UpdatePrefixRequest{
+								Cidr:                ctx.String("cidr"),
+								Labels:              labels,
+								Description:         optionalString(ctx, "description"),
+								ReservedIps:         reservedIPs(ctx),
+								Exclusions:          exclusions(ctx),
+								Quarantine:          quarantine(ctx),
+								AllocationStrategy:  strategy,
+								Policy:              policy(ctx),
+								ChildPrefixStrategy: childStrategy,
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("prefix:%q updated\n", result.Msg.GetPrefix().GetCidr())
+							return nil
+						},
+					},
+					{
+						Name:  "resize",
+						Usage: "grow or shrink a prefix, all acquired ips and child prefixes are moved to the resized prefix",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "cidr",
+							},
+							&cli.UintFlag{
+								Name:  "length",
+								Usage: "new length of the prefix",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.ResizePrefix(context.Background(), connect.NewRequest(&v1.ResizePrefixRequest{
+								Cidr:   ctx.String("cidr"),
+								Length: uint32(ctx.Uint("length")), // nolint:gosec
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("prefix:%q resized to %q\n", ctx.String("cidr"), result.Msg.GetPrefix().GetCidr())
+							return nil
+						},
+					},
+					{
+						Name:  "split",
+						Usage: "split a prefix into all its parts with the given length, acquired ips and child prefixes are moved to the parts",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "cidr",
+							},
+							&cli.UintFlag{
+								Name:  "length",
+								Usage: "length of the parts",
+							},
+						},
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
-							result, err := c.ListPrefixes(context.Background(), connect.NewRequest(&v1.ListPrefixesRequest{}))
+							result, err := c.SplitPrefix(context.Background(), connect.NewRequest(&v1.SplitPrefixRequest{
+								Cidr:   ctx.String("cidr"),
+								Length: uint32(ctx.Uint("length")), // nolint:gosec
+							}))
 
 							if err != nil {
 								return err
 							}
 							for _, p := range result.Msg.GetPrefixes() {
-								fmt.Printf("Prefix:%q parent:%q\n", p.GetCidr(), p.GetParentCidr())
+								fmt.Printf("prefix:%q created\n", p.GetCidr())
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "merge",
+						Usage: "merge adjacent prefixes with the same parent into their supernet",
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:  "cidr",
+								Usage: "prefix to merge, must be given at least twice",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.MergePrefixes(context.Background(), connect.NewRequest(&v1.MergePrefixesRequest{
+								Cidrs: ctx.StringSlice("cidr"),
+							}))
+
+							if err != nil {
+								return err
 							}
+							fmt.Printf("prefixes %s merged into %q\n", strings.Join(ctx.StringSlice("cidr"), ","), result.Msg.GetPrefix().GetCidr())
 							return nil
 						},
 					},
@@ -127,17 +447,38 @@ func main() {
 							&cli.StringFlag{
 								Name: "cidr",
 							},
+							&cli.BoolFlag{
+								Name:  "recursive",
+								Usage: "delete the prefix with all its descendants and release all their ips",
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "only list what would be deleted",
+							},
 						},
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
 							result, err := c.DeletePrefix(context.Background(), connect.NewRequest(&v1.DeletePrefixRequest{
-								Cidr: ctx.String("cidr"),
+								Cidr:      ctx.String("cidr"),
+								Recursive: ctx.Bool("recursive"),
+								DryRun:    ctx.Bool("dry-run"),
 							}))
 
 							if err != nil {
 								return err
 							}
-							fmt.Printf("prefix:%q deleted\n", result.Msg.GetPrefix().GetCidr())
+							action := "deleted"
+							if ctx.Bool("dry-run") {
+								action = "would be deleted"
+							}
+							for _, cidr := range slices.Sorted(maps.Keys(result.Msg.GetReleasedIpRanges())) {
+								for _, r := range result.Msg.GetReleasedIpRanges()[cidr].GetRanges() {
+									fmt.Printf("ips:%q of prefix:%q %s\n", r, cidr, action)
+								}
+							}
+							for _, p := range result.Msg.GetDeletedPrefixes() {
+								fmt.Printf("prefix:%q %s\n", p.GetCidr(), action)
+							}
 							return nil
 						},
 					},
@@ -155,11 +496,63 @@ func main() {
 							&cli.StringFlag{
 								Name: "prefix",
 							},
+							&cli.UintFlag{
+								Name:  "count",
+								Value: 1,
+								Usage: "number of ips to acquire, either all or none are acquired",
+							},
+							ttlFlag(),
+							&cli.StringFlag{
+								Name:  "ip",
+								Usage: "acquire this specific ip instead of the next free one",
+							},
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "acquire the specific ip even if it is excluded in the prefix",
+							},
+							&cli.StringSliceFlag{
+								Name:  "annotation",
+								Usage: "annotation to store with the ip in the form key=value, can be given multiple times",
+							},
+							strategyFlag(),
+							idempotencyKeyFlag(),
 						},
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
+							annotations, err := parseKeyValues(ctx.StringSlice("annotation"))
+							if err != nil {
+								return err
+							}
+							strategy, err := allocationStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							if ctx.Uint("count") != 1 {
+								result, err := c.AcquireIPs(context.Background(), connect.NewRequest(&v1.AcquireIPsRequest{
+									PrefixCidr:         ctx.String("prefix"),
+									Count:              uint32(ctx.Uint("count")), // nolint:gosec
+									Annotations:        annotations,
+									AllocationStrategy: strategy,
+									Ttl:                ttl(ctx),
+									IdempotencyKey:     optionalString(ctx, "idempotency-key"),
+								}))
+
+								if err != nil {
+									return err
+								}
+								for _, ip := range result.Msg.GetIps() {
+									fmt.Printf("ip:%q acquired\n", ip.GetIp())
+								}
+								return nil
+							}
 							result, err := c.AcquireIP(context.Background(), connect.NewRequest(&v1.AcquireIPRequest{
-								PrefixCidr: ctx.String("prefix"),
+								PrefixCidr:         ctx.String("prefix"),
+								Ip:                 optionalString(ctx, "ip"),
+								Annotations:        annotations,
+								AllocationStrategy: strategy,
+								Force:              ctx.Bool("force"),
+								Ttl:                ttl(ctx),
+								IdempotencyKey:     optionalString(ctx, "idempotency-key"),
 							}))
 
 							if err != nil {
@@ -170,83 +563,899 @@ func main() {
 						},
 					},
 					{
-						Name:  "release",
-						Usage: "release a ip",
+						Name:  "acquire-from-mac",
+						Usage: "acquire the eui-64 address of a mac from a ipv6 prefix",
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name: "ip",
+								Name: "prefix",
 							},
 							&cli.StringFlag{
-								Name: "prefix",
+								Name: "mac",
+							},
+							ttlFlag(),
+							&cli.StringSliceFlag{
+								Name:  "annotation",
+								Usage: "annotation to store with the ip in the form key=value, can be given multiple times",
 							},
+							idempotencyKeyFlag(),
 						},
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
-							result, err := c.ReleaseIP(context.Background(), connect.NewRequest(&v1.ReleaseIPRequest{
-								Ip:         ctx.String("ip"),
-								PrefixCidr: ctx.String("prefix"),
+							annotations, err := parseKeyValues(ctx.StringSlice("annotation"))
+							if err != nil {
+								return err
+							}
+							result, err := c.AcquireIPFromMAC(context.Background(), connect.NewRequest(&v1.AcquireIPFromMACRequest{
+								PrefixCidr:     ctx.String("prefix"),
+								Mac:            ctx.String("mac"),
+								Annotations:    annotations,
+								Ttl:            ttl(ctx),
+								IdempotencyKey: optionalString(ctx, "idempotency-key"),
 							}))
 
 							if err != nil {
 								return err
 							}
-							fmt.Printf("ip:%q released\n", result.Msg.GetIp().GetIp())
+							fmt.Printf("ip:%q acquired\n", result.Msg.GetIp().GetIp())
 							return nil
 						},
 					},
-				},
-			},
-			{
-				Name:  "backup",
-				Usage: "create and restore a backup",
-				Subcommands: []*cli.Command{
 					{
-						Name:  "create",
-						Usage: "create a json file of the whole ipam db for backup purpose",
+						Name:  "acquire-for-key",
+						Usage: "acquire the ip a key is hashed to, the same key results in the same ip as long as it is free",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "prefix",
+							},
+							&cli.StringFlag{
+								Name:  "key",
+								Usage: "arbitrary identifier, e.g. the serial number of a machine",
+							},
+							ttlFlag(),
+							&cli.StringSliceFlag{
+								Name:  "annotation",
+								Usage: "annotation to store with the ip in the form key=value, can be given multiple times",
+							},
+							idempotencyKeyFlag(),
+						},
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
-							result, err := c.Dump(context.Background(), connect.NewRequest(&v1.DumpRequest{}))
+							annotations, err := parseKeyValues(ctx.StringSlice("annotation"))
 							if err != nil {
 								return err
 							}
-							fmt.Println(result.Msg.GetDump())
+							result, err := c.AcquireIPForKey(context.Background(), connect.NewRequest(&v1.AcquireIPForKeyRequest{
+								PrefixCidr:     ctx.String("prefix"),
+								Key:            ctx.String("key"),
+								Annotations:    annotations,
+								Ttl:            ttl(ctx),
+								IdempotencyKey: optionalString(ctx, "idempotency-key"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("ip:%q acquired\n", result.Msg.GetIp().GetIp())
 							return nil
 						},
 					},
 					{
-						Name:  "restore",
-						Usage: "load the whole ipam db from json file, previously created, only works if database is already empty",
+						Name:  "acquire-dualstack",
+						Usage: "acquire a ipv4 and a ipv6 address together, either both or none are acquired",
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name: "file",
+								Name: "ipv4-prefix",
+							},
+							&cli.StringFlag{
+								Name: "ipv6-prefix",
+							},
+							&cli.BoolFlag{
+								Name:  "embed-ipv4",
+								Usage: "derive the ipv6 address from the ipv4 address, which is embedded into the last bits of the ipv6 prefix",
 							},
+							ttlFlag(),
+							&cli.StringSliceFlag{
+								Name:  "annotation",
+								Usage: "annotation to store with both ips in the form key=value, can be given multiple times",
+							},
+							strategyFlag(),
+							idempotencyKeyFlag(),
 						},
 						Action: func(ctx *cli.Context) error {
 							c := client(ctx)
-							json, err := os.ReadFile(ctx.String("file"))
+							annotations, err := parseKeyValues(ctx.StringSlice("annotation"))
 							if err != nil {
 								return err
 							}
-							_, err = c.Load(context.Background(), connect.NewRequest(&v1.LoadRequest{
-								Dump: string(json),
+							strategy, err := allocationStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							result, err := c.AcquireDualStack(context.Background(), connect.NewRequest(&v1.AcquireDualStackRequest{
+								Ipv4PrefixCidr:     ctx.String("ipv4-prefix"),
+								Ipv6PrefixCidr:     ctx.String("ipv6-prefix"),
+								EmbedIpv4:          ctx.Bool("embed-ipv4"),
+								Annotations:        annotations,
+								AllocationStrategy: strategy,
+								Ttl:                ttl(ctx),
+								IdempotencyKey:     optionalString(ctx, "idempotency-key"),
 							}))
 
 							if err != nil {
 								return err
 							}
-							fmt.Printf("database restored\n")
+							fmt.Printf("ip:%q and ip:%q acquired\n", result.Msg.GetIpv4().GetIp(), result.Msg.GetIpv6().GetIp())
 							return nil
 						},
 					},
-				},
-			},
-		},
-	}
-	err := app.Run(os.Args)
-	if err != nil {
-		fmt.Println(err.Error())
-		os.Exit(1)
+					{
+						Name:  "get",
+						Usage: "show a acquired ip with its annotations",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "ip",
+							},
+							&cli.StringFlag{
+								Name: "prefix",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.GetIP(context.Background(), connect.NewRequest(&v1.GetIPRequest{
+								Ip:         ctx.String("ip"),
+								PrefixCidr: ctx.String("prefix"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("ip:%q prefix:%q annotations:%v\n", result.Msg.GetIp().GetIp(), result.Msg.GetIp().GetParentPrefix(), result.Msg.GetIp().GetAnnotations())
+							return nil
+						},
+					},
+					{
+						Name:  "lookup",
+						Usage: "show all prefixes containing a ip and its allocation state in the deepest prefix",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "ip",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.LookupIP(context.Background(), connect.NewRequest(&v1.LookupIPRequest{
+								Ip: ctx.String("ip"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							var prefixes []string
+							for _, p := range result.Msg.GetPrefixes() {
+								prefixes = append(prefixes, p.GetCidr())
+							}
+							state := strings.ToLower(strings.TrimPrefix(result.Msg.GetState().String(), "IP_STATE_"))
+							fmt.Printf("ip:%q state:%s prefixes:%q annotations:%v\n", result.Msg.GetIp().GetIp(), state, prefixes, result.Msg.GetIp().GetAnnotations())
+							return nil
+						},
+					},
+					{
+						Name:  "release",
+						Usage: "release a ip",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "ip",
+							},
+							&cli.StringFlag{
+								Name: "prefix",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.ReleaseIP(context.Background(), connect.NewRequest(&v1.ReleaseIPRequest{
+								Ip:         ctx.String("ip"),
+								PrefixCidr: ctx.String("prefix"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("ip:%q released\n", result.Msg.GetIp().GetIp())
+							return nil
+						},
+					},
+					{
+						Name:  "acquire-range",
+						Usage: "acquire a range of consecutive ips",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "prefix",
+							},
+							&cli.UintFlag{
+								Name: "count",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.AcquireIPRange(context.Background(), connect.NewRequest(&v1.AcquireIPRangeRequest{
+								PrefixCidr: ctx.String("prefix"),
+								Count:      uint32(ctx.Uint("count")), // nolint:gosec
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("ip range:%q-%q acquired\n", result.Msg.GetIpRange().GetFrom(), result.Msg.GetIpRange().GetTo())
+							return nil
+						},
+					},
+					{
+						Name:  "release-range",
+						Usage: "release a range of consecutive ips",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "from",
+							},
+							&cli.StringFlag{
+								Name: "to",
+							},
+							&cli.StringFlag{
+								Name: "prefix",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.ReleaseIPRange(context.Background(), connect.NewRequest(&v1.ReleaseIPRangeRequest{
+								PrefixCidr: ctx.String("prefix"),
+								From:       ctx.String("from"),
+								To:         ctx.String("to"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("ip range:%q-%q released\n", result.Msg.GetIpRange().GetFrom(), result.Msg.GetIpRange().GetTo())
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:    "lease",
+				Aliases: []string{"l"},
+				Usage:   "lease manipulation",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "list",
+						Usage: "list all leases ordered by their expiry",
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.ListLeases(context.Background(), connect.NewRequest(&v1.ListLeasesRequest{}))
+
+							if err != nil {
+								return err
+							}
+							for _, l := range result.Msg.GetLeases() {
+								fmt.Printf("Lease:%q prefix:%q ip:%q cidr:%q expires:%s\n", l.GetId(), l.GetParentPrefix(), l.GetIp(), l.GetCidr(), l.GetExpires().AsTime().Format(time.RFC3339))
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "renew",
+						Usage: "renew a lease",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "id",
+							},
+							&cli.DurationFlag{
+								Name:  "ttl",
+								Usage: "new lifetime of the lease starting now",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.RenewLease(context.Background(), connect.NewRequest(&v1.RenewLeaseRequest{
+								Id:  ctx.String("id"),
+								Ttl: durationpb.New(ctx.Duration("ttl")),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("lease:%q renewed, expires:%s\n", result.Msg.GetLease().GetId(), result.Msg.GetLease().GetExpires().AsTime().Format(time.RFC3339))
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:    "pool",
+				Aliases: []string{"po"},
+				Usage:   "pool manipulation",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "create",
+						Usage: "create a pool which groups prefixes",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "name",
+							},
+							&cli.StringSliceFlag{
+								Name:  "prefix",
+								Usage: "cidr of a prefix of the pool, can be given multiple times, fill-first tries them in the given order",
+							},
+							poolStrategyFlag(),
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							strategy, err := poolStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							result, err := c.CreatePool(context.Background(), connect.NewRequest(&v1.CreatePoolRequest{
+								Name:     ctx.String("name"),
+								Prefixes: ctx.StringSlice("prefix"),
+								Strategy: strategy,
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("pool:%q with prefixes:%v created\n", result.Msg.GetPool().GetName(), result.Msg.GetPool().GetPrefixes())
+							return nil
+						},
+					},
+					{
+						Name:  "update",
+						Usage: "replace the prefixes and the strategy of a pool",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "name",
+							},
+							&cli.StringSliceFlag{
+								Name:  "prefix",
+								Usage: "cidr of a prefix of the pool, can be given multiple times, fill-first tries them in the given order",
+							},
+							poolStrategyFlag(),
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							strategy, err := poolStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							result, err := c.UpdatePool(context.Background(), connect.NewRequest(&v1.UpdatePoolRequest{
+								Name:     ctx.String("name"),
+								Prefixes: ctx.StringSlice("prefix"),
+								Strategy: strategy,
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("pool:%q with prefixes:%v updated\n", result.Msg.GetPool().GetName(), result.Msg.GetPool().GetPrefixes())
+							return nil
+						},
+					},
+					{
+						Name:  "list",
+						Usage: "list all pools",
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.ListPools(context.Background(), connect.NewRequest(&v1.ListPoolsRequest{}))
+
+							if err != nil {
+								return err
+							}
+							for _, p := range result.Msg.GetPools() {
+								fmt.Printf("Pool:%q prefixes:%v strategy:%s\n", p.GetName(), p.GetPrefixes(), p.GetStrategy())
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "delete",
+						Usage: "delete a pool, its prefixes are kept",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "name",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							_, err := c.DeletePool(context.Background(), connect.NewRequest(&v1.DeletePoolRequest{
+								Name: ctx.String("name"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("pool:%q deleted\n", ctx.String("name"))
+							return nil
+						},
+					},
+					{
+						Name:  "acquire-ip",
+						Usage: "acquire a ip from any prefix of a pool",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "name",
+							},
+							ttlFlag(),
+							&cli.StringSliceFlag{
+								Name:  "annotation",
+								Usage: "annotation to store with the ip in the form key=value, can be given multiple times",
+							},
+							strategyFlag(),
+							idempotencyKeyFlag(),
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							annotations, err := parseKeyValues(ctx.StringSlice("annotation"))
+							if err != nil {
+								return err
+							}
+							strategy, err := allocationStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							result, err := c.AcquireIPFromPool(context.Background(), connect.NewRequest(&v1.AcquireIPFromPoolRequest{
+								Pool:               ctx.String("name"),
+								Annotations:        annotations,
+								AllocationStrategy: strategy,
+								Ttl:                ttl(ctx),
+								IdempotencyKey:     optionalString(ctx, "idempotency-key"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("ip:%q from %q acquired\n", result.Msg.GetIp().GetIp(), result.Msg.GetIp().GetParentPrefix())
+							return nil
+						},
+					},
+					{
+						Name:  "acquire-prefix",
+						Usage: "acquire a child prefix from any prefix of a pool",
+						Flags: append([]cli.Flag{
+							&cli.StringFlag{
+								Name: "name",
+							},
+							&cli.UintFlag{
+								Name: "length",
+							},
+							ttlFlag(),
+							&cli.StringSliceFlag{
+								Name:  "label",
+								Usage: "label of the child prefix in the form key=value, can be given multiple times",
+							},
+							&cli.StringFlag{
+								Name: "description",
+							},
+							strategyFlag(),
+							placementFlag(),
+							quarantineFlag(),
+							idempotencyKeyFlag(),
+						}, slices.Concat(reservedIPsFlags(), exclusionsFlags())...),
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							labels, err := parseKeyValues(ctx.StringSlice("label"))
+							if err != nil {
+								return err
+							}
+							strategy, err := allocationStrategy(ctx)
+							if err != nil {
+								return err
+							}
+							placement, err := childPrefixStrategy(ctx, "placement")
+							if err != nil {
+								return err
+							}
+							result, err := c.AcquireChildPrefixFromPool(context.Background(), connect.NewRequest(&v1.AcquireChildPrefixFromPoolRequest{
+								Pool:               ctx.String("name"),
+								Length:             uint32(ctx.Uint("length")), // nolint:gosec
+								Labels:             labels,
+								Description:        optionalString(ctx, "description"),
+								ReservedIps:        reservedIPs(ctx),
+								Exclusions:         exclusions(ctx),
+								Quarantine:         quarantine(ctx),
+								AllocationStrategy: strategy,
+								Ttl:                ttl(ctx),
+								IdempotencyKey:     optionalString(ctx, "idempotency-key"),
+								Placement:          placement,
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("child prefix:%q from %q created\n", result.Msg.GetPrefix().GetCidr(), result.Msg.GetPrefix().GetParentCidr())
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:    "namespace",
+				Aliases: []string{"n"},
+				Usage:   "namespace manipulation",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "quota",
+						Usage: "show the quota of a namespace together with its usage",
+						Flags: []cli.Flag{
+							namespaceFlag(),
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.GetNamespaceQuota(context.Background(), connect.NewRequest(&v1.GetNamespaceQuotaRequest{
+								Namespace: ctx.String("namespace"),
+							}))
+
+							if err != nil {
+								return err
+							}
+							q := result.Msg.GetQuota()
+							fmt.Printf("namespace:%q prefixes:%d of %d ips:%d of %d address space:%s of %s (0 or empty is unlimited)\n",
+								ctx.String("namespace"), result.Msg.GetPrefixes(), q.GetMaxPrefixes(), result.Msg.GetIps(), q.GetMaxIps(), result.Msg.GetAddressSpace(), q.GetMaxAddressSpace())
+							return nil
+						},
+					},
+					{
+						Name:  "set-quota",
+						Usage: "set the quota of a namespace, limits which are not given are removed",
+						Flags: []cli.Flag{
+							namespaceFlag(),
+							&cli.Uint64Flag{
+								Name:  "max-prefixes",
+								Usage: "maximum number of top level prefixes",
+							},
+							&cli.Uint64Flag{
+								Name:  "max-ips",
+								Usage: "maximum number of acquired ips in all prefixes",
+							},
+							&cli.StringFlag{
+								Name:  "max-address-space",
+								Usage: "maximum number of addresses of all top level prefixes as decimal number",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							_, err := c.SetNamespaceQuota(context.Background(), connect.NewRequest(&v1.SetNamespaceQuotaRequest{
+								Namespace: ctx.String("namespace"),
+								Quota: &v1.Quota{
+									MaxPrefixes:     ctx.Uint64("max-prefixes"),
+									MaxIps:          ctx.Uint64("max-ips"),
+									MaxAddressSpace: ctx.String("max-address-space"),
+								},
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("quota of namespace:%q set\n", ctx.String("namespace"))
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:  "backup",
+				Usage: "create and restore a backup",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "create",
+						Usage: "create a json file of the whole ipam db for backup purpose",
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							result, err := c.Dump(context.Background(), connect.NewRequest(&v1.DumpRequest{}))
+							if err != nil {
+								return err
+							}
+							fmt.Println(result.Msg.GetDump())
+							return nil
+						},
+					},
+					{
+						Name:  "restore",
+						Usage: "load the whole ipam db from json file, previously created, only works if database is already empty",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name: "file",
+							},
+						},
+						Action: func(ctx *cli.Context) error {
+							c := client(ctx)
+							json, err := os.ReadFile(ctx.String("file"))
+							if err != nil {
+								return err
+							}
+							_, err = c.Load(context.Background(), connect.NewRequest(&v1.LoadRequest{
+								Dump: string(json),
+							}))
+
+							if err != nil {
+								return err
+							}
+							fmt.Printf("database restored\n")
+							return nil
+						},
+					},
+				},
+			},
+		},
+	}
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+var allocationStrategies = map[string]v1.AllocationStrategy{
+	"first-free":      v1.AllocationStrategy_ALLOCATION_STRATEGY_FIRST_FREE,
+	"last-free":       v1.AllocationStrategy_ALLOCATION_STRATEGY_LAST_FREE,
+	"next-after-last": v1.AllocationStrategy_ALLOCATION_STRATEGY_NEXT_AFTER_LAST,
+	"random":          v1.AllocationStrategy_ALLOCATION_STRATEGY_RANDOM,
+}
+
+func namespaceFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "namespace",
+		Value: "root",
+		Usage: "name of the namespace",
+	}
+}
+
+func strategyFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "strategy",
+		Usage: "allocation strategy of ips, one of first-free, last-free, next-after-last or random",
+	}
+}
+
+var poolStrategies = map[string]v1.PoolStrategy{
+	"fill-first": v1.PoolStrategy_POOL_STRATEGY_FILL_FIRST,
+	"spread":     v1.PoolStrategy_POOL_STRATEGY_SPREAD,
+}
+
+func poolStrategyFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "strategy",
+		Usage: "order in which the prefixes of the pool are tried, one of fill-first or spread",
+	}
+}
+
+// poolStrategy returns the pool strategy given with the strategy flag.
+func poolStrategy(ctx *cli.Context) (v1.PoolStrategy, error) {
+	if !ctx.IsSet("strategy") {
+		return v1.PoolStrategy_POOL_STRATEGY_UNSPECIFIED, nil
+	}
+	strategy, ok := poolStrategies[ctx.String("strategy")]
+	if !ok {
+		return v1.PoolStrategy_POOL_STRATEGY_UNSPECIFIED, fmt.Errorf("unknown pool strategy:%q", ctx.String("strategy"))
+	}
+	return strategy, nil
+}
+
+var childPrefixStrategies = map[string]v1.ChildPrefixStrategy{
+	"best-fit":  v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_BEST_FIT,
+	"first-fit": v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_FIRST_FIT,
+	"sparse":    v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_SPARSE,
+}
+
+func childStrategyFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "child-strategy",
+		Usage: "from which free block child prefixes are acquired, one of best-fit, first-fit or sparse",
+	}
+}
+
+func placementFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "placement",
+		Usage: "overrides the child strategy of the parent for this request, one of best-fit, first-fit or sparse",
+	}
+}
+
+// childPrefixStrategy returns the child prefix strategy given with the flag of the given name.
+func childPrefixStrategy(ctx *cli.Context, name string) (v1.ChildPrefixStrategy, error) {
+	if !ctx.IsSet(name) {
+		return v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED, nil
+	}
+	strategy, ok := childPrefixStrategies[ctx.String(name)]
+	if !ok {
+		return v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED, fmt.Errorf("unknown child prefix strategy:%q", ctx.String(name))
+	}
+	return strategy, nil
+}
+
+// allocationStrategy returns the allocation strategy given with the strategy flag.
+func allocationStrategy(ctx *cli.Context) (v1.AllocationStrategy, error) {
+	if !ctx.IsSet("strategy") {
+		return v1.AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED, nil
+	}
+	strategy, ok := allocationStrategies[ctx.String("strategy")]
+	if !ok {
+		return v1.AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED, fmt.Errorf("unknown allocation strategy:%q", ctx.String("strategy"))
+	}
+	return strategy, nil
+}
+
+// reservedIPsFlags configure the reserved addresses of a prefix.
+func reservedIPsFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "no-reserved",
+			Usage: "reserve no address at all, e.g. for point-to-point links and loopback pools",
+		},
+		&cli.Uint64Flag{
+			Name:  "reserve-first",
+			Usage: "reserve the first n addresses",
+		},
+		&cli.Uint64Flag{
+			Name:  "reserve-last",
+			Usage: "reserve the last n addresses",
+		},
+		&cli.StringSliceFlag{
+			Name:  "reserve",
+			Usage: "reserve a address, range in the form from-to or cidr, can be given multiple times",
+		},
+	}
+}
+
+// reservedIPs returns the reserved ips configuration if one of the reservedIPsFlags was set, otherwise nil.
+func reservedIPs(ctx *cli.Context) *v1.ReservedIPs {
+	if !ctx.IsSet("no-reserved") && !ctx.IsSet("reserve-first") && !ctx.IsSet("reserve-last") && !ctx.IsSet("reserve") {
+		return nil
+	}
+	return &v1.ReservedIPs{
+		First:  ctx.Uint64("reserve-first"),
+		Last:   ctx.Uint64("reserve-last"),
+		Ranges: ctx.StringSlice("reserve"),
+	}
+}
+
+// exclusionsFlags configure the excluded addresses of a prefix.
+func exclusionsFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "no-exclusions",
+			Usage: "remove all exclusions",
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "exclude a address, range in the form from-to or cidr from acquisition, can be given multiple times, replaces all existing exclusions",
+		},
+	}
+}
+
+// exclusions returns the exclusions configuration if one of the exclusionsFlags was set, otherwise nil.
+func exclusions(ctx *cli.Context) *v1.Exclusions {
+	if !ctx.IsSet("no-exclusions") && !ctx.IsSet("exclude") {
+		return nil
+	}
+	return &v1.Exclusions{
+		Ranges: ctx.StringSlice("exclude"),
+	}
+}
+
+func policyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "no-policy",
+			Usage: "remove the policy, child prefixes and ips are not restricted",
+		},
+		&cli.UintFlag{
+			Name:  "min-child-length",
+			Usage: "smallest length of child prefixes acquired from the prefix",
+		},
+		&cli.UintFlag{
+			Name:  "max-child-length",
+			Usage: "biggest length of child prefixes acquired from the prefix",
+		},
+		&cli.Uint64Flag{
+			Name:  "max-child-prefixes",
+			Usage: "maximum number of child prefixes acquired from the prefix",
+		},
+		&cli.BoolFlag{
+			Name:  "deny-ips",
+			Usage: "deny the acquisition of ips from the prefix",
+		},
+	}
+}
+
+// policy returns the policy if one of the policyFlags was set, otherwise nil.
+// The policy replaces an existing one, unset restrictions are removed.
+func policy(ctx *cli.Context) *v1.Policy {
+	if !slices.ContainsFunc([]string{"no-policy", "min-child-length", "max-child-length", "max-child-prefixes", "deny-ips"}, ctx.IsSet) {
+		return nil
+	}
+	return &v1.Policy{
+		MinChildLength:   uint32(ctx.Uint("min-child-length")), // nolint:gosec
+		MaxChildLength:   uint32(ctx.Uint("max-child-length")), // nolint:gosec
+		MaxChildPrefixes: ctx.Uint64("max-child-prefixes"),
+		DenyIps:          ctx.Bool("deny-ips"),
+	}
+}
+
+func quarantineFlag() cli.Flag {
+	return &cli.DurationFlag{
+		Name:  "quarantine",
+		Usage: "duration released ips are not acquired again, 0 disables the quarantine",
+	}
+}
+
+// quarantine returns the quarantine if it was set, otherwise nil.
+func quarantine(ctx *cli.Context) *durationpb.Duration {
+	if !ctx.IsSet("quarantine") {
+		return nil
+	}
+	return durationpb.New(ctx.Duration("quarantine"))
+}
+
+func ttlFlag() cli.Flag {
+	return &cli.DurationFlag{
+		Name:  "ttl",
+		Usage: "lease with this lifetime, released after the ttl unless the lease is renewed",
+	}
+}
+
+// ttl returns the ttl if it was set, otherwise nil.
+func ttl(ctx *cli.Context) *durationpb.Duration {
+	if !ctx.IsSet("ttl") {
+		return nil
+	}
+	return durationpb.New(ctx.Duration("ttl"))
+}
+
+func idempotencyKeyFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "idempotency-key",
+		Usage: "key to safely retry the request, a repeated request with the same key returns the original result",
+	}
+}
+
+// printPrefixNodes renders the prefix trees with box drawing characters, every prefix on its own line.
+func printPrefixNodes(nodes []*v1.PrefixNode, indent string, root bool) {
+	for i, n := range nodes {
+		var (
+			branch = "├── "
+			next   = "│   "
+		)
+		if i == len(nodes)-1 {
+			branch = "└── "
+			next = "    "
+		}
+		if root {
+			branch, next = "", ""
+		}
+		u := n.GetUsage()
+		fmt.Printf("%s%s%s acquired prefixes:%d acquired ips:%d free ips:%s utilization:%.2f%%\n", indent, branch, n.GetPrefix().GetCidr(), u.GetAcquiredPrefixes(), u.GetAcquiredIps(), u.GetFreeIpsNotation(), u.GetUtilization())
+		printPrefixNodes(n.GetChildren(), indent+next, false)
+	}
+}
+
+// parseKeyValues parses annotations and labels given in the form key=value.
+func parseKeyValues(kvs []string) (map[string]string, error) {
+	result := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q must be in the form key=value", kv)
+		}
+		result[k] = v
+	}
+	return result, nil
+}
+
+// optionalString returns a pointer to the value of the given flag if it was set, otherwise nil.
+func optionalString(ctx *cli.Context, name string) *string {
+	if !ctx.IsSet(name) {
+		return nil
 	}
+	s := ctx.String(name)
+	return &s
 }
 
 func client(ctx *cli.Context) apiv1connect.IpamServiceClient {
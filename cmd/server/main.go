@@ -6,6 +6,7 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"time"
 
 	goipam "github.com/metal-stack/go-ipam"
 	"github.com/metal-stack/v"
@@ -32,6 +33,12 @@ func main() {
 				Usage:   "metrics endpoint",
 				EnvVars: []string{"GOIPAM_METRICS_ENDPOINT"},
 			},
+			&cli.DurationFlag{
+				Name:    "lease-reaper-interval",
+				Value:   time.Minute,
+				Usage:   "interval in which ips and child prefixes with expired leases are released, 0 disables the reaper",
+				EnvVars: []string{"GOIPAM_LEASE_REAPER_INTERVAL"},
+			},
 			&cli.StringFlag{
 				Name:    "log-level",
 				Value:   "info",
@@ -312,8 +319,9 @@ func getConfig(ctx *cli.Context) config {
 	}
 
 	return config{
-		GrpcServerEndpoint: ctx.String("grpc-server-endpoint"),
-		MetricsEndpoint:    ctx.String("metrics-endpoint"),
-		Log:                slog.New(slog.NewJSONHandler(os.Stdout, opts)),
+		GrpcServerEndpoint:  ctx.String("grpc-server-endpoint"),
+		MetricsEndpoint:     ctx.String("metrics-endpoint"),
+		LeaseReaperInterval: ctx.Duration("lease-reaper-interval"),
+		Log:                 slog.New(slog.NewJSONHandler(os.Stdout, opts)),
 	}
 }
@@ -29,8 +29,10 @@ import (
 type config struct {
 	GrpcServerEndpoint string
 	MetricsEndpoint    string
-	Log                *slog.Logger
-	Storage            goipam.Storage
+	// LeaseReaperInterval is the interval in which expired leases are released, 0 disables the reaper
+	LeaseReaperInterval time.Duration
+	Log                 *slog.Logger
+	Storage             goipam.Storage
 }
 type server struct {
 	c       config
@@ -91,6 +93,10 @@ func (s *server) Run() error {
 		}
 	}()
 
+	if s.c.LeaseReaperInterval > 0 {
+		go s.reapExpiredLeases(context.Background())
+	}
+
 	otelInterceptor, err := otelconnect.NewInterceptor(otelconnect.WithMeterProvider(provider))
 	if err != nil {
 		return err
@@ -137,6 +143,34 @@ func (s *server) Run() error {
 	return err
 }
 
+// reapExpiredLeases releases ips and child prefixes with expired leases in all namespaces periodically.
+func (s *server) reapExpiredLeases(ctx context.Context) {
+	s.log.Info("starting lease reaper", "interval", s.c.LeaseReaperInterval)
+	ticker := time.NewTicker(s.c.LeaseReaperInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		namespaces, err := s.ipamer.ListNamespaces(ctx)
+		if err != nil {
+			s.log.Error("unable to list namespaces to release expired leases", "error", err)
+			continue
+		}
+		for _, namespace := range namespaces {
+			released, err := s.ipamer.ReleaseExpiredLeases(goipam.NewContextWithNamespace(ctx, namespace))
+			if err != nil {
+				s.log.Error("unable to release expired leases", "namespace", namespace, "error", err)
+			}
+			for _, lease := range released {
+				s.log.Info("released expired lease", "namespace", namespace, "id", lease.ID, "prefix", lease.ParentPrefix, "ip", lease.IP, "cidr", lease.Cidr)
+			}
+		}
+	}
+}
+
 func newLoggingInterceptor(log *slog.Logger) connect.UnaryInterceptorFunc {
 	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
 		return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
@@ -0,0 +1,136 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+)
+
+// DeleteReport lists everything removed by DeletePrefix, or everything which would be removed in a dry run.
+type DeleteReport struct {
+	// Prefixes are the deleted Prefixes, descendants before their parents and the given Prefix last
+	Prefixes Prefixes
+	// IPRanges are the acquired ips of the deleted Prefixes which are released with them, as addresses and ranges by the cidr of their Prefix
+	IPRanges map[string][]string
+	// IPs are the released ips which have annotations or a lease, ordered by their Prefix and address
+	IPs []IP
+}
+
+// add lists the given Prefix and its acquired ips. The ips are listed as ranges, a large Prefix may have too many to list them one by one.
+func (r *DeleteReport) add(p Prefix) {
+	r.Prefixes = append(r.Prefixes, p)
+	if len(p.ips) == 0 {
+		return
+	}
+	if r.IPRanges == nil {
+		r.IPRanges = make(map[string][]string)
+	}
+	r.IPRanges[p.Cidr] = p.ips.strings()
+
+	var addrs []netip.Addr
+	for _, ip := range slices.Concat(slices.Collect(maps.Keys(p.ipAnnotations)), slices.Collect(maps.Keys(p.leases))) {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || !p.ips.contains(addr) || slices.Contains(addrs, addr) {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, netip.Addr.Compare)
+	for _, addr := range addrs {
+		acquired, err := p.acquiredIP(addr.String())
+		if err != nil {
+			continue
+		}
+		r.IPs = append(r.IPs, *acquired)
+	}
+}
+
+// deletePrefixRecursive deletes the Prefix with the given cidr together with all its descendants and releases all their ips.
+// Descendants are deleted bottom-up and every deleted prefix is released in its parent first, the tree stays consistent
+// if one of the deletions fails.
+func (i *ipamer) deletePrefixRecursive(ctx context.Context, namespace, cidr string, o deleteOptions) error {
+	p, err := i.PrefixFrom(ctx, cidr)
+	if err != nil {
+		return fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
+	}
+	prefixes, err := i.storage.ReadAllPrefixes(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("unable to read prefixes:%w", err)
+	}
+	children := make(map[string][]Prefix)
+	for _, child := range prefixes {
+		if child.ParentCidr != "" {
+			children[child.ParentCidr] = append(children[child.ParentCidr], child)
+		}
+	}
+	ordered := append(descendantsOf(p.Cidr, children), *p)
+
+	if o.report != nil {
+		*o.report = DeleteReport{}
+		for _, d := range ordered {
+			o.report.add(d)
+		}
+	}
+	if o.dryRun {
+		return nil
+	}
+	// fails if one of the prefixes was modified since it was read, its new ips or children would not be deleted otherwise
+	for _, d := range ordered {
+		_, err := i.storage.UpdatePrefix(ctx, d, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to update prefix:%s error:%w", d.Cidr, err)
+		}
+	}
+	for _, d := range ordered {
+		if err := i.deleteReleasedPrefix(ctx, namespace, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// descendantsOf returns all descendants of the prefix with the given cidr, children after their own descendants
+// and siblings ordered by their network address.
+func descendantsOf(cidr string, children map[string][]Prefix) Prefixes {
+	var descendants Prefixes
+	slices.SortFunc(children[cidr], comparePrefixes)
+	for _, child := range children[cidr] {
+		descendants = append(descendants, descendantsOf(child.Cidr, children)...)
+		descendants = append(descendants, child)
+	}
+	return descendants
+}
+
+// deleteReleasedPrefix releases the given prefix in its parent and deletes it with all its ips.
+func (i *ipamer) deleteReleasedPrefix(ctx context.Context, namespace string, p Prefix) error {
+	if p.ParentCidr != "" {
+		err := retryOnOptimisticLock(func() error {
+			parent, err := i.PrefixFrom(ctx, p.ParentCidr)
+			if errors.Is(err, ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("unable to find parent prefix:%s error:%w", p.ParentCidr, err)
+			}
+			parent.availableChildPrefixes[p.Cidr] = true
+			parent.forgetIdempotencyResult(idempotencyChildPrefixes, p.Cidr)
+			_, err = i.storage.UpdatePrefix(ctx, *parent, namespace)
+			if err != nil {
+				return fmt.Errorf("unable to update parent:%q to release child prefix:%q :%w", p.ParentCidr, p.Cidr, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	_, err := i.storage.DeletePrefix(ctx, p, namespace)
+	if err != nil {
+		return fmt.Errorf("delete prefix:%s %w", p.Cidr, err)
+	}
+	i.unindexPrefix(namespace, p.Cidr)
+	return nil
+}
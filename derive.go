@@ -0,0 +1,136 @@
+package ipam
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"net"
+	"net/netip"
+)
+
+func (i *ipamer) AcquireIPFromMAC(ctx context.Context, prefixCidr, mac string, opts ...AcquireOption) (*IP, error) {
+	ipprefix, err := netip.ParsePrefix(prefixCidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", prefixCidr, err)
+	}
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return nil, fmt.Errorf("given mac:%s is not valid %w", mac, err)
+	}
+	ip, err := eui64Address(ipprefix, hw)
+	if err != nil {
+		return nil, err
+	}
+	return i.AcquireSpecificIP(ctx, prefixCidr, ip.String(), opts...)
+}
+
+// eui64Address returns the address of the IPv6 prefix with the modified EUI-64 interface identifier of the given mac
+// as described in RFC 4291 appendix A, e.g. 00:00:5e:00:53:01 in 2001:db8::/64 is 2001:db8::200:5eff:fe00:5301.
+func eui64Address(prefix netip.Prefix, mac net.HardwareAddr) (netip.Addr, error) {
+	if !prefix.Addr().Is6() {
+		return netip.Addr{}, fmt.Errorf("prefix:%s is not an IPv6 prefix", prefix)
+	}
+	if prefix.Bits() > 64 {
+		return netip.Addr{}, fmt.Errorf("prefix:%s must not be longer than 64 bits to contain eui-64 addresses", prefix)
+	}
+	var iid [8]byte
+	switch len(mac) {
+	case 6:
+		copy(iid[:3], mac[:3])
+		iid[3], iid[4] = 0xff, 0xfe
+		copy(iid[5:], mac[3:])
+	case 8:
+		copy(iid[:], mac)
+	default:
+		return netip.Addr{}, fmt.Errorf("mac:%s must be either a eui-48 or a eui-64 address", mac)
+	}
+	// invert the universal/local bit
+	iid[0] ^= 0x02
+
+	addr := prefix.Masked().Addr().As16()
+	copy(addr[8:], iid[:])
+	return netip.AddrFrom16(addr), nil
+}
+
+// KeyAnnotation is the annotation AcquireIPForKey stores the key with on the acquired ip,
+// a repeated call with the same key returns this ip instead of acquiring another one.
+const KeyAnnotation = "go-ipam/key"
+
+func (i *ipamer) AcquireIPForKey(ctx context.Context, prefixCidr, key string, opts ...AcquireOption) (*IP, error) {
+	namespace := namespaceFromContext(ctx)
+	o := newAcquireOptions(opts...)
+	var ip *IP
+	return ip, retryOnOptimisticLock(func() error {
+		var err error
+		ip, err = i.acquireIPForKeyInternal(ctx, namespace, prefixCidr, key, o)
+		return err
+	})
+}
+
+// acquireIPForKeyInternal acquires the address the key is hashed to, if it is not free the next free address after it.
+// If an ip was already acquired for the key, it is returned instead.
+func (i *ipamer) acquireIPForKeyInternal(ctx context.Context, namespace, prefixCidr, key string, o acquireOptions) (*IP, error) {
+	if key == "" {
+		return nil, errors.New("key must not be empty")
+	}
+	prefix, err := i.acquirablePrefix(ctx, prefixCidr)
+	if err != nil {
+		return nil, err
+	}
+	if ips, ok := prefix.idempotentIPs(o.idempotencyKey); ok {
+		return ips[0], nil
+	}
+	if ip, ok := prefix.ipForKey(key); ok {
+		return ip, nil
+	}
+	ipprefix, err := netip.ParsePrefix(prefix.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", prefix.Cidr, err)
+	}
+	allocatable, err := prefix.allocatableIPSet()
+	if err != nil {
+		return nil, err
+	}
+	ip, ok := prefix.firstFreeIPFrom(allocatable.Ranges(), hashedAddress(ipprefix, key))
+	if !ok {
+		return nil, fmt.Errorf("%w: no more ips in prefix: %s left, length of prefix.ips: %d", ErrNoIPAvailable, prefix.Cidr, prefix.acquiredips())
+	}
+	annotations := maps.Clone(o.annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[KeyAnnotation] = key
+	o.annotations = annotations
+	return i.acquireAndStore(ctx, namespace, prefix, ip, o)
+}
+
+// ipForKey returns the acquired ip which is annotated with the given key, false if there is none.
+func (p *Prefix) ipForKey(key string) (*IP, bool) {
+	for ip, annotations := range p.ipAnnotations {
+		if annotations[KeyAnnotation] != key {
+			continue
+		}
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || !p.ips.contains(addr) {
+			continue
+		}
+		acquired, err := p.acquiredIP(ip)
+		if err != nil {
+			return nil, false
+		}
+		return acquired, true
+	}
+	return nil, false
+}
+
+// hashedAddress returns the address of the prefix the key is hashed to, it is always the same for the same prefix and key.
+func hashedAddress(prefix netip.Prefix, key string) netip.Addr {
+	sum := sha256.Sum256([]byte(key))
+	offset := new(big.Int).SetBytes(sum[:])
+	offset.Mod(offset, prefixSize(prefix))
+	ip, _ := addrOffset(prefix.Masked().Addr(), offset)
+	return ip
+}
@@ -0,0 +1,266 @@
+package ipam
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+// DualStackIP is a pair of an IPv4 and an IPv6 address acquired together with AcquireDualStack.
+type DualStackIP struct {
+	IPv4 *IP
+	IPv6 *IP
+}
+
+func (i *ipamer) AcquireDualStack(ctx context.Context, v4PrefixCidr, v6PrefixCidr string, opts ...AcquireOption) (*DualStackIP, error) {
+	namespace := namespaceFromContext(ctx)
+	o := newAcquireOptions(opts...)
+	var ips *DualStackIP
+	return ips, retryOnOptimisticLock(func() error {
+		var err error
+		ips, err = i.acquireDualStackInternal(ctx, namespace, v4PrefixCidr, v6PrefixCidr, o)
+		return err
+	})
+}
+
+// acquireDualStackInternal acquires one ip of each prefix, the IPv4 prefix is persisted first.
+// If the IPv6 prefix can not be persisted, the IPv4 acquisition is rolled back.
+func (i *ipamer) acquireDualStackInternal(ctx context.Context, namespace, v4PrefixCidr, v6PrefixCidr string, o acquireOptions) (*DualStackIP, error) {
+	v4Prefix, err := i.acquirablePrefix(ctx, v4PrefixCidr)
+	if err != nil {
+		return nil, err
+	}
+	v6Prefix, err := i.acquirablePrefix(ctx, v6PrefixCidr)
+	if err != nil {
+		return nil, err
+	}
+	v4ipprefix, err := netip.ParsePrefix(v4Prefix.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", v4Prefix.Cidr, err)
+	}
+	v6ipprefix, err := netip.ParsePrefix(v6Prefix.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", v6Prefix.Cidr, err)
+	}
+	if !v4ipprefix.Addr().Is4() {
+		return nil, fmt.Errorf("prefix:%s is not an IPv4 prefix", v4Prefix.Cidr)
+	}
+	if !v6ipprefix.Addr().Is6() {
+		return nil, fmt.Errorf("prefix:%s is not an IPv6 prefix", v6Prefix.Cidr)
+	}
+	if o.embedIPv4 && 128-v6ipprefix.Bits() < 32-v4ipprefix.Bits() {
+		return nil, fmt.Errorf("host part of prefix:%s is too small to embed the addresses of prefix:%s", v6Prefix.Cidr, v4Prefix.Cidr)
+	}
+
+	v4ips, v4ok := v4Prefix.idempotentIPs(o.idempotencyKey)
+	v6ips, v6ok := v6Prefix.idempotentIPs(o.idempotencyKey)
+	if v4ok && v6ok {
+		return &DualStackIP{IPv4: v4ips[0], IPv6: v6ips[0]}, nil
+	}
+
+	// an earlier acquisition with the same idempotency key may only survive on one side, e.g. because the ip of the
+	// other side was released in the meantime, the surviving ip is kept and only the missing one is acquired
+	var (
+		result = &DualStackIP{}
+		count  = uint64(2)
+		v4ip   netip.Addr
+		v6ip   netip.Addr
+	)
+	if v4ok || v6ok {
+		count = 1
+	}
+	if err := i.checkQuota(ctx, namespace, 0, count, nil); err != nil {
+		return nil, err
+	}
+	switch {
+	case v4ok:
+		result.IPv4 = v4ips[0]
+		v6ip, err = counterpartIPv6(v6Prefix, v6ipprefix, result.IPv4.IP, o)
+	case v6ok:
+		result.IPv6 = v6ips[0]
+		v4ip, err = counterpartIPv4(v4Prefix, v4ipprefix, v6ipprefix, result.IPv6.IP, o)
+	default:
+		v4ip, v6ip, err = nextFreeDualStackIPs(v4Prefix, v6Prefix, v6ipprefix, o)
+	}
+	if err != nil {
+		i.releaseQuota(namespace, 0, count, nil)
+		return nil, err
+	}
+
+	var v4Previous ipSnapshot
+	if !v4ok {
+		v4Previous = v4Prefix.acquireSnapshot(v4ip)
+		result.IPv4 = v4Prefix.acquire(v4ip, o)
+		v4Prefix.rememberIdempotencyKey(idempotencyIPs, o.idempotencyKey, v4ip.String())
+		_, err = i.storage.UpdatePrefix(ctx, *v4Prefix, namespace)
+		if err != nil {
+			i.releaseQuota(namespace, 0, count, nil)
+			return nil, fmt.Errorf("unable to persist acquired ip:%v error:%w", v4Prefix, err)
+		}
+	}
+	if !v6ok {
+		result.IPv6 = v6Prefix.acquire(v6ip, o)
+		v6Prefix.rememberIdempotencyKey(idempotencyIPs, o.idempotencyKey, v6ip.String())
+		_, err = i.storage.UpdatePrefix(ctx, *v6Prefix, namespace)
+		if err != nil {
+			i.releaseQuota(namespace, 0, count, nil)
+			err = fmt.Errorf("unable to persist acquired ip:%v error:%w", v6Prefix, err)
+			if v4ok {
+				return nil, err
+			}
+			if rollbackErr := i.rollbackAcquiredIP(ctx, namespace, v4Prefix.Cidr, v4Previous, o.idempotencyKey); rollbackErr != nil {
+				return nil, errors.Join(err, rollbackErr)
+			}
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// nextFreeDualStackIPs returns the next free ip of both prefixes according to the allocation strategy.
+// If the IPv6 address is derived from the IPv4 address, IPv4 addresses whose IPv6 counterpart is not free are skipped.
+func nextFreeDualStackIPs(v4Prefix, v6Prefix *Prefix, v6ipprefix netip.Prefix, o acquireOptions) (netip.Addr, netip.Addr, error) {
+	if !o.embedIPv4 {
+		v4ip, err := nextFreeIPOf(v4Prefix, o)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		v6ip, err := nextFreeIPOf(v6Prefix, o)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		return v4ip, v6ip, nil
+	}
+
+	// skipped candidates are marked as acquired to get the next one, they are freed again before returning
+	var skipped []netip.Addr
+	defer func() {
+		for _, ip := range skipped {
+			v4Prefix.ips.remove(ip)
+		}
+	}()
+	for {
+		v4ip, ok, err := v4Prefix.nextFreeIP(o.strategyFor(v4Prefix))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		if !ok {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("%w: no ip in prefix:%s left whose embedding into prefix:%s is free", ErrNoIPAvailable, v4Prefix.Cidr, v6Prefix.Cidr)
+		}
+		v6ip := embedIPv4(v6ipprefix, v4ip)
+		if v6Prefix.isFree(v6ip) {
+			return v4ip, v6ip, nil
+		}
+		v4Prefix.ips.add(v4ip)
+		skipped = append(skipped, v4ip)
+	}
+}
+
+// nextFreeIPOf returns the next free ip of the prefix according to the allocation strategy.
+func nextFreeIPOf(prefix *Prefix, o acquireOptions) (netip.Addr, error) {
+	ip, ok, err := prefix.nextFreeIP(o.strategyFor(prefix))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if !ok {
+		return netip.Addr{}, fmt.Errorf("%w: no more ips in prefix: %s left, length of prefix.ips: %d", ErrNoIPAvailable, prefix.Cidr, prefix.acquiredips())
+	}
+	return ip, nil
+}
+
+// counterpartIPv6 returns the ip of the IPv6 prefix to acquire together with the already acquired IPv4 address.
+func counterpartIPv6(v6Prefix *Prefix, v6ipprefix netip.Prefix, v4ip netip.Addr, o acquireOptions) (netip.Addr, error) {
+	if !o.embedIPv4 {
+		return nextFreeIPOf(v6Prefix, o)
+	}
+	v6ip := embedIPv4(v6ipprefix, v4ip)
+	if !v6Prefix.isFree(v6ip) {
+		return netip.Addr{}, fmt.Errorf("%w: embedding ip:%s of ip:%s into prefix:%s is not free", ErrNoIPAvailable, v6ip, v4ip, v6Prefix.Cidr)
+	}
+	return v6ip, nil
+}
+
+// counterpartIPv4 returns the ip of the IPv4 prefix to acquire together with the already acquired IPv6 address.
+// If the IPv6 address is derived from the IPv4 address, it is the IPv4 address embedded into it.
+func counterpartIPv4(v4Prefix *Prefix, v4ipprefix, v6ipprefix netip.Prefix, v6ip netip.Addr, o acquireOptions) (netip.Addr, error) {
+	if !o.embedIPv4 {
+		return nextFreeIPOf(v4Prefix, o)
+	}
+	// the host part of the IPv6 prefix is at least as long as the one of the IPv4 prefix, it ends with the whole IPv4 host part
+	hostmask := uint32(1)<<(32-v4ipprefix.Bits()) - 1
+	v4bytes := v4ipprefix.Masked().Addr().As4()
+	v6bytes := v6ip.As16()
+	host := binary.BigEndian.Uint32(v4bytes[:]) | binary.BigEndian.Uint32(v6bytes[12:])&hostmask
+	binary.BigEndian.PutUint32(v4bytes[:], host)
+	v4ip := netip.AddrFrom4(v4bytes)
+	if embedIPv4(v6ipprefix, v4ip) != v6ip || !v4Prefix.isFree(v4ip) {
+		return netip.Addr{}, fmt.Errorf("%w: ip:%s embedded into ip:%s of prefix:%s is not free", ErrNoIPAvailable, v4ip, v6ip, v6ipprefix)
+	}
+	return v4ip, nil
+}
+
+// embedIPv4 returns the address of the IPv6 prefix whose host part ends with the IPv4 address,
+// e.g. 10.0.1.5 embedded into 2001:db8::/64 is 2001:db8::a00:105.
+// If the host part is shorter than 32 bits, only the last bits of the IPv4 address are embedded.
+func embedIPv4(v6 netip.Prefix, v4 netip.Addr) netip.Addr {
+	hostBits := min(128-v6.Bits(), 32)
+	mask := uint32(1<<hostBits - 1) // nolint:gosec
+	v4bytes := v4.As4()
+	addr := v6.Masked().Addr().As16()
+	host := binary.BigEndian.Uint32(addr[12:]) | binary.BigEndian.Uint32(v4bytes[:])&mask
+	binary.BigEndian.PutUint32(addr[12:], host)
+	return netip.AddrFrom16(addr)
+}
+
+// isFree returns true if the given ip can be acquired, i.e. it is neither acquired, reserved, excluded nor quarantined.
+func (p *Prefix) isFree(ip netip.Addr) bool {
+	return !p.ips.contains(ip) && !p.isReserved(ip) && !p.isExcluded(ip) && !p.isQuarantined(ip.String())
+}
+
+// ipSnapshot is the state of a prefix which is changed by acquiring the ip, besides the ip itself.
+type ipSnapshot struct {
+	ip             netip.Addr
+	lastAllocated  string
+	quarantined    time.Time
+	wasQuarantined bool
+}
+
+// acquireSnapshot returns the state which is changed by acquiring the given ip, to restore it on rollback.
+func (p *Prefix) acquireSnapshot(ip netip.Addr) ipSnapshot {
+	quarantined, ok := p.quarantined[ip.String()]
+	return ipSnapshot{ip: ip, lastAllocated: p.lastAllocated, quarantined: quarantined, wasQuarantined: ok}
+}
+
+// rollbackAcquiredIP frees the ip of the snapshot again and restores the cursor of the NextAfterLast allocation strategy
+// and the quarantine of the ip as they were before it was acquired.
+func (i *ipamer) rollbackAcquiredIP(ctx context.Context, namespace, prefixCidr string, previous ipSnapshot, idempotencyKey string) error {
+	ip := previous.ip
+	return retryOnOptimisticLock(func() error {
+		prefix, err := i.PrefixFrom(ctx, prefixCidr)
+		if err != nil {
+			return fmt.Errorf("unable to rollback acquired ip:%s error:%w", ip, err)
+		}
+		prefix.ips.remove(ip)
+		delete(prefix.ipAnnotations, ip.String())
+		delete(prefix.leases, ip.String())
+		// another ip may have been acquired in the meantime, its cursor is kept then
+		if prefix.lastAllocated == ip.String() {
+			prefix.lastAllocated = previous.lastAllocated
+		}
+		if previous.wasQuarantined {
+			if prefix.quarantined == nil {
+				prefix.quarantined = make(map[string]time.Time)
+			}
+			prefix.quarantined[ip.String()] = previous.quarantined
+		}
+		prefix.forgetIdempotencyKey(idempotencyIPs, idempotencyKey)
+		_, err = i.storage.UpdatePrefix(ctx, *prefix, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to rollback acquired ip:%s error:%w", ip, err)
+		}
+		return nil
+	})
+}
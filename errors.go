@@ -7,6 +7,8 @@ var (
 	ErrNotFound = errors.New("NotFound")
 	// ErrNoIPAvailable is returned if no IP is available anymore
 	ErrNoIPAvailable = errors.New("NoIPAvailableError")
+	// ErrNoPrefixAvailable is returned if no child prefix with the requested length is available anymore
+	ErrNoPrefixAvailable = errors.New("NoPrefixAvailableError")
 	// ErrAlreadyAllocated is returned if the requested address is not available
 	ErrAlreadyAllocated = errors.New("AlreadyAllocatedError")
 	// ErrOptimisticLockError is returned if insert or update conflicts with the existing data
@@ -15,4 +17,8 @@ var (
 	ErrNamespaceDoesNotExist = errors.New("NamespaceDoesNotExist")
 	// ErrNameTooLong is returned when a name exceeds the databases max identifier length
 	ErrNameTooLong = errors.New("NameTooLong")
+	// ErrPolicyViolation is returned if an acquisition is not allowed by the Policy of the Prefix
+	ErrPolicyViolation = errors.New("PolicyViolation")
+	// ErrQuotaExceeded is returned if an allocation exceeds the Quota of the namespace
+	ErrQuotaExceeded = errors.New("QuotaExceeded")
 )
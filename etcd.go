@@ -70,6 +70,14 @@ func etcdNamespaceKey(namespace string) string {
 	return namespaceKey + "/" + namespace
 }
 
+func etcdQuotaKey(namespace string) string {
+	return quotaKey + "/" + namespace
+}
+
+func etcdPoolKey(namespace, name string) string {
+	return poolKey + "/" + namespace + "/" + name
+}
+
 // This should ONLY be called when e.Lock() has been acquired
 func (e *etcd) checkNamespaceExists(ctx context.Context, namespace string) error {
 	if _, ok := e.namespaces[namespace]; ok {
@@ -336,7 +344,144 @@ func (e *etcd) DeleteNamespace(ctx context.Context, namespace string) error {
 	}
 	e.lock.Lock()
 	defer e.lock.Unlock()
+	if _, err := e.etcdDB.Delete(ctx, etcdQuotaKey(namespace)); err != nil {
+		return err
+	}
+	if _, err := e.etcdDB.Delete(ctx, etcdPoolKey(namespace, ""), clientv3.WithPrefix()); err != nil {
+		return err
+	}
 	_, err := e.etcdDB.Delete(ctx, etcdNamespaceKey(namespace))
 	delete(e.namespaces, namespace)
 	return err
 }
+
+func (e *etcd) ReadNamespaceQuota(ctx context.Context, namespace string) (Quota, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.checkNamespaceExists(ctx, namespace); err != nil {
+		return Quota{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	get, err := e.etcdDB.Get(ctx, etcdQuotaKey(namespace))
+	if err != nil {
+		return Quota{}, fmt.Errorf("unable to read quota of namespace:%s, error:%w", namespace, err)
+	}
+	if get.Count == 0 {
+		return Quota{}, nil
+	}
+	return quotaFromJSON(get.Kvs[0].Value)
+}
+
+func (e *etcd) UpdateNamespaceQuota(ctx context.Context, namespace string, quota Quota) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if quota.isZero() {
+		if _, err := e.etcdDB.Delete(ctx, etcdQuotaKey(namespace)); err != nil {
+			return fmt.Errorf("unable to delete quota of namespace:%s, error:%w", namespace, err)
+		}
+		return nil
+	}
+	q, err := quota.toJSON()
+	if err != nil {
+		return err
+	}
+	if _, err = e.etcdDB.Put(ctx, etcdQuotaKey(namespace), string(q)); err != nil {
+		return fmt.Errorf("unable to update quota of namespace:%s, error:%w", namespace, err)
+	}
+	return nil
+}
+
+func (e *etcd) ReadPool(ctx context.Context, name, namespace string) (Pool, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.checkNamespaceExists(ctx, namespace); err != nil {
+		return Pool{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	get, err := e.etcdDB.Get(ctx, etcdPoolKey(namespace, name))
+	if err != nil {
+		return Pool{}, fmt.Errorf("unable to read data from ETCD error:%w", err)
+	}
+	if get.Count == 0 {
+		return Pool{}, fmt.Errorf("%w unable to read existing pool:%s", ErrNotFound, name)
+	}
+	return poolFromJSON(get.Kvs[0].Value)
+}
+
+func (e *etcd) ReadAllPools(ctx context.Context, namespace string) ([]Pool, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.checkNamespaceExists(ctx, namespace); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	get, err := e.etcdDB.Get(ctx, etcdPoolKey(namespace, ""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all pools:%w", err)
+	}
+	result := make([]Pool, 0, len(get.Kvs))
+	for _, kv := range get.Kvs {
+		pool, err := poolFromJSON(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pool)
+	}
+	return result, nil
+}
+
+func (e *etcd) UpdatePool(ctx context.Context, pool Pool, namespace string) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	p, err := pool.toJSON()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err = e.etcdDB.Put(ctx, etcdPoolKey(namespace, pool.Name), string(p)); err != nil {
+		return fmt.Errorf("unable to update pool:%s, error:%w", pool.Name, err)
+	}
+	return nil
+}
+
+func (e *etcd) DeletePool(ctx context.Context, name, namespace string) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := e.etcdDB.Delete(ctx, etcdPoolKey(namespace, name))
+	if err != nil {
+		return fmt.Errorf("unable to delete pool:%s, error:%w", name, err)
+	}
+	if resp.Deleted == 0 {
+		return fmt.Errorf("%w pool:%s not found", ErrNotFound, name)
+	}
+	return nil
+}
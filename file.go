@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"os"
 	"path"
+	"strings"
 	"sync"
 	"time"
 )
@@ -34,6 +35,12 @@ var (
 // fileJSONData is a representation of JSON file's structure
 type fileJSONData map[string]map[string]prefixJSON
 
+// fileQuotaJSONData is a representation of the quota file's structure, the quotas are keyed by namespace
+type fileQuotaJSONData map[string]Quota
+
+// filePoolJSONData is a representation of the pool file's structure, the pools are keyed by namespace and name
+type filePoolJSONData map[string]map[string]Pool
+
 func init() {
 	nullModTime = time.Unix(0, 0)
 	DefaultLocalFilePath = path.Join(getXDGDataHome(), "go-ipam", "ipam-db.json")
@@ -68,6 +75,17 @@ func NewLocalFile(ctx context.Context, path string) Storage {
 	}
 }
 
+// quotaPath() returns the location of the file which stores the namespace quotas next to the state file,
+// they are kept separate to stay compatible with existing state files
+func (f *file) quotaPath() string {
+	return strings.TrimSuffix(f.path, ".json") + "-quotas.json"
+}
+
+// poolPath() returns the location of the file which stores the pools next to the state file
+func (f *file) poolPath() string {
+	return strings.TrimSuffix(f.path, ".json") + "-pools.json"
+}
+
 // clearParent() empties the internal state
 func (f *file) clearParent(ctx context.Context) (err error) {
 	namespaces, err := f.parent.ListNamespaces(ctx)
@@ -79,6 +97,18 @@ func (f *file) clearParent(ctx context.Context) (err error) {
 			return fmt.Errorf("failed to delete prefixes for %s namespace: %w", namespace, err)
 		}
 		if namespace == defaultNamespace {
+			if err = f.parent.UpdateNamespaceQuota(ctx, namespace, Quota{}); err != nil {
+				return fmt.Errorf("failed to delete quota of %s namespace: %w", namespace, err)
+			}
+			pools, err := f.parent.ReadAllPools(ctx, namespace)
+			if err != nil {
+				return fmt.Errorf("failed to read pools of %s namespace: %w", namespace, err)
+			}
+			for _, pool := range pools {
+				if err = f.parent.DeletePool(ctx, pool.Name, namespace); err != nil {
+					return fmt.Errorf("failed to delete pool %s of %s namespace: %w", pool.Name, namespace, err)
+				}
+			}
 			// skip deletion instead of replicating NewMemory behavior
 			continue
 		}
@@ -138,6 +168,64 @@ func (f *file) reload(ctx context.Context) (err error) {
 			}
 		}
 	}
+	if err = f.reloadQuotas(ctx); err != nil {
+		return err
+	}
+	return f.reloadPools(ctx)
+}
+
+// readSidecar() parses the file at the given path into v, a missing file leaves v untouched
+func readSidecar(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read file %q: %w", path, err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse file %q: %w", path, err)
+	}
+	return nil
+}
+
+// reloadQuotas() reads the namespace quotas from the quota file, it must be called after the namespaces were reloaded
+func (f *file) reloadQuotas(ctx context.Context) error {
+	quotas := make(fileQuotaJSONData)
+	err := readSidecar(f.quotaPath(), &quotas)
+	if err != nil {
+		return err
+	}
+	for namespace, quota := range quotas {
+		err = f.parent.UpdateNamespaceQuota(ctx, namespace, quota)
+		if errors.Is(err, ErrNamespaceDoesNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reload quota of %s namespace: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+// reloadPools() reads the pools from the pool file, it must be called after the namespaces were reloaded
+func (f *file) reloadPools(ctx context.Context) error {
+	pools := make(filePoolJSONData)
+	err := readSidecar(f.poolPath(), &pools)
+	if err != nil {
+		return err
+	}
+	for namespace, ps := range pools {
+		for _, pool := range ps {
+			err = f.parent.UpdatePool(ctx, pool, namespace)
+			if errors.Is(err, ErrNamespaceDoesNotExist) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to reload pool %s of %s namespace: %w", pool.Name, namespace, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -147,6 +235,8 @@ func (f *file) reload(ctx context.Context) (err error) {
 // after https://github.com/metal-stack/go-ipam/issues/111 is addressed
 func (f *file) persist(ctx context.Context) (err error) {
 	storage := make(fileJSONData)
+	quotas := make(fileQuotaJSONData)
+	pools := make(filePoolJSONData)
 	var (
 		prefixes map[string]prefixJSON
 		ok       bool
@@ -169,6 +259,29 @@ func (f *file) persist(ctx context.Context) (err error) {
 		for _, prefix := range ps {
 			prefixes[prefix.Cidr] = prefix.toPrefixJSON()
 		}
+		quota, err := f.parent.ReadNamespaceQuota(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to read quota of %s namespace while building external state representation: %w", namespace, err)
+		}
+		if !quota.isZero() {
+			quotas[namespace] = quota
+		}
+		nsPools, err := f.parent.ReadAllPools(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to read pools of %s namespace while building external state representation: %w", namespace, err)
+		}
+		for _, pool := range nsPools {
+			if pools[namespace] == nil {
+				pools[namespace] = make(map[string]Pool)
+			}
+			pools[namespace][pool.Name] = pool
+		}
+	}
+	if err = f.persistSidecar(f.quotaPath(), quotas, len(quotas) == 0); err != nil {
+		return err
+	}
+	if err = f.persistSidecar(f.poolPath(), pools, len(pools) == 0); err != nil {
+		return err
 	}
 	if f.prettyJSON {
 		data, err = json.MarshalIndent(storage, "", "  ")
@@ -185,6 +298,34 @@ func (f *file) persist(ctx context.Context) (err error) {
 	f.modTime = f.getModTime()
 	return err
 }
+
+// persistSidecar() writes v to the file at the given path, the file is removed if v is empty
+func (f *file) persistSidecar(path string, v any, empty bool) error {
+	if empty {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error removing %q: %w", path, err)
+		}
+		return nil
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if f.prettyJSON {
+		data, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to serialize JSON: %w", err)
+	}
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("error storing %q: %w", path, err)
+	}
+	return nil
+}
+
 func (f *file) Name() string {
 	return "file"
 }
@@ -305,3 +446,72 @@ func (f *file) DeleteNamespace(ctx context.Context, namespace string) (err error
 	}
 	return f.persist(ctx)
 }
+
+func (f *file) ReadNamespaceQuota(ctx context.Context, namespace string) (q Quota, err error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if err = f.reload(ctx); err != nil {
+		return q, err
+	}
+	return f.parent.ReadNamespaceQuota(ctx, namespace)
+}
+
+func (f *file) UpdateNamespaceQuota(ctx context.Context, namespace string, quota Quota) (err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err = f.reload(ctx); err != nil {
+		return err
+	}
+	if err = f.parent.UpdateNamespaceQuota(ctx, namespace, quota); err != nil {
+		return err
+	}
+	return f.persist(ctx)
+}
+
+func (f *file) ReadPool(ctx context.Context, name, namespace string) (p Pool, err error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if err = f.reload(ctx); err != nil {
+		return p, err
+	}
+	return f.parent.ReadPool(ctx, name, namespace)
+}
+
+func (f *file) ReadAllPools(ctx context.Context, namespace string) (ps []Pool, err error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if err = f.reload(ctx); err != nil {
+		return ps, err
+	}
+	return f.parent.ReadAllPools(ctx, namespace)
+}
+
+func (f *file) UpdatePool(ctx context.Context, pool Pool, namespace string) (err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err = f.reload(ctx); err != nil {
+		return err
+	}
+	if err = f.parent.UpdatePool(ctx, pool, namespace); err != nil {
+		return err
+	}
+	return f.persist(ctx)
+}
+
+func (f *file) DeletePool(ctx context.Context, name, namespace string) (err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err = f.reload(ctx); err != nil {
+		return err
+	}
+	if err = f.parent.DeletePool(ctx, name, namespace); err != nil {
+		return err
+	}
+	return f.persist(ctx)
+}
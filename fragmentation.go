@@ -0,0 +1,217 @@
+package ipam
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"slices"
+
+	"go4.org/netipx"
+)
+
+// FragmentationReport describes how the free address space of a parent Prefix is split into blocks.
+type FragmentationReport struct {
+	Cidr string
+	// LargestFreeLength is the shortest length of a child prefix which can still be acquired, 0 if no child prefix can be acquired
+	LargestFreeLength uint8
+	// FreeBlocks is the number of free blocks by their length, the free address space is split into the largest aligned blocks
+	FreeBlocks map[uint8]uint64
+	// FreeAddresses is the exact number of addresses which are neither part of an acquired child prefix nor excluded
+	FreeAddresses *big.Int
+	// Score is 0 if all free addresses are in a single block and approaches 1 the more the free address space is scattered,
+	// it is the share of free addresses outside of the largest free block
+	Score float64
+}
+
+// DefragmentationPlan proposes which child prefixes to move to free up a contiguous block of a parent Prefix.
+type DefragmentationPlan struct {
+	// Block is the contiguous block which is free after all Moves are done
+	Block string
+	// Moves are the child prefixes to renumber ordered by their current cidr, empty if Block is already free
+	Moves []ChildPrefixMove
+}
+
+// ChildPrefixMove proposes to renumber a child prefix to a free prefix of the same length.
+type ChildPrefixMove struct {
+	From string
+	To   string
+}
+
+func (i *ipamer) FragmentationReport(ctx context.Context, cidr string) (*FragmentationReport, error) {
+	prefix, err := i.PrefixFrom(ctx, cidr)
+	if err != nil {
+		return nil, err
+	}
+	return prefix.fragmentationReport()
+}
+
+func (p *Prefix) fragmentationReport() (*FragmentationReport, error) {
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+	}
+	free, err := p.freeChildIPSet()
+	if err != nil {
+		return nil, err
+	}
+	report := &FragmentationReport{
+		Cidr:          p.Cidr,
+		FreeBlocks:    make(map[uint8]uint64),
+		FreeAddresses: new(big.Int),
+	}
+	var largest netip.Prefix
+	for _, block := range free.Prefixes() {
+		report.FreeBlocks[uint8(block.Bits())]++ // nolint:gosec
+		report.FreeAddresses.Add(report.FreeAddresses, prefixSize(block))
+		if !largest.IsValid() || block.Bits() < largest.Bits() {
+			largest = block
+		}
+	}
+	if !largest.IsValid() {
+		return report, nil
+	}
+	report.LargestFreeLength = p.largestChildLength(ipprefix, largest.Bits())
+	score, _ := new(big.Rat).SetFrac(prefixSize(largest), report.FreeAddresses).Float64()
+	report.Score = 1 - score
+	return report, nil
+}
+
+// largestChildLength returns the shortest child length which can be acquired from a free block with the given length,
+// limited by the Policy of the Prefix, 0 if the Prefix has ips or the Policy does not allow any child from this block.
+func (p *Prefix) largestChildLength(ipprefix netip.Prefix, blockLength int) uint8 {
+	if p.hasIPs() {
+		return 0
+	}
+	length := max(blockLength, ipprefix.Bits()+1)
+	if p.Policy != nil {
+		length = max(length, int(p.Policy.MinChildLength))
+		if p.Policy.MaxChildLength > 0 && length > int(p.Policy.MaxChildLength) {
+			return 0
+		}
+	}
+	return uint8(length) // nolint:gosec
+}
+
+func (i *ipamer) PlanDefragmentation(ctx context.Context, cidr string, length uint8) (*DefragmentationPlan, error) {
+	prefix, err := i.PrefixFrom(ctx, cidr)
+	if err != nil {
+		return nil, err
+	}
+	return prefix.planDefragmentation(int(length))
+}
+
+// planDefragmentation looks at every aligned block with the given length which contains child prefixes and chooses the one
+// which needs the fewest moves, then the fewest moved addresses, then the lowest one.
+// The moved child prefixes are placed best fit into the free address space outside of the block, the largest first.
+func (p *Prefix) planDefragmentation(length int) (*DefragmentationPlan, error) {
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+	}
+	if ipprefix.Bits() >= length || length > ipprefix.Addr().BitLen() {
+		return nil, fmt.Errorf("given length:%d must be greater than prefix length:%d and not greater than %d", length, ipprefix.Bits(), ipprefix.Addr().BitLen())
+	}
+	if p.hasIPs() {
+		return nil, fmt.Errorf("prefix %s has ips, defragmentation of child prefixes not possible", p.Cidr)
+	}
+	free, err := p.freeChildIPSet()
+	if err != nil {
+		return nil, err
+	}
+	if block, _, ok := free.RemoveFreePrefix(uint8(length)); ok { // nolint:gosec
+		return &DefragmentationPlan{Block: block.String()}, nil
+	}
+	excluded, err := p.exclusionIPSet()
+	if err != nil {
+		return nil, err
+	}
+
+	var children []netip.Prefix
+	for cp, available := range p.availableChildPrefixes {
+		if available {
+			continue
+		}
+		child, err := netip.ParsePrefix(cp)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	slices.SortFunc(children, func(a, b netip.Prefix) int {
+		return a.Compare(b)
+	})
+
+	var (
+		best      *DefragmentationPlan
+		bestMoved *big.Int
+		planned   = make(map[netip.Prefix]bool)
+	)
+	for _, child := range children {
+		if child.Bits() < length {
+			// the child is larger than the requested block, it must stay where it is
+			continue
+		}
+		block := netip.PrefixFrom(child.Addr(), length).Masked()
+		if planned[block] || excluded.OverlapsPrefix(block) {
+			continue
+		}
+		planned[block] = true
+		plan, moved, ok, err := planBlock(free, block, children)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if best == nil || len(plan.Moves) < len(best.Moves) || len(plan.Moves) == len(best.Moves) && moved.Cmp(bestMoved) < 0 {
+			best, bestMoved = plan, moved
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: no block with length:%d can be freed in %s by moving child prefixes", ErrNoPrefixAvailable, length, p.Cidr)
+	}
+	return best, nil
+}
+
+// planBlock moves all children inside the block to the free addresses outside of it and returns the plan with the number
+// of moved addresses. It returns false if the children do not fit into the free addresses outside of the block.
+func planBlock(free *netipx.IPSet, block netip.Prefix, children []netip.Prefix) (*DefragmentationPlan, *big.Int, bool, error) {
+	var inside []netip.Prefix
+	for _, child := range children {
+		if block.Contains(child.Addr()) {
+			inside = append(inside, child)
+		}
+	}
+	var builder netipx.IPSetBuilder
+	builder.AddSet(free)
+	builder.RemovePrefix(block)
+	outside, err := builder.IPSet()
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("error constructing ipset:%w", err)
+	}
+
+	// place the largest children first, smaller ones fit into the remaining gaps
+	placement := slices.Clone(inside)
+	slices.SortStableFunc(placement, func(a, b netip.Prefix) int {
+		return cmp.Compare(a.Bits(), b.Bits())
+	})
+	targets := make(map[netip.Prefix]netip.Prefix, len(placement))
+	moved := new(big.Int)
+	for _, child := range placement {
+		target, remaining, ok := outside.RemoveFreePrefix(uint8(child.Bits())) // nolint:gosec
+		if !ok {
+			return nil, nil, false, nil
+		}
+		targets[child] = target
+		moved.Add(moved, prefixSize(child))
+		outside = remaining
+	}
+
+	plan := &DefragmentationPlan{Block: block.String()}
+	for _, child := range inside {
+		plan.Moves = append(plan.Moves, ChildPrefixMove{From: child.String(), To: targets[child].String()})
+	}
+	return plan, moved, true, nil
+}
@@ -0,0 +1,142 @@
+package ipam
+
+import (
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+)
+
+// IdempotencyRetention is the duration an idempotency key is remembered.
+// Repeating a request with the same key within this duration returns the original result instead of acquiring again.
+const IdempotencyRetention = 24 * time.Hour
+
+// idempotencyKind separates the idempotency records of the different operations on a prefix,
+// a key used for one operation never returns the results of another one.
+type idempotencyKind string
+
+const (
+	// idempotencyCreate records NewPrefix, the result is the created prefix itself
+	idempotencyCreate idempotencyKind = "create"
+	// idempotencyChildPrefixes records AcquireChildPrefix and AcquireChildPrefixes, the results are the acquired child prefixes
+	idempotencyChildPrefixes idempotencyKind = "child-prefixes"
+	// idempotencyIPs records all ip acquisitions, the results are the acquired ips
+	idempotencyIPs idempotencyKind = "ips"
+)
+
+// recordKey returns the key of the idempotency record of the given kind and idempotency key.
+func (k idempotencyKind) recordKey(key string) string {
+	return string(k) + ":" + key
+}
+
+// idempotencyRecord is the result of a request with an idempotency key.
+type idempotencyRecord struct {
+	Results []string  `json:"Results"` // the acquired ips or created prefixes
+	Created time.Time `json:"Created"`
+}
+
+// expired returns true if the record is older than the IdempotencyRetention at the given time.
+func (r idempotencyRecord) expired(now time.Time) bool {
+	return !now.Before(r.Created.Add(IdempotencyRetention))
+}
+
+// idempotentResults returns the results of an earlier request of the given kind with the given key,
+// false if there is none within the retention.
+func (p *Prefix) idempotentResults(kind idempotencyKind, key string) ([]string, bool) {
+	if key == "" {
+		return nil, false
+	}
+	record, ok := p.idempotencyKeys[kind.recordKey(key)]
+	if !ok || record.expired(time.Now()) || len(record.Results) == 0 {
+		return nil, false
+	}
+	return record.Results, true
+}
+
+// idempotentIPs returns the ips acquired by an earlier request with the given key,
+// false if there is none or one of them was released in the meantime.
+func (p *Prefix) idempotentIPs(key string) ([]*IP, bool) {
+	results, ok := p.idempotentResults(idempotencyIPs, key)
+	if !ok {
+		return nil, false
+	}
+	ips := make([]*IP, 0, len(results))
+	for _, result := range results {
+		addr, err := netip.ParseAddr(result)
+		if err != nil || !p.ips.contains(addr) {
+			return nil, false
+		}
+		ip, err := p.acquiredIP(result)
+		if err != nil {
+			return nil, false
+		}
+		ips = append(ips, ip)
+	}
+	return ips, true
+}
+
+// idempotentChildPrefixes returns the cidrs of the child prefixes acquired by an earlier request with the given key,
+// false if there is none or one of them was released in the meantime.
+func (p *Prefix) idempotentChildPrefixes(key string) ([]string, bool) {
+	results, ok := p.idempotentResults(idempotencyChildPrefixes, key)
+	if !ok {
+		return nil, false
+	}
+	for _, cidr := range results {
+		if available, ok := p.availableChildPrefixes[cidr]; !ok || available {
+			return nil, false
+		}
+	}
+	return results, true
+}
+
+// rememberIdempotencyKey stores the results of a request of the given kind with the given key and forgets all expired keys,
+// the prefix must be persisted afterwards.
+func (p *Prefix) rememberIdempotencyKey(kind idempotencyKind, key string, results ...string) {
+	now := time.Now()
+	for k, record := range p.idempotencyKeys {
+		if record.expired(now) {
+			delete(p.idempotencyKeys, k)
+		}
+	}
+	if key == "" {
+		return
+	}
+	if p.idempotencyKeys == nil {
+		p.idempotencyKeys = make(map[string]idempotencyRecord)
+	}
+	p.idempotencyKeys[kind.recordKey(key)] = idempotencyRecord{
+		Results: results,
+		Created: storableTime(now),
+	}
+}
+
+// idempotencyKeyOf returns the idempotency key configured by the given PrefixOptions, empty if there is none.
+func idempotencyKeyOf(opts ...PrefixOption) string {
+	probe := &Prefix{}
+	for _, opt := range opts {
+		// only WithIdempotencyKey is of interest here, all other options are validated on the real prefix
+		_ = opt(probe)
+	}
+	return probe.idempotencyKey
+}
+
+// forgetIdempotencyKey removes the record of the given kind and key, e.g. if its results were rolled back,
+// the prefix must be persisted afterwards.
+func (p *Prefix) forgetIdempotencyKey(kind idempotencyKind, key string) {
+	if key == "" {
+		return
+	}
+	delete(p.idempotencyKeys, kind.recordKey(key))
+}
+
+// forgetIdempotencyResult removes all records of the given kind which contain the released result,
+// a repeated request must not return an ip or child prefix which was released and may be acquired by someone else.
+// The prefix must be persisted afterwards.
+func (p *Prefix) forgetIdempotencyResult(kind idempotencyKind, result string) {
+	for recordKey, record := range p.idempotencyKeys {
+		if strings.HasPrefix(recordKey, kind.recordKey("")) && slices.Contains(record.Results, result) {
+			delete(p.idempotencyKeys, recordKey)
+		}
+	}
+}
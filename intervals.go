@@ -0,0 +1,172 @@
+package ipam
+
+import (
+	"net/netip"
+	"slices"
+	"sort"
+
+	"go4.org/netipx"
+)
+
+// ipIntervals is a set of ips stored as sorted, non overlapping and non adjacent ranges.
+// Consecutive ips collapse into a single range, which keeps the allocation state of large prefixes small
+// and allows scans for free ips to skip all ips of a range at once.
+type ipIntervals []netipx.IPRange
+
+// ipIntervalsOf returns the set of the given addresses and ranges in the form "from-to", the inverse of strings.
+// Invalid entries are skipped, they are never written by strings.
+func ipIntervalsOf(ranges []string) ipIntervals {
+	var ipsetBuilder netipx.IPSetBuilder
+	for _, r := range ranges {
+		if iprange, err := parseIPRange(r); err == nil {
+			ipsetBuilder.AddRange(iprange)
+		}
+	}
+	ipset, err := ipsetBuilder.IPSet()
+	if err != nil || len(ipset.Ranges()) == 0 {
+		return nil
+	}
+	return ipset.Ranges()
+}
+
+// strings returns the ranges of the set, single ips are formatted without range notation.
+func (s ipIntervals) strings() []string {
+	if len(s) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(s))
+	for _, r := range s {
+		result = append(result, formatIPRange(r))
+	}
+	return result
+}
+
+// search returns the index of the first range which does not end before ip.
+func (s ipIntervals) search(ip netip.Addr) int {
+	return sort.Search(len(s), func(i int) bool {
+		return !s[i].To().Less(ip)
+	})
+}
+
+// rangeOf returns the range which contains ip.
+func (s ipIntervals) rangeOf(ip netip.Addr) (netipx.IPRange, bool) {
+	i := s.search(ip)
+	if i < len(s) && !ip.Less(s[i].From()) {
+		return s[i], true
+	}
+	return netipx.IPRange{}, false
+}
+
+// contains returns true if ip is in the set.
+func (s ipIntervals) contains(ip netip.Addr) bool {
+	_, ok := s.rangeOf(ip)
+	return ok
+}
+
+// firstIn returns the lowest ip of the set which is in the given range.
+func (s ipIntervals) firstIn(r netipx.IPRange) (netip.Addr, bool) {
+	i := s.search(r.From())
+	if i == len(s) || r.To().Less(s[i].From()) {
+		return netip.Addr{}, false
+	}
+	if s[i].From().Less(r.From()) {
+		return r.From(), true
+	}
+	return s[i].From(), true
+}
+
+// add inserts ip and joins it with the adjacent ranges.
+func (s *ipIntervals) add(ip netip.Addr) {
+	r := *s
+	i := r.search(ip)
+	if i < len(r) && !ip.Less(r[i].From()) {
+		return
+	}
+	joinPrev := i > 0 && r[i-1].To().Next() == ip
+	joinNext := i < len(r) && ip.Next() == r[i].From()
+	switch {
+	case joinPrev && joinNext:
+		r[i-1] = netipx.IPRangeFrom(r[i-1].From(), r[i].To())
+		r = slices.Delete(r, i, i+1)
+	case joinPrev:
+		r[i-1] = netipx.IPRangeFrom(r[i-1].From(), ip)
+	case joinNext:
+		r[i] = netipx.IPRangeFrom(ip, r[i].To())
+	default:
+		r = slices.Insert(r, i, netipx.IPRangeFrom(ip, ip))
+	}
+	*s = r
+}
+
+// remove deletes ip and splits its range if required, it returns false if ip is not in the set.
+func (s *ipIntervals) remove(ip netip.Addr) bool {
+	r := *s
+	i := r.search(ip)
+	if i == len(r) || ip.Less(r[i].From()) {
+		return false
+	}
+	from, to := r[i].From(), r[i].To()
+	switch {
+	case from == to:
+		r = slices.Delete(r, i, i+1)
+	case from == ip:
+		r[i] = netipx.IPRangeFrom(ip.Next(), to)
+	case to == ip:
+		r[i] = netipx.IPRangeFrom(from, ip.Prev())
+	default:
+		r[i] = netipx.IPRangeFrom(from, ip.Prev())
+		r = slices.Insert(r, i+1, netipx.IPRangeFrom(ip.Next(), to))
+	}
+	if len(r) == 0 {
+		r = nil
+	}
+	*s = r
+	return true
+}
+
+// within returns the ips of the set which are in the given range.
+func (s ipIntervals) within(r netipx.IPRange) ipIntervals {
+	var result ipIntervals
+	for i := s.search(r.From()); i < len(s) && !r.To().Less(s[i].From()); i++ {
+		from, to := s[i].From(), s[i].To()
+		if from.Less(r.From()) {
+			from = r.From()
+		}
+		if r.To().Less(to) {
+			to = r.To()
+		}
+		result = append(result, netipx.IPRangeFrom(from, to))
+	}
+	return result
+}
+
+// union returns the ips which are in the set or in other.
+func (s ipIntervals) union(other ipIntervals) ipIntervals {
+	var ipsetBuilder netipx.IPSetBuilder
+	for _, r := range slices.Concat(s, other) {
+		ipsetBuilder.AddRange(r)
+	}
+	ipset, err := ipsetBuilder.IPSet()
+	if err != nil || len(ipset.Ranges()) == 0 {
+		return nil
+	}
+	return ipset.Ranges()
+}
+
+// count returns the number of ips in the set.
+func (s ipIntervals) count() uint64 {
+	var total uint64
+	for _, r := range s {
+		total += rangeSize(r).Uint64()
+	}
+	return total
+}
+
+// ipSet returns the ips of the set as IPSet.
+func (s ipIntervals) ipSet() (*netipx.IPSet, error) {
+	var ipsetBuilder netipx.IPSetBuilder
+	for _, r := range s {
+		ipsetBuilder.AddRange(r)
+	}
+	return ipsetBuilder.IPSet()
+}
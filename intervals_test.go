@@ -0,0 +1,57 @@
+package ipam
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go4.org/netipx"
+)
+
+func TestIPIntervals(t *testing.T) {
+	var s ipIntervals
+	for _, ip := range []string{"10.0.0.5", "10.0.0.1", "10.0.0.3", "10.0.0.2", "10.0.0.9"} {
+		s.add(netip.MustParseAddr(ip))
+	}
+	require.Equal(t, []string{"10.0.0.1-10.0.0.3", "10.0.0.5", "10.0.0.9"}, s.strings())
+	require.Equal(t, uint64(5), s.count())
+
+	// adding an ip twice has no effect, adding the gap joins both neighbours
+	s.add(netip.MustParseAddr("10.0.0.2"))
+	s.add(netip.MustParseAddr("10.0.0.4"))
+	require.Equal(t, []string{"10.0.0.1-10.0.0.5", "10.0.0.9"}, s.strings())
+
+	require.True(t, s.contains(netip.MustParseAddr("10.0.0.3")))
+	require.False(t, s.contains(netip.MustParseAddr("10.0.0.6")))
+	require.False(t, s.contains(netip.MustParseAddr("10.0.0.0")))
+
+	first, ok := s.firstIn(netipx.MustParseIPRange("10.0.0.3-10.0.0.10"))
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.3", first.String())
+	first, ok = s.firstIn(netipx.MustParseIPRange("10.0.0.6-10.0.0.10"))
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.9", first.String())
+	_, ok = s.firstIn(netipx.MustParseIPRange("10.0.0.6-10.0.0.8"))
+	require.False(t, ok)
+
+	// removing from the middle splits the range
+	require.True(t, s.remove(netip.MustParseAddr("10.0.0.3")))
+	require.False(t, s.remove(netip.MustParseAddr("10.0.0.3")))
+	require.Equal(t, []string{"10.0.0.1-10.0.0.2", "10.0.0.4-10.0.0.5", "10.0.0.9"}, s.strings())
+
+	require.True(t, s.remove(netip.MustParseAddr("10.0.0.1")))
+	require.True(t, s.remove(netip.MustParseAddr("10.0.0.5")))
+	require.True(t, s.remove(netip.MustParseAddr("10.0.0.9")))
+	require.Equal(t, []string{"10.0.0.2", "10.0.0.4"}, s.strings())
+	require.True(t, s.remove(netip.MustParseAddr("10.0.0.2")))
+	require.True(t, s.remove(netip.MustParseAddr("10.0.0.4")))
+	require.Nil(t, s)
+
+	s = ipIntervalsOf([]string{"10.0.0.1-10.0.0.5", "10.0.0.9", "10.0.0.20-10.0.0.30"})
+	require.Equal(t, []string{"10.0.0.4-10.0.0.5", "10.0.0.9", "10.0.0.20-10.0.0.22"}, s.within(netipx.MustParseIPRange("10.0.0.4-10.0.0.22")).strings())
+	require.Nil(t, s.within(netipx.MustParseIPRange("10.0.0.10-10.0.0.19")))
+	require.Equal(t, []string{"10.0.0.1-10.0.0.9", "10.0.0.20-10.0.0.30"}, s.union(ipIntervalsOf([]string{"10.0.0.6-10.0.0.8"})).strings())
+
+	require.Equal(t, []string{"2001:db8::1-2001:db8::3"}, ipIntervalsOf([]string{"2001:db8::3", "2001:db8::1-2001:db8::2", "invalid"}).strings())
+	require.Nil(t, ipIntervalsOf(nil))
+}
@@ -8,4 +8,19 @@ import (
 type IP struct {
 	IP           netip.Addr
 	ParentPrefix string
+	// Annotations carry arbitrary metadata like owner, hostname or description of this ip.
+	Annotations map[string]string
+	// Lease is set if the ip was acquired with a ttl, nil otherwise.
+	Lease *Lease
+}
+
+// IPRange is a range of consecutive ipaddresses acquired together.
+type IPRange struct {
+	From         netip.Addr
+	To           netip.Addr
+	ParentPrefix string
+}
+
+func (r *IPRange) String() string {
+	return r.From.String() + "-" + r.To.String()
 }
@@ -3,6 +3,7 @@ package ipam
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 type namespaceContextKey struct{}
@@ -14,34 +15,164 @@ const (
 // Ipamer can be used to do IPAM stuff.
 type Ipamer interface {
 	// NewPrefix creates a new Prefix from a string notation.
+	// The Prefix can be further configured with PrefixOptions, e.g. WithLabels.
+	// Unless configured otherwise with reservation options like WithoutReservedIPs, the first address
+	// and for IPv4 the broadcast address are reserved and never acquired.
+	// With WithIdempotencyKey a repeated call with the same cidr within the IdempotencyRetention returns the originally created Prefix.
+	// If the Prefix exceeds the Quota of the namespace, a QuotaExceeded error is returned.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
-	NewPrefix(ctx context.Context, cidr string) (*Prefix, error)
+	NewPrefix(ctx context.Context, cidr string, opts ...PrefixOption) (*Prefix, error)
 	// DeletePrefix delete a Prefix from a string notation.
+	// A Prefix with IPs is only deleted with DeleteRecursive, which also deletes all descendants and releases all their IPs.
+	// With DeleteDryRun nothing is deleted, DeleteWithReport lists what is or would be deleted.
 	// If the Prefix is not found an NotFoundError is returned.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
-	DeletePrefix(ctx context.Context, cidr string) (*Prefix, error)
+	DeletePrefix(ctx context.Context, cidr string, opts ...DeleteOption) (*Prefix, error)
 	// AcquireChildPrefix will return a Prefix with a smaller length from the given Prefix.
+	// The free block it is taken from is chosen by the ChildPrefixStrategy of the given Prefix, WithChildPlacement overrides it.
+	// If the length or the number of child Prefixes is not allowed by the Policy of the given Prefix, a PolicyViolation is returned.
+	// With WithIdempotencyKey a repeated call within the IdempotencyRetention returns the originally acquired Prefix.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
-	AcquireChildPrefix(ctx context.Context, parentCidr string, length uint8) (*Prefix, error)
+	AcquireChildPrefix(ctx context.Context, parentCidr string, length uint8, opts ...PrefixOption) (*Prefix, error)
+	// AcquireChildPrefixes will return count Prefixes with a smaller length from the given Prefix.
+	// The parent Prefix is updated once, if one of the children can not be created, the parent update is rolled back
+	// and no child Prefix is acquired.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireChildPrefixes(ctx context.Context, parentCidr string, length uint8, count int, opts ...PrefixOption) (Prefixes, error)
 	// AcquireSpecificChildPrefix will return a Prefix with a smaller length from the given Prefix.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
-	AcquireSpecificChildPrefix(ctx context.Context, parentCidr, childCidr string) (*Prefix, error)
+	AcquireSpecificChildPrefix(ctx context.Context, parentCidr, childCidr string, opts ...PrefixOption) (*Prefix, error)
 	// ReleaseChildPrefix will mark this child Prefix as available again.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
 	ReleaseChildPrefix(ctx context.Context, child *Prefix) error
+	// EditPrefix applies the given PrefixOptions to an existing Prefix, e.g. to change its labels or description.
+	// If the Prefix is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	EditPrefix(ctx context.Context, cidr string, opts ...PrefixOption) (*Prefix, error)
+	// ResizePrefix grows or shrinks the Prefix to the given length, the network address stays the same.
+	// A Prefix can only grow if its network address is also the one of the grown Prefix, e.g. 10.0.0.0/24 to /23 but not 10.0.1.0/24.
+	// A growing Prefix must not overlap other top level Prefixes or, for a child Prefix, its siblings and must fit into its parent.
+	// A shrinking Prefix must still contain all its acquired IPs and child Prefixes, otherwise an AlreadyAllocatedError is returned.
+	// Acquired IPs, leases, annotations and child Prefixes are moved to the resized Prefix, which also replaces the Prefix in its Pools.
+	// If one of the updates fails all are rolled back.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	ResizePrefix(ctx context.Context, cidr string, length uint8) (*Prefix, error)
+	// SplitPrefix replaces the Prefix with all its parts of the given length, e.g. a /24 with two /25, and moves every acquired IP
+	// and child Prefix with its annotations and lease to the part which contains it. Child Prefixes must not be larger than a part
+	// and an acquired IP must not become the network or broadcast address of a part, otherwise an AlreadyAllocatedError is returned.
+	// For a child Prefix the parts become child Prefixes of its parent. In Pools the parts take the position of the Prefix.
+	// If one of the updates fails all are rolled back.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	SplitPrefix(ctx context.Context, cidr string, length uint8) (Prefixes, error)
+	// MergePrefixes replaces the given adjacent Prefixes with the same parent by their supernet, which takes over all acquired IPs
+	// and child Prefixes. Labels, description, allocation strategy, quarantine and policy are taken from the first Prefix.
+	// In Pools the supernet takes the position of the first merged member. If one of the updates fails all are rolled back.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	MergePrefixes(ctx context.Context, cidrs ...string) (*Prefix, error)
+	// CreatePool creates a Pool with the given name which groups the given Prefixes, e.g. several public IPv4 blocks.
+	// The strategy defines in which order the Prefixes are tried on acquisition, FillFirst if empty.
+	// If one of the Prefixes is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	CreatePool(ctx context.Context, name string, strategy PoolStrategy, cidrs ...string) (*Pool, error)
+	// UpdatePool replaces the strategy and the Prefixes of the Pool with the given name.
+	// If the Pool or one of the Prefixes is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	UpdatePool(ctx context.Context, name string, strategy PoolStrategy, cidrs ...string) (*Pool, error)
+	// GetPool returns the Pool with the given name.
+	// If the Pool is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	GetPool(ctx context.Context, name string) (*Pool, error)
+	// ListPools returns all Pools ordered by their name.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	ListPools(ctx context.Context) ([]Pool, error)
+	// DeletePool deletes the Pool with the given name, its Prefixes are kept.
+	// If the Pool is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	DeletePool(ctx context.Context, name string) error
+	// AcquireIPFromPool acquires the next unused IP from the Prefixes of the Pool in the order of its strategy,
+	// a Prefix without free IPs, with child Prefixes or whose Policy denies IPs is skipped. If no Prefix has a free IP a NoIPAvailableError is returned.
+	// The acquisition can be further configured with AcquireOptions like in AcquireIP.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireIPFromPool(ctx context.Context, name string, opts ...AcquireOption) (*IP, error)
+	// AcquireChildPrefixFromPool acquires a child Prefix with the given length from the Prefixes of the Pool in the order of its strategy,
+	// a Prefix which is too small, has IPs, has no space left or whose Policy does not allow the child Prefix is skipped. If no Prefix has space a NoPrefixAvailableError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireChildPrefixFromPool(ctx context.Context, name string, length uint8, opts ...PrefixOption) (*Prefix, error)
+	// ListPrefixes returns all Prefixes whose labels match the given kubernetes style label selector,
+	// e.g. "site=fra1,purpose in (underlay,overlay)". An empty selector returns all Prefixes.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	ListPrefixes(ctx context.Context, labelSelector string) (Prefixes, error)
+	// PrefixTree returns the parent/child hierarchy below the Prefix with rootCidr together with the Usage of every Prefix.
+	// If rootCidr is empty, the hierarchies of all top level Prefixes are returned.
+	// depth limits the number of child levels below the root, 0 returns all levels.
+	// If the Prefix is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	PrefixTree(ctx context.Context, rootCidr string, depth int) ([]PrefixNode, error)
+	// FragmentationReport reports how the free address space of the Prefix with the given cidr is split into blocks,
+	// the largest child prefix which can still be acquired and a fragmentation score.
+	// If the Prefix is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	FragmentationReport(ctx context.Context, cidr string) (*FragmentationReport, error)
+	// PlanDefragmentation proposes the child prefixes to move to free up a contiguous block with the given length
+	// in the Prefix with the given cidr. Nothing is moved, the plan is meant to prepare a renumbering.
+	// If no block can be freed by moving child prefixes, a NoPrefixAvailableError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	PlanDefragmentation(ctx context.Context, cidr string, length uint8) (*DefragmentationPlan, error)
 	// PrefixFrom will return a known Prefix.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
 	// If the IP is not found an NotFoundError is returned, otherwise the underlying error
 	PrefixFrom(ctx context.Context, cidr string) (*Prefix, error)
+	// LookupIP returns all Prefixes containing the given IP, from the top level Prefix down to the deepest one,
+	// together with the allocation state and, if acquired, the annotations and lease of the IP in the deepest Prefix.
+	// If no Prefix contains the IP an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	LookupIP(ctx context.Context, ip string) (*IPLookup, error)
 	// AcquireSpecificIP will acquire given IP and mark this IP as used, if already in use, return nil.
-	// If specificIP is empty, the next free IP is returned.
+	// If specificIP is empty, the next free IP according to the AllocationStrategy is returned.
 	// If there is no free IP an NoIPAvailableError is returned.
+	// Excluded IPs of the Prefix are only acquired if AcquireForced is given, quarantined IPs are acquired if given explicitly.
+	// If the Policy of the Prefix denies IPs, a PolicyViolation is returned, if the IP exceeds the Quota of the namespace a QuotaExceeded error.
+	// The acquisition can be further configured with AcquireOptions, e.g. AcquireWithStrategy.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireSpecificIP(ctx context.Context, prefixCidr, specificIP string, opts ...AcquireOption) (*IP, error)
+	// AcquireIP will return the next unused IP from this Prefix according to its AllocationStrategy.
+	// The acquisition can be further configured with AcquireOptions, e.g. AcquireWithTTL to lease the IP.
+	// With AcquireWithIdempotencyKey a repeated call within the IdempotencyRetention returns the originally acquired IP.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireIP(ctx context.Context, prefixCidr string, opts ...AcquireOption) (*IP, error)
+	// AcquireIPs acquires count IPs from this Prefix according to its AllocationStrategy and persists them with a single update.
+	// If less than count IPs are free, a NoIPAvailableError is returned and no IP is acquired.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireIPs(ctx context.Context, prefixCidr string, count int, opts ...AcquireOption) ([]*IP, error)
+	// AcquireIPFromMAC acquires the address of the IPv6 Prefix with the modified EUI-64 interface identifier of the given MAC address.
+	// The Prefix must not be longer than 64 bits. If the address is already acquired, an AlreadyAllocatedError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireIPFromMAC(ctx context.Context, prefixCidr, mac string, opts ...AcquireOption) (*IP, error)
+	// AcquireIPForKey acquires the address the given key is hashed to, the same key always results in the same address of a Prefix
+	// as long as it is free. If it is not free, e.g. because of a hash collision, the next free address after it is acquired.
+	// The key is stored with the acquired IP as KeyAnnotation, a repeated call with the same key returns this IP again.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	AcquireIPForKey(ctx context.Context, prefixCidr, key string, opts ...AcquireOption) (*IP, error)
+	// AcquireDualStack acquires one IP from the IPv4 Prefix and one IP from the IPv6 Prefix, either both or none are acquired.
+	// With AcquireWithEmbeddedIPv4 the IPv6 address is derived from the acquired IPv4 address instead of the allocation strategy.
+	// All other AcquireOptions apply to both IPs. If only one IP of a pair acquired with AcquireWithIdempotencyKey is still acquired,
+	// a repeated call keeps it and only acquires the other one.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
-	AcquireSpecificIP(ctx context.Context, prefixCidr, specificIP string) (*IP, error)
-	// AcquireIP will return the next unused IP from this Prefix.
+	AcquireDualStack(ctx context.Context, v4PrefixCidr, v6PrefixCidr string, opts ...AcquireOption) (*DualStackIP, error)
+	// AcquireIPRange acquires the lowest range of count consecutive free IPs of this Prefix with a single update.
+	// If there is no such range a NoIPAvailableError is returned.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
-	AcquireIP(ctx context.Context, prefixCidr string) (*IP, error)
+	AcquireIPRange(ctx context.Context, prefixCidr string, count int) (*IPRange, error)
+	// ReleaseIPRange releases all IPs of the given range and returns the updated Prefix.
+	// If one of the IPs is not acquired a NotFoundError is returned and no IP is released.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	ReleaseIPRange(ctx context.Context, iprange *IPRange) (*Prefix, error)
+	// GetIP returns the acquired IP of the given Prefix together with its annotations.
+	// If the Prefix or the IP is not found an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	GetIP(ctx context.Context, prefixCidr, ip string) (*IP, error)
 	// ReleaseIP will release the given IP for later usage and returns the updated Prefix.
+	// If the Prefix has a quarantine, the IP is not acquired again by AcquireIP until the quarantine is over.
 	// If the IP is not found an NotFoundError is returned.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
 	ReleaseIP(ctx context.Context, ip *IP) (*Prefix, error)
@@ -49,6 +180,17 @@ type Ipamer interface {
 	// If the Prefix or the IP is not found an NotFoundError is returned.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
 	ReleaseIPFromPrefix(ctx context.Context, prefixCidr, ip string) error
+	// ListLeases returns all leases of IPs and child Prefixes ordered by their expiry.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	ListLeases(ctx context.Context) ([]Lease, error)
+	// RenewLease extends the lease with the given id to expire after ttl.
+	// If the lease is not found or already expired an NotFoundError is returned.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	RenewLease(ctx context.Context, id string, ttl time.Duration) (*Lease, error)
+	// ReleaseExpiredLeases releases all IPs and child Prefixes whose lease is expired and returns the released leases.
+	// Child Prefixes which still have IPs are not released.
+	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
+	ReleaseExpiredLeases(ctx context.Context) ([]Lease, error)
 	// Dump all stored prefixes as json formatted string
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
 	Dump(ctx context.Context) (string, error)
@@ -58,7 +200,7 @@ type Ipamer interface {
 	// ReadAllPrefixCidrs retrieves all existing Prefix CIDRs from the underlying storage.
 	// This operation is scoped to the root namespace unless a different namespace is provided in the context.
 	ReadAllPrefixCidrs(ctx context.Context) ([]string, error)
-	// CreateNamespace creates a namespace with the given name.
+	// CreateNamespace creates a namespace with the given name, names starting with "@" are reserved.
 	// Any namespace provided in the context is ignored for this operation.
 	// It is idempotent, so attempts to create a namespace which already exists will not return an error.
 	CreateNamespace(ctx context.Context, namespace string) error
@@ -69,11 +211,25 @@ type Ipamer interface {
 	// Any namespace provided in the context is ignored for this operation.
 	// It not idempotent, so attempts to delete a namespace which does not exist will return an error.
 	DeleteNamespace(ctx context.Context, namespace string) error
+	// SetNamespaceQuota limits the number of top level Prefixes, acquired IPs and the address space of the namespace.
+	// The zero Quota removes all limits. Allocations which exceed the Quota return a QuotaExceeded error.
+	// Other ipamers sharing the storage apply a changed Quota within ten seconds.
+	// Any namespace provided in the context is ignored for this operation.
+	SetNamespaceQuota(ctx context.Context, namespace string, quota Quota) error
+	// GetNamespaceQuota returns the Quota of the namespace together with its current usage.
+	// Any namespace provided in the context is ignored for this operation.
+	GetNamespaceQuota(ctx context.Context, namespace string) (*QuotaUsage, error)
 }
 
 type ipamer struct {
 	mu      sync.Mutex
 	storage Storage
+	// indexMu guards indexes
+	indexMu sync.Mutex
+	indexes map[string]*prefixTrie // prefix cidrs per namespace used by LookupIP, built on first use
+	// quotaMu guards quotas
+	quotaMu sync.Mutex
+	quotas  map[string]*quotaCacheEntry // quota and usage per namespace used by checkQuota, read on first use
 }
 
 // New returns a Ipamer with in memory storage for networks, prefixes and ips.
@@ -0,0 +1,87 @@
+package ipam
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"strings"
+
+	"go4.org/netipx"
+)
+
+// parseIPRange parses a single ip address, a range in the form "from-to" or a cidr into a IPRange.
+func parseIPRange(s string) (netipx.IPRange, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.Contains(s, "-"):
+		r, err := netipx.ParseIPRange(s)
+		if err != nil {
+			return netipx.IPRange{}, fmt.Errorf("unable to parse ip range:%s %w", s, err)
+		}
+		return r, nil
+	case strings.Contains(s, "/"):
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netipx.IPRange{}, fmt.Errorf("unable to parse ip range:%s %w", s, err)
+		}
+		return netipx.RangeOfPrefix(p.Masked()), nil
+	default:
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			return netipx.IPRange{}, fmt.Errorf("unable to parse ip range:%s %w", s, err)
+		}
+		return netipx.IPRangeFrom(ip, ip), nil
+	}
+}
+
+// formatIPRange is the inverse of parseIPRange, single ips are formatted without range notation.
+func formatIPRange(r netipx.IPRange) string {
+	if r.From() == r.To() {
+		return r.From().String()
+	}
+	return r.String()
+}
+
+// addrOffset returns the address which is offset addresses after ip, the second return value is false on overflow.
+func addrOffset(ip netip.Addr, offset *big.Int) (netip.Addr, bool) {
+	a := ip.As16()
+	n := new(big.Int).SetBytes(a[:])
+	n.Add(n, offset)
+	if n.Sign() < 0 {
+		return netip.Addr{}, false
+	}
+	b := n.Bytes()
+	if len(b) > 16 {
+		return netip.Addr{}, false
+	}
+	var result [16]byte
+	copy(result[16-len(b):], b)
+	addr := netip.AddrFrom16(result)
+	if ip.Is4() {
+		if !addr.Is4In6() {
+			return netip.Addr{}, false
+		}
+		addr = addr.Unmap()
+	}
+	return addr, true
+}
+
+// addrDistance returns the number of addresses between from and to.
+func addrDistance(from, to netip.Addr) *big.Int {
+	f, t := from.As16(), to.As16()
+	return new(big.Int).Sub(new(big.Int).SetBytes(t[:]), new(big.Int).SetBytes(f[:]))
+}
+
+// rangeSize returns the number of addresses in the given range.
+func rangeSize(r netipx.IPRange) *big.Int {
+	return new(big.Int).Add(addrDistance(r.From(), r.To()), big.NewInt(1))
+}
+
+// clampedUint64 converts n to uint64, values above 2^31 are reported as math.MaxInt32.
+func clampedUint64(n *big.Int) uint64 {
+	if n.Cmp(big.NewInt(math.MaxInt32)) > 0 {
+		return math.MaxInt32
+	}
+	return n.Uint64()
+}
@@ -3,6 +3,8 @@ package ipam
 import (
 	"encoding/json"
 	"fmt"
+	"net/netip"
+	"time"
 )
 
 type prefixJSON struct {
@@ -10,10 +12,19 @@ type prefixJSON struct {
 	Namespace              string          `json:"Namespace"`
 	AvailableChildPrefixes map[string]bool `json:"AvailableChildPrefixes"` // available child prefixes of this prefix
 	// TODO remove this in the next release
-	ChildPrefixLength int             `json:"ChildPrefixLength"` // the length of the child prefixes. Legacy to migrate existing prefixes stored in the db to set the IsParent on reads.
-	IsParent          bool            `json:"IsParent"`          // set to true if there are child prefixes
-	IPs               map[string]bool `json:"IPs"`               // The ips contained in this prefix
-	Version           int64           `json:"Version"`           // Version is used for optimistic locking
+	ChildPrefixLength int                          `json:"ChildPrefixLength"`         // the length of the child prefixes. Legacy to migrate existing prefixes stored in the db to set the IsParent on reads.
+	IsParent          bool                         `json:"IsParent"`                  // set to true if there are child prefixes
+	IPs               map[string]bool              `json:"IPs,omitempty"`             // Legacy, the acquired ips of prefixes stored before AcquiredIPs, only read if AcquiredIPs is missing
+	AcquiredIPs       []string                     `json:"AcquiredIPs"`               // The acquired ips of this prefix as addresses and ranges
+	IPAnnotations     map[string]map[string]string `json:"IPAnnotations,omitempty"`   // annotations of acquired ips, keyed by ip
+	Reserved          []string                     `json:"Reserved"`                  // addresses and ranges which are never acquired
+	LastAllocated     string                       `json:"LastAllocated,omitempty"`   // the last acquired ip, used by the NextAfterLast allocation strategy
+	Exclusions        []string                     `json:"Exclusions,omitempty"`      // addresses and ranges which are skipped on acquisition unless forced
+	Leases            map[string]Lease             `json:"Leases,omitempty"`          // leases of acquired ips, keyed by ip
+	Lease             *Lease                       `json:"Lease,omitempty"`           // lease of this child prefix
+	Quarantined       map[string]time.Time         `json:"Quarantined,omitempty"`     // release time of quarantined ips, keyed by ip
+	IdempotencyKeys   map[string]idempotencyRecord `json:"IdempotencyKeys,omitempty"` // results of requests with an idempotency key
+	Version           int64                        `json:"Version"`                   // Version is used for optimistic locking
 }
 
 func (p prefixJSON) toPrefix() Prefix {
@@ -22,13 +33,46 @@ func (p prefixJSON) toPrefix() Prefix {
 	if p.ChildPrefixLength > 0 {
 		p.IsParent = true
 	}
+	// Legacy support, prefixes stored before the acquired ips were stored as ranges
+	// have every acquired ip as key in IPs and no AcquiredIPs.
+	acquired := p.AcquiredIPs
+	if acquired == nil {
+		for ip := range p.IPs {
+			acquired = append(acquired, ip)
+		}
+	}
+	ips := ipIntervalsOf(acquired)
+	// Legacy support, prefixes stored before reservations were configurable
+	// have the first address and the ipv4 broadcast address acquired.
+	if p.Reserved == nil {
+		if ipprefix, err := netip.ParsePrefix(p.Cidr); err == nil {
+			p.Reserved = defaultReservedIPs(ipprefix.Masked())
+			for _, ip := range p.Reserved {
+				ips.remove(netip.MustParseAddr(ip))
+			}
+		}
+	}
 	return Prefix{
 		Cidr:                   p.Cidr,
 		ParentCidr:             p.ParentCidr,
+		Labels:                 p.Labels,
+		Description:            p.Description,
+		AllocationStrategy:     p.AllocationStrategy,
+		ChildPrefixStrategy:    p.ChildPrefixStrategy,
+		Quarantine:             p.Quarantine,
+		Policy:                 p.Policy,
 		availableChildPrefixes: p.AvailableChildPrefixes,
 		childPrefixLength:      p.ChildPrefixLength,
 		isParent:               p.IsParent,
-		ips:                    p.IPs,
+		ips:                    ips,
+		ipAnnotations:          p.IPAnnotations,
+		reserved:               p.Reserved,
+		lastAllocated:          p.LastAllocated,
+		exclusions:             p.Exclusions,
+		leases:                 p.Leases,
+		lease:                  p.Lease,
+		quarantined:            p.Quarantined,
+		idempotencyKeys:        p.IdempotencyKeys,
 		version:                p.Version,
 	}
 }
@@ -36,15 +80,30 @@ func (p prefixJSON) toPrefix() Prefix {
 func (p *Prefix) toPrefixJSON() prefixJSON {
 	return prefixJSON{
 		Prefix: Prefix{
-			Cidr:       p.Cidr,
-			ParentCidr: p.ParentCidr,
+			Cidr:                p.Cidr,
+			ParentCidr:          p.ParentCidr,
+			Labels:              p.Labels,
+			Description:         p.Description,
+			AllocationStrategy:  p.AllocationStrategy,
+			ChildPrefixStrategy: p.ChildPrefixStrategy,
+			Quarantine:          p.Quarantine,
+			Policy:              p.Policy,
 		},
 		AvailableChildPrefixes: p.availableChildPrefixes,
 		IsParent:               p.isParent,
 		// TODO remove this in the next release
 		ChildPrefixLength: p.childPrefixLength,
-		IPs:               p.ips,
-		Version:           p.version,
+		// always written, IPs is only read if it is missing
+		AcquiredIPs:     append([]string{}, p.ips.strings()...),
+		IPAnnotations:   p.ipAnnotations,
+		Reserved:        p.reserved,
+		LastAllocated:   p.lastAllocated,
+		Exclusions:      p.exclusions,
+		Leases:          p.leases,
+		Lease:           p.lease,
+		Quarantined:     p.quarantined,
+		IdempotencyKeys: p.idempotencyKeys,
+		Version:         p.version,
 	}
 }
 
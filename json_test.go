@@ -1,7 +1,9 @@
 package ipam
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -11,11 +13,26 @@ func TestPrefixes_JSON(t *testing.T) {
 	p1 := Prefix{
 		Cidr:                   "192.168.0.0/24",
 		ParentCidr:             "192.168.0.0/20",
+		AllocationStrategy:     NextAfterLast,
+		Quarantine:             time.Hour,
+		Policy:                 &Policy{MinChildLength: 26, MaxChildLength: 28, MaxChildPrefixes: 4, DenyIPs: true},
 		isParent:               false,
 		availableChildPrefixes: map[string]bool{},
 		childPrefixLength:      0,
-		ips:                    map[string]bool{"192.168.0.1": true, "192.168.0.2": true},
-		version:                0,
+		ips:                    ipIntervalsOf([]string{"192.168.0.1-192.168.0.2", "192.168.0.10"}),
+		ipAnnotations:          map[string]map[string]string{"192.168.0.1": {"owner": "tenant-a"}},
+		reserved:               []string{"192.168.0.0", "192.168.0.255"},
+		lastAllocated:          "192.168.0.2",
+		exclusions:             []string{"192.168.0.100-192.168.0.199"},
+		leases: map[string]Lease{
+			"192.168.0.2": {ID: "lease-1", ParentPrefix: "192.168.0.0/24", IP: "192.168.0.2", Expires: time.Date(2030, 1, 2, 3, 4, 5, 6000000, time.UTC)},
+		},
+		lease:       &Lease{ID: "lease-2", ParentPrefix: "192.168.0.0/20", Cidr: "192.168.0.0/24", Expires: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+		quarantined: map[string]time.Time{"192.168.0.3": time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+		idempotencyKeys: map[string]idempotencyRecord{
+			"key-1": {Results: []string{"192.168.0.1"}, Created: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+		version: 0,
 	}
 
 	p2 := Prefix{
@@ -24,7 +41,8 @@ func TestPrefixes_JSON(t *testing.T) {
 		isParent:               false,
 		availableChildPrefixes: map[string]bool{},
 		childPrefixLength:      0,
-		ips:                    map[string]bool{"172.17.0.1": true, "172.17.0.2": true},
+		ips:                    ipIntervalsOf([]string{"172.17.0.1", "172.17.0.2"}),
+		reserved:               []string{},
 		version:                0,
 	}
 
@@ -57,3 +75,57 @@ func TestPrefixes_JSON(t *testing.T) {
 	require.Equal(t, ps1, ps1reverse)
 
 }
+
+func TestPrefix_JSONLegacyReserved(t *testing.T) {
+	// prefixes stored before reservations were configurable have network and broadcast acquired
+	js := []byte(`{"Cidr":"192.168.0.0/24","ParentCidr":"","AvailableChildPrefixes":{},"IsParent":false,"IPs":{"192.168.0.0":true,"192.168.0.1":true,"192.168.0.255":true},"Version":3}`)
+	p, err := fromJSON(js)
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.168.0.1"}, p.ips.strings())
+	require.Equal(t, []string{"192.168.0.0", "192.168.0.255"}, p.reserved)
+	require.Equal(t, uint64(1), p.Usage().AcquiredIPs)
+	require.Equal(t, uint64(2), p.Usage().ReservedIPs)
+
+	js = []byte(`{"Cidr":"2001:db8::/120","ParentCidr":"","AvailableChildPrefixes":{},"IsParent":false,"IPs":{"2001:db8::":true},"Version":1}`)
+	p, err = fromJSON(js)
+	require.NoError(t, err)
+	require.Empty(t, p.ips)
+	require.Equal(t, []string{"2001:db8::"}, p.reserved)
+	require.False(t, p.hasIPs())
+}
+
+func TestPrefix_JSONLegacyIPs(t *testing.T) {
+	// prefixes stored before acquired ips were stored as ranges have every acquired ip as key in IPs
+	js := []byte(`{"Cidr":"192.168.0.0/24","ParentCidr":"","AvailableChildPrefixes":{},"IsParent":false,"IPs":{"192.168.0.1":true,"192.168.0.2":true,"192.168.0.3":true,"192.168.0.7":true},"Reserved":["192.168.0.0","192.168.0.255"],"Version":3}`)
+	p, err := fromJSON(js)
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.168.0.1-192.168.0.3", "192.168.0.7"}, p.ips.strings())
+	require.Equal(t, uint64(4), p.Usage().AcquiredIPs)
+
+	// the legacy field is not written anymore
+	migrated, err := p.toJSON()
+	require.NoError(t, err)
+	require.NotContains(t, string(migrated), `"IPs"`)
+	require.Contains(t, string(migrated), `"AcquiredIPs":["192.168.0.1-192.168.0.3","192.168.0.7"]`)
+
+	p, err = fromJSON(migrated)
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.168.0.1-192.168.0.3", "192.168.0.7"}, p.ips.strings())
+
+	// the legacy field is ignored if AcquiredIPs is written, even if there are no acquired ips
+	p.ips = nil
+	migrated, err = p.toJSON()
+	require.NoError(t, err)
+	require.Contains(t, string(migrated), `"AcquiredIPs":[]`)
+	js = bytes.Replace(migrated, []byte(`"AcquiredIPs":[]`), []byte(`"AcquiredIPs":[],"IPs":{"192.168.0.9":true}`), 1)
+	p, err = fromJSON(js)
+	require.NoError(t, err)
+	require.Empty(t, p.ips)
+
+	// prefixes stored before reservations were configurable have the network and broadcast address acquired
+	js = []byte(`{"Cidr":"192.168.0.0/24","ParentCidr":"","AvailableChildPrefixes":{},"IsParent":false,"IPs":{"192.168.0.0":true,"192.168.0.5":true,"192.168.0.255":true},"Version":4}`)
+	p, err = fromJSON(js)
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.168.0.5"}, p.ips.strings())
+	require.Equal(t, []string{"192.168.0.0", "192.168.0.255"}, p.ReservedIPs())
+}
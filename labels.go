@@ -0,0 +1,165 @@
+package ipam
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type selectorOperator int
+
+const (
+	selectorEquals selectorOperator = iota
+	selectorNotEquals
+	selectorIn
+	selectorNotIn
+	selectorExists
+	selectorDoesNotExist
+)
+
+// labelRequirement is a single term of a label selector, e.g. "site=fra1" or "purpose in (underlay,overlay)"
+type labelRequirement struct {
+	key      string
+	operator selectorOperator
+	values   []string
+}
+
+// labelSelector is a list of requirements which must all match.
+type labelSelector []labelRequirement
+
+// parseLabelSelector parses a kubernetes style label selector.
+// Supported are equality based requirements (=, ==, !=), set based requirements (in, notin)
+// and existence checks (key, !key), separated by commas.
+// An empty selector matches everything.
+func parseLabelSelector(selector string) (labelSelector, error) {
+	var result labelSelector
+	for _, term := range splitSelectorTerms(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		r, err := parseLabelRequirement(term)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector:%q %w", selector, err)
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// splitSelectorTerms splits at commas which are not enclosed in parentheses.
+func splitSelectorTerms(selector string) []string {
+	var (
+		terms []string
+		depth int
+		start int
+	)
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, selector[start:])
+}
+
+func parseLabelRequirement(term string) (labelRequirement, error) {
+	if key, ok := strings.CutPrefix(term, "!"); ok {
+		key = strings.TrimSpace(key)
+		if err := validateLabelKey(key); err != nil {
+			return labelRequirement{}, err
+		}
+		return labelRequirement{key: key, operator: selectorDoesNotExist}, nil
+	}
+	if open := strings.Index(term, "("); open >= 0 {
+		if !strings.HasSuffix(term, ")") {
+			return labelRequirement{}, fmt.Errorf("missing closing parenthesis in %q", term)
+		}
+		fields := strings.Fields(term[:open])
+		if len(fields) != 2 {
+			return labelRequirement{}, fmt.Errorf("expected \"key in (values)\" or \"key notin (values)\" in %q", term)
+		}
+		var operator selectorOperator
+		switch fields[1] {
+		case "in":
+			operator = selectorIn
+		case "notin":
+			operator = selectorNotIn
+		default:
+			return labelRequirement{}, fmt.Errorf("unknown operator %q in %q", fields[1], term)
+		}
+		if err := validateLabelKey(fields[0]); err != nil {
+			return labelRequirement{}, err
+		}
+		var values []string
+		for v := range strings.SplitSeq(term[open+1:len(term)-1], ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+		return labelRequirement{key: fields[0], operator: operator, values: values}, nil
+	}
+	for _, op := range []struct {
+		token    string
+		operator selectorOperator
+	}{
+		{token: "!=", operator: selectorNotEquals},
+		{token: "==", operator: selectorEquals},
+		{token: "=", operator: selectorEquals},
+	} {
+		key, value, ok := strings.Cut(term, op.token)
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if err := validateLabelKey(key); err != nil {
+			return labelRequirement{}, err
+		}
+		return labelRequirement{key: key, operator: op.operator, values: []string{strings.TrimSpace(value)}}, nil
+	}
+	if err := validateLabelKey(term); err != nil {
+		return labelRequirement{}, err
+	}
+	return labelRequirement{key: term, operator: selectorExists}, nil
+}
+
+func validateLabelKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("label key must not be empty")
+	}
+	if strings.ContainsAny(key, " \t=!(),") {
+		return fmt.Errorf("label key %q contains invalid characters", key)
+	}
+	return nil
+}
+
+// matches returns true if all requirements of the selector are fulfilled by the given labels.
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, r := range s {
+		value, exists := labels[r.key]
+		switch r.operator {
+		case selectorEquals, selectorIn:
+			if !exists || !slices.Contains(r.values, value) {
+				return false
+			}
+		case selectorNotEquals, selectorNotIn:
+			if exists && slices.Contains(r.values, value) {
+				return false
+			}
+		case selectorExists:
+			if !exists {
+				return false
+			}
+		case selectorDoesNotExist:
+			if exists {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package ipam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"site": "fra1", "purpose": "underlay", "tier": "gold"}
+	tests := []struct {
+		name     string
+		selector string
+		want     bool
+		wantErr  string
+	}{
+		{name: "empty selector matches everything", selector: "", want: true},
+		{name: "equals", selector: "site=fra1", want: true},
+		{name: "double equals", selector: "site==fra1", want: true},
+		{name: "equals with spaces", selector: " site = fra1 ", want: true},
+		{name: "equals other value", selector: "site=muc1", want: false},
+		{name: "multiple requirements", selector: "site=fra1,purpose=underlay", want: true},
+		{name: "multiple requirements one mismatch", selector: "site=fra1,purpose=overlay", want: false},
+		{name: "not equals", selector: "site!=muc1", want: true},
+		{name: "not equals matching value", selector: "site!=fra1", want: false},
+		{name: "not equals missing key", selector: "zone!=a", want: true},
+		{name: "in", selector: "purpose in (overlay, underlay)", want: true},
+		{name: "in mismatch", selector: "purpose in (overlay,storage)", want: false},
+		{name: "notin", selector: "tier notin (silver,bronze)", want: true},
+		{name: "notin mismatch", selector: "tier notin (gold)", want: false},
+		{name: "in combined", selector: "purpose in (overlay,underlay),site=fra1", want: true},
+		{name: "exists", selector: "tier", want: true},
+		{name: "exists missing", selector: "zone", want: false},
+		{name: "does not exist", selector: "!zone", want: true},
+		{name: "does not exist present", selector: "!tier", want: false},
+		{name: "empty key", selector: "=fra1", wantErr: "invalid label selector:\"=fra1\" label key must not be empty"},
+		{name: "unknown set operator", selector: "site within (fra1)", wantErr: "invalid label selector:\"site within (fra1)\" unknown operator \"within\" in \"site within (fra1)\""},
+		{name: "missing parenthesis", selector: "site in (fra1", wantErr: "invalid label selector:\"site in (fra1\" missing closing parenthesis in \"site in (fra1\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseLabelSelector(tt.selector)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, s.matches(labels))
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package ipam
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Lease limits the lifetime of an acquired ip or child prefix.
+// Expired leases are released by ReleaseExpiredLeases unless they are renewed before with RenewLease.
+type Lease struct {
+	ID           string `json:"ID"`
+	ParentPrefix string `json:"ParentPrefix"`
+	// IP is the leased ip, empty for child prefix leases
+	IP string `json:"IP,omitempty"`
+	// Cidr is the leased child prefix, empty for ip leases
+	Cidr    string    `json:"Cidr,omitempty"`
+	Expires time.Time `json:"Expires"`
+}
+
+// newLease returns a lease with a random id which expires after ttl.
+func newLease(parentPrefix string, ttl time.Duration) Lease {
+	return Lease{
+		ID:           rand.Text(),
+		ParentPrefix: parentPrefix,
+		Expires:      expiresAfter(ttl),
+	}
+}
+
+// expiresAfter returns the expiry after ttl.
+func expiresAfter(ttl time.Duration) time.Time {
+	return storableTime(time.Now().Add(ttl))
+}
+
+// storableTime returns t with millisecond precision in UTC, which survives all backends unchanged.
+func storableTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Millisecond)
+}
+
+// expired returns true if the lease is expired at the given time.
+func (l Lease) expired(now time.Time) bool {
+	return !now.Before(l.Expires)
+}
+
+// allLeases returns all leases of this Prefix, the lease of the Prefix itself first.
+func (p *Prefix) allLeases() []Lease {
+	var leases []Lease
+	if p.lease != nil {
+		leases = append(leases, *p.lease)
+	}
+	for _, ip := range slices.Sorted(maps.Keys(p.leases)) {
+		leases = append(leases, p.leases[ip])
+	}
+	return leases
+}
+
+func (i *ipamer) ListLeases(ctx context.Context) ([]Lease, error) {
+	namespace := namespaceFromContext(ctx)
+	prefixes, err := i.storage.ReadAllPrefixes(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read prefixes:%w", err)
+	}
+	var leases []Lease
+	for _, p := range prefixes {
+		leases = append(leases, p.allLeases()...)
+	}
+	slices.SortStableFunc(leases, func(a, b Lease) int {
+		return a.Expires.Compare(b.Expires)
+	})
+	return leases, nil
+}
+
+func (i *ipamer) RenewLease(ctx context.Context, id string, ttl time.Duration) (*Lease, error) {
+	namespace := namespaceFromContext(ctx)
+	if ttl <= 0 {
+		return nil, fmt.Errorf("ttl:%s must be greater than 0", ttl)
+	}
+	var lease *Lease
+	return lease, retryOnOptimisticLock(func() error {
+		var err error
+		lease, err = i.renewLeaseInternal(ctx, namespace, id, ttl)
+		return err
+	})
+}
+
+// renewLeaseInternal extends the lease with the given id to expire after ttl.
+// Expired leases can not be renewed because they might be released already.
+func (i *ipamer) renewLeaseInternal(ctx context.Context, namespace, id string, ttl time.Duration) (*Lease, error) {
+	prefix, lease, err := i.prefixOfLease(ctx, namespace, id)
+	if err != nil {
+		return nil, err
+	}
+	if lease.expired(time.Now()) {
+		return nil, fmt.Errorf("%w: lease:%s is expired", ErrNotFound, id)
+	}
+	lease.Expires = expiresAfter(ttl)
+	if lease.IP != "" {
+		prefix.leases[lease.IP] = lease
+	} else {
+		prefix.lease = &lease
+	}
+	_, err = i.storage.UpdatePrefix(ctx, *prefix, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to renew lease:%s error:%w", id, err)
+	}
+	return &lease, nil
+}
+
+// prefixOfLease returns the lease with the given id together with the prefix it is stored in.
+func (i *ipamer) prefixOfLease(ctx context.Context, namespace, id string) (*Prefix, Lease, error) {
+	prefixes, err := i.storage.ReadAllPrefixes(ctx, namespace)
+	if err != nil {
+		return nil, Lease{}, fmt.Errorf("unable to read prefixes:%w", err)
+	}
+	for _, p := range prefixes {
+		for _, lease := range p.allLeases() {
+			if lease.ID == id {
+				return &p, lease, nil
+			}
+		}
+	}
+	return nil, Lease{}, fmt.Errorf("%w: lease:%s not found", ErrNotFound, id)
+}
+
+func (i *ipamer) ReleaseExpiredLeases(ctx context.Context) ([]Lease, error) {
+	namespace := namespaceFromContext(ctx)
+	leases, err := i.ListLeases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var (
+		released []Lease
+		errs     []error
+	)
+	for _, lease := range leases {
+		if !lease.expired(now) {
+			// leases are sorted by expiry
+			break
+		}
+		var ok bool
+		err := retryOnOptimisticLock(func() error {
+			var err error
+			ok, err = i.releaseExpiredLeaseInternal(ctx, namespace, lease, now)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to release expired lease:%s error:%w", lease.ID, err))
+			continue
+		}
+		if ok {
+			released = append(released, lease)
+		}
+	}
+	return released, errors.Join(errs...)
+}
+
+// releaseExpiredLeaseInternal releases the ip or child prefix of the given lease if the lease is still expired.
+// It returns false if the lease was renewed or released in the meantime or if the child prefix still has ips.
+func (i *ipamer) releaseExpiredLeaseInternal(ctx context.Context, namespace string, lease Lease, now time.Time) (bool, error) {
+	if lease.Cidr != "" {
+		child, err := i.PrefixFrom(ctx, lease.Cidr)
+		if err != nil {
+			return false, err
+		}
+		if child.lease == nil || child.lease.ID != lease.ID || !child.lease.expired(now) {
+			return false, nil
+		}
+		if child.hasIPs() {
+			// skipped until its ips are released
+			return false, nil
+		}
+		return true, i.releaseChildPrefixInternal(ctx, namespace, child)
+	}
+
+	prefix, err := i.PrefixFrom(ctx, lease.ParentPrefix)
+	if err != nil {
+		return false, err
+	}
+	current, ok := prefix.leases[lease.IP]
+	if !ok || current.ID != lease.ID || !current.expired(now) {
+		return false, nil
+	}
+	prefix.release(lease.IP)
+	_, err = i.storage.UpdatePrefix(ctx, *prefix, namespace)
+	if err != nil {
+		return false, fmt.Errorf("unable to release ip %v:%w", lease.IP, err)
+	}
+	return true, nil
+}
+
+// copyLease returns a copy of the given lease, nil stays nil.
+func copyLease(l *Lease) *Lease {
+	if l == nil {
+		return nil
+	}
+	c := *l
+	return &c
+}
@@ -0,0 +1,196 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// IPState is the allocation state of an ip in the deepest Prefix containing it.
+type IPState string
+
+const (
+	// IPFree is neither acquired nor reserved, excluded or quarantined.
+	// In a parent Prefix the ip is not part of an acquired child Prefix.
+	IPFree IPState = "free"
+	// IPAcquired is acquired.
+	IPAcquired IPState = "acquired"
+	// IPReserved is reserved and never acquired.
+	IPReserved IPState = "reserved"
+	// IPExcluded is excluded and only acquired if forced.
+	IPExcluded IPState = "excluded"
+	// IPQuarantined was released and is not acquired again until its quarantine is over.
+	IPQuarantined IPState = "quarantined"
+)
+
+// IPLookup is the result of LookupIP.
+type IPLookup struct {
+	// IP is the looked up ip in the deepest Prefix, annotations and lease are set if it is acquired
+	IP IP
+	// Prefixes are all Prefixes containing the ip, from the top level Prefix down to the deepest one
+	Prefixes Prefixes
+	// State is the allocation state of the ip in the deepest Prefix
+	State IPState
+}
+
+func (i *ipamer) LookupIP(ctx context.Context, ip string) (*IPLookup, error) {
+	namespace := namespaceFromContext(ctx)
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, fmt.Errorf("given ip:%s in not valid", ip)
+	}
+	prefixes, err := i.enclosingPrefixes(ctx, namespace, addr)
+	if errors.Is(err, ErrNotFound) || err == nil && len(prefixes) == 0 {
+		// the index is outdated if the prefix was deleted or created by another ipamer sharing the storage
+		i.dropIndex(namespace)
+		prefixes, err = i.enclosingPrefixes(ctx, namespace, addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(prefixes) == 0 {
+		return nil, fmt.Errorf("%w: no prefix found for ip:%s", ErrNotFound, ip)
+	}
+
+	deepest := prefixes[len(prefixes)-1]
+	lookup := &IPLookup{
+		IP: IP{
+			IP:           addr,
+			ParentPrefix: deepest.Cidr,
+		},
+		Prefixes: prefixes,
+		State:    deepest.ipState(addr),
+	}
+	if lookup.State == IPAcquired {
+		acquired, err := deepest.acquiredIP(addr.String())
+		if err != nil {
+			return nil, err
+		}
+		lookup.IP = *acquired
+	}
+	return lookup, nil
+}
+
+// enclosingPrefixes returns all prefixes containing the given ip, the top level prefix first.
+func (i *ipamer) enclosingPrefixes(ctx context.Context, namespace string, addr netip.Addr) (Prefixes, error) {
+	cidrs, err := i.indexLookup(ctx, namespace, addr)
+	if err != nil {
+		return nil, err
+	}
+	var prefixes Prefixes
+	for _, cidr := range cidrs {
+		p, err := i.storage.ReadPrefix(ctx, cidr.String(), namespace)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, p)
+	}
+	// child prefixes acquired by another ipamer sharing the storage are not indexed, they are found through their parent
+	for len(prefixes) > 0 {
+		deepest := prefixes[len(prefixes)-1]
+		child, ok := deepest.acquiredChildPrefixOf(addr)
+		if !ok {
+			break
+		}
+		p, err := i.storage.ReadPrefix(ctx, child, namespace)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, p)
+	}
+	return prefixes, nil
+}
+
+// acquiredChildPrefixOf returns the acquired child prefix which contains the given ip.
+func (p *Prefix) acquiredChildPrefixOf(addr netip.Addr) (string, bool) {
+	for cidr, available := range p.availableChildPrefixes {
+		if available {
+			continue
+		}
+		child, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		if child.Contains(addr) {
+			return cidr, true
+		}
+	}
+	return "", false
+}
+
+// ipState returns the allocation state of the given ip in this Prefix.
+func (p *Prefix) ipState(addr netip.Addr) IPState {
+	switch {
+	case p.ips.contains(addr):
+		return IPAcquired
+	case p.isReserved(addr):
+		return IPReserved
+	case p.isExcluded(addr):
+		return IPExcluded
+	case p.isQuarantined(addr.String()):
+		return IPQuarantined
+	default:
+		return IPFree
+	}
+}
+
+// indexLookup returns the cidrs of all prefixes of the namespace which contain the given ip, the shortest first.
+// The index of the namespace is built from the storage on first use.
+func (i *ipamer) indexLookup(ctx context.Context, namespace string, addr netip.Addr) ([]netip.Prefix, error) {
+	i.indexMu.Lock()
+	defer i.indexMu.Unlock()
+	index, ok := i.indexes[namespace]
+	if !ok {
+		cidrs, err := i.storage.ReadAllPrefixCidrs(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read prefixes:%w", err)
+		}
+		index = newPrefixTrie()
+		for _, cidr := range cidrs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return nil, err
+			}
+			index.insert(prefix)
+		}
+		if i.indexes == nil {
+			i.indexes = make(map[string]*prefixTrie)
+		}
+		i.indexes[namespace] = index
+	}
+	return index.lookup(addr), nil
+}
+
+// indexPrefix adds the given cidr to the index of the namespace if it was built already.
+func (i *ipamer) indexPrefix(namespace, cidr string) {
+	i.updateIndex(namespace, cidr, (*prefixTrie).insert)
+}
+
+// unindexPrefix removes the given cidr from the index of the namespace if it was built already.
+func (i *ipamer) unindexPrefix(namespace, cidr string) {
+	i.updateIndex(namespace, cidr, (*prefixTrie).remove)
+}
+
+func (i *ipamer) updateIndex(namespace, cidr string, update func(*prefixTrie, netip.Prefix)) {
+	i.indexMu.Lock()
+	defer i.indexMu.Unlock()
+	index, ok := i.indexes[namespace]
+	if !ok {
+		return
+	}
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		// rebuild on next use
+		delete(i.indexes, namespace)
+		return
+	}
+	update(index, prefix)
+}
+
+// dropIndex removes the index of the namespace, it is rebuilt on next use.
+func (i *ipamer) dropIndex(namespace string) {
+	i.indexMu.Lock()
+	defer i.indexMu.Unlock()
+	delete(i.indexes, namespace)
+}
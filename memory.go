@@ -8,6 +8,8 @@ import (
 
 type memory struct {
 	prefixes map[string]map[string]Prefix
+	quotas   map[string]Quota
+	pools    map[string]map[string]Pool
 	lock     sync.RWMutex
 }
 
@@ -15,6 +17,8 @@ type memory struct {
 func NewMemory(ctx context.Context) Storage {
 	m := &memory{
 		prefixes: make(map[string]map[string]Prefix),
+		quotas:   make(map[string]Quota),
+		pools:    make(map[string]map[string]Pool),
 		lock:     sync.RWMutex{},
 	}
 	_ = m.CreateNamespace(ctx, defaultNamespace)
@@ -93,7 +97,7 @@ func (m *memory) UpdatePrefix(_ context.Context, prefix Prefix, namespace string
 	prefix.version = oldVersion + 1
 
 	if prefix.Cidr == "" {
-		return Prefix{}, fmt.Errorf("prefix not present:%v", prefix)
+		return Prefix{}, fmt.Errorf("prefix not present:%s", prefix.Cidr)
 	}
 
 	if _, ok := m.prefixes[namespace]; !ok {
@@ -144,5 +148,82 @@ func (m *memory) DeleteNamespace(_ context.Context, namespace string) error {
 		return ErrNamespaceDoesNotExist
 	}
 	delete(m.prefixes, namespace)
+	delete(m.quotas, namespace)
+	delete(m.pools, namespace)
+	return nil
+}
+
+func (m *memory) ReadNamespaceQuota(_ context.Context, namespace string) (Quota, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if _, ok := m.prefixes[namespace]; !ok {
+		return Quota{}, ErrNamespaceDoesNotExist
+	}
+	return m.quotas[namespace].deepCopy(), nil
+}
+
+func (m *memory) UpdateNamespaceQuota(_ context.Context, namespace string, quota Quota) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.prefixes[namespace]; !ok {
+		return ErrNamespaceDoesNotExist
+	}
+	if quota.isZero() {
+		delete(m.quotas, namespace)
+		return nil
+	}
+	m.quotas[namespace] = quota.deepCopy()
+	return nil
+}
+
+func (m *memory) ReadPool(_ context.Context, name, namespace string) (Pool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if _, ok := m.prefixes[namespace]; !ok {
+		return Pool{}, ErrNamespaceDoesNotExist
+	}
+	pool, ok := m.pools[namespace][name]
+	if !ok {
+		return Pool{}, fmt.Errorf("%w pool %s not found", ErrNotFound, name)
+	}
+	return pool.deepCopy(), nil
+}
+
+func (m *memory) ReadAllPools(_ context.Context, namespace string) ([]Pool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if _, ok := m.prefixes[namespace]; !ok {
+		return nil, ErrNamespaceDoesNotExist
+	}
+	pools := make([]Pool, 0, len(m.pools[namespace]))
+	for _, pool := range m.pools[namespace] {
+		pools = append(pools, pool.deepCopy())
+	}
+	return pools, nil
+}
+
+func (m *memory) UpdatePool(_ context.Context, pool Pool, namespace string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.prefixes[namespace]; !ok {
+		return ErrNamespaceDoesNotExist
+	}
+	if _, ok := m.pools[namespace]; !ok {
+		m.pools[namespace] = make(map[string]Pool)
+	}
+	m.pools[namespace][pool.Name] = pool.deepCopy()
+	return nil
+}
+
+func (m *memory) DeletePool(_ context.Context, name, namespace string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.prefixes[namespace]; !ok {
+		return ErrNamespaceDoesNotExist
+	}
+	if _, ok := m.pools[namespace][name]; !ok {
+		return fmt.Errorf("%w pool %s not found", ErrNotFound, name)
+	}
+	delete(m.pools[namespace], name)
 	return nil
 }
@@ -13,12 +13,30 @@ import (
 
 const dbCidr = `prefix.cidr`
 const versionKey = `version`
+const dbNamespace = `namespace`
+const dbName = `name`
 
 type MongoConfig struct {
 	DatabaseName       string
 	MongoClientOptions *options.ClientOptions
 }
 
+// namespaceCollections matches all collections except the ones which store the quotas and pools
+var namespaceCollections = bson.D{{Key: "name", Value: bson.D{{Key: "$nin", Value: bson.A{quotaKey, poolKey}}}}}
+
+// mongoQuota is a quota document, the quota itself is stored as JSON to keep the big address space intact
+type mongoQuota struct {
+	Namespace string `bson:"namespace"`
+	Quota     string `bson:"quota"`
+}
+
+// mongoPool is a pool document
+type mongoPool struct {
+	Namespace string `bson:"namespace"`
+	Name      string `bson:"name"`
+	Pool      string `bson:"pool"`
+}
+
 type mongodb struct {
 	db         *mongo.Database
 	namespaces map[string]struct{}
@@ -60,7 +78,7 @@ func (m *mongodb) checkNamespaceExists(ctx context.Context, namespace string) er
 		return nil
 	}
 
-	r, err := m.db.ListCollectionNames(ctx, bson.D{})
+	r, err := m.db.ListCollectionNames(ctx, namespaceCollections)
 	if err != nil {
 		return ErrNotFound
 	}
@@ -289,7 +307,7 @@ func (m *mongodb) CreateNamespace(ctx context.Context, namespace string) error {
 func (m *mongodb) ListNamespaces(ctx context.Context) ([]string, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	r, err := m.db.ListCollectionNames(ctx, bson.D{})
+	r, err := m.db.ListCollectionNames(ctx, namespaceCollections)
 	if err != nil {
 		return nil, err
 	}
@@ -306,5 +324,154 @@ func (m *mongodb) DeleteNamespace(ctx context.Context, namespace string) error {
 	if err := m.checkNamespaceExists(ctx, namespace); err != nil {
 		return err
 	}
+	f := bson.D{{Key: dbNamespace, Value: namespace}}
+	if _, err := m.db.Collection(quotaKey).DeleteOne(ctx, f); err != nil {
+		return fmt.Errorf("unable to delete quota of namespace:%s, error:%w", namespace, err)
+	}
+	if _, err := m.db.Collection(poolKey).DeleteMany(ctx, f); err != nil {
+		return fmt.Errorf("unable to delete pools of namespace:%s, error:%w", namespace, err)
+	}
 	return m.db.Collection(namespace).Drop(ctx)
 }
+
+func (m *mongodb) ReadNamespaceQuota(ctx context.Context, namespace string) (Quota, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkNamespaceExists(ctx, namespace); err != nil {
+		return Quota{}, err
+	}
+
+	f := bson.D{{Key: dbNamespace, Value: namespace}}
+	r := m.db.Collection(quotaKey).FindOne(ctx, f)
+	if r.Err() != nil && errors.Is(r.Err(), mongo.ErrNoDocuments) {
+		return Quota{}, nil
+	} else if r.Err() != nil {
+		return Quota{}, fmt.Errorf(`error while trying to find quota of namespace:%s, error:%w`, namespace, r.Err())
+	}
+
+	q := mongoQuota{}
+	if err := r.Decode(&q); err != nil {
+		return Quota{}, fmt.Errorf("unable to read quota:%w", err)
+	}
+	return quotaFromJSON([]byte(q.Quota))
+}
+
+func (m *mongodb) UpdateNamespaceQuota(ctx context.Context, namespace string, quota Quota) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	f := bson.D{{Key: dbNamespace, Value: namespace}}
+	if quota.isZero() {
+		if _, err := m.db.Collection(quotaKey).DeleteOne(ctx, f); err != nil {
+			return fmt.Errorf("unable to delete quota of namespace:%s, error:%w", namespace, err)
+		}
+		return nil
+	}
+	qj, err := quota.toJSON()
+	if err != nil {
+		return err
+	}
+	o := options.Replace().SetUpsert(true)
+	_, err = m.db.Collection(quotaKey).ReplaceOne(ctx, f, mongoQuota{Namespace: namespace, Quota: string(qj)}, o)
+	if err != nil {
+		return fmt.Errorf("unable to update quota of namespace:%s, error:%w", namespace, err)
+	}
+	return nil
+}
+
+func (m *mongodb) ReadPool(ctx context.Context, name, namespace string) (Pool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkNamespaceExists(ctx, namespace); err != nil {
+		return Pool{}, err
+	}
+
+	f := bson.D{{Key: dbNamespace, Value: namespace}, {Key: dbName, Value: name}}
+	r := m.db.Collection(poolKey).FindOne(ctx, f)
+	if r.Err() != nil && errors.Is(r.Err(), mongo.ErrNoDocuments) {
+		return Pool{}, fmt.Errorf(`%w pool not found:%s, error:%w`, ErrNotFound, name, r.Err())
+	} else if r.Err() != nil {
+		return Pool{}, fmt.Errorf(`error while trying to find pool:%s, error:%w`, name, r.Err())
+	}
+
+	p := mongoPool{}
+	if err := r.Decode(&p); err != nil {
+		return Pool{}, fmt.Errorf("unable to read pool:%w", err)
+	}
+	return poolFromJSON([]byte(p.Pool))
+}
+
+func (m *mongodb) ReadAllPools(ctx context.Context, namespace string) ([]Pool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkNamespaceExists(ctx, namespace); err != nil {
+		return nil, err
+	}
+
+	f := bson.D{{Key: dbNamespace, Value: namespace}}
+	c, err := m.db.Collection(poolKey).Find(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf(`error reading all pools: %w`, err)
+	}
+	var r []mongoPool
+	if err := c.All(ctx, &r); err != nil {
+		return nil, fmt.Errorf(`error reading all pools: %w`, err)
+	}
+
+	result := make([]Pool, 0, len(r))
+	for _, p := range r {
+		pool, err := poolFromJSON([]byte(p.Pool))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pool)
+	}
+	return result, nil
+}
+
+func (m *mongodb) UpdatePool(ctx context.Context, pool Pool, namespace string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	pj, err := pool.toJSON()
+	if err != nil {
+		return err
+	}
+	f := bson.D{{Key: dbNamespace, Value: namespace}, {Key: dbName, Value: pool.Name}}
+	o := options.Replace().SetUpsert(true)
+	_, err = m.db.Collection(poolKey).ReplaceOne(ctx, f, mongoPool{Namespace: namespace, Name: pool.Name, Pool: string(pj)}, o)
+	if err != nil {
+		return fmt.Errorf("unable to update pool:%s, error:%w", pool.Name, err)
+	}
+	return nil
+}
+
+func (m *mongodb) DeletePool(ctx context.Context, name, namespace string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	f := bson.D{{Key: dbNamespace, Value: namespace}, {Key: dbName, Value: name}}
+	r, err := m.db.Collection(poolKey).DeleteOne(ctx, f)
+	if err != nil {
+		return fmt.Errorf("unable to delete pool:%s, error:%w", name, err)
+	}
+	if r.DeletedCount == 0 {
+		return fmt.Errorf("%w pool not found:%s", ErrNotFound, name)
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+package ipam
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"net/netip"
+	"time"
+
+	"go4.org/netipx"
+)
+
+// PrefixOption configures a Prefix on creation with NewPrefix and AcquireChildPrefix or later with EditPrefix.
+type PrefixOption func(p *Prefix) error
+
+// WithLabels sets the labels of a Prefix, existing labels are replaced.
+func WithLabels(labels map[string]string) PrefixOption {
+	return func(p *Prefix) error {
+		for k := range labels {
+			if k == "" {
+				return fmt.Errorf("label key must not be empty")
+			}
+		}
+		if len(labels) == 0 {
+			p.Labels = nil
+			return nil
+		}
+		p.Labels = maps.Clone(labels)
+		return nil
+	}
+}
+
+// WithDescription sets the free text description of a Prefix.
+func WithDescription(description string) PrefixOption {
+	return func(p *Prefix) error {
+		p.Description = description
+		return nil
+	}
+}
+
+// WithoutReservedIPs removes all reserved addresses of a Prefix, every address can be acquired.
+// This is useful for /31 and /32 point-to-point links and loopback pools.
+// Without any reservation option the first address and for IPv4 the broadcast address are reserved.
+func WithoutReservedIPs() PrefixOption {
+	return func(p *Prefix) error {
+		p.reserved = []string{}
+		return nil
+	}
+}
+
+// WithReservedFirst reserves the first n addresses of a Prefix.
+func WithReservedFirst(n uint64) PrefixOption {
+	return func(p *Prefix) error {
+		ipprefix, err := netip.ParsePrefix(p.Cidr)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return p.reserve()
+		}
+		from := netipx.RangeOfPrefix(ipprefix).From()
+		to, ok := addrOffset(from, new(big.Int).SetUint64(n-1))
+		if !ok || !ipprefix.Contains(to) {
+			return fmt.Errorf("unable to reserve the first %d addresses of prefix:%s", n, p.Cidr)
+		}
+		return p.reserve(netipx.IPRangeFrom(from, to))
+	}
+}
+
+// WithReservedLast reserves the last n addresses of a Prefix.
+func WithReservedLast(n uint64) PrefixOption {
+	return func(p *Prefix) error {
+		ipprefix, err := netip.ParsePrefix(p.Cidr)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return p.reserve()
+		}
+		to := netipx.RangeOfPrefix(ipprefix).To()
+		from, ok := addrOffset(to, new(big.Int).Neg(new(big.Int).SetUint64(n-1)))
+		if !ok || !ipprefix.Contains(from) {
+			return fmt.Errorf("unable to reserve the last %d addresses of prefix:%s", n, p.Cidr)
+		}
+		return p.reserve(netipx.IPRangeFrom(from, to))
+	}
+}
+
+// WithReservedIPs reserves the given addresses of a Prefix.
+// Single addresses, ranges in the form "10.0.0.1-10.0.0.10" and cidrs are accepted.
+// Reservation options add to each other, to replace existing reservations use WithoutReservedIPs first.
+func WithReservedIPs(ranges ...string) PrefixOption {
+	return func(p *Prefix) error {
+		var ipranges []netipx.IPRange
+		for _, r := range ranges {
+			iprange, err := parseIPRange(r)
+			if err != nil {
+				return err
+			}
+			ipranges = append(ipranges, iprange)
+		}
+		return p.reserve(ipranges...)
+	}
+}
+
+// reserve adds the given ranges to the reserved addresses of the Prefix.
+// The ranges must be part of the prefix and must not contain acquired ips.
+func (p *Prefix) reserve(ipranges ...netipx.IPRange) error {
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return err
+	}
+	if p.reserved == nil {
+		p.reserved = []string{}
+	}
+	for _, iprange := range ipranges {
+		if !iprange.IsValid() || !ipprefix.Contains(iprange.From()) || !ipprefix.Contains(iprange.To()) {
+			return fmt.Errorf("reserved range:%s is not in prefix:%s", formatIPRange(iprange), p.Cidr)
+		}
+		if ip, ok := p.ips.firstIn(iprange); ok {
+			return fmt.Errorf("%w: reserved range:%s contains acquired ip:%s", ErrAlreadyAllocated, formatIPRange(iprange), ip)
+		}
+		p.reserved = append(p.reserved, formatIPRange(iprange))
+	}
+	return nil
+}
+
+// WithExclusions sets the exclusions of a Prefix, existing exclusions are replaced.
+// Excluded addresses are skipped by AcquireIP and AcquireChildPrefix and only acquired by AcquireSpecificIP if forced,
+// e.g. for dhcp ranges or statically assigned infrastructure addresses.
+// Single addresses, ranges in the form "10.0.0.1-10.0.0.10" and cidrs are accepted, no ranges removes all exclusions.
+func WithExclusions(ranges ...string) PrefixOption {
+	return func(p *Prefix) error {
+		ipprefix, err := netip.ParsePrefix(p.Cidr)
+		if err != nil {
+			return err
+		}
+		var exclusions []string
+		for _, r := range ranges {
+			iprange, err := parseIPRange(r)
+			if err != nil {
+				return err
+			}
+			if !ipprefix.Contains(iprange.From()) || !ipprefix.Contains(iprange.To()) {
+				return fmt.Errorf("excluded range:%s is not in prefix:%s", formatIPRange(iprange), p.Cidr)
+			}
+			for cp, available := range p.availableChildPrefixes {
+				if available {
+					continue
+				}
+				cpipprefix, err := netip.ParsePrefix(cp)
+				if err != nil {
+					return err
+				}
+				if iprange.Overlaps(netipx.RangeOfPrefix(cpipprefix)) {
+					return fmt.Errorf("%w: excluded range:%s overlaps acquired child prefix:%s", ErrAlreadyAllocated, formatIPRange(iprange), cp)
+				}
+			}
+			exclusions = append(exclusions, formatIPRange(iprange))
+		}
+		p.exclusions = exclusions
+		return nil
+	}
+}
+
+// WithTTL acquires a child Prefix with a lease which expires after ttl, a ttl of 0 removes the lease.
+// Expired child prefixes are released by ReleaseExpiredLeases unless the lease is renewed with RenewLease.
+func WithTTL(ttl time.Duration) PrefixOption {
+	return func(p *Prefix) error {
+		if p.ParentCidr == "" {
+			return fmt.Errorf("ttl is only supported for child prefixes")
+		}
+		if ttl <= 0 {
+			p.lease = nil
+			return nil
+		}
+		lease := newLease(p.ParentCidr, ttl)
+		lease.Cidr = p.Cidr
+		p.lease = &lease
+		return nil
+	}
+}
+
+// WithQuarantine sets the duration released ips of a Prefix are not acquired again by AcquireIP, a duration of 0 disables the quarantine.
+// Quarantined ips can still be acquired with AcquireSpecificIP.
+func WithQuarantine(quarantine time.Duration) PrefixOption {
+	return func(p *Prefix) error {
+		if quarantine < 0 {
+			return fmt.Errorf("quarantine:%s must not be negative", quarantine)
+		}
+		p.Quarantine = quarantine
+		return nil
+	}
+}
+
+// WithPolicy sets the Policy which restricts the child prefixes and ips acquired from a Prefix, the zero Policy removes it.
+func WithPolicy(policy Policy) PrefixOption {
+	return func(p *Prefix) error {
+		if policy == (Policy{}) {
+			p.Policy = nil
+			return nil
+		}
+		if err := policy.validate(p.Cidr); err != nil {
+			return err
+		}
+		p.Policy = &policy
+		return nil
+	}
+}
+
+// WithIdempotencyKey makes NewPrefix, AcquireChildPrefix and AcquireChildPrefixes idempotent, repeating them with the same key
+// within the IdempotencyRetention returns the originally created prefixes instead of creating them again.
+// The key is remembered by the created prefix or the parent of the acquired child prefixes, never by the child prefixes.
+func WithIdempotencyKey(key string) PrefixOption {
+	return func(p *Prefix) error {
+		p.idempotencyKey = key
+		return nil
+	}
+}
+
+// WithChildPrefixStrategy sets the strategy from which free block of a Prefix child prefixes are acquired.
+func WithChildPrefixStrategy(strategy ChildPrefixStrategy) PrefixOption {
+	return func(p *Prefix) error {
+		if err := strategy.validate(); err != nil {
+			return err
+		}
+		p.ChildPrefixStrategy = strategy
+		return nil
+	}
+}
+
+// WithChildPlacement overrides the ChildPrefixStrategy of the parent for a single AcquireChildPrefix or AcquireChildPrefixes,
+// it is not stored with the acquired child prefix.
+func WithChildPlacement(strategy ChildPrefixStrategy) PrefixOption {
+	return func(p *Prefix) error {
+		if err := strategy.validate(); err != nil {
+			return err
+		}
+		p.placement = strategy
+		return nil
+	}
+}
+
+// WithAllocationStrategy sets the strategy in which order ips of a Prefix are acquired.
+func WithAllocationStrategy(strategy AllocationStrategy) PrefixOption {
+	return func(p *Prefix) error {
+		if err := strategy.validate(); err != nil {
+			return err
+		}
+		p.AllocationStrategy = strategy
+		return nil
+	}
+}
+
+func (p *Prefix) apply(opts ...PrefixOption) error {
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteOption configures the deletion of a Prefix with DeletePrefix.
+type DeleteOption func(o *deleteOptions)
+
+type deleteOptions struct {
+	recursive bool
+	dryRun    bool
+	report    *DeleteReport
+}
+
+func newDeleteOptions(opts ...DeleteOption) deleteOptions {
+	var o deleteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// DeleteRecursive deletes a Prefix even if it has ips, together with all its descendants, which are deleted bottom-up.
+// All ips of the deleted prefixes are released and the Prefix is released in its parent.
+func DeleteRecursive() DeleteOption {
+	return func(o *deleteOptions) {
+		o.recursive = true
+	}
+}
+
+// DeleteDryRun only determines what would be deleted, nothing is changed. Use DeleteWithReport to get the result.
+func DeleteDryRun() DeleteOption {
+	return func(o *deleteOptions) {
+		o.dryRun = true
+	}
+}
+
+// DeleteWithReport fills report with all deleted prefixes and released ips.
+func DeleteWithReport(report *DeleteReport) DeleteOption {
+	return func(o *deleteOptions) {
+		o.report = report
+	}
+}
+
+// AcquireOption configures ip acquisitions with AcquireIP, AcquireSpecificIP and AcquireIPs.
+type AcquireOption func(o *acquireOptions)
+
+type acquireOptions struct {
+	annotations    map[string]string
+	strategy       AllocationStrategy
+	force          bool
+	ttl            time.Duration
+	idempotencyKey string
+	embedIPv4      bool
+}
+
+func newAcquireOptions(opts ...AcquireOption) acquireOptions {
+	var o acquireOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// strategyFor returns the strategy of this acquisition, which defaults to the strategy of the prefix.
+func (o acquireOptions) strategyFor(p *Prefix) AllocationStrategy {
+	if o.strategy != "" {
+		return o.strategy
+	}
+	return p.AllocationStrategy
+}
+
+// AcquireWithAnnotations stores the given annotations with the acquired ip.
+func AcquireWithAnnotations(annotations map[string]string) AcquireOption {
+	return func(o *acquireOptions) {
+		o.annotations = annotations
+	}
+}
+
+// AcquireWithStrategy overrides the allocation strategy of the Prefix for this acquisition.
+func AcquireWithStrategy(strategy AllocationStrategy) AcquireOption {
+	return func(o *acquireOptions) {
+		o.strategy = strategy
+	}
+}
+
+// AcquireForced allows AcquireSpecificIP to acquire an ip which is part of the exclusions of the Prefix.
+func AcquireForced() AcquireOption {
+	return func(o *acquireOptions) {
+		o.force = true
+	}
+}
+
+// AcquireWithTTL acquires the ip with a lease which expires after ttl.
+// Expired ips are released by ReleaseExpiredLeases unless the lease is renewed with RenewLease.
+func AcquireWithTTL(ttl time.Duration) AcquireOption {
+	return func(o *acquireOptions) {
+		o.ttl = ttl
+	}
+}
+
+// AcquireWithIdempotencyKey makes the acquisition idempotent, repeating it with the same key within the IdempotencyRetention
+// returns the originally acquired ips instead of acquiring again.
+func AcquireWithIdempotencyKey(key string) AcquireOption {
+	return func(o *acquireOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// AcquireWithEmbeddedIPv4 derives the IPv6 address of AcquireDualStack from the IPv4 address, which is embedded into
+// the last bits of the IPv6 prefix, e.g. 10.0.1.5 and 2001:db8::/64 result in 2001:db8::a00:105.
+func AcquireWithEmbeddedIPv4() AcquireOption {
+	return func(o *acquireOptions) {
+		o.embedIPv4 = true
+	}
+}
@@ -3,14 +3,19 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"math/big"
 
 	"net/netip"
+	"time"
 
 	"connectrpc.com/connect"
 	goipam "github.com/metal-stack/go-ipam"
 	v1 "github.com/metal-stack/go-ipam/api/v1"
 	"github.com/metal-stack/v"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type IPAMService struct {
@@ -38,19 +43,30 @@ func (i *IPAMService) CreatePrefix(ctx context.Context, req *connect.Request[v1.
 	if req.Msg.GetNamespace() != "" {
 		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
 	}
-	resp, err := i.ipamer.NewPrefix(ctx, req.Msg.GetCidr())
+	opts := prefixOptions(req.Msg.GetLabels(), req.Msg.Description, req.Msg.GetReservedIps(), req.Msg.GetAllocationStrategy(), req.Msg.GetExclusions(), req.Msg.GetQuarantine())
+	if key := req.Msg.GetIdempotencyKey(); key != "" {
+		opts = append(opts, goipam.WithIdempotencyKey(key))
+	}
+	if s := fromV1ChildPrefixStrategy(req.Msg.GetChildPrefixStrategy()); s != "" {
+		opts = append(opts, goipam.WithChildPrefixStrategy(s))
+	}
+	if policy := req.Msg.GetPolicy(); policy != nil {
+		opt, err := policyOption(policy)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		opts = append(opts, opt)
+	}
+	resp, err := i.ipamer.NewPrefix(ctx, req.Msg.GetCidr(), opts...)
 	if err != nil {
 		if errors.Is(err, goipam.ErrNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, err)
 		}
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(
 		&v1.CreatePrefixResponse{
-			Prefix: &v1.Prefix{
-				Cidr:       resp.Cidr,
-				ParentCidr: resp.ParentCidr,
-			},
+			Prefix: toV1Prefix(resp),
 		},
 	), nil
 }
@@ -58,7 +74,15 @@ func (i *IPAMService) DeletePrefix(ctx context.Context, req *connect.Request[v1.
 	if req.Msg.GetNamespace() != "" {
 		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
 	}
-	resp, err := i.ipamer.DeletePrefix(ctx, req.Msg.GetCidr())
+	var report goipam.DeleteReport
+	opts := []goipam.DeleteOption{goipam.DeleteWithReport(&report)}
+	if req.Msg.GetRecursive() {
+		opts = append(opts, goipam.DeleteRecursive())
+	}
+	if req.Msg.GetDryRun() {
+		opts = append(opts, goipam.DeleteDryRun())
+	}
+	resp, err := i.ipamer.DeletePrefix(ctx, req.Msg.GetCidr(), opts...)
 	if err != nil {
 		if errors.Is(err, goipam.ErrNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, err)
@@ -66,12 +90,122 @@ func (i *IPAMService) DeletePrefix(ctx context.Context, req *connect.Request[v1.
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
+	deleted := make([]*v1.Prefix, 0, len(report.Prefixes))
+	for _, p := range report.Prefixes {
+		deleted = append(deleted, toV1Prefix(&p))
+	}
+	released := make([]*v1.IP, 0, len(report.IPs))
+	for _, ip := range report.IPs {
+		released = append(released, &v1.IP{
+			Ip:           ip.IP.String(),
+			ParentPrefix: ip.ParentPrefix,
+			Annotations:  ip.Annotations,
+			Lease:        toV1Lease(ip.Lease),
+		})
+	}
+	ranges := make(map[string]*v1.IPRanges, len(report.IPRanges))
+	for cidr, r := range report.IPRanges {
+		ranges[cidr] = &v1.IPRanges{Ranges: r}
+	}
 	return connect.NewResponse(
 		&v1.DeletePrefixResponse{
-			Prefix: &v1.Prefix{
-				Cidr:       resp.Cidr,
-				ParentCidr: resp.ParentCidr,
-			},
+			Prefix:           toV1Prefix(resp),
+			DeletedPrefixes:  deleted,
+			ReleasedIps:      released,
+			ReleasedIpRanges: ranges,
+		},
+	), nil
+}
+func (i *IPAMService) UpdatePrefix(ctx context.Context, req *connect.Request[v1.UpdatePrefixRequest]) (*connect.Response[v1.UpdatePrefixResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	opts := prefixOptions(req.Msg.GetLabels(), req.Msg.Description, req.Msg.GetReservedIps(), req.Msg.GetAllocationStrategy(), req.Msg.GetExclusions(), req.Msg.GetQuarantine())
+	if s := fromV1ChildPrefixStrategy(req.Msg.GetChildPrefixStrategy()); s != "" {
+		opts = append(opts, goipam.WithChildPrefixStrategy(s))
+	}
+	if policy := req.Msg.GetPolicy(); policy != nil {
+		opt, err := policyOption(policy)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		opts = append(opts, opt)
+	}
+	resp, err := i.ipamer.EditPrefix(ctx, req.Msg.GetCidr(), opts...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return connect.NewResponse(
+		&v1.UpdatePrefixResponse{
+			Prefix: toV1Prefix(resp),
+		},
+	), nil
+}
+func (i *IPAMService) ResizePrefix(ctx context.Context, req *connect.Request[v1.ResizePrefixRequest]) (*connect.Response[v1.ResizePrefixResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.ResizePrefix(ctx, req.Msg.GetCidr(), uint8(req.Msg.GetLength())) // nolint:gosec
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		if errors.Is(err, goipam.ErrAlreadyAllocated) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		return nil, connectError(err)
+	}
+
+	return connect.NewResponse(
+		&v1.ResizePrefixResponse{
+			Prefix: toV1Prefix(resp),
+		},
+	), nil
+}
+func (i *IPAMService) SplitPrefix(ctx context.Context, req *connect.Request[v1.SplitPrefixRequest]) (*connect.Response[v1.SplitPrefixResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.SplitPrefix(ctx, req.Msg.GetCidr(), uint8(req.Msg.GetLength())) // nolint:gosec
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		if errors.Is(err, goipam.ErrAlreadyAllocated) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		return nil, connectError(err)
+	}
+
+	var result []*v1.Prefix
+	for _, p := range resp {
+		result = append(result, toV1Prefix(&p))
+	}
+	return connect.NewResponse(
+		&v1.SplitPrefixResponse{
+			Prefixes: result,
+		},
+	), nil
+}
+func (i *IPAMService) MergePrefixes(ctx context.Context, req *connect.Request[v1.MergePrefixesRequest]) (*connect.Response[v1.MergePrefixesResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.MergePrefixes(ctx, req.Msg.GetCidrs()...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return connect.NewResponse(
+		&v1.MergePrefixesResponse{
+			Prefix: toV1Prefix(resp),
 		},
 	), nil
 }
@@ -89,10 +223,7 @@ func (i *IPAMService) GetPrefix(ctx context.Context, req *connect.Request[v1.Get
 
 	return connect.NewResponse(
 		&v1.GetPrefixResponse{
-			Prefix: &v1.Prefix{
-				Cidr:       resp.Cidr,
-				ParentCidr: resp.ParentCidr,
-			},
+			Prefix: toV1Prefix(resp),
 		},
 	), nil
 }
@@ -100,18 +231,14 @@ func (i *IPAMService) ListPrefixes(ctx context.Context, req *connect.Request[v1.
 	if req.Msg.GetNamespace() != "" {
 		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
 	}
-	resp, err := i.ipamer.ReadAllPrefixCidrs(ctx)
+	resp, err := i.ipamer.ListPrefixes(ctx, req.Msg.GetLabelSelector())
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
 	var result []*v1.Prefix
-	for _, cidr := range resp {
-		p, err := i.ipamer.PrefixFrom(ctx, cidr)
-		if err != nil || p == nil {
-			return nil, connect.NewError(connect.CodeInternal, err)
-		}
-		result = append(result, &v1.Prefix{Cidr: cidr, ParentCidr: p.ParentCidr})
+	for _, p := range resp {
+		result = append(result, toV1Prefix(&p))
 	}
 	return connect.NewResponse(
 		&v1.ListPrefixesResponse{
@@ -120,6 +247,149 @@ func (i *IPAMService) ListPrefixes(ctx context.Context, req *connect.Request[v1.
 	), nil
 }
 
+func (i *IPAMService) CreatePool(ctx context.Context, req *connect.Request[v1.CreatePoolRequest]) (*connect.Response[v1.CreatePoolResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.CreatePool(ctx, req.Msg.GetName(), fromV1PoolStrategy(req.Msg.GetStrategy()), req.Msg.GetPrefixes()...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.CreatePoolResponse{
+			Pool: toV1Pool(resp),
+		},
+	), nil
+}
+
+func (i *IPAMService) UpdatePool(ctx context.Context, req *connect.Request[v1.UpdatePoolRequest]) (*connect.Response[v1.UpdatePoolResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.UpdatePool(ctx, req.Msg.GetName(), fromV1PoolStrategy(req.Msg.GetStrategy()), req.Msg.GetPrefixes()...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.UpdatePoolResponse{
+			Pool: toV1Pool(resp),
+		},
+	), nil
+}
+
+func (i *IPAMService) GetPool(ctx context.Context, req *connect.Request[v1.GetPoolRequest]) (*connect.Response[v1.GetPoolResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.GetPool(ctx, req.Msg.GetName())
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.GetPoolResponse{
+			Pool: toV1Pool(resp),
+		},
+	), nil
+}
+
+func (i *IPAMService) ListPools(ctx context.Context, req *connect.Request[v1.ListPoolsRequest]) (*connect.Response[v1.ListPoolsResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.ListPools(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	pools := make([]*v1.Pool, 0, len(resp))
+	for _, p := range resp {
+		pools = append(pools, toV1Pool(&p))
+	}
+	return connect.NewResponse(
+		&v1.ListPoolsResponse{
+			Pools: pools,
+		},
+	), nil
+}
+
+func (i *IPAMService) DeletePool(ctx context.Context, req *connect.Request[v1.DeletePoolRequest]) (*connect.Response[v1.DeletePoolResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	err := i.ipamer.DeletePool(ctx, req.Msg.GetName())
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&v1.DeletePoolResponse{}), nil
+}
+
+func (i *IPAMService) AcquireIPFromPool(ctx context.Context, req *connect.Request[v1.AcquireIPFromPoolRequest]) (*connect.Response[v1.AcquireIPFromPoolResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	opts := acquireOptions(req.Msg.GetAnnotations(), req.Msg.GetAllocationStrategy(), false, req.Msg.GetTtl(), req.Msg.GetIdempotencyKey())
+	resp, err := i.ipamer.AcquireIPFromPool(ctx, req.Msg.GetPool(), opts...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) || errors.Is(err, goipam.ErrNoIPAvailable) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(
+		&v1.AcquireIPFromPoolResponse{
+			Ip: &v1.IP{
+				Ip:           resp.IP.String(),
+				ParentPrefix: resp.ParentPrefix,
+				Annotations:  resp.Annotations,
+				Lease:        toV1Lease(resp.Lease),
+			},
+		},
+	), nil
+}
+
+func (i *IPAMService) AcquireChildPrefixFromPool(ctx context.Context, req *connect.Request[v1.AcquireChildPrefixFromPoolRequest]) (*connect.Response[v1.AcquireChildPrefixFromPoolResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	length := req.Msg.GetLength()
+	if length > 128 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("length must not be greater than 128"))
+	}
+	opts := prefixOptions(req.Msg.GetLabels(), req.Msg.Description, req.Msg.GetReservedIps(), req.Msg.GetAllocationStrategy(), req.Msg.GetExclusions(), req.Msg.GetQuarantine())
+	if ttl := req.Msg.GetTtl(); ttl != nil {
+		opts = append(opts, goipam.WithTTL(ttl.AsDuration()))
+	}
+	if key := req.Msg.GetIdempotencyKey(); key != "" {
+		opts = append(opts, goipam.WithIdempotencyKey(key))
+	}
+	if s := fromV1ChildPrefixStrategy(req.Msg.GetPlacement()); s != "" {
+		opts = append(opts, goipam.WithChildPlacement(s))
+	}
+	resp, err := i.ipamer.AcquireChildPrefixFromPool(ctx, req.Msg.GetPool(), uint8(length), opts...) // nolint:gosec
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) || errors.Is(err, goipam.ErrNoPrefixAvailable) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(
+		&v1.AcquireChildPrefixFromPoolResponse{
+			Prefix: toV1Prefix(resp),
+		},
+	), nil
+}
+
 func (i *IPAMService) AcquireChildPrefix(ctx context.Context, req *connect.Request[v1.AcquireChildPrefixRequest]) (*connect.Response[v1.AcquireChildPrefixResponse], error) {
 	if req.Msg.GetNamespace() != "" {
 		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
@@ -130,27 +400,64 @@ func (i *IPAMService) AcquireChildPrefix(ctx context.Context, req *connect.Reque
 		parentCidr = req.Msg.GetCidr()
 		childCidr  = req.Msg.GetChildCidr()
 		length     = req.Msg.GetLength()
+		opts       = prefixOptions(req.Msg.GetLabels(), req.Msg.Description, req.Msg.GetReservedIps(), req.Msg.GetAllocationStrategy(), req.Msg.GetExclusions(), req.Msg.GetQuarantine())
 	)
+	if ttl := req.Msg.GetTtl(); ttl != nil {
+		opts = append(opts, goipam.WithTTL(ttl.AsDuration()))
+	}
+	if key := req.Msg.GetIdempotencyKey(); key != "" {
+		opts = append(opts, goipam.WithIdempotencyKey(key))
+	}
+	if s := fromV1ChildPrefixStrategy(req.Msg.GetPlacement()); s != "" {
+		opts = append(opts, goipam.WithChildPlacement(s))
+	}
 	if length > 128 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("length must not be greater than 128"))
 	}
 	if req.Msg.GetChildCidr() != "" {
-		resp, err = i.ipamer.AcquireSpecificChildPrefix(ctx, parentCidr, childCidr)
-		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
-		}
+		resp, err = i.ipamer.AcquireSpecificChildPrefix(ctx, parentCidr, childCidr, opts...)
 	} else {
-		resp, err = i.ipamer.AcquireChildPrefix(ctx, parentCidr, uint8(length)) // nolint:gosec
-		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
-		}
+		resp, err = i.ipamer.AcquireChildPrefix(ctx, parentCidr, uint8(length), opts...) // nolint:gosec
+	}
+	if err != nil {
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(
 		&v1.AcquireChildPrefixResponse{
-			Prefix: &v1.Prefix{
-				Cidr:       resp.Cidr,
-				ParentCidr: resp.ParentCidr,
-			},
+			Prefix: toV1Prefix(resp),
+		},
+	), nil
+}
+
+func (i *IPAMService) AcquireChildPrefixes(ctx context.Context, req *connect.Request[v1.AcquireChildPrefixesRequest]) (*connect.Response[v1.AcquireChildPrefixesResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	length := req.Msg.GetLength()
+	if length > 128 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("length must not be greater than 128"))
+	}
+	opts := prefixOptions(req.Msg.GetLabels(), req.Msg.Description, req.Msg.GetReservedIps(), req.Msg.GetAllocationStrategy(), req.Msg.GetExclusions(), req.Msg.GetQuarantine())
+	if ttl := req.Msg.GetTtl(); ttl != nil {
+		opts = append(opts, goipam.WithTTL(ttl.AsDuration()))
+	}
+	if key := req.Msg.GetIdempotencyKey(); key != "" {
+		opts = append(opts, goipam.WithIdempotencyKey(key))
+	}
+	if s := fromV1ChildPrefixStrategy(req.Msg.GetPlacement()); s != "" {
+		opts = append(opts, goipam.WithChildPlacement(s))
+	}
+	resp, err := i.ipamer.AcquireChildPrefixes(ctx, req.Msg.GetCidr(), uint8(length), int(req.Msg.GetCount()), opts...) // nolint:gosec
+	if err != nil {
+		return nil, connectError(err)
+	}
+	prefixes := make([]*v1.Prefix, 0, len(resp))
+	for _, p := range resp {
+		prefixes = append(prefixes, toV1Prefix(&p))
+	}
+	return connect.NewResponse(
+		&v1.AcquireChildPrefixesResponse{
+			Prefixes: prefixes,
 		},
 	), nil
 }
@@ -173,10 +480,7 @@ func (i *IPAMService) ReleaseChildPrefix(ctx context.Context, req *connect.Reque
 	}
 	return connect.NewResponse(
 		&v1.ReleaseChildPrefixResponse{
-			Prefix: &v1.Prefix{
-				Cidr:       prefix.Cidr,
-				ParentCidr: prefix.ParentCidr,
-			},
+			Prefix: toV1Prefix(prefix),
 		},
 	), nil
 }
@@ -187,21 +491,22 @@ func (i *IPAMService) AcquireIP(ctx context.Context, req *connect.Request[v1.Acq
 	}
 	var resp *goipam.IP
 	var err error
+	opts := acquireOptions(req.Msg.GetAnnotations(), req.Msg.GetAllocationStrategy(), req.Msg.GetForce(), req.Msg.GetTtl(), req.Msg.GetIdempotencyKey())
 	if req.Msg.GetIp() != "" {
-		resp, err = i.ipamer.AcquireSpecificIP(ctx, req.Msg.GetPrefixCidr(), req.Msg.GetIp())
+		resp, err = i.ipamer.AcquireSpecificIP(ctx, req.Msg.GetPrefixCidr(), req.Msg.GetIp(), opts...)
 		if err != nil {
 			if errors.Is(err, goipam.ErrAlreadyAllocated) {
 				return nil, connect.NewError(connect.CodeAlreadyExists, err)
 			}
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			return nil, connectError(err)
 		}
 	} else {
-		resp, err = i.ipamer.AcquireIP(ctx, req.Msg.GetPrefixCidr())
+		resp, err = i.ipamer.AcquireIP(ctx, req.Msg.GetPrefixCidr(), opts...)
 		if err != nil {
 			if errors.Is(err, goipam.ErrNoIPAvailable) {
 				return nil, connect.NewError(connect.CodeNotFound, err)
 			}
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			return nil, connectError(err)
 		}
 	}
 	return connect.NewResponse(
@@ -209,10 +514,102 @@ func (i *IPAMService) AcquireIP(ctx context.Context, req *connect.Request[v1.Acq
 			Ip: &v1.IP{
 				Ip:           resp.IP.String(),
 				ParentPrefix: resp.ParentPrefix,
+				Annotations:  resp.Annotations,
+				Lease:        toV1Lease(resp.Lease),
 			},
 		},
 	), nil
 }
+func (i *IPAMService) AcquireIPs(ctx context.Context, req *connect.Request[v1.AcquireIPsRequest]) (*connect.Response[v1.AcquireIPsResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	opts := acquireOptions(req.Msg.GetAnnotations(), req.Msg.GetAllocationStrategy(), false, req.Msg.GetTtl(), req.Msg.GetIdempotencyKey())
+	resp, err := i.ipamer.AcquireIPs(ctx, req.Msg.GetPrefixCidr(), int(req.Msg.GetCount()), opts...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNoIPAvailable) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connectError(err)
+	}
+	ips := make([]*v1.IP, 0, len(resp))
+	for _, ip := range resp {
+		ips = append(ips, &v1.IP{
+			Ip:           ip.IP.String(),
+			ParentPrefix: ip.ParentPrefix,
+			Annotations:  ip.Annotations,
+			Lease:        toV1Lease(ip.Lease),
+		})
+	}
+	return connect.NewResponse(
+		&v1.AcquireIPsResponse{
+			Ips: ips,
+		},
+	), nil
+}
+func (i *IPAMService) AcquireDualStack(ctx context.Context, req *connect.Request[v1.AcquireDualStackRequest]) (*connect.Response[v1.AcquireDualStackResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	opts := acquireOptions(req.Msg.GetAnnotations(), req.Msg.GetAllocationStrategy(), false, req.Msg.GetTtl(), req.Msg.GetIdempotencyKey())
+	if req.Msg.GetEmbedIpv4() {
+		opts = append(opts, goipam.AcquireWithEmbeddedIPv4())
+	}
+	resp, err := i.ipamer.AcquireDualStack(ctx, req.Msg.GetIpv4PrefixCidr(), req.Msg.GetIpv6PrefixCidr(), opts...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) || errors.Is(err, goipam.ErrNoIPAvailable) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(
+		&v1.AcquireDualStackResponse{
+			Ipv4: toV1IP(resp.IPv4),
+			Ipv6: toV1IP(resp.IPv6),
+		},
+	), nil
+}
+func (i *IPAMService) AcquireIPFromMAC(ctx context.Context, req *connect.Request[v1.AcquireIPFromMACRequest]) (*connect.Response[v1.AcquireIPFromMACResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	opts := acquireOptions(req.Msg.GetAnnotations(), v1.AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED, false, req.Msg.GetTtl(), req.Msg.GetIdempotencyKey())
+	resp, err := i.ipamer.AcquireIPFromMAC(ctx, req.Msg.GetPrefixCidr(), req.Msg.GetMac(), opts...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		if errors.Is(err, goipam.ErrAlreadyAllocated) {
+			return nil, connect.NewError(connect.CodeAlreadyExists, err)
+		}
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(
+		&v1.AcquireIPFromMACResponse{
+			Ip: toV1IP(resp),
+		},
+	), nil
+}
+
+func (i *IPAMService) AcquireIPForKey(ctx context.Context, req *connect.Request[v1.AcquireIPForKeyRequest]) (*connect.Response[v1.AcquireIPForKeyResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	opts := acquireOptions(req.Msg.GetAnnotations(), v1.AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED, false, req.Msg.GetTtl(), req.Msg.GetIdempotencyKey())
+	resp, err := i.ipamer.AcquireIPForKey(ctx, req.Msg.GetPrefixCidr(), req.Msg.GetKey(), opts...)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) || errors.Is(err, goipam.ErrNoIPAvailable) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(
+		&v1.AcquireIPForKeyResponse{
+			Ip: toV1IP(resp),
+		},
+	), nil
+}
+
 func (i *IPAMService) ReleaseIP(ctx context.Context, req *connect.Request[v1.ReleaseIPRequest]) (*connect.Response[v1.ReleaseIPResponse], error) {
 	if req.Msg.GetNamespace() != "" {
 		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
@@ -241,6 +638,147 @@ func (i *IPAMService) ReleaseIP(ctx context.Context, req *connect.Request[v1.Rel
 		},
 	), nil
 }
+func (i *IPAMService) AcquireIPRange(ctx context.Context, req *connect.Request[v1.AcquireIPRangeRequest]) (*connect.Response[v1.AcquireIPRangeResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.AcquireIPRange(ctx, req.Msg.GetPrefixCidr(), int(req.Msg.GetCount()))
+	if err != nil {
+		if errors.Is(err, goipam.ErrNoIPAvailable) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(
+		&v1.AcquireIPRangeResponse{
+			IpRange: &v1.IPRange{
+				From:         resp.From.String(),
+				To:           resp.To.String(),
+				ParentPrefix: resp.ParentPrefix,
+			},
+		},
+	), nil
+}
+func (i *IPAMService) ReleaseIPRange(ctx context.Context, req *connect.Request[v1.ReleaseIPRangeRequest]) (*connect.Response[v1.ReleaseIPRangeResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	from, err := netip.ParseAddr(req.Msg.GetFrom())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	to, err := netip.ParseAddr(req.Msg.GetTo())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	iprange := &goipam.IPRange{
+		From:         from,
+		To:           to,
+		ParentPrefix: req.Msg.GetPrefixCidr(),
+	}
+	resp, err := i.ipamer.ReleaseIPRange(ctx, iprange)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.ReleaseIPRangeResponse{
+			IpRange: &v1.IPRange{
+				From:         req.Msg.GetFrom(),
+				To:           req.Msg.GetTo(),
+				ParentPrefix: resp.Cidr,
+			},
+		},
+	), nil
+}
+func (i *IPAMService) GetIP(ctx context.Context, req *connect.Request[v1.GetIPRequest]) (*connect.Response[v1.GetIPResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.GetIP(ctx, req.Msg.GetPrefixCidr(), req.Msg.GetIp())
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.GetIPResponse{
+			Ip: &v1.IP{
+				Ip:           resp.IP.String(),
+				ParentPrefix: resp.ParentPrefix,
+				Annotations:  resp.Annotations,
+				Lease:        toV1Lease(resp.Lease),
+			},
+		},
+	), nil
+}
+
+func (i *IPAMService) LookupIP(ctx context.Context, req *connect.Request[v1.LookupIPRequest]) (*connect.Response[v1.LookupIPResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.LookupIP(ctx, req.Msg.GetIp())
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	prefixes := make([]*v1.Prefix, 0, len(resp.Prefixes))
+	for _, p := range resp.Prefixes {
+		prefixes = append(prefixes, toV1Prefix(&p))
+	}
+	return connect.NewResponse(
+		&v1.LookupIPResponse{
+			Ip: &v1.IP{
+				Ip:           resp.IP.IP.String(),
+				ParentPrefix: resp.IP.ParentPrefix,
+				Annotations:  resp.IP.Annotations,
+				Lease:        toV1Lease(resp.IP.Lease),
+			},
+			Prefixes: prefixes,
+			State:    ipStates[resp.State],
+		},
+	), nil
+}
+func (i *IPAMService) RenewLease(ctx context.Context, req *connect.Request[v1.RenewLeaseRequest]) (*connect.Response[v1.RenewLeaseResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.RenewLease(ctx, req.Msg.GetId(), req.Msg.GetTtl().AsDuration())
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.RenewLeaseResponse{
+			Lease: toV1Lease(resp),
+		},
+	), nil
+}
+func (i *IPAMService) ListLeases(ctx context.Context, req *connect.Request[v1.ListLeasesRequest]) (*connect.Response[v1.ListLeasesResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	resp, err := i.ipamer.ListLeases(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	leases := make([]*v1.Lease, 0, len(resp))
+	for _, l := range resp {
+		leases = append(leases, toV1Lease(&l))
+	}
+	return connect.NewResponse(
+		&v1.ListLeasesResponse{
+			Leases: leases,
+		},
+	), nil
+}
 func (i *IPAMService) Dump(ctx context.Context, req *connect.Request[v1.DumpRequest]) (*connect.Response[v1.DumpResponse], error) {
 	if req.Msg.GetNamespace() != "" {
 		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
@@ -277,14 +815,77 @@ func (i *IPAMService) PrefixUsage(ctx context.Context, req *connect.Request[v1.P
 		}
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	u := p.Usage()
+	return connect.NewResponse(toV1PrefixUsage(p.Usage())), nil
+}
+
+func (i *IPAMService) GetPrefixTree(ctx context.Context, req *connect.Request[v1.GetPrefixTreeRequest]) (*connect.Response[v1.GetPrefixTreeResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	nodes, err := i.ipamer.PrefixTree(ctx, req.Msg.GetCidr(), int(req.Msg.GetDepth()))
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.GetPrefixTreeResponse{
+			Nodes: toV1PrefixNodes(nodes),
+		},
+	), nil
+}
+
+func (i *IPAMService) FragmentationReport(ctx context.Context, req *connect.Request[v1.FragmentationReportRequest]) (*connect.Response[v1.FragmentationReportResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	report, err := i.ipamer.FragmentationReport(ctx, req.Msg.GetCidr())
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	freeBlocks := make(map[uint32]uint64, len(report.FreeBlocks))
+	for length, count := range report.FreeBlocks {
+		freeBlocks[uint32(length)] = count
+	}
 	return connect.NewResponse(
-		&v1.PrefixUsageResponse{
-			AvailableIps:              u.AvailableIPs,
-			AcquiredIps:               u.AcquiredIPs,
-			AvailableSmallestPrefixes: u.AvailableSmallestPrefixes,
-			AvailablePrefixes:         u.AvailablePrefixes,
-			AcquiredPrefixes:          u.AcquiredPrefixes,
+		&v1.FragmentationReportResponse{
+			Cidr:                  report.Cidr,
+			LargestFreeLength:     uint32(report.LargestFreeLength),
+			FreeBlocks:            freeBlocks,
+			FreeAddresses:         report.FreeAddresses.String(),
+			FreeAddressesNotation: goipam.PowerOfTwoNotation(report.FreeAddresses),
+			Score:                 report.Score,
+		},
+	), nil
+}
+
+func (i *IPAMService) PlanDefragmentation(ctx context.Context, req *connect.Request[v1.PlanDefragmentationRequest]) (*connect.Response[v1.PlanDefragmentationResponse], error) {
+	if req.Msg.GetNamespace() != "" {
+		ctx = goipam.NewContextWithNamespace(ctx, req.Msg.GetNamespace())
+	}
+	length := req.Msg.GetLength()
+	if length > 128 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("length must not be greater than 128"))
+	}
+	plan, err := i.ipamer.PlanDefragmentation(ctx, req.Msg.GetCidr(), uint8(length)) // nolint:gosec
+	if err != nil {
+		if errors.Is(err, goipam.ErrNotFound) || errors.Is(err, goipam.ErrNoPrefixAvailable) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	moves := make([]*v1.ChildPrefixMove, 0, len(plan.Moves))
+	for _, m := range plan.Moves {
+		moves = append(moves, &v1.ChildPrefixMove{From: m.From, To: m.To})
+	}
+	return connect.NewResponse(
+		&v1.PlanDefragmentationResponse{
+			Block: plan.Block,
+			Moves: moves,
 		},
 	), nil
 }
@@ -316,3 +917,299 @@ func (i *IPAMService) ListNamespaces(ctx context.Context, req *connect.Request[v
 		},
 	), nil
 }
+
+func (i *IPAMService) SetNamespaceQuota(ctx context.Context, req *connect.Request[v1.SetNamespaceQuotaRequest]) (*connect.Response[v1.SetNamespaceQuotaResponse], error) {
+	quota, err := fromV1Quota(req.Msg.GetQuota())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	err = i.ipamer.SetNamespaceQuota(ctx, req.Msg.GetNamespace(), quota)
+	if err != nil {
+		if errors.Is(err, goipam.ErrNamespaceDoesNotExist) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&v1.SetNamespaceQuotaResponse{}), nil
+}
+
+func (i *IPAMService) GetNamespaceQuota(ctx context.Context, req *connect.Request[v1.GetNamespaceQuotaRequest]) (*connect.Response[v1.GetNamespaceQuotaResponse], error) {
+	usage, err := i.ipamer.GetNamespaceQuota(ctx, req.Msg.GetNamespace())
+	if err != nil {
+		if errors.Is(err, goipam.ErrNamespaceDoesNotExist) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(
+		&v1.GetNamespaceQuotaResponse{
+			Quota:        toV1Quota(usage.Quota),
+			Prefixes:     usage.Prefixes,
+			Ips:          usage.IPs,
+			AddressSpace: usage.AddressSpace.String(),
+		},
+	), nil
+}
+
+// connectError returns the error of the ipamer as connect error, a violated Policy is a failed precondition,
+// an exceeded Quota exhausts a resource and any other error is an invalid argument.
+func connectError(err error) error {
+	switch {
+	case errors.Is(err, goipam.ErrPolicyViolation):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, goipam.ErrQuotaExceeded):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	default:
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+}
+
+// prefixOptions converts the optional labels, description, reserved ips, allocation strategy, exclusions and quarantine of a request to PrefixOptions.
+func prefixOptions(labels map[string]string, description *string, reserved *v1.ReservedIPs, strategy v1.AllocationStrategy, exclusions *v1.Exclusions, quarantine *durationpb.Duration) []goipam.PrefixOption {
+	var opts []goipam.PrefixOption
+	if len(labels) > 0 {
+		opts = append(opts, goipam.WithLabels(labels))
+	}
+	if description != nil {
+		opts = append(opts, goipam.WithDescription(*description))
+	}
+	if reserved != nil {
+		opts = append(opts,
+			goipam.WithoutReservedIPs(),
+			goipam.WithReservedFirst(reserved.GetFirst()),
+			goipam.WithReservedLast(reserved.GetLast()),
+			goipam.WithReservedIPs(reserved.GetRanges()...),
+		)
+	}
+	if s := fromV1AllocationStrategy(strategy); s != "" {
+		opts = append(opts, goipam.WithAllocationStrategy(s))
+	}
+	if exclusions != nil {
+		opts = append(opts, goipam.WithExclusions(exclusions.GetRanges()...))
+	}
+	if quarantine != nil {
+		opts = append(opts, goipam.WithQuarantine(quarantine.AsDuration()))
+	}
+	return opts
+}
+
+func toV1PrefixUsage(u goipam.Usage) *v1.PrefixUsageResponse {
+	return &v1.PrefixUsageResponse{
+		AvailableIps:                   u.AvailableIPs,
+		AcquiredIps:                    u.AcquiredIPs,
+		AvailableSmallestPrefixes:      u.AvailableSmallestPrefixes,
+		AvailablePrefixes:              u.AvailablePrefixes,
+		AcquiredPrefixes:               u.AcquiredPrefixes,
+		ReservedIps:                    u.ReservedIPs,
+		ExcludedIps:                    u.ExcludedIPs,
+		QuarantinedIps:                 u.QuarantinedIPs,
+		AvailableIpsExact:              u.AvailableIPsExact.String(),
+		ReservedIpsExact:               u.ReservedIPsExact.String(),
+		ExcludedIpsExact:               u.ExcludedIPsExact.String(),
+		AvailableSmallestPrefixesExact: u.AvailableSmallestPrefixesExact.String(),
+		FreeIps:                        u.FreeIPs.String(),
+		FreeIpsNotation:                goipam.PowerOfTwoNotation(u.FreeIPs),
+		AvailableIpsNotation:           goipam.PowerOfTwoNotation(u.AvailableIPsExact),
+		Utilization:                    u.Utilization,
+	}
+}
+
+func toV1PrefixNodes(nodes []goipam.PrefixNode) []*v1.PrefixNode {
+	result := make([]*v1.PrefixNode, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, &v1.PrefixNode{
+			Prefix:   toV1Prefix(&n.Prefix),
+			Usage:    toV1PrefixUsage(n.Usage),
+			Children: toV1PrefixNodes(n.Children),
+		})
+	}
+	return result
+}
+
+// acquireOptions converts the annotations, allocation strategy, force flag and ttl of a request to AcquireOptions.
+func acquireOptions(annotations map[string]string, strategy v1.AllocationStrategy, force bool, ttl *durationpb.Duration, idempotencyKey string) []goipam.AcquireOption {
+	opts := []goipam.AcquireOption{goipam.AcquireWithAnnotations(annotations)}
+	if s := fromV1AllocationStrategy(strategy); s != "" {
+		opts = append(opts, goipam.AcquireWithStrategy(s))
+	}
+	if force {
+		opts = append(opts, goipam.AcquireForced())
+	}
+	if ttl != nil {
+		opts = append(opts, goipam.AcquireWithTTL(ttl.AsDuration()))
+	}
+	if idempotencyKey != "" {
+		opts = append(opts, goipam.AcquireWithIdempotencyKey(idempotencyKey))
+	}
+	return opts
+}
+
+var ipStates = map[goipam.IPState]v1.IPState{
+	goipam.IPFree:        v1.IPState_IP_STATE_FREE,
+	goipam.IPAcquired:    v1.IPState_IP_STATE_ACQUIRED,
+	goipam.IPReserved:    v1.IPState_IP_STATE_RESERVED,
+	goipam.IPExcluded:    v1.IPState_IP_STATE_EXCLUDED,
+	goipam.IPQuarantined: v1.IPState_IP_STATE_QUARANTINED,
+}
+
+var allocationStrategies = map[v1.AllocationStrategy]goipam.AllocationStrategy{
+	v1.AllocationStrategy_ALLOCATION_STRATEGY_FIRST_FREE:      goipam.FirstFree,
+	v1.AllocationStrategy_ALLOCATION_STRATEGY_LAST_FREE:       goipam.LastFree,
+	v1.AllocationStrategy_ALLOCATION_STRATEGY_NEXT_AFTER_LAST: goipam.NextAfterLast,
+	v1.AllocationStrategy_ALLOCATION_STRATEGY_RANDOM:          goipam.Random,
+}
+
+// fromV1AllocationStrategy returns an empty strategy for ALLOCATION_STRATEGY_UNSPECIFIED.
+func fromV1AllocationStrategy(strategy v1.AllocationStrategy) goipam.AllocationStrategy {
+	return allocationStrategies[strategy]
+}
+
+func toV1AllocationStrategy(strategy goipam.AllocationStrategy) v1.AllocationStrategy {
+	for v1strategy, s := range allocationStrategies {
+		if s == strategy {
+			return v1strategy
+		}
+	}
+	return v1.AllocationStrategy_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+var poolStrategies = map[v1.PoolStrategy]goipam.PoolStrategy{
+	v1.PoolStrategy_POOL_STRATEGY_FILL_FIRST: goipam.FillFirst,
+	v1.PoolStrategy_POOL_STRATEGY_SPREAD:     goipam.Spread,
+}
+
+// fromV1PoolStrategy returns an empty strategy for POOL_STRATEGY_UNSPECIFIED.
+func fromV1PoolStrategy(strategy v1.PoolStrategy) goipam.PoolStrategy {
+	return poolStrategies[strategy]
+}
+
+var childPrefixStrategies = map[v1.ChildPrefixStrategy]goipam.ChildPrefixStrategy{
+	v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_BEST_FIT:  goipam.BestFit,
+	v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_FIRST_FIT: goipam.FirstFit,
+	v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_SPARSE:    goipam.Sparse,
+}
+
+// fromV1ChildPrefixStrategy returns an empty strategy for CHILD_PREFIX_STRATEGY_UNSPECIFIED.
+func fromV1ChildPrefixStrategy(strategy v1.ChildPrefixStrategy) goipam.ChildPrefixStrategy {
+	return childPrefixStrategies[strategy]
+}
+
+func toV1ChildPrefixStrategy(strategy goipam.ChildPrefixStrategy) v1.ChildPrefixStrategy {
+	for v1strategy, s := range childPrefixStrategies {
+		if s == strategy {
+			return v1strategy
+		}
+	}
+	return v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_UNSPECIFIED
+}
+
+func toV1IP(ip *goipam.IP) *v1.IP {
+	return &v1.IP{
+		Ip:           ip.IP.String(),
+		ParentPrefix: ip.ParentPrefix,
+		Annotations:  ip.Annotations,
+		Lease:        toV1Lease(ip.Lease),
+	}
+}
+
+func toV1Pool(p *goipam.Pool) *v1.Pool {
+	pool := &v1.Pool{
+		Name:     p.Name,
+		Prefixes: p.Prefixes,
+	}
+	for v1strategy, s := range poolStrategies {
+		if s == p.Strategy {
+			pool.Strategy = v1strategy
+		}
+	}
+	return pool
+}
+
+func toV1Prefix(p *goipam.Prefix) *v1.Prefix {
+	return &v1.Prefix{
+		Cidr:                p.Cidr,
+		ParentCidr:          p.ParentCidr,
+		Labels:              p.Labels,
+		Description:         p.Description,
+		ReservedIps:         p.ReservedIPs(),
+		AllocationStrategy:  toV1AllocationStrategy(p.AllocationStrategy),
+		Exclusions:          p.Exclusions(),
+		Lease:               toV1Lease(p.Lease()),
+		Quarantine:          toV1Duration(p.Quarantine),
+		Policy:              toV1Policy(p.Policy),
+		ChildPrefixStrategy: toV1ChildPrefixStrategy(p.ChildPrefixStrategy),
+	}
+}
+
+func toV1Policy(p *goipam.Policy) *v1.Policy {
+	if p == nil {
+		return nil
+	}
+	return &v1.Policy{
+		MinChildLength:   uint32(p.MinChildLength),
+		MaxChildLength:   uint32(p.MaxChildLength),
+		MaxChildPrefixes: p.MaxChildPrefixes,
+		DenyIps:          p.DenyIPs,
+	}
+}
+
+// policyOption converts the policy of a request to a PrefixOption.
+func policyOption(p *v1.Policy) (goipam.PrefixOption, error) {
+	if p.GetMinChildLength() > 128 || p.GetMaxChildLength() > 128 {
+		return nil, errors.New("child length must not be greater than 128")
+	}
+	return goipam.WithPolicy(goipam.Policy{
+		MinChildLength:   uint8(p.GetMinChildLength()), // nolint:gosec
+		MaxChildLength:   uint8(p.GetMaxChildLength()), // nolint:gosec
+		MaxChildPrefixes: p.GetMaxChildPrefixes(),
+		DenyIPs:          p.GetDenyIps(),
+	}), nil
+}
+
+// fromV1Quota converts the quota of a request, the address space is given as decimal number.
+func fromV1Quota(q *v1.Quota) (goipam.Quota, error) {
+	quota := goipam.Quota{
+		MaxPrefixes: q.GetMaxPrefixes(),
+		MaxIPs:      q.GetMaxIps(),
+	}
+	if q.GetMaxAddressSpace() != "" {
+		space, ok := new(big.Int).SetString(q.GetMaxAddressSpace(), 10)
+		if !ok {
+			return goipam.Quota{}, fmt.Errorf("max address space:%q is not a decimal number", q.GetMaxAddressSpace())
+		}
+		quota.MaxAddressSpace = space
+	}
+	return quota, nil
+}
+
+func toV1Quota(q goipam.Quota) *v1.Quota {
+	quota := &v1.Quota{
+		MaxPrefixes: q.MaxPrefixes,
+		MaxIps:      q.MaxIPs,
+	}
+	if q.MaxAddressSpace != nil {
+		quota.MaxAddressSpace = q.MaxAddressSpace.String()
+	}
+	return quota
+}
+
+// toV1Duration returns nil for a zero duration.
+func toV1Duration(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
+
+func toV1Lease(l *goipam.Lease) *v1.Lease {
+	if l == nil {
+		return nil
+	}
+	return &v1.Lease{
+		Id:           l.ID,
+		ParentPrefix: l.ParentPrefix,
+		Ip:           l.IP,
+		Cidr:         l.Cidr,
+		Expires:      timestamppb.New(l.Expires),
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"os"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	goipam "github.com/metal-stack/go-ipam"
@@ -15,6 +18,7 @@ import (
 	"github.com/metal-stack/go-ipam/api/v1/apiv1connect"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestIpamService(t *testing.T) {
@@ -72,6 +76,951 @@ func TestIpamService(t *testing.T) {
 		}
 	})
 
+	t.Run("PrefixLabels", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			namespace := fmt.Sprintf("labelns-%d", counter)
+			_, err := client.CreateNamespace(t.Context(), connect.NewRequest(&v1.CreateNamespaceRequest{Namespace: namespace}))
+			require.NoError(t, err)
+
+			description := "fra1 underlay"
+			result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:        "10.0.0.0/16",
+				Namespace:   &namespace,
+				Labels:      map[string]string{"site": "fra1", "purpose": "underlay"},
+				Description: &description,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, description, result.Msg.GetPrefix().GetDescription())
+
+			_, err = client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:      "10.1.0.0/16",
+				Namespace: &namespace,
+				Labels:    map[string]string{"site": "muc1", "purpose": "underlay"},
+			}))
+			require.NoError(t, err)
+
+			selector := "site=fra1,purpose=underlay"
+			listresult, err := client.ListPrefixes(t.Context(), connect.NewRequest(&v1.ListPrefixesRequest{
+				Namespace:     &namespace,
+				LabelSelector: &selector,
+			}))
+			require.NoError(t, err)
+			require.Len(t, listresult.Msg.GetPrefixes(), 1)
+			assert.Equal(t, "10.0.0.0/16", listresult.Msg.GetPrefixes()[0].GetCidr())
+
+			updateresult, err := client.UpdatePrefix(t.Context(), connect.NewRequest(&v1.UpdatePrefixRequest{
+				Cidr:      "10.1.0.0/16",
+				Namespace: &namespace,
+				Labels:    map[string]string{"site": "fra1", "purpose": "underlay"},
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, "fra1", updateresult.Msg.GetPrefix().GetLabels()["site"])
+
+			listresult, err = client.ListPrefixes(t.Context(), connect.NewRequest(&v1.ListPrefixesRequest{
+				Namespace:     &namespace,
+				LabelSelector: &selector,
+			}))
+			require.NoError(t, err)
+			require.Len(t, listresult.Msg.GetPrefixes(), 2)
+
+			counter++
+		}
+	})
+
+	t.Run("ReservedIPs", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.165.%d.0/31", counter)
+			result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:        cidr,
+				ReservedIps: &v1.ReservedIPs{},
+			}))
+			require.NoError(t, err)
+			assert.Empty(t, result.Msg.GetPrefix().GetReservedIps())
+
+			acquireresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.165.%d.0", counter), acquireresult.Msg.GetIp().GetIp())
+
+			updateresult, err := client.UpdatePrefix(t.Context(), connect.NewRequest(&v1.UpdatePrefixRequest{
+				Cidr:        cidr,
+				ReservedIps: &v1.ReservedIPs{Last: 1},
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, []string{fmt.Sprintf("192.165.%d.1", counter)}, updateresult.Msg.GetPrefix().GetReservedIps())
+
+			usageresult, err := client.PrefixUsage(t.Context(), connect.NewRequest(&v1.PrefixUsageRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), usageresult.Msg.GetAcquiredIps())
+			assert.Equal(t, uint64(1), usageresult.Msg.GetReservedIps())
+
+			counter++
+		}
+	})
+
+	t.Run("AllocationStrategy", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.164.%d.0/24", counter)
+			result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:               cidr,
+				AllocationStrategy: v1.AllocationStrategy_ALLOCATION_STRATEGY_LAST_FREE,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, v1.AllocationStrategy_ALLOCATION_STRATEGY_LAST_FREE, result.Msg.GetPrefix().GetAllocationStrategy())
+
+			acquireresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.164.%d.254", counter), acquireresult.Msg.GetIp().GetIp())
+
+			acquireresult, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr:         cidr,
+				AllocationStrategy: v1.AllocationStrategy_ALLOCATION_STRATEGY_FIRST_FREE,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.164.%d.1", counter), acquireresult.Msg.GetIp().GetIp())
+
+			counter++
+		}
+	})
+
+	t.Run("Exclusions", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.160.%d.0/24", counter)
+			result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:       cidr,
+				Exclusions: &v1.Exclusions{Ranges: []string{fmt.Sprintf("192.160.%d.1-192.160.%d.10", counter, counter)}},
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, []string{fmt.Sprintf("192.160.%d.1-192.160.%d.10", counter, counter)}, result.Msg.GetPrefix().GetExclusions())
+
+			acquireresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.160.%d.11", counter), acquireresult.Msg.GetIp().GetIp())
+
+			excluded := fmt.Sprintf("192.160.%d.5", counter)
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+				Ip:         &excluded,
+			}))
+			require.Error(t, err)
+
+			acquireresult, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+				Ip:         &excluded,
+				Force:      true,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, excluded, acquireresult.Msg.GetIp().GetIp())
+
+			usageresult, err := client.PrefixUsage(t.Context(), connect.NewRequest(&v1.PrefixUsageRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(10), usageresult.Msg.GetExcludedIps())
+
+			updateresult, err := client.UpdatePrefix(t.Context(), connect.NewRequest(&v1.UpdatePrefixRequest{
+				Cidr:       cidr,
+				Exclusions: &v1.Exclusions{},
+			}))
+			require.NoError(t, err)
+			assert.Empty(t, updateresult.Msg.GetPrefix().GetExclusions())
+
+			counter++
+		}
+	})
+
+	t.Run("Leases", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.159.%d.0/24", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+
+			acquireresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+				Ttl:        durationpb.New(time.Hour),
+			}))
+			require.NoError(t, err)
+			lease := acquireresult.Msg.GetIp().GetLease()
+			require.NotNil(t, lease)
+			assert.Equal(t, acquireresult.Msg.GetIp().GetIp(), lease.GetIp())
+			assert.Equal(t, cidr, lease.GetParentPrefix())
+
+			listresult, err := client.ListLeases(t.Context(), connect.NewRequest(&v1.ListLeasesRequest{}))
+			require.NoError(t, err)
+			assert.Contains(t, leaseIDs(listresult.Msg.GetLeases()), lease.GetId())
+
+			renewresult, err := client.RenewLease(t.Context(), connect.NewRequest(&v1.RenewLeaseRequest{
+				Id:  lease.GetId(),
+				Ttl: durationpb.New(2 * time.Hour),
+			}))
+			require.NoError(t, err)
+			assert.True(t, renewresult.Msg.GetLease().GetExpires().AsTime().After(lease.GetExpires().AsTime()))
+
+			_, err = client.RenewLease(t.Context(), connect.NewRequest(&v1.RenewLeaseRequest{
+				Id:  "unknown",
+				Ttl: durationpb.New(time.Hour),
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("Quarantine", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.158.%d.0/24", counter)
+			result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:       cidr,
+				Quarantine: durationpb.New(time.Hour),
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, time.Hour, result.Msg.GetPrefix().GetQuarantine().AsDuration())
+
+			acquireresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+			}))
+			require.NoError(t, err)
+			_, err = client.ReleaseIP(t.Context(), connect.NewRequest(&v1.ReleaseIPRequest{
+				PrefixCidr: cidr,
+				Ip:         acquireresult.Msg.GetIp().GetIp(),
+			}))
+			require.NoError(t, err)
+
+			usageresult, err := client.PrefixUsage(t.Context(), connect.NewRequest(&v1.PrefixUsageRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), usageresult.Msg.GetQuarantinedIps())
+
+			nextresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.158.%d.2", counter), nextresult.Msg.GetIp().GetIp())
+
+			counter++
+		}
+	})
+
+	t.Run("IdempotencyKey", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			var (
+				cidr      = fmt.Sprintf("192.157.%d.0/24", counter)
+				createkey = fmt.Sprintf("create-%d", counter)
+				ipkey     = "acquire-ip"
+				childkey  = "acquire-child"
+			)
+			for range 2 {
+				result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+					Cidr:           cidr,
+					IdempotencyKey: &createkey,
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, cidr, result.Msg.GetPrefix().GetCidr())
+			}
+
+			firstchild, err := client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:           cidr,
+				Length:         28,
+				IdempotencyKey: &childkey,
+			}))
+			require.NoError(t, err)
+			secondchild, err := client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:           cidr,
+				Length:         28,
+				IdempotencyKey: &childkey,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, firstchild.Msg.GetPrefix().GetCidr(), secondchild.Msg.GetPrefix().GetCidr())
+
+			first, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr:     firstchild.Msg.GetPrefix().GetCidr(),
+				IdempotencyKey: &ipkey,
+			}))
+			require.NoError(t, err)
+			second, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr:     firstchild.Msg.GetPrefix().GetCidr(),
+				IdempotencyKey: &ipkey,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, first.Msg.GetIp().GetIp(), second.Msg.GetIp().GetIp())
+
+			counter++
+		}
+	})
+
+	t.Run("GetPrefixTree", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.156.%d.0/24", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			acquireresult, err := client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:   cidr,
+				Length: 28,
+			}))
+			require.NoError(t, err)
+
+			treeresult, err := client.GetPrefixTree(t.Context(), connect.NewRequest(&v1.GetPrefixTreeRequest{
+				Cidr: &cidr,
+			}))
+			require.NoError(t, err)
+			require.Len(t, treeresult.Msg.GetNodes(), 1)
+			root := treeresult.Msg.GetNodes()[0]
+			assert.Equal(t, cidr, root.GetPrefix().GetCidr())
+			assert.Equal(t, uint64(1), root.GetUsage().GetAcquiredPrefixes())
+			require.Len(t, root.GetChildren(), 1)
+			assert.Equal(t, acquireresult.Msg.GetPrefix().GetCidr(), root.GetChildren()[0].GetPrefix().GetCidr())
+
+			missing := fmt.Sprintf("192.156.%d.0/25", counter)
+			_, err = client.GetPrefixTree(t.Context(), connect.NewRequest(&v1.GetPrefixTreeRequest{
+				Cidr: &missing,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("LookupIP", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.155.%d.0/24", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			acquireresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr:  cidr,
+				Annotations: map[string]string{"owner": "a"},
+			}))
+			require.NoError(t, err)
+
+			lookupresult, err := client.LookupIP(t.Context(), connect.NewRequest(&v1.LookupIPRequest{
+				Ip: acquireresult.Msg.GetIp().GetIp(),
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, v1.IPState_IP_STATE_ACQUIRED, lookupresult.Msg.GetState())
+			assert.Equal(t, map[string]string{"owner": "a"}, lookupresult.Msg.GetIp().GetAnnotations())
+			require.Len(t, lookupresult.Msg.GetPrefixes(), 1)
+			assert.Equal(t, cidr, lookupresult.Msg.GetPrefixes()[0].GetCidr())
+
+			lookupresult, err = client.LookupIP(t.Context(), connect.NewRequest(&v1.LookupIPRequest{
+				Ip: fmt.Sprintf("192.155.%d.255", counter),
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, v1.IPState_IP_STATE_RESERVED, lookupresult.Msg.GetState())
+
+			_, err = client.LookupIP(t.Context(), connect.NewRequest(&v1.LookupIPRequest{
+				Ip: fmt.Sprintf("192.154.%d.1", counter),
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("ResizePrefix", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.153.%d.0/25", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+			}))
+			require.NoError(t, err)
+
+			resizeresult, err := client.ResizePrefix(t.Context(), connect.NewRequest(&v1.ResizePrefixRequest{
+				Cidr:   cidr,
+				Length: 24,
+			}))
+			require.NoError(t, err)
+			grown := fmt.Sprintf("192.153.%d.0/24", counter)
+			assert.Equal(t, grown, resizeresult.Msg.GetPrefix().GetCidr())
+
+			_, err = client.GetIP(t.Context(), connect.NewRequest(&v1.GetIPRequest{
+				PrefixCidr: grown,
+				Ip:         fmt.Sprintf("192.153.%d.1", counter),
+			}))
+			require.NoError(t, err)
+
+			_, err = client.ResizePrefix(t.Context(), connect.NewRequest(&v1.ResizePrefixRequest{
+				Cidr:   grown,
+				Length: 32,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+			_, err = client.ResizePrefix(t.Context(), connect.NewRequest(&v1.ResizePrefixRequest{
+				Cidr:   cidr,
+				Length: 24,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("SplitAndMergePrefixes", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.152.%d.0/24", counter)
+			ip := fmt.Sprintf("192.152.%d.200", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+				Ip:         &ip,
+			}))
+			require.NoError(t, err)
+
+			splitresult, err := client.SplitPrefix(t.Context(), connect.NewRequest(&v1.SplitPrefixRequest{
+				Cidr:   cidr,
+				Length: 26,
+			}))
+			require.NoError(t, err)
+			require.Len(t, splitresult.Msg.GetPrefixes(), 4)
+			var cidrs []string
+			for _, p := range splitresult.Msg.GetPrefixes() {
+				cidrs = append(cidrs, p.GetCidr())
+			}
+			assert.Equal(t, fmt.Sprintf("192.152.%d.192/26", counter), cidrs[3])
+
+			_, err = client.GetIP(t.Context(), connect.NewRequest(&v1.GetIPRequest{
+				PrefixCidr: cidrs[3],
+				Ip:         ip,
+			}))
+			require.NoError(t, err)
+
+			_, err = client.MergePrefixes(t.Context(), connect.NewRequest(&v1.MergePrefixesRequest{
+				Cidrs: []string{cidrs[0], cidrs[3]},
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			mergeresult, err := client.MergePrefixes(t.Context(), connect.NewRequest(&v1.MergePrefixesRequest{
+				Cidrs: cidrs,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, cidr, mergeresult.Msg.GetPrefix().GetCidr())
+
+			counter++
+		}
+	})
+
+	t.Run("DeletePrefixRecursive", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.151.%d.0/24", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			childresult, err := client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:   cidr,
+				Length: 26,
+			}))
+			require.NoError(t, err)
+			child := childresult.Msg.GetPrefix().GetCidr()
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: child,
+			}))
+			require.NoError(t, err)
+
+			_, err = client.DeletePrefix(t.Context(), connect.NewRequest(&v1.DeletePrefixRequest{
+				Cidr: child,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			deleteresult, err := client.DeletePrefix(t.Context(), connect.NewRequest(&v1.DeletePrefixRequest{
+				Cidr:      cidr,
+				Recursive: true,
+				DryRun:    true,
+			}))
+			require.NoError(t, err)
+			require.Len(t, deleteresult.Msg.GetDeletedPrefixes(), 2)
+			assert.Equal(t, child, deleteresult.Msg.GetDeletedPrefixes()[0].GetCidr())
+			assert.Equal(t, cidr, deleteresult.Msg.GetDeletedPrefixes()[1].GetCidr())
+			require.Empty(t, deleteresult.Msg.GetReleasedIps())
+			require.Len(t, deleteresult.Msg.GetReleasedIpRanges(), 1)
+			assert.Len(t, deleteresult.Msg.GetReleasedIpRanges()[child].GetRanges(), 1)
+
+			_, err = client.GetPrefix(t.Context(), connect.NewRequest(&v1.GetPrefixRequest{
+				Cidr: child,
+			}))
+			require.NoError(t, err)
+
+			deleteresult, err = client.DeletePrefix(t.Context(), connect.NewRequest(&v1.DeletePrefixRequest{
+				Cidr:      cidr,
+				Recursive: true,
+			}))
+			require.NoError(t, err)
+			require.Len(t, deleteresult.Msg.GetDeletedPrefixes(), 2)
+
+			_, err = client.GetPrefix(t.Context(), connect.NewRequest(&v1.GetPrefixRequest{
+				Cidr: child,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.150.%d.0/24", counter)
+			result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:   cidr,
+				Policy: &v1.Policy{MinChildLength: 26, MaxChildLength: 28, MaxChildPrefixes: 1, DenyIps: true},
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, uint32(26), result.Msg.GetPrefix().GetPolicy().GetMinChildLength())
+			assert.True(t, result.Msg.GetPrefix().GetPolicy().GetDenyIps())
+
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+			_, err = client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:   cidr,
+				Length: 25,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+			_, err = client.AcquireChildPrefixes(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixesRequest{
+				Cidr:   cidr,
+				Length: 28,
+				Count:  2,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+			_, err = client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:   cidr,
+				Length: 28,
+			}))
+			require.NoError(t, err)
+
+			_, err = client.UpdatePrefix(t.Context(), connect.NewRequest(&v1.UpdatePrefixRequest{
+				Cidr:   cidr,
+				Policy: &v1.Policy{MinChildLength: 200},
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			updateresult, err := client.UpdatePrefix(t.Context(), connect.NewRequest(&v1.UpdatePrefixRequest{
+				Cidr:   cidr,
+				Policy: &v1.Policy{},
+			}))
+			require.NoError(t, err)
+			assert.Nil(t, updateresult.Msg.GetPrefix().GetPolicy())
+
+			_, err = client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:   cidr,
+				Length: 25,
+			}))
+			require.NoError(t, err)
+
+			counter++
+		}
+	})
+
+	t.Run("NamespaceQuota", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			namespace := fmt.Sprintf("quotans-%d", counter)
+			_, err := client.SetNamespaceQuota(t.Context(), connect.NewRequest(&v1.SetNamespaceQuotaRequest{
+				Namespace: namespace,
+				Quota:     &v1.Quota{MaxPrefixes: 1},
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			_, err = client.CreateNamespace(t.Context(), connect.NewRequest(&v1.CreateNamespaceRequest{Namespace: namespace}))
+			require.NoError(t, err)
+
+			_, err = client.SetNamespaceQuota(t.Context(), connect.NewRequest(&v1.SetNamespaceQuotaRequest{
+				Namespace: namespace,
+				Quota:     &v1.Quota{MaxAddressSpace: "a lot"},
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			_, err = client.SetNamespaceQuota(t.Context(), connect.NewRequest(&v1.SetNamespaceQuotaRequest{
+				Namespace: namespace,
+				Quota:     &v1.Quota{MaxPrefixes: 1, MaxIps: 1, MaxAddressSpace: "256"},
+			}))
+			require.NoError(t, err)
+
+			cidr := fmt.Sprintf("192.149.%d.0/24", counter)
+			_, err = client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:      cidr,
+				Namespace: &namespace,
+			}))
+			require.NoError(t, err)
+			_, err = client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:      fmt.Sprintf("192.149.%d.0/24", counter+10),
+				Namespace: &namespace,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+				Namespace:  &namespace,
+			}))
+			require.NoError(t, err)
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+				Namespace:  &namespace,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+			result, err := client.GetNamespaceQuota(t.Context(), connect.NewRequest(&v1.GetNamespaceQuotaRequest{
+				Namespace: namespace,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), result.Msg.GetQuota().GetMaxPrefixes())
+			assert.Equal(t, "256", result.Msg.GetQuota().GetMaxAddressSpace())
+			assert.Equal(t, uint64(1), result.Msg.GetPrefixes())
+			assert.Equal(t, uint64(1), result.Msg.GetIps())
+			assert.Equal(t, "256", result.Msg.GetAddressSpace())
+
+			_, err = client.SetNamespaceQuota(t.Context(), connect.NewRequest(&v1.SetNamespaceQuotaRequest{
+				Namespace: namespace,
+			}))
+			require.NoError(t, err)
+			_, err = client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
+				PrefixCidr: cidr,
+				Namespace:  &namespace,
+			}))
+			require.NoError(t, err)
+
+			counter++
+		}
+	})
+
+	t.Run("Pools", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			name := fmt.Sprintf("pool-%d", counter)
+			small := fmt.Sprintf("192.148.%d.0/30", counter)
+			big := fmt.Sprintf("192.147.%d.0/24", counter)
+			parent := fmt.Sprintf("192.146.%d.0/24", counter)
+			for _, cidr := range []string{small, big, parent} {
+				_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{Cidr: cidr}))
+				require.NoError(t, err)
+			}
+
+			_, err := client.CreatePool(t.Context(), connect.NewRequest(&v1.CreatePoolRequest{
+				Name:     name,
+				Prefixes: []string{small, "192.154.0.0/24"},
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			created, err := client.CreatePool(t.Context(), connect.NewRequest(&v1.CreatePoolRequest{
+				Name:     name,
+				Prefixes: []string{small, big},
+				Strategy: v1.PoolStrategy_POOL_STRATEGY_FILL_FIRST,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, []string{small, big}, created.Msg.GetPool().GetPrefixes())
+			assert.Equal(t, v1.PoolStrategy_POOL_STRATEGY_FILL_FIRST, created.Msg.GetPool().GetStrategy())
+
+			// the /30 has two usable ips, the third ip is taken from the next member
+			for _, parent := range []string{small, small, big} {
+				ip, err := client.AcquireIPFromPool(t.Context(), connect.NewRequest(&v1.AcquireIPFromPoolRequest{Pool: name}))
+				require.NoError(t, err)
+				assert.Equal(t, parent, ip.Msg.GetIp().GetParentPrefix())
+			}
+
+			// both members have ips now, child prefixes are taken from the new member
+			updated, err := client.UpdatePool(t.Context(), connect.NewRequest(&v1.UpdatePoolRequest{
+				Name:     name,
+				Prefixes: []string{small, big, parent},
+				Strategy: v1.PoolStrategy_POOL_STRATEGY_SPREAD,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, v1.PoolStrategy_POOL_STRATEGY_SPREAD, updated.Msg.GetPool().GetStrategy())
+
+			prefix, err := client.AcquireChildPrefixFromPool(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixFromPoolRequest{
+				Pool:   name,
+				Length: 28,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, parent, prefix.Msg.GetPrefix().GetParentCidr())
+			_, err = client.AcquireChildPrefixFromPool(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixFromPoolRequest{
+				Pool:   name,
+				Length: 23,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			got, err := client.GetPool(t.Context(), connect.NewRequest(&v1.GetPoolRequest{Name: name}))
+			require.NoError(t, err)
+			assert.Equal(t, []string{small, big, parent}, got.Msg.GetPool().GetPrefixes())
+
+			list, err := client.ListPools(t.Context(), connect.NewRequest(&v1.ListPoolsRequest{}))
+			require.NoError(t, err)
+			var names []string
+			for _, p := range list.Msg.GetPools() {
+				names = append(names, p.GetName())
+			}
+			assert.Contains(t, names, name)
+
+			_, err = client.DeletePool(t.Context(), connect.NewRequest(&v1.DeletePoolRequest{Name: name}))
+			require.NoError(t, err)
+			_, err = client.DeletePool(t.Context(), connect.NewRequest(&v1.DeletePoolRequest{Name: name}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			_, err = client.AcquireIPFromPool(t.Context(), connect.NewRequest(&v1.AcquireIPFromPoolRequest{Pool: name}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("AcquireDualStack", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			v4 := fmt.Sprintf("192.145.%d.0/24", counter)
+			v6 := fmt.Sprintf("2001:db8:145%d::/64", counter)
+			for _, cidr := range []string{v4, v6} {
+				_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{Cidr: cidr}))
+				require.NoError(t, err)
+			}
+
+			_, err := client.AcquireDualStack(t.Context(), connect.NewRequest(&v1.AcquireDualStackRequest{
+				Ipv4PrefixCidr: v6,
+				Ipv6PrefixCidr: v4,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			_, err = client.AcquireDualStack(t.Context(), connect.NewRequest(&v1.AcquireDualStackRequest{
+				Ipv4PrefixCidr: "192.154.0.0/24",
+				Ipv6PrefixCidr: v6,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			result, err := client.AcquireDualStack(t.Context(), connect.NewRequest(&v1.AcquireDualStackRequest{
+				Ipv4PrefixCidr: v4,
+				Ipv6PrefixCidr: v6,
+				Annotations:    map[string]string{"machine": "m1"},
+				EmbedIpv4:      true,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.145.%d.1", counter), result.Msg.GetIpv4().GetIp())
+			expected := netip.MustParseAddr(fmt.Sprintf("2001:db8:145%d::c091:%02x01", counter, counter))
+			assert.Equal(t, expected.String(), result.Msg.GetIpv6().GetIp())
+			assert.Equal(t, v6, result.Msg.GetIpv6().GetParentPrefix())
+			assert.Equal(t, map[string]string{"machine": "m1"}, result.Msg.GetIpv6().GetAnnotations())
+
+			counter++
+		}
+	})
+
+	t.Run("AcquireDerivedIPs", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			v4 := fmt.Sprintf("192.144.%d.0/24", counter)
+			v6 := fmt.Sprintf("2001:db8:144%d::/64", counter)
+			for _, cidr := range []string{v4, v6} {
+				_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{Cidr: cidr}))
+				require.NoError(t, err)
+			}
+
+			result, err := client.AcquireIPFromMAC(t.Context(), connect.NewRequest(&v1.AcquireIPFromMACRequest{
+				PrefixCidr: v6,
+				Mac:        "00:00:5e:00:53:01",
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("2001:db8:144%d:0:200:5eff:fe00:5301", counter), result.Msg.GetIp().GetIp())
+			_, err = client.AcquireIPFromMAC(t.Context(), connect.NewRequest(&v1.AcquireIPFromMACRequest{
+				PrefixCidr: v6,
+				Mac:        "00:00:5e:00:53:01",
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+			_, err = client.AcquireIPFromMAC(t.Context(), connect.NewRequest(&v1.AcquireIPFromMACRequest{
+				PrefixCidr: v4,
+				Mac:        "00:00:5e:00:53:01",
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			first, err := client.AcquireIPForKey(t.Context(), connect.NewRequest(&v1.AcquireIPForKeyRequest{
+				PrefixCidr: v4,
+				Key:        "machine-1",
+			}))
+			require.NoError(t, err)
+			_, err = client.ReleaseIP(t.Context(), connect.NewRequest(&v1.ReleaseIPRequest{
+				PrefixCidr: v4,
+				Ip:         first.Msg.GetIp().GetIp(),
+			}))
+			require.NoError(t, err)
+			again, err := client.AcquireIPForKey(t.Context(), connect.NewRequest(&v1.AcquireIPForKeyRequest{
+				PrefixCidr: v4,
+				Key:        "machine-1",
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, first.Msg.GetIp().GetIp(), again.Msg.GetIp().GetIp())
+			_, err = client.AcquireIPForKey(t.Context(), connect.NewRequest(&v1.AcquireIPForKeyRequest{
+				PrefixCidr: "192.154.0.0/24",
+				Key:        "machine-1",
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("ChildPrefixStrategies", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.143.%d.0/24", counter)
+			result, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr:                cidr,
+				ChildPrefixStrategy: v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_SPARSE,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_SPARSE, result.Msg.GetPrefix().GetChildPrefixStrategy())
+
+			children, err := client.AcquireChildPrefixes(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixesRequest{
+				Cidr:   cidr,
+				Length: 26,
+				Count:  2,
+			}))
+			require.NoError(t, err)
+			require.Len(t, children.Msg.GetPrefixes(), 2)
+			assert.Equal(t, fmt.Sprintf("192.143.%d.0/26", counter), children.Msg.GetPrefixes()[0].GetCidr())
+			assert.Equal(t, fmt.Sprintf("192.143.%d.128/26", counter), children.Msg.GetPrefixes()[1].GetCidr())
+
+			child, err := client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+				Cidr:      cidr,
+				Length:    28,
+				Placement: v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_FIRST_FIT,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.143.%d.64/28", counter), child.Msg.GetPrefix().GetCidr())
+
+			updated, err := client.UpdatePrefix(t.Context(), connect.NewRequest(&v1.UpdatePrefixRequest{
+				Cidr:                cidr,
+				ChildPrefixStrategy: v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_BEST_FIT,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, v1.ChildPrefixStrategy_CHILD_PREFIX_STRATEGY_BEST_FIT, updated.Msg.GetPrefix().GetChildPrefixStrategy())
+
+			counter++
+		}
+	})
+
+	t.Run("Fragmentation", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.142.%d.0/24", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{Cidr: cidr}))
+			require.NoError(t, err)
+			for _, child := range []string{"0/26", "96/27", "192/28"} {
+				childCidr := fmt.Sprintf("192.142.%d.%s", counter, child)
+				_, err = client.AcquireChildPrefix(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixRequest{
+					Cidr:      cidr,
+					ChildCidr: &childCidr,
+				}))
+				require.NoError(t, err)
+			}
+
+			report, err := client.FragmentationReport(t.Context(), connect.NewRequest(&v1.FragmentationReportRequest{Cidr: cidr}))
+			require.NoError(t, err)
+			assert.Equal(t, uint32(26), report.Msg.GetLargestFreeLength())
+			assert.Equal(t, map[uint32]uint64{26: 1, 27: 2, 28: 1}, report.Msg.GetFreeBlocks())
+			assert.Equal(t, "144", report.Msg.GetFreeAddresses())
+			assert.InDelta(t, 1-64.0/144.0, report.Msg.GetScore(), 0.001)
+
+			plan, err := client.PlanDefragmentation(t.Context(), connect.NewRequest(&v1.PlanDefragmentationRequest{
+				Cidr:   cidr,
+				Length: 25,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.142.%d.128/25", counter), plan.Msg.GetBlock())
+			require.Len(t, plan.Msg.GetMoves(), 1)
+			assert.Equal(t, fmt.Sprintf("192.142.%d.192/28", counter), plan.Msg.GetMoves()[0].GetFrom())
+			assert.Equal(t, fmt.Sprintf("192.142.%d.64/28", counter), plan.Msg.GetMoves()[0].GetTo())
+
+			_, err = client.FragmentationReport(t.Context(), connect.NewRequest(&v1.FragmentationReportRequest{Cidr: "192.154.0.0/24"}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
+	t.Run("PrefixUsageExact", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("2001:db8:%d::/64", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			_, err = client.AcquireIPs(t.Context(), connect.NewRequest(&v1.AcquireIPsRequest{
+				PrefixCidr: cidr,
+				Count:      2,
+			}))
+			require.NoError(t, err)
+
+			usageresult, err := client.PrefixUsage(t.Context(), connect.NewRequest(&v1.PrefixUsageRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(math.MaxInt32), usageresult.Msg.GetAvailableIps())
+			assert.Equal(t, "18446744073709551616", usageresult.Msg.GetAvailableIpsExact())
+			assert.Equal(t, "2^64", usageresult.Msg.GetAvailableIpsNotation())
+			assert.Equal(t, "18446744073709551613", usageresult.Msg.GetFreeIps())
+			assert.Equal(t, "2^64 - 3", usageresult.Msg.GetFreeIpsNotation())
+			assert.Positive(t, usageresult.Msg.GetUtilization())
+
+			counter++
+		}
+	})
+
 	t.Run("AcquireReleaseChildPrefix", func(t *testing.T) {
 		counter := 0
 		for _, client := range clients {
@@ -98,6 +1047,39 @@ func TestIpamService(t *testing.T) {
 		}
 	})
 
+	t.Run("AcquireChildPrefixes", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.161.%d.0/24", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+
+			acquireresult, err := client.AcquireChildPrefixes(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixesRequest{
+				Cidr:   cidr,
+				Length: 28,
+				Count:  16,
+				Labels: map[string]string{"tenant": "a"},
+			}))
+			require.NoError(t, err)
+			require.Len(t, acquireresult.Msg.GetPrefixes(), 16)
+			for _, p := range acquireresult.Msg.GetPrefixes() {
+				assert.Equal(t, cidr, p.GetParentCidr())
+				assert.Equal(t, "a", p.GetLabels()["tenant"])
+			}
+
+			_, err = client.AcquireChildPrefixes(t.Context(), connect.NewRequest(&v1.AcquireChildPrefixesRequest{
+				Cidr:   cidr,
+				Length: 28,
+				Count:  1,
+			}))
+			require.Error(t, err)
+
+			counter++
+		}
+	})
+
 	t.Run("AcquireReleaseIP", func(t *testing.T) {
 		counter := 0
 		for _, client := range clients {
@@ -108,11 +1090,19 @@ func TestIpamService(t *testing.T) {
 			assert.Equal(t, result.Msg.GetPrefix().GetCidr(), fmt.Sprintf("192.166.%d.0/24", counter))
 
 			acquireresult, err := client.AcquireIP(t.Context(), connect.NewRequest(&v1.AcquireIPRequest{
-				PrefixCidr: fmt.Sprintf("192.166.%d.0/24", counter),
+				PrefixCidr:  fmt.Sprintf("192.166.%d.0/24", counter),
+				Annotations: map[string]string{"owner": "tenant-a"},
 			}))
 			require.NoError(t, err)
 			assert.Equal(t, acquireresult.Msg.GetIp().GetIp(), fmt.Sprintf("192.166.%d.1", counter))
 
+			getresult, err := client.GetIP(t.Context(), connect.NewRequest(&v1.GetIPRequest{
+				PrefixCidr: fmt.Sprintf("192.166.%d.0/24", counter),
+				Ip:         acquireresult.Msg.GetIp().GetIp(),
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"owner": "tenant-a"}, getresult.Msg.GetIp().GetAnnotations())
+
 			releaseresult, err := client.ReleaseIP(t.Context(), connect.NewRequest(&v1.ReleaseIPRequest{
 				PrefixCidr: fmt.Sprintf("192.166.%d.0/24", counter),
 				Ip:         acquireresult.Msg.GetIp().GetIp(),
@@ -124,6 +1114,73 @@ func TestIpamService(t *testing.T) {
 		}
 	})
 
+	t.Run("AcquireIPs", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.163.%d.0/29", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+
+			_, err = client.AcquireIPs(t.Context(), connect.NewRequest(&v1.AcquireIPsRequest{
+				PrefixCidr: cidr,
+				Count:      7,
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			acquireresult, err := client.AcquireIPs(t.Context(), connect.NewRequest(&v1.AcquireIPsRequest{
+				PrefixCidr:  cidr,
+				Count:       6,
+				Annotations: map[string]string{"rack": "r01"},
+			}))
+			require.NoError(t, err)
+			require.Len(t, acquireresult.Msg.GetIps(), 6)
+			assert.Equal(t, fmt.Sprintf("192.163.%d.1", counter), acquireresult.Msg.GetIps()[0].GetIp())
+			assert.Equal(t, map[string]string{"rack": "r01"}, acquireresult.Msg.GetIps()[5].GetAnnotations())
+
+			counter++
+		}
+	})
+
+	t.Run("AcquireReleaseIPRange", func(t *testing.T) {
+		counter := 0
+		for _, client := range clients {
+			cidr := fmt.Sprintf("192.162.%d.0/24", counter)
+			_, err := client.CreatePrefix(t.Context(), connect.NewRequest(&v1.CreatePrefixRequest{
+				Cidr: cidr,
+			}))
+			require.NoError(t, err)
+
+			acquireresult, err := client.AcquireIPRange(t.Context(), connect.NewRequest(&v1.AcquireIPRangeRequest{
+				PrefixCidr: cidr,
+				Count:      16,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("192.162.%d.1", counter), acquireresult.Msg.GetIpRange().GetFrom())
+			assert.Equal(t, fmt.Sprintf("192.162.%d.16", counter), acquireresult.Msg.GetIpRange().GetTo())
+
+			releaseresult, err := client.ReleaseIPRange(t.Context(), connect.NewRequest(&v1.ReleaseIPRangeRequest{
+				PrefixCidr: cidr,
+				From:       acquireresult.Msg.GetIpRange().GetFrom(),
+				To:         acquireresult.Msg.GetIpRange().GetTo(),
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, cidr, releaseresult.Msg.GetIpRange().GetParentPrefix())
+
+			_, err = client.ReleaseIPRange(t.Context(), connect.NewRequest(&v1.ReleaseIPRangeRequest{
+				PrefixCidr: cidr,
+				From:       acquireresult.Msg.GetIpRange().GetFrom(),
+				To:         acquireresult.Msg.GetIpRange().GetTo(),
+			}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+			counter++
+		}
+	})
+
 	t.Run("CreateDeleteGetPrefixNamespaced", func(t *testing.T) {
 		counter := 0
 		for _, client := range clients {
@@ -161,3 +1218,11 @@ func TestIpamService(t *testing.T) {
 		}
 	})
 }
+
+func leaseIDs(leases []*v1.Lease) []string {
+	var ids []string
+	for _, l := range leases {
+		ids = append(ids, l.GetId())
+	}
+	return ids
+}
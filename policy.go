@@ -0,0 +1,71 @@
+package ipam
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// Policy restricts the allocations from a parent Prefix, the zero value of a field does not restrict anything.
+// A Policy only applies to new allocations, existing child prefixes and ips are kept if it is changed.
+type Policy struct {
+	// MinChildLength is the smallest length of child prefixes acquired from the Prefix, i.e. the biggest child prefix
+	MinChildLength uint8 `json:"MinChildLength,omitempty"`
+	// MaxChildLength is the biggest length of child prefixes acquired from the Prefix, i.e. the smallest child prefix
+	MaxChildLength uint8 `json:"MaxChildLength,omitempty"`
+	// MaxChildPrefixes is the maximum number of child prefixes acquired from the Prefix at the same time
+	MaxChildPrefixes uint64 `json:"MaxChildPrefixes,omitempty"`
+	// DenyIPs denies the acquisition of ips from the Prefix, it is only used to acquire child prefixes from
+	DenyIPs bool `json:"DenyIPs,omitempty"`
+}
+
+// validate returns an error if the Policy can not be fulfilled by a prefix with the given cidr.
+func (pol Policy) validate(cidr string) error {
+	ipprefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return err
+	}
+	bits := ipprefix.Addr().BitLen()
+	if int(pol.MinChildLength) > bits {
+		return fmt.Errorf("minimum child length:%d must not be greater than %d", pol.MinChildLength, bits)
+	}
+	if int(pol.MaxChildLength) > bits {
+		return fmt.Errorf("maximum child length:%d must not be greater than %d", pol.MaxChildLength, bits)
+	}
+	if pol.MaxChildLength > 0 && pol.MinChildLength > pol.MaxChildLength {
+		return fmt.Errorf("minimum child length:%d must not be greater than maximum child length:%d", pol.MinChildLength, pol.MaxChildLength)
+	}
+	return nil
+}
+
+func copyPolicy(pol *Policy) *Policy {
+	if pol == nil {
+		return nil
+	}
+	c := *pol
+	return &c
+}
+
+// checkChildPrefixPolicy returns an ErrPolicyViolation if the policy of the Prefix does not allow to acquire count more child prefixes with the given length.
+func (p *Prefix) checkChildPrefixPolicy(length, count int) error {
+	if p.Policy == nil {
+		return nil
+	}
+	if p.Policy.MinChildLength > 0 && length < int(p.Policy.MinChildLength) {
+		return fmt.Errorf("%w: child prefix length:%d is smaller than the minimum length:%d of prefix:%s", ErrPolicyViolation, length, p.Policy.MinChildLength, p.Cidr)
+	}
+	if p.Policy.MaxChildLength > 0 && length > int(p.Policy.MaxChildLength) {
+		return fmt.Errorf("%w: child prefix length:%d is greater than the maximum length:%d of prefix:%s", ErrPolicyViolation, length, p.Policy.MaxChildLength, p.Cidr)
+	}
+	if p.Policy.MaxChildPrefixes > 0 && p.acquiredPrefixes()+uint64(count) > p.Policy.MaxChildPrefixes { // nolint:gosec
+		return fmt.Errorf("%w: prefix:%s has %d of at most %d child prefixes, %d more requested", ErrPolicyViolation, p.Cidr, p.acquiredPrefixes(), p.Policy.MaxChildPrefixes, count)
+	}
+	return nil
+}
+
+// checkIPPolicy returns an ErrPolicyViolation if the policy of the Prefix does not allow to acquire ips.
+func (p *Prefix) checkIPPolicy() error {
+	if p.Policy != nil && p.Policy.DenyIPs {
+		return fmt.Errorf("%w: acquisition of ips from prefix:%s is denied", ErrPolicyViolation, p.Cidr)
+	}
+	return nil
+}
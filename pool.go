@@ -0,0 +1,271 @@
+package ipam
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+)
+
+// PoolStrategy defines in which order the members of a Pool are tried.
+type PoolStrategy string
+
+const (
+	// FillFirst tries the members in their order in the Pool, a member is only used if all members before it are exhausted.
+	// This is the default.
+	FillFirst PoolStrategy = "fill-first"
+	// Spread tries the members ordered by their utilization, the least utilized member first.
+	Spread PoolStrategy = "spread"
+)
+
+// PoolStrategies contains all supported pool strategies.
+var PoolStrategies = []PoolStrategy{FillFirst, Spread}
+
+func (s PoolStrategy) validate() error {
+	if s == "" || slices.Contains(PoolStrategies, s) {
+		return nil
+	}
+	return fmt.Errorf("unknown pool strategy:%q, supported are %v", s, PoolStrategies)
+}
+
+// Pool is a named group of prefixes of a namespace which serve the same purpose, e.g. public ipv4 blocks bought at different times.
+// IPs and child prefixes acquired from the Pool are taken from any of its members.
+type Pool struct {
+	Name string `json:"Name"`
+	// Prefixes are the cidrs of the members, FillFirst tries them in this order
+	Prefixes []string `json:"Prefixes"`
+	// Strategy defines in which order the members are tried, FillFirst if empty
+	Strategy PoolStrategy `json:"Strategy,omitempty"`
+}
+
+func (p Pool) deepCopy() Pool {
+	p.Prefixes = slices.Clone(p.Prefixes)
+	return p
+}
+
+func (p Pool) toJSON() ([]byte, error) {
+	pj, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal pool:%w", err)
+	}
+	return pj, nil
+}
+
+func poolFromJSON(js []byte) (Pool, error) {
+	var p Pool
+	err := json.Unmarshal(js, &p)
+	if err != nil {
+		return Pool{}, fmt.Errorf("unable to unmarshal pool:%w", err)
+	}
+	return p, nil
+}
+
+func (i *ipamer) CreatePool(ctx context.Context, name string, strategy PoolStrategy, cidrs ...string) (*Pool, error) {
+	namespace := namespaceFromContext(ctx)
+	pool, err := i.newPool(ctx, name, strategy, cidrs)
+	if err != nil {
+		return nil, err
+	}
+	_, err = i.storage.ReadPool(ctx, name, namespace)
+	if err == nil {
+		return nil, fmt.Errorf("pool:%s already exists", name)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	if err := i.storage.UpdatePool(ctx, *pool, namespace); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+func (i *ipamer) UpdatePool(ctx context.Context, name string, strategy PoolStrategy, cidrs ...string) (*Pool, error) {
+	namespace := namespaceFromContext(ctx)
+	pool, err := i.newPool(ctx, name, strategy, cidrs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := i.storage.ReadPool(ctx, name, namespace); err != nil {
+		return nil, err
+	}
+	if err := i.storage.UpdatePool(ctx, *pool, namespace); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// newPool returns a validated Pool, all members must exist in the namespace of the context.
+func (i *ipamer) newPool(ctx context.Context, name string, strategy PoolStrategy, cidrs []string) (*Pool, error) {
+	if name == "" {
+		return nil, errors.New("pool name must not be empty")
+	}
+	if err := strategy.validate(); err != nil {
+		return nil, err
+	}
+	if len(cidrs) == 0 {
+		return nil, fmt.Errorf("pool:%s must have at least one prefix", name)
+	}
+	pool := &Pool{Name: name, Strategy: strategy}
+	for _, cidr := range cidrs {
+		p, err := i.PrefixFrom(ctx, cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
+		}
+		if slices.Contains(pool.Prefixes, p.Cidr) {
+			return nil, fmt.Errorf("prefix:%s is given more than once", p.Cidr)
+		}
+		pool.Prefixes = append(pool.Prefixes, p.Cidr)
+	}
+	return pool, nil
+}
+
+func (i *ipamer) GetPool(ctx context.Context, name string) (*Pool, error) {
+	pool, err := i.storage.ReadPool(ctx, name, namespaceFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+func (i *ipamer) ListPools(ctx context.Context) ([]Pool, error) {
+	pools, err := i.storage.ReadAllPools(ctx, namespaceFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(pools, func(a, b Pool) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return pools, nil
+}
+
+func (i *ipamer) DeletePool(ctx context.Context, name string) error {
+	return i.storage.DeletePool(ctx, name, namespaceFromContext(ctx))
+}
+
+// poolsWithMembers returns the pools of the namespace which have one of the given cidrs as member.
+func (i *ipamer) poolsWithMembers(ctx context.Context, namespace string, cidrs []string) ([]Pool, error) {
+	pools, err := i.storage.ReadAllPools(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read pools:%w", err)
+	}
+	return slices.DeleteFunc(pools, func(pool Pool) bool {
+		return !slices.ContainsFunc(pool.Prefixes, func(cidr string) bool {
+			return slices.Contains(cidrs, cidr)
+		})
+	}), nil
+}
+
+// replaceMembers replaces the members from with the members to at the position of the first one of from,
+// the pool must be persisted afterwards.
+func (p *Pool) replaceMembers(from, to []string) {
+	var prefixes []string
+	replaced := false
+	for _, cidr := range p.Prefixes {
+		if !slices.Contains(from, cidr) {
+			prefixes = append(prefixes, cidr)
+			continue
+		}
+		if !replaced {
+			prefixes = append(prefixes, to...)
+			replaced = true
+		}
+	}
+	p.Prefixes = prefixes
+}
+
+func (i *ipamer) AcquireIPFromPool(ctx context.Context, name string, opts ...AcquireOption) (*IP, error) {
+	members, err := i.poolMembers(ctx, name, idempotencyIPs, newAcquireOptions(opts...).idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.isParent {
+			// the member is only used for child prefixes
+			continue
+		}
+		ip, err := i.AcquireIP(ctx, member.Cidr, opts...)
+		if memberUnusable(err) {
+			continue
+		}
+		return ip, err
+	}
+	return nil, fmt.Errorf("%w: no ip left in any prefix of pool:%s", ErrNoIPAvailable, name)
+}
+
+func (i *ipamer) AcquireChildPrefixFromPool(ctx context.Context, name string, length uint8, opts ...PrefixOption) (*Prefix, error) {
+	members, err := i.poolMembers(ctx, name, idempotencyChildPrefixes, idempotencyKeyOf(opts...))
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		ipprefix, err := netip.ParsePrefix(member.Cidr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse prefix:%s %w", member.Cidr, err)
+		}
+		if ipprefix.Bits() >= int(length) || member.hasIPs() {
+			// the member is too small or only used for ips
+			continue
+		}
+		prefix, err := i.AcquireChildPrefix(ctx, member.Cidr, length, opts...)
+		if memberUnusable(err) {
+			continue
+		}
+		return prefix, err
+	}
+	return nil, fmt.Errorf("%w: no prefix with length:%d left in any prefix of pool:%s", ErrNoPrefixAvailable, length, name)
+}
+
+// memberUnusable returns true if the acquisition from a member of a Pool failed because of the member itself,
+// e.g. it is exhausted, its Policy does not allow the acquisition or it was deleted meanwhile. The next member is tried then.
+// Other errors like an exceeded Quota of the namespace or a failing storage are returned.
+func memberUnusable(err error) bool {
+	return errors.Is(err, ErrNoIPAvailable) ||
+		errors.Is(err, ErrNoPrefixAvailable) ||
+		errors.Is(err, ErrPolicyViolation) ||
+		errors.Is(err, ErrNotFound)
+}
+
+// poolMembers returns the existing members of the Pool in the order they are tried according to its strategy.
+// A member which already remembers the given idempotency key is tried first, so that a repeated request returns the original result.
+func (i *ipamer) poolMembers(ctx context.Context, name string, kind idempotencyKind, idempotencyKey string) ([]*Prefix, error) {
+	pool, err := i.GetPool(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var members []*Prefix
+	for _, cidr := range pool.Prefixes {
+		p, err := i.PrefixFrom(ctx, cidr)
+		if errors.Is(err, ErrNotFound) {
+			// the member was deleted after it was added to the pool
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, p)
+	}
+	if pool.Strategy == Spread {
+		utilization := make(map[string]float64, len(members))
+		for _, m := range members {
+			utilization[m.Cidr] = m.Usage().Utilization
+		}
+		slices.SortStableFunc(members, func(a, b *Prefix) int {
+			return cmp.Compare(utilization[a.Cidr], utilization[b.Cidr])
+		})
+	}
+	slices.SortStableFunc(members, func(a, b *Prefix) int {
+		_, aRemembers := a.idempotentResults(kind, idempotencyKey)
+		_, bRemembers := b.idempotentResults(kind, idempotencyKey)
+		switch {
+		case aRemembers && !bRemembers:
+			return -1
+		case bRemembers && !aRemembers:
+			return 1
+		}
+		return 0
+	})
+	return members, nil
+}
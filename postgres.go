@@ -17,6 +17,16 @@ CREATE TABLE IF NOT EXISTS prefixes (
 	prefix JSONB
 );
 CREATE INDEX IF NOT EXISTS prefix_idx ON prefixes USING GIN(prefix);
+CREATE TABLE IF NOT EXISTS quotas (
+	namespace text PRIMARY KEY NOT NULL,
+	quota     JSONB
+);
+CREATE TABLE IF NOT EXISTS pools (
+	namespace text NOT NULL,
+	name      text NOT NULL,
+	pool      JSONB,
+	PRIMARY KEY (namespace, name)
+);
 `
 
 // SSLMode specifies how to configure ssl encryption to the database
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"maps"
 	"math"
+	"math/big"
 	"net/netip"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/avast/retry-go/v4"
 	"go4.org/netipx"
@@ -17,14 +20,34 @@ import (
 
 // Prefix is a expression of a ip with length and forms a classless network.
 type Prefix struct {
-	Cidr                   string          `json:"Cidr"`       // The Cidr of this prefix
-	ParentCidr             string          `json:"ParentCidr"` // if this prefix is a child this is a pointer back
+	Cidr        string            `json:"Cidr"`                  // The Cidr of this prefix
+	ParentCidr  string            `json:"ParentCidr"`            // if this prefix is a child this is a pointer back
+	Labels      map[string]string `json:"Labels,omitempty"`      // user defined labels of this prefix
+	Description string            `json:"Description,omitempty"` // free text description of this prefix
+	// AllocationStrategy defines in which order ips of this prefix are acquired, FirstFree if empty
+	AllocationStrategy AllocationStrategy `json:"AllocationStrategy,omitempty"`
+	// ChildPrefixStrategy defines from which free block child prefixes of this prefix are acquired, BestFit if empty
+	ChildPrefixStrategy ChildPrefixStrategy `json:"ChildPrefixStrategy,omitempty"`
+	// Quarantine is the duration released ips are not acquired again, 0 if released ips are free immediately
+	Quarantine time.Duration `json:"Quarantine,omitempty"`
+	// Policy restricts the child prefixes and ips acquired from this prefix, nil if unrestricted
+	Policy                 *Policy         `json:"Policy,omitempty"`
 	isParent               bool            // if this Prefix has child prefixes, this is set to true
 	availableChildPrefixes map[string]bool // available child prefixes of this prefix
 	// TODO remove this in the next release
-	childPrefixLength int             // the length of the child prefixes
-	ips               map[string]bool // The ips contained in this prefix
-	version           int64           // version is used for optimistic locking
+	childPrefixLength int                          // the length of the child prefixes
+	ips               ipIntervals                  // The acquired ips of this prefix
+	ipAnnotations     map[string]map[string]string // annotations of acquired ips, keyed by ip
+	reserved          []string                     // addresses and ranges which are never acquired, nil for prefixes stored before reservations were configurable
+	exclusions        []string                     // addresses and ranges which are skipped on acquisition unless forced, e.g. dhcp ranges
+	lastAllocated     string                       // the last acquired ip, used as cursor by the NextAfterLast allocation strategy
+	leases            map[string]Lease             // leases of acquired ips, keyed by ip
+	lease             *Lease                       // lease of this child prefix, nil if it does not expire
+	quarantined       map[string]time.Time         // release time of quarantined ips, keyed by ip
+	idempotencyKeys   map[string]idempotencyRecord // results of requests with an idempotency key, keyed by idempotency key
+	version           int64                        // version is used for optimistic locking
+	placement         ChildPrefixStrategy          // strategy of a single child prefix acquisition, set by WithChildPlacement and never stored
+	idempotencyKey    string                       // idempotency key of a single request, set by WithIdempotencyKey and never stored
 }
 
 type Prefixes []Prefix
@@ -34,18 +57,39 @@ func (p *Prefix) deepCopy() *Prefix {
 	return &Prefix{
 		Cidr:                   p.Cidr,
 		ParentCidr:             p.ParentCidr,
+		Labels:                 maps.Clone(p.Labels),
+		Description:            p.Description,
+		AllocationStrategy:     p.AllocationStrategy,
+		ChildPrefixStrategy:    p.ChildPrefixStrategy,
+		Quarantine:             p.Quarantine,
+		Policy:                 copyPolicy(p.Policy),
 		isParent:               p.isParent,
 		childPrefixLength:      p.childPrefixLength,
 		availableChildPrefixes: copyMap(p.availableChildPrefixes),
-		ips:                    copyMap(p.ips),
+		ips:                    slices.Clone(p.ips),
+		ipAnnotations:          copyAnnotations(p.ipAnnotations),
+		reserved:               slices.Clone(p.reserved),
+		exclusions:             slices.Clone(p.exclusions),
+		lastAllocated:          p.lastAllocated,
+		leases:                 maps.Clone(p.leases),
+		lease:                  copyLease(p.lease),
+		quarantined:            maps.Clone(p.quarantined),
+		idempotencyKeys:        copyIdempotencyKeys(p.idempotencyKeys),
 		version:                p.version,
 	}
 }
 
+// prefixGobVersion is encoded first by GobEncode. Prefixes encoded before the encoding was versioned start with
+// their child prefixes and have the acquired ips as keys of a map, they are still decoded.
+const prefixGobVersion = 2
+
 // GobEncode implements GobEncode for Prefix
 func (p *Prefix) GobEncode() ([]byte, error) {
 	w := new(bytes.Buffer)
 	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(prefixGobVersion); err != nil {
+		return nil, err
+	}
 	if err := encoder.Encode(p.availableChildPrefixes); err != nil {
 		return nil, err
 	}
@@ -55,7 +99,7 @@ func (p *Prefix) GobEncode() ([]byte, error) {
 	if err := encoder.Encode(p.isParent); err != nil {
 		return nil, err
 	}
-	if err := encoder.Encode(p.ips); err != nil {
+	if err := encoder.Encode(p.ips.strings()); err != nil {
 		return nil, err
 	}
 	if err := encoder.Encode(p.version); err != nil {
@@ -67,6 +111,58 @@ func (p *Prefix) GobEncode() ([]byte, error) {
 	if err := encoder.Encode(p.ParentCidr); err != nil {
 		return nil, err
 	}
+	if err := encoder.Encode(p.ipAnnotations); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.Labels); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.Description); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.reserved); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.AllocationStrategy); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.lastAllocated); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.exclusions); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.leases); err != nil {
+		return nil, err
+	}
+	// gob is not able to encode nil pointers, an empty lease is decoded as nil
+	var lease Lease
+	if p.lease != nil {
+		lease = *p.lease
+	}
+	if err := encoder.Encode(lease); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.Quarantine); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.quarantined); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.idempotencyKeys); err != nil {
+		return nil, err
+	}
+	// gob is not able to encode nil pointers, an empty policy is decoded as nil
+	var policy Policy
+	if p.Policy != nil {
+		policy = *p.Policy
+	}
+	if err := encoder.Encode(policy); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(p.ChildPrefixStrategy); err != nil {
+		return nil, err
+	}
 	return w.Bytes(), nil
 }
 
@@ -74,6 +170,14 @@ func (p *Prefix) GobEncode() ([]byte, error) {
 func (p *Prefix) GobDecode(buf []byte) error {
 	r := bytes.NewBuffer(buf)
 	decoder := gob.NewDecoder(r)
+	var version int
+	if err := decoder.Decode(&version); err != nil {
+		// the first value of an unversioned encoding is a map
+		return p.gobDecodeUnversioned(buf)
+	}
+	if version != prefixGobVersion {
+		return fmt.Errorf("unsupported prefix encoding version:%d", version)
+	}
 	if err := decoder.Decode(&p.availableChildPrefixes); err != nil {
 		return err
 	}
@@ -83,16 +187,133 @@ func (p *Prefix) GobDecode(buf []byte) error {
 	if err := decoder.Decode(&p.isParent); err != nil {
 		return err
 	}
-	if err := decoder.Decode(&p.ips); err != nil {
+	var ips []string
+	if err := decoder.Decode(&ips); err != nil {
 		return err
 	}
+	p.ips = ipIntervalsOf(ips)
 	if err := decoder.Decode(&p.version); err != nil {
 		return err
 	}
 	if err := decoder.Decode(&p.Cidr); err != nil {
 		return err
 	}
-	return decoder.Decode(&p.ParentCidr)
+	if err := decoder.Decode(&p.ParentCidr); err != nil {
+		return err
+	}
+	var ipAnnotations map[string]map[string]string
+	if err := decoder.Decode(&ipAnnotations); err != nil {
+		return err
+	}
+	var labels map[string]string
+	if err := decoder.Decode(&labels); err != nil {
+		return err
+	}
+	// gob decodes a nil map as empty map, keep the prefix comparable to the encoded one
+	if len(ipAnnotations) > 0 {
+		p.ipAnnotations = ipAnnotations
+	}
+	if len(labels) > 0 {
+		p.Labels = labels
+	}
+	if err := decoder.Decode(&p.Description); err != nil {
+		return err
+	}
+	var reserved []string
+	if err := decoder.Decode(&reserved); err != nil {
+		return err
+	}
+	// gob does not distinguish between nil and empty slices, an empty slice means no reservations
+	p.reserved = reserved
+	if p.reserved == nil {
+		p.reserved = []string{}
+	}
+	if err := decoder.Decode(&p.AllocationStrategy); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&p.lastAllocated); err != nil {
+		return err
+	}
+	var exclusions []string
+	if err := decoder.Decode(&exclusions); err != nil {
+		return err
+	}
+	if len(exclusions) > 0 {
+		p.exclusions = exclusions
+	}
+	var leases map[string]Lease
+	if err := decoder.Decode(&leases); err != nil {
+		return err
+	}
+	if len(leases) > 0 {
+		p.leases = leases
+	}
+	var lease Lease
+	if err := decoder.Decode(&lease); err != nil {
+		return err
+	}
+	if lease.ID != "" {
+		p.lease = &lease
+	}
+	if err := decoder.Decode(&p.Quarantine); err != nil {
+		return err
+	}
+	var quarantined map[string]time.Time
+	if err := decoder.Decode(&quarantined); err != nil {
+		return err
+	}
+	if len(quarantined) > 0 {
+		p.quarantined = quarantined
+	}
+	var idempotencyKeys map[string]idempotencyRecord
+	if err := decoder.Decode(&idempotencyKeys); err != nil {
+		return err
+	}
+	if len(idempotencyKeys) > 0 {
+		p.idempotencyKeys = idempotencyKeys
+	}
+	var policy Policy
+	if err := decoder.Decode(&policy); err != nil {
+		return err
+	}
+	if policy != (Policy{}) {
+		p.Policy = &policy
+	}
+	if err := decoder.Decode(&p.ChildPrefixStrategy); err != nil {
+		return err
+	}
+	return nil
+}
+
+// gobDecodeUnversioned decodes a Prefix encoded before the encoding was versioned,
+// it is migrated like a Prefix stored as json before reservations were configurable.
+func (p *Prefix) gobDecodeUnversioned(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	var legacy prefixJSON
+	if err := decoder.Decode(&legacy.AvailableChildPrefixes); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&legacy.ChildPrefixLength); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&legacy.IsParent); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&legacy.IPs); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&legacy.Version); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&legacy.Cidr); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&legacy.ParentCidr); err != nil {
+		return err
+	}
+	*p = legacy.toPrefix()
+	return nil
 }
 
 func copyMap(m map[string]bool) map[string]bool {
@@ -101,6 +322,28 @@ func copyMap(m map[string]bool) map[string]bool {
 	return cm
 }
 
+func copyIdempotencyKeys(m map[string]idempotencyRecord) map[string]idempotencyRecord {
+	if m == nil {
+		return nil
+	}
+	cm := make(map[string]idempotencyRecord, len(m))
+	for key, record := range m {
+		cm[key] = idempotencyRecord{Results: slices.Clone(record.Results), Created: record.Created}
+	}
+	return cm
+}
+
+func copyAnnotations(m map[string]map[string]string) map[string]map[string]string {
+	if m == nil {
+		return nil
+	}
+	cm := make(map[string]map[string]string, len(m))
+	for ip, annotations := range m {
+		cm[ip] = maps.Clone(annotations)
+	}
+	return cm
+}
+
 // Usage of ips and child Prefixes of a Prefix
 type Usage struct {
 	// AvailableIPs the number of available IPs if this is not a parent prefix
@@ -108,6 +351,14 @@ type Usage struct {
 	AvailableIPs uint64
 	// AcquiredIPs the number of acquired IPs if this is not a parent prefix
 	AcquiredIPs uint64
+	// ReservedIPs the number of reserved IPs which are never acquired
+	// No more than 2^31 reserved IPs are reported
+	ReservedIPs uint64
+	// ExcludedIPs the number of excluded IPs which are only acquired if forced
+	// No more than 2^31 excluded IPs are reported
+	ExcludedIPs uint64
+	// QuarantinedIPs the number of released IPs which are not acquired again until their quarantine is over
+	QuarantinedIPs uint64
 	// AvailableSmallestPrefixes is the count of available Prefixes with 2 countable Bits
 	// No more than 2^31 available Prefixes are reported
 	AvailableSmallestPrefixes uint64
@@ -115,9 +366,23 @@ type Usage struct {
 	AvailablePrefixes []string
 	// AcquiredPrefixes the number of acquired prefixes if this is a parent prefix
 	AcquiredPrefixes uint64
+	// AvailableIPsExact the exact number of available IPs, which is not limited to 2^31
+	AvailableIPsExact *big.Int
+	// ReservedIPsExact the exact number of reserved IPs, which is not limited to 2^31
+	ReservedIPsExact *big.Int
+	// ExcludedIPsExact the exact number of excluded IPs, which is not limited to 2^31
+	ExcludedIPsExact *big.Int
+	// AvailableSmallestPrefixesExact the exact count of available Prefixes with 2 countable Bits, which is not limited to 2^31
+	AvailableSmallestPrefixesExact *big.Int
+	// FreeIPs the exact number of IPs which can still be acquired if this is not a parent prefix,
+	// these are neither acquired nor reserved, excluded or quarantined
+	FreeIPs *big.Int
+	// Utilization the percentage of acquired IPs of all IPs which are neither reserved nor excluded,
+	// for a parent prefix the percentage of its addresses covered by acquired child prefixes
+	Utilization float64
 }
 
-func (i *ipamer) NewPrefix(ctx context.Context, cidr string) (*Prefix, error) {
+func (i *ipamer) NewPrefix(ctx context.Context, cidr string, opts ...PrefixOption) (*Prefix, error) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 	namespace := namespaceFromContext(ctx)
@@ -125,24 +390,46 @@ func (i *ipamer) NewPrefix(ctx context.Context, cidr string) (*Prefix, error) {
 	if err != nil {
 		return nil, err
 	}
-	p, err := i.newPrefix(cidr, "")
+	p, err := i.newPrefix(cidr, "", opts...)
 	if err != nil {
 		return nil, err
 	}
+	key := idempotencyKeyOf(opts...)
+	if key != "" {
+		existing, err := i.storage.ReadPrefix(ctx, p.Cidr, namespace)
+		if err == nil {
+			if _, ok := existing.idempotentResults(idempotencyCreate, key); ok {
+				return &existing, nil
+			}
+		} else if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		p.rememberIdempotencyKey(idempotencyCreate, key, p.Cidr)
+	}
 	err = PrefixesOverlapping(existingPrefixes, []string{p.Cidr})
 	if err != nil {
 		return nil, err
 	}
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+	}
+	err = i.checkQuota(ctx, namespace, 1, 0, prefixSize(ipprefix))
+	if err != nil {
+		return nil, err
+	}
 	newPrefix, err := i.storage.CreatePrefix(ctx, *p, namespace)
 	if err != nil {
 		return nil, err
 	}
+	i.indexPrefix(namespace, newPrefix.Cidr)
 
 	return &newPrefix, nil
 }
 
-func (i *ipamer) DeletePrefix(ctx context.Context, cidr string) (*Prefix, error) {
+func (i *ipamer) DeletePrefix(ctx context.Context, cidr string, opts ...DeleteOption) (*Prefix, error) {
 	namespace := namespaceFromContext(ctx)
+	o := newDeleteOptions(opts...)
 	p, err := i.PrefixFrom(ctx, cidr)
 	if err != nil {
 		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
@@ -150,41 +437,87 @@ func (i *ipamer) DeletePrefix(ctx context.Context, cidr string) (*Prefix, error)
 	if p == nil {
 		return nil, fmt.Errorf("%w: delete prefix:%s", ErrNotFound, cidr)
 	}
+	if o.recursive {
+		err := retryOnOptimisticLock(func() error {
+			return i.deletePrefixRecursive(ctx, namespace, cidr, o)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
 	if p.hasIPs() {
 		return nil, fmt.Errorf("prefix %s has ips, delete prefix not possible", p.Cidr)
 	}
+	if o.report != nil {
+		*o.report = DeleteReport{}
+		o.report.add(*p)
+	}
+	if o.dryRun {
+		return p, nil
+	}
 	prefix, err := i.storage.DeletePrefix(ctx, *p, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("delete prefix:%s %w", cidr, err)
 	}
+	i.unindexPrefix(namespace, prefix.Cidr)
 
 	return &prefix, nil
 }
 
-func (i *ipamer) AcquireChildPrefix(ctx context.Context, parentCidr string, length uint8) (*Prefix, error) {
+func (i *ipamer) AcquireChildPrefix(ctx context.Context, parentCidr string, length uint8, opts ...PrefixOption) (*Prefix, error) {
 	namespace := namespaceFromContext(ctx)
 	var prefix *Prefix
 	return prefix, retryOnOptimisticLock(func() error {
 		var err error
-		prefix, err = i.acquireChildPrefixInternal(ctx, namespace, parentCidr, "", int(length))
+		prefix, err = i.acquireChildPrefixInternal(ctx, namespace, parentCidr, "", int(length), opts...)
 		return err
 	})
 }
 
-func (i *ipamer) AcquireSpecificChildPrefix(ctx context.Context, parentCidr, childCidr string) (*Prefix, error) {
+func (i *ipamer) AcquireSpecificChildPrefix(ctx context.Context, parentCidr, childCidr string, opts ...PrefixOption) (*Prefix, error) {
 	namespace := namespaceFromContext(ctx)
 	var prefix *Prefix
 	return prefix, retryOnOptimisticLock(func() error {
 		var err error
-		prefix, err = i.acquireChildPrefixInternal(ctx, namespace, parentCidr, childCidr, 0)
+		prefix, err = i.acquireChildPrefixInternal(ctx, namespace, parentCidr, childCidr, 0, opts...)
 		return err
 	})
 }
 
+func (i *ipamer) AcquireChildPrefixes(ctx context.Context, parentCidr string, length uint8, count int, opts ...PrefixOption) (Prefixes, error) {
+	namespace := namespaceFromContext(ctx)
+	var prefixes Prefixes
+	return prefixes, retryOnOptimisticLock(func() error {
+		children, err := i.acquireChildPrefixesInternal(ctx, namespace, parentCidr, "", int(length), count, opts...)
+		if err != nil {
+			return err
+		}
+		prefixes = make(Prefixes, 0, len(children))
+		for _, child := range children {
+			prefixes = append(prefixes, *child)
+		}
+		return nil
+	})
+}
+
 // acquireChildPrefixInternal will return a Prefix with a smaller length from the given Prefix.
-func (i *ipamer) acquireChildPrefixInternal(ctx context.Context, namespace, parentCidr, childCidr string, length int) (*Prefix, error) {
+func (i *ipamer) acquireChildPrefixInternal(ctx context.Context, namespace, parentCidr, childCidr string, length int, opts ...PrefixOption) (*Prefix, error) {
+	children, err := i.acquireChildPrefixesInternal(ctx, namespace, parentCidr, childCidr, length, 1, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return children[0], nil
+}
+
+// acquireChildPrefixesInternal will return count Prefixes with a smaller length from the given Prefix.
+// The parent is updated once, if one of the children can not be created the parent update is rolled back.
+func (i *ipamer) acquireChildPrefixesInternal(ctx context.Context, namespace, parentCidr, childCidr string, length, count int, opts ...PrefixOption) ([]*Prefix, error) {
 	specificChildRequest := childCidr != ""
 	var childprefix netip.Prefix
+	if count <= 0 {
+		return nil, fmt.Errorf("count:%d must be greater than 0", count)
+	}
 	parent, err := i.PrefixFrom(ctx, parentCidr)
 	if err != nil {
 		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, parentCidr, err.Error())
@@ -206,84 +539,160 @@ func (i *ipamer) acquireChildPrefixInternal(ctx context.Context, namespace, pare
 	if ipprefix.Bits() >= length {
 		return nil, fmt.Errorf("given length:%d must be greater than prefix length:%d", length, ipprefix.Bits())
 	}
+	// a repeated request returns its original children, even if the policy does not allow more children anymore
+	key := idempotencyKeyOf(opts...)
+	if cidrs, ok := parent.idempotentChildPrefixes(key); ok {
+		return i.childPrefixesOf(ctx, cidrs)
+	}
 	if parent.hasIPs() {
 		return nil, fmt.Errorf("prefix %s has ips, acquire child prefix not possible", parent.Cidr)
 	}
-
-	var ipsetBuilder netipx.IPSetBuilder
-	ipsetBuilder.AddPrefix(ipprefix)
-	for cp, available := range parent.availableChildPrefixes {
-		if available {
-			continue
-		}
-		cpipprefix, err := netip.ParsePrefix(cp)
-		if err != nil {
-			return nil, err
-		}
-		ipsetBuilder.RemovePrefix(cpipprefix)
+	if err := parent.checkChildPrefixPolicy(length, count); err != nil {
+		return nil, err
+	}
+	strategy := parent.ChildPrefixStrategy
+	if placement := placementOf(opts...); placement != "" {
+		strategy = placement
 	}
 
-	ipset, err := ipsetBuilder.IPSet()
+	ipset, err := parent.freeChildIPSet()
 	if err != nil {
-		return nil, fmt.Errorf("error constructing ipset:%w", err)
+		return nil, err
 	}
 
-	var cp netip.Prefix
+	var cps []netip.Prefix
 	if !specificChildRequest {
-		var ok bool
-		cp, _, ok = ipset.RemoveFreePrefix(uint8(length)) // nolint:gosec
-		if !ok {
-			pfxs := ipset.Prefixes()
-			if len(pfxs) == 0 {
-				return nil, fmt.Errorf("no prefix found in %s with length:%d", parentCidr, length)
-			}
-
-			var availablePrefixes []string
-			for _, p := range pfxs {
-				availablePrefixes = append(availablePrefixes, p.String())
+		for range count {
+			cp, remaining, ok, err := removeFreePrefix(ipset, length, strategy)
+			if err != nil {
+				return nil, err
 			}
-			adj := "are"
-			if len(availablePrefixes) == 1 {
-				adj = "is"
+			if !ok {
+				if count > 1 {
+					return nil, fmt.Errorf("%w: only %d of %d requested prefixes with length:%d left in %s", ErrNoPrefixAvailable, len(cps), count, length, parentCidr)
+				}
+				pfxs := ipset.Prefixes()
+				if len(pfxs) == 0 {
+					return nil, fmt.Errorf("%w: no prefix found in %s with length:%d", ErrNoPrefixAvailable, parentCidr, length)
+				}
+
+				var availablePrefixes []string
+				for _, p := range pfxs {
+					availablePrefixes = append(availablePrefixes, p.String())
+				}
+				adj := "are"
+				if len(availablePrefixes) == 1 {
+					adj = "is"
+				}
+
+				return nil, fmt.Errorf("%w: no prefix found in %s with length:%d, but %s %s available", ErrNoPrefixAvailable, parentCidr, length, strings.Join(availablePrefixes, ","), adj)
 			}
-
-			return nil, fmt.Errorf("no prefix found in %s with length:%d, but %s %s available", parentCidr, length, strings.Join(availablePrefixes, ","), adj)
+			cps = append(cps, cp)
+			ipset = remaining
 		}
 	} else {
+		if count != 1 {
+			return nil, fmt.Errorf("only one specific prefix can be acquired at once")
+		}
 		if ok := ipset.ContainsPrefix(childprefix); !ok {
 			// Parent prefix does not contain specific child prefix
 			return nil, fmt.Errorf("specific prefix %s is not available in prefix %s", childCidr, parentCidr)
 		}
-		cp = childprefix
+		cps = append(cps, childprefix)
 	}
 
-	// Ensure acquired child prefix is valid
-	if !cp.IsValid() {
-		return nil, fmt.Errorf("acquired child prefix:%s is not valid", cp.String())
+	children := make([]*Prefix, 0, len(cps))
+	for _, cp := range cps {
+		// Ensure acquired child prefix is valid
+		if !cp.IsValid() {
+			return nil, fmt.Errorf("acquired child prefix:%s is not valid", cp.String())
+		}
+		child, err := i.newPrefix(cp.String(), parentCidr, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to persist created child:%w", err)
+		}
+		children = append(children, child)
 	}
 
-	child := &Prefix{
-		Cidr:       cp.String(),
-		ParentCidr: parentCidr,
+	// remember previously released children to be able to rollback
+	previous := make(map[string]bool)
+	for _, child := range children {
+		if available, ok := parent.availableChildPrefixes[child.Cidr]; ok {
+			previous[child.Cidr] = available
+		}
+		parent.availableChildPrefixes[child.Cidr] = false
 	}
-
-	parent.availableChildPrefixes[child.Cidr] = false
+	wasParent := parent.isParent
 	parent.isParent = true
+	cidrs := make([]string, 0, len(children))
+	for _, child := range children {
+		cidrs = append(cidrs, child.Cidr)
+	}
+	parent.rememberIdempotencyKey(idempotencyChildPrefixes, key, cidrs...)
 
 	_, err = i.storage.UpdatePrefix(ctx, *parent, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to update parent prefix:%v error:%w", parent, err)
 	}
-	child, err = i.newPrefix(child.Cidr, parentCidr)
-	if err != nil {
-		return nil, fmt.Errorf("unable to persist created child:%w", err)
+	for idx, child := range children {
+		_, err = i.storage.CreatePrefix(ctx, *child, namespace)
+		if err != nil {
+			err = fmt.Errorf("unable to create child prefix:%v error:%w", child, err)
+			if rollbackErr := i.rollbackChildPrefixes(ctx, namespace, parentCidr, key, children, idx, previous, wasParent); rollbackErr != nil {
+				return nil, errors.Join(err, rollbackErr)
+			}
+			return nil, err
+		}
+		i.indexPrefix(namespace, child.Cidr)
 	}
-	_, err = i.storage.CreatePrefix(ctx, *child, namespace)
-	if err != nil {
-		return nil, fmt.Errorf("unable to update parent prefix:%v error:%w", child, err)
+
+	return children, nil
+}
+
+// childPrefixesOf returns the child prefixes with the given cidrs.
+func (i *ipamer) childPrefixesOf(ctx context.Context, cidrs []string) ([]*Prefix, error) {
+	children := make([]*Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		child, err := i.PrefixFrom(ctx, cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to find child prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
+		}
+		children = append(children, child)
 	}
+	return children, nil
+}
 
-	return child, nil
+// rollbackChildPrefixes deletes the already created children and restores the child prefixes of the parent
+// to the state before they were acquired.
+func (i *ipamer) rollbackChildPrefixes(ctx context.Context, namespace, parentCidr, key string, children []*Prefix, created int, previous map[string]bool, wasParent bool) error {
+	for _, child := range children[:created] {
+		_, err := i.storage.DeletePrefix(ctx, *child, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to rollback created child prefix:%s error:%w", child.Cidr, err)
+		}
+		i.unindexPrefix(namespace, child.Cidr)
+	}
+	return retryOnOptimisticLock(func() error {
+		parent, err := i.PrefixFrom(ctx, parentCidr)
+		if err != nil {
+			return fmt.Errorf("unable to rollback parent prefix:%s error:%w", parentCidr, err)
+		}
+		for _, child := range children {
+			if available, ok := previous[child.Cidr]; ok {
+				parent.availableChildPrefixes[child.Cidr] = available
+				continue
+			}
+			delete(parent.availableChildPrefixes, child.Cidr)
+		}
+		parent.isParent = wasParent || parent.acquiredPrefixes() > 0
+		// the key must not point to children which do not exist
+		parent.forgetIdempotencyKey(idempotencyChildPrefixes, key)
+		_, err = i.storage.UpdatePrefix(ctx, *parent, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to rollback parent prefix:%s error:%w", parentCidr, err)
+		}
+		return nil
+	})
 }
 
 func (i *ipamer) ReleaseChildPrefix(ctx context.Context, child *Prefix) error {
@@ -302,11 +711,12 @@ func (i *ipamer) releaseChildPrefixInternal(ctx context.Context, namespace strin
 	if parent == nil || !parent.isParent {
 		return fmt.Errorf("prefix:%q is no child prefix", child.Cidr)
 	}
-	if len(child.ips) > 2 {
+	if child.hasIPs() {
 		return fmt.Errorf("prefix %s has ips, deletion not possible", child.Cidr)
 	}
 
 	parent.availableChildPrefixes[child.Cidr] = true
+	parent.forgetIdempotencyResult(idempotencyChildPrefixes, child.Cidr)
 	_, err = i.storage.UpdatePrefix(ctx, *parent, namespace)
 	if err != nil {
 		return fmt.Errorf("unable to update parent:%q to release child prefix:%q :%w", child.ParentCidr, child.Cidr, err)
@@ -320,6 +730,45 @@ func (i *ipamer) releaseChildPrefixInternal(ctx context.Context, namespace strin
 	return nil
 }
 
+func (i *ipamer) EditPrefix(ctx context.Context, cidr string, opts ...PrefixOption) (*Prefix, error) {
+	namespace := namespaceFromContext(ctx)
+	var prefix *Prefix
+	return prefix, retryOnOptimisticLock(func() error {
+		p, err := i.PrefixFrom(ctx, cidr)
+		if err != nil {
+			return fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
+		}
+		if err := p.apply(opts...); err != nil {
+			return err
+		}
+		updated, err := i.storage.UpdatePrefix(ctx, *p, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to update prefix:%s error:%w", cidr, err)
+		}
+		prefix = &updated
+		return nil
+	})
+}
+
+func (i *ipamer) ListPrefixes(ctx context.Context, labelSelector string) (Prefixes, error) {
+	namespace := namespaceFromContext(ctx)
+	selector, err := parseLabelSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	pfxs, err := i.storage.ReadAllPrefixes(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	result := Prefixes{}
+	for _, p := range pfxs {
+		if selector.matches(p.Labels) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 func (i *ipamer) PrefixFrom(ctx context.Context, cidr string) (*Prefix, error) {
 	namespace := namespaceFromContext(ctx)
 	ipprefix, err := netip.ParsePrefix(cidr)
@@ -333,30 +782,28 @@ func (i *ipamer) PrefixFrom(ctx context.Context, cidr string) (*Prefix, error) {
 	return &prefix, nil
 }
 
-func (i *ipamer) AcquireSpecificIP(ctx context.Context, prefixCidr, specificIP string) (*IP, error) {
+func (i *ipamer) AcquireSpecificIP(ctx context.Context, prefixCidr, specificIP string, opts ...AcquireOption) (*IP, error) {
 	namespace := namespaceFromContext(ctx)
+	o := newAcquireOptions(opts...)
 	var ip *IP
 	return ip, retryOnOptimisticLock(func() error {
 		var err error
-		ip, err = i.acquireSpecificIPInternal(ctx, namespace, prefixCidr, specificIP)
+		ip, err = i.acquireSpecificIPInternal(ctx, namespace, prefixCidr, specificIP, o)
 		return err
 	})
 }
 
 // acquireSpecificIPInternal will acquire given IP and mark this IP as used, if already in use, return nil.
-// If specificIP is empty, the next free IP is returned.
+// If specificIP is empty, the next free IP according to the allocation strategy is returned.
 // If there is no free IP an NoIPAvailableError is returned.
 // If the Prefix is not found an NotFoundError is returned.
-func (i *ipamer) acquireSpecificIPInternal(ctx context.Context, namespace, prefixCidr, specificIP string) (*IP, error) {
-	prefix, err := i.PrefixFrom(ctx, prefixCidr)
+func (i *ipamer) acquireSpecificIPInternal(ctx context.Context, namespace, prefixCidr, specificIP string, o acquireOptions) (*IP, error) {
+	prefix, err := i.acquirablePrefix(ctx, prefixCidr)
 	if err != nil {
-		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, prefixCidr, err.Error())
+		return nil, err
 	}
-	if prefix == nil {
-		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s", ErrNotFound, prefixCidr)
-	}
-	if prefix.isParent {
-		return nil, fmt.Errorf("prefix %s has childprefixes, acquire ip not possible", prefix.Cidr)
+	if ips, ok := prefix.idempotentIPs(o.idempotencyKey); ok {
+		return ips[0], nil
 	}
 	ipnet, err := netip.ParsePrefix(prefix.Cidr)
 	if err != nil {
@@ -372,32 +819,54 @@ func (i *ipamer) acquireSpecificIPInternal(ctx context.Context, namespace, prefi
 		if !ipnet.Contains(specificIPnet) {
 			return nil, fmt.Errorf("given ip:%s is not in %s", specificIP, prefixCidr)
 		}
-		_, ok := prefix.ips[specificIPnet.String()]
-		if ok {
+		if prefix.ips.contains(specificIPnet) {
 			return nil, fmt.Errorf("%w: given ip:%s is already allocated", ErrAlreadyAllocated, specificIPnet)
 		}
-		return i.acquireAndStore(ctx, namespace, prefix, specificIPnet)
+		if prefix.isReserved(specificIPnet) {
+			return nil, fmt.Errorf("%w: given ip:%s is reserved", ErrAlreadyAllocated, specificIPnet)
+		}
+		if prefix.isExcluded(specificIPnet) && !o.force {
+			return nil, fmt.Errorf("%w: given ip:%s is excluded", ErrAlreadyAllocated, specificIPnet)
+		}
+		// quarantined ips are only skipped by AcquireIP, a specific request takes them
+		return i.acquireAndStore(ctx, namespace, prefix, specificIPnet, o)
 	}
 
-	iprange := netipx.RangeOfPrefix(ipnet)
-	for ip := iprange.From(); ipnet.Contains(ip); ip = ip.Next() {
-		ipstring := ip.String()
-		_, ok := prefix.ips[ipstring]
-		if ok {
-			continue
-		}
-		return i.acquireAndStore(ctx, namespace, prefix, ip)
+	ip, ok, err := prefix.nextFreeIP(o.strategyFor(prefix))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return i.acquireAndStore(ctx, namespace, prefix, ip, o)
 	}
 
-	return nil, fmt.Errorf("%w: no more ips in prefix: %s left, length of prefix.ips: %d", ErrNoIPAvailable, prefix.Cidr, len(prefix.ips))
+	return nil, fmt.Errorf("%w: no more ips in prefix: %s left, length of prefix.ips: %d", ErrNoIPAvailable, prefix.Cidr, prefix.acquiredips())
 }
 
-func (i *ipamer) acquireAndStore(ctx context.Context, namespace string, prefix *Prefix, ip netip.Addr) (*IP, error) {
-	acquired := &IP{
-		IP:           ip,
-		ParentPrefix: prefix.Cidr,
+// acquirablePrefix returns the prefix with the given cidr if ips can be acquired from it.
+func (i *ipamer) acquirablePrefix(ctx context.Context, prefixCidr string) (*Prefix, error) {
+	prefix, err := i.PrefixFrom(ctx, prefixCidr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, prefixCidr, err.Error())
 	}
-	prefix.ips[ip.String()] = true
+	if prefix == nil {
+		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s", ErrNotFound, prefixCidr)
+	}
+	if prefix.isParent {
+		return nil, fmt.Errorf("prefix %s has childprefixes, acquire ip not possible", prefix.Cidr)
+	}
+	if err := prefix.checkIPPolicy(); err != nil {
+		return nil, err
+	}
+	return prefix, nil
+}
+
+func (i *ipamer) acquireAndStore(ctx context.Context, namespace string, prefix *Prefix, ip netip.Addr, o acquireOptions) (*IP, error) {
+	if err := i.checkQuota(ctx, namespace, 0, 1, nil); err != nil {
+		return nil, err
+	}
+	acquired := prefix.acquire(ip, o)
+	prefix.rememberIdempotencyKey(idempotencyIPs, o.idempotencyKey, ip.String())
 	_, err := i.storage.UpdatePrefix(ctx, *prefix, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to persist acquired ip:%v error:%w", prefix, err)
@@ -405,8 +874,288 @@ func (i *ipamer) acquireAndStore(ctx context.Context, namespace string, prefix *
 	return acquired, nil
 }
 
-func (i *ipamer) AcquireIP(ctx context.Context, prefixCidr string) (*IP, error) {
-	return i.AcquireSpecificIP(ctx, prefixCidr, "")
+// acquire marks the given ip as acquired, the prefix must be persisted afterwards.
+func (p *Prefix) acquire(ip netip.Addr, o acquireOptions) *IP {
+	p.ips.add(ip)
+	delete(p.quarantined, ip.String())
+	p.pruneQuarantined()
+	p.lastAllocated = ip.String()
+	if len(o.annotations) > 0 {
+		if p.ipAnnotations == nil {
+			p.ipAnnotations = make(map[string]map[string]string)
+		}
+		p.ipAnnotations[ip.String()] = maps.Clone(o.annotations)
+	}
+	acquired := &IP{
+		IP:           ip,
+		ParentPrefix: p.Cidr,
+		Annotations:  maps.Clone(o.annotations),
+	}
+	if o.ttl > 0 {
+		lease := newLease(p.Cidr, o.ttl)
+		lease.IP = ip.String()
+		if p.leases == nil {
+			p.leases = make(map[string]Lease)
+		}
+		p.leases[ip.String()] = lease
+		acquired.Lease = &lease
+	}
+	return acquired
+}
+
+// release marks the given ip as free again together with its annotations and lease, the prefix must be persisted afterwards.
+// If the prefix has a quarantine, the ip is quarantined instead of being free immediately.
+func (p *Prefix) release(ip string) {
+	if addr, err := netip.ParseAddr(ip); err == nil {
+		p.ips.remove(addr)
+	}
+	delete(p.ipAnnotations, ip)
+	delete(p.leases, ip)
+	p.forgetIdempotencyResult(idempotencyIPs, ip)
+	p.pruneQuarantined()
+	if p.Quarantine > 0 {
+		if p.quarantined == nil {
+			p.quarantined = make(map[string]time.Time)
+		}
+		p.quarantined[ip] = storableTime(time.Now())
+	}
+}
+
+// isQuarantined returns true if the given ip was released less than the quarantine duration ago.
+func (p *Prefix) isQuarantined(ip string) bool {
+	released, ok := p.quarantined[ip]
+	return ok && time.Now().Before(released.Add(p.Quarantine))
+}
+
+// isAcquired returns true if the given ip is acquired.
+func (p *Prefix) isAcquired(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && p.ips.contains(addr)
+}
+
+// pruneQuarantined removes all ips whose quarantine is over.
+func (p *Prefix) pruneQuarantined() {
+	for ip := range p.quarantined {
+		if !p.isQuarantined(ip) {
+			delete(p.quarantined, ip)
+		}
+	}
+	if len(p.quarantined) == 0 {
+		p.quarantined = nil
+	}
+}
+
+// quarantinedips return the number of ips which are quarantined in this Prefix
+func (p *Prefix) quarantinedips() uint64 {
+	var count uint64
+	for ip := range p.quarantined {
+		if p.isQuarantined(ip) {
+			count++
+		}
+	}
+	return count
+}
+
+func (i *ipamer) AcquireIP(ctx context.Context, prefixCidr string, opts ...AcquireOption) (*IP, error) {
+	return i.AcquireSpecificIP(ctx, prefixCidr, "", opts...)
+}
+
+func (i *ipamer) AcquireIPs(ctx context.Context, prefixCidr string, count int, opts ...AcquireOption) ([]*IP, error) {
+	namespace := namespaceFromContext(ctx)
+	o := newAcquireOptions(opts...)
+	var ips []*IP
+	return ips, retryOnOptimisticLock(func() error {
+		var err error
+		ips, err = i.acquireIPsInternal(ctx, namespace, prefixCidr, count, o)
+		return err
+	})
+}
+
+// acquireIPsInternal acquires count ips according to the allocation strategy and persists them with a single update.
+// If less than count ips are free, a NoIPAvailableError is returned and nothing is acquired.
+func (i *ipamer) acquireIPsInternal(ctx context.Context, namespace, prefixCidr string, count int, o acquireOptions) ([]*IP, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count:%d must be greater than 0", count)
+	}
+	prefix, err := i.acquirablePrefix(ctx, prefixCidr)
+	if err != nil {
+		return nil, err
+	}
+	if ips, ok := prefix.idempotentIPs(o.idempotencyKey); ok {
+		return ips, nil
+	}
+	if err := i.checkQuota(ctx, namespace, 0, uint64(count), nil); err != nil { // nolint:gosec
+		return nil, err
+	}
+	strategy := o.strategyFor(prefix)
+	acquired := make([]*IP, 0, count)
+	for range count {
+		ip, ok, err := prefix.nextFreeIP(strategy)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: only %d of %d requested ips left in prefix: %s", ErrNoIPAvailable, len(acquired), count, prefix.Cidr)
+		}
+		acquired = append(acquired, prefix.acquire(ip, o))
+	}
+	ips := make([]string, 0, len(acquired))
+	for _, ip := range acquired {
+		ips = append(ips, ip.IP.String())
+	}
+	prefix.rememberIdempotencyKey(idempotencyIPs, o.idempotencyKey, ips...)
+	_, err = i.storage.UpdatePrefix(ctx, *prefix, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to persist acquired ips:%v error:%w", prefix, err)
+	}
+	return acquired, nil
+}
+
+func (i *ipamer) AcquireIPRange(ctx context.Context, prefixCidr string, count int) (*IPRange, error) {
+	namespace := namespaceFromContext(ctx)
+	var iprange *IPRange
+	return iprange, retryOnOptimisticLock(func() error {
+		var err error
+		iprange, err = i.acquireIPRangeInternal(ctx, namespace, prefixCidr, count)
+		return err
+	})
+}
+
+// acquireIPRangeInternal acquires the lowest range of count consecutive free ips with a single update.
+func (i *ipamer) acquireIPRangeInternal(ctx context.Context, namespace, prefixCidr string, count int) (*IPRange, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count:%d must be greater than 0", count)
+	}
+	prefix, err := i.acquirablePrefix(ctx, prefixCidr)
+	if err != nil {
+		return nil, err
+	}
+	iprange, ok, err := prefix.freeIPRange(count)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: no %d consecutive ips left in prefix: %s", ErrNoIPAvailable, count, prefix.Cidr)
+	}
+	if err := i.checkQuota(ctx, namespace, 0, uint64(count), nil); err != nil { // nolint:gosec
+		return nil, err
+	}
+	for ip := iprange.From(); ip.IsValid() && !iprange.To().Less(ip); ip = ip.Next() {
+		prefix.acquire(ip, acquireOptions{})
+	}
+	_, err = i.storage.UpdatePrefix(ctx, *prefix, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to persist acquired ip range:%v error:%w", prefix, err)
+	}
+	return &IPRange{
+		From:         iprange.From(),
+		To:           iprange.To(),
+		ParentPrefix: prefix.Cidr,
+	}, nil
+}
+
+// freeIPRange returns the lowest range of count consecutive ips which are neither acquired nor reserved.
+func (p *Prefix) freeIPRange(count int) (netipx.IPRange, bool, error) {
+	allocatable, err := p.allocatableIPSet()
+	if err != nil {
+		return netipx.IPRange{}, false, err
+	}
+	for _, r := range allocatable.Ranges() {
+		var (
+			start netip.Addr
+			free  int
+		)
+		for ip := r.From(); ip.IsValid() && !r.To().Less(ip); {
+			if acquired, ok := p.ips.rangeOf(ip); ok {
+				free = 0
+				ip = acquired.To().Next()
+				continue
+			}
+			if p.isQuarantined(ip.String()) {
+				free = 0
+				ip = ip.Next()
+				continue
+			}
+			if free == 0 {
+				start = ip
+			}
+			free++
+			if free == count {
+				return netipx.IPRangeFrom(start, ip), true, nil
+			}
+			ip = ip.Next()
+		}
+	}
+	return netipx.IPRange{}, false, nil
+}
+
+func (i *ipamer) ReleaseIPRange(ctx context.Context, iprange *IPRange) (*Prefix, error) {
+	namespace := namespaceFromContext(ctx)
+	err := retryOnOptimisticLock(func() error {
+		return i.releaseIPRangeInternal(ctx, namespace, iprange)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.PrefixFrom(ctx, iprange.ParentPrefix)
+}
+
+// releaseIPRangeInternal releases all ips of the given range with a single update.
+// If one of the ips is not acquired, a NotFoundError is returned and nothing is released.
+func (i *ipamer) releaseIPRangeInternal(ctx context.Context, namespace string, iprange *IPRange) error {
+	prefix, err := i.PrefixFrom(ctx, iprange.ParentPrefix)
+	if err != nil {
+		return fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, iprange.ParentPrefix, err.Error())
+	}
+	r := netipx.IPRangeFrom(iprange.From, iprange.To)
+	if !r.IsValid() {
+		return fmt.Errorf("ip range:%s is not valid", iprange)
+	}
+	for ip := r.From(); ip.IsValid() && !r.To().Less(ip); ip = ip.Next() {
+		if !prefix.ips.contains(ip) {
+			return fmt.Errorf("%w: unable to release ip range:%s because ip:%s is not allocated in prefix:%s", ErrNotFound, iprange, ip, iprange.ParentPrefix)
+		}
+	}
+	for ip := r.From(); ip.IsValid() && !r.To().Less(ip); ip = ip.Next() {
+		prefix.release(ip.String())
+	}
+	_, err = i.storage.UpdatePrefix(ctx, *prefix, namespace)
+	if err != nil {
+		return fmt.Errorf("unable to release ip range %s:%w", iprange, err)
+	}
+	return nil
+}
+
+func (i *ipamer) GetIP(ctx context.Context, prefixCidr, ip string) (*IP, error) {
+	prefix, err := i.PrefixFrom(ctx, prefixCidr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, prefixCidr, err.Error())
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, fmt.Errorf("given ip:%s in not valid", ip)
+	}
+	if !prefix.ips.contains(addr) {
+		return nil, fmt.Errorf("%w: ip:%s is not allocated in prefix:%s", ErrNotFound, ip, prefixCidr)
+	}
+	return prefix.acquiredIP(addr.String())
+}
+
+// acquiredIP returns the given acquired ip of the prefix together with its annotations and lease.
+func (p *Prefix) acquiredIP(ip string) (*IP, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, fmt.Errorf("given ip:%s in not valid", ip)
+	}
+	acquired := &IP{
+		IP:           addr,
+		ParentPrefix: p.Cidr,
+		Annotations:  maps.Clone(p.ipAnnotations[addr.String()]),
+	}
+	if lease, ok := p.leases[addr.String()]; ok {
+		acquired.Lease = &lease
+	}
+	return acquired, nil
 }
 
 func (i *ipamer) ReleaseIP(ctx context.Context, ip *IP) (*Prefix, error) {
@@ -433,11 +1182,10 @@ func (i *ipamer) releaseIPFromPrefixInternal(ctx context.Context, namespace, pre
 	if prefix == nil {
 		return fmt.Errorf("%w: unable to find prefix for cidr:%s", ErrNotFound, prefixCidr)
 	}
-	_, ok := prefix.ips[ip]
-	if !ok {
+	if !prefix.isAcquired(ip) {
 		return fmt.Errorf("%w: unable to release ip:%s because it is not allocated in prefix:%s", ErrNotFound, ip, prefixCidr)
 	}
-	delete(prefix.ips, ip)
+	prefix.release(ip)
 	_, err = i.storage.UpdatePrefix(ctx, *prefix, namespace)
 	if err != nil {
 		return fmt.Errorf("unable to release ip %v:%w", ip, err)
@@ -467,7 +1215,7 @@ func PrefixesOverlapping(existingPrefixes []string, newPrefixes []string) error
 }
 
 // newPrefix create a new Prefix from a string notation.
-func (i *ipamer) newPrefix(cidr, parentCidr string) (*Prefix, error) {
+func (i *ipamer) newPrefix(cidr, parentCidr string, opts ...PrefixOption) (*Prefix, error) {
 	ipnet, err := netip.ParsePrefix(cidr)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse cidr:%s %w", cidr, err)
@@ -483,18 +1231,18 @@ func (i *ipamer) newPrefix(cidr, parentCidr string) (*Prefix, error) {
 	p := &Prefix{
 		Cidr:                   ipnet.Masked().String(),
 		ParentCidr:             parentCidr,
-		ips:                    make(map[string]bool),
 		availableChildPrefixes: make(map[string]bool),
 		isParent:               false,
 	}
 
-	// FIXME: should this be done by the user ?
-	// First ip in the prefix and broadcast is blocked.
-	iprange := netipx.RangeOfPrefix(ipnet)
-	p.ips[iprange.From().String()] = true
-	if ipnet.Addr().Is4() {
-		// broadcast is ipv4 only
-		p.ips[iprange.To().String()] = true
+	if err := p.apply(opts...); err != nil {
+		return nil, err
+	}
+	// the placement and the idempotency key of a request are not properties of the created prefix
+	p.placement = ""
+	p.idempotencyKey = ""
+	if p.reserved == nil {
+		p.reserved = defaultReservedIPs(ipnet.Masked())
 	}
 
 	return p, nil
@@ -538,6 +1286,7 @@ func (i *ipamer) NamespacedLoad(ctx context.Context, namespace, dump string) err
 	if err != nil {
 		return err
 	}
+	defer i.dropIndex(namespace)
 	for _, pfx := range pfxs {
 		_, err = i.storage.CreatePrefix(ctx, pfx, namespace)
 		if err != nil {
@@ -559,6 +1308,9 @@ func (i *ipamer) ReadAllNamespacedPrefixCidrs(ctx context.Context, namespace str
 
 // CreateNamespaces creates a namespace with the given name.
 func (i *ipamer) CreateNamespace(ctx context.Context, namespace string) error {
+	if strings.HasPrefix(namespace, reservedNamespacePrefix) {
+		return fmt.Errorf("namespace:%s must not start with %q, it is reserved", namespace, reservedNamespacePrefix)
+	}
 	return i.storage.CreateNamespace(ctx, namespace)
 }
 
@@ -576,6 +1328,8 @@ func (i *ipamer) DeleteNamespace(ctx context.Context, namespace string) error {
 	if len(prefixes) > 0 {
 		return fmt.Errorf("cannot delete namespace with allocated prefixes")
 	}
+	i.dropIndex(namespace)
+	i.dropQuota(namespace)
 	return i.storage.DeleteNamespace(ctx, namespace)
 }
 
@@ -599,19 +1353,147 @@ func (p *Prefix) Network() (netip.Addr, error) {
 	return ipprefix.Addr(), nil
 }
 
-// hasIPs will return true if there are allocated IPs
+// ReservedIPs returns the addresses and ranges of this Prefix which are never acquired.
+func (p *Prefix) ReservedIPs() []string {
+	return slices.Clone(p.reserved)
+}
+
+// Lease returns the lease of this child Prefix, nil if it does not expire.
+func (p *Prefix) Lease() *Lease {
+	return copyLease(p.lease)
+}
+
+// Exclusions returns the addresses and ranges of this Prefix which are only acquired if forced.
+func (p *Prefix) Exclusions() []string {
+	return slices.Clone(p.exclusions)
+}
+
+// hasIPs will return true if there are allocated IPs, reserved IPs are not taken into account
 func (p *Prefix) hasIPs() bool {
+	return len(p.ips) > 0
+}
+
+// defaultReservedIPs returns the first address and for IPv4 the broadcast address of the given prefix.
+func defaultReservedIPs(ipprefix netip.Prefix) []string {
+	iprange := netipx.RangeOfPrefix(ipprefix)
+	reserved := []string{iprange.From().String()}
+	// broadcast is ipv4 only
+	if ipprefix.Addr().Is4() && iprange.To() != iprange.From() {
+		reserved = append(reserved, iprange.To().String())
+	}
+	return reserved
+}
+
+// ipSetOf returns the set of the given addresses, ranges and cidrs.
+func ipSetOf(ranges []string) (*netipx.IPSet, error) {
+	var ipsetBuilder netipx.IPSetBuilder
+	for _, r := range ranges {
+		iprange, err := parseIPRange(r)
+		if err != nil {
+			return nil, err
+		}
+		ipsetBuilder.AddRange(iprange)
+	}
+	return ipsetBuilder.IPSet()
+}
+
+// reservedIPSet returns all reserved addresses of this Prefix.
+func (p *Prefix) reservedIPSet() (*netipx.IPSet, error) {
+	return ipSetOf(p.reserved)
+}
+
+// exclusionIPSet returns all excluded addresses of this Prefix.
+func (p *Prefix) exclusionIPSet() (*netipx.IPSet, error) {
+	return ipSetOf(p.exclusions)
+}
+
+// allocatableIPSet returns all addresses of this Prefix which are neither reserved nor excluded.
+func (p *Prefix) allocatableIPSet() (*netipx.IPSet, error) {
 	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := p.reservedIPSet()
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := p.exclusionIPSet()
+	if err != nil {
+		return nil, err
+	}
+	var ipsetBuilder netipx.IPSetBuilder
+	ipsetBuilder.AddPrefix(ipprefix)
+	ipsetBuilder.RemoveSet(reserved)
+	ipsetBuilder.RemoveSet(excluded)
+	return ipsetBuilder.IPSet()
+}
+
+// isReserved returns true if the given ip is reserved in this Prefix
+func (p *Prefix) isReserved(ip netip.Addr) bool {
+	reserved, err := p.reservedIPSet()
 	if err != nil {
 		return false
 	}
-	if ipprefix.Addr().Is4() && len(p.ips) > 2 {
-		return true
+	return reserved.Contains(ip)
+}
+
+// isExcluded returns true if the given ip is excluded in this Prefix
+func (p *Prefix) isExcluded(ip netip.Addr) bool {
+	excluded, err := p.exclusionIPSet()
+	if err != nil {
+		return false
+	}
+	return excluded.Contains(ip)
+}
+
+// reservedips return the number of reserved ips in this Prefix
+func (p *Prefix) reservedips() uint64 {
+	reserved, err := p.reservedIPSet()
+	if err != nil {
+		return 0
+	}
+	return ipSetSize(reserved)
+}
+
+// excludedips return the number of excluded ips in this Prefix
+func (p *Prefix) excludedips() uint64 {
+	excluded, err := p.exclusionIPSet()
+	if err != nil {
+		return 0
 	}
-	if ipprefix.Addr().Is6() && len(p.ips) > 1 {
-		return true
+	return ipSetSize(excluded)
+}
+
+// reservedipsExact return the exact number of reserved ips in this Prefix
+func (p *Prefix) reservedipsExact() *big.Int {
+	reserved, err := p.reservedIPSet()
+	if err != nil {
+		return new(big.Int)
 	}
-	return false
+	return ipSetSizeExact(reserved)
+}
+
+// excludedipsExact return the exact number of excluded ips in this Prefix
+func (p *Prefix) excludedipsExact() *big.Int {
+	excluded, err := p.exclusionIPSet()
+	if err != nil {
+		return new(big.Int)
+	}
+	return ipSetSizeExact(excluded)
+}
+
+// ipSetSize returns the number of addresses in the given set, clamped to 2^31.
+func ipSetSize(ipset *netipx.IPSet) uint64 {
+	return clampedUint64(ipSetSizeExact(ipset))
+}
+
+// ipSetSizeExact returns the number of addresses in the given set.
+func ipSetSizeExact(ipset *netipx.IPSet) *big.Int {
+	total := new(big.Int)
+	for _, iprange := range ipset.Ranges() {
+		total.Add(total, rangeSize(iprange))
+	}
+	return total
 }
 
 // availableips return the number of ips available in this Prefix
@@ -627,16 +1509,108 @@ func (p *Prefix) availableips() uint64 {
 	return 1 << (ipprefix.Addr().BitLen() - ipprefix.Bits())
 }
 
+// availableipsExact return the exact number of ips available in this Prefix
+func (p *Prefix) availableipsExact() *big.Int {
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return new(big.Int)
+	}
+	return prefixSize(ipprefix)
+}
+
+// freeips return the number of ips which can still be acquired from this Prefix and the number of acquired ips among them,
+// the ips of child prefixes can not be acquired from a parent Prefix
+func (p *Prefix) freeips() (free *big.Int, acquired *big.Int) {
+	free, acquired = new(big.Int), new(big.Int)
+	if p.isParent {
+		return free, acquired
+	}
+	allocatable, err := p.allocatableIPSet()
+	if err != nil {
+		return free, acquired
+	}
+	free = ipSetSizeExact(allocatable)
+	ips, err := p.ips.ipSet()
+	if err != nil {
+		return free, acquired
+	}
+	var ipsetBuilder netipx.IPSetBuilder
+	ipsetBuilder.AddSet(ips)
+	ipsetBuilder.Intersect(allocatable)
+	acquiredSet, err := ipsetBuilder.IPSet()
+	if err != nil {
+		return free, acquired
+	}
+	acquired = ipSetSizeExact(acquiredSet)
+	free.Sub(free, acquired)
+	one := big.NewInt(1)
+	for ip := range p.quarantined {
+		addr, err := netip.ParseAddr(ip)
+		if err == nil && !p.ips.contains(addr) && p.isQuarantined(ip) && allocatable.Contains(addr) {
+			free.Sub(free, one)
+		}
+	}
+	return free, acquired
+}
+
+// utilization returns the percentage of acquired ips of all ips which are neither reserved nor excluded,
+// for a parent Prefix the percentage of its addresses covered by acquired child prefixes.
+func (p *Prefix) utilization(acquired *big.Int) float64 {
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return 0
+	}
+	total := new(big.Int)
+	if p.isParent {
+		acquired = new(big.Int)
+		for cp, available := range p.availableChildPrefixes {
+			if available {
+				continue
+			}
+			child, err := netip.ParsePrefix(cp)
+			if err != nil {
+				continue
+			}
+			acquired.Add(acquired, prefixSize(child))
+		}
+		total = prefixSize(ipprefix)
+	} else {
+		allocatable, err := p.allocatableIPSet()
+		if err != nil {
+			return 0
+		}
+		total = ipSetSizeExact(allocatable)
+	}
+	if total.Sign() == 0 {
+		return 0
+	}
+	percentage := new(big.Float).Quo(new(big.Float).SetInt(acquired), new(big.Float).SetInt(total))
+	result, _ := percentage.Mul(percentage, big.NewFloat(100)).Float64()
+	return result
+}
+
+// prefixSize returns the number of addresses of the given prefix.
+func prefixSize(prefix netip.Prefix) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(prefix.Addr().BitLen()-prefix.Bits())) // nolint:gosec
+}
+
 // acquiredips return the number of ips acquired in this Prefix
 func (p *Prefix) acquiredips() uint64 {
-	return uint64(len(p.ips))
+	return p.ips.count()
 }
 
 // availablePrefixes will return the amount of prefixes allocatable and the amount of smallest 2 bit prefixes
 func (p *Prefix) availablePrefixes() (uint64, []string) {
+	totalAvailable, availablePrefixes := p.availablePrefixesExact()
+	// we are not reporting more that 2^31 available prefixes
+	return clampedUint64(totalAvailable), availablePrefixes
+}
+
+// availablePrefixesExact will return the exact amount of prefixes allocatable and the amount of smallest 2 bit prefixes
+func (p *Prefix) availablePrefixesExact() (*big.Int, []string) {
 	prefix, err := netip.ParsePrefix(p.Cidr)
 	if err != nil {
-		return 0, nil
+		return new(big.Int), nil
 	}
 	var ipsetBuilder netipx.IPSetBuilder
 	ipsetBuilder.AddPrefix(prefix)
@@ -650,33 +1624,67 @@ func (p *Prefix) availablePrefixes() (uint64, []string) {
 		}
 		ipsetBuilder.RemovePrefix(ipprefix)
 	}
+	excluded, err := p.exclusionIPSet()
+	if err != nil {
+		return new(big.Int), []string{}
+	}
+	ipsetBuilder.RemoveSet(excluded)
 
 	ipset, err := ipsetBuilder.IPSet()
 	if err != nil {
-		return 0, []string{}
+		return new(big.Int), []string{}
 	}
 
 	// Only 2 Bit Prefixes are usable, set max bits available 2 less than max in family
 	maxBits := prefix.Addr().BitLen() - 2
 	pfxs := ipset.Prefixes()
-	totalAvailable := uint64(0)
+	totalAvailable := new(big.Int)
 	availablePrefixes := []string{}
 	for _, pfx := range pfxs {
 		bits := maxBits - pfx.Bits()
 		if bits < 0 {
 			continue
 		}
-		// same as: totalAvailable += uint64(math.Pow(float64(2), float64(maxBits-pfx.Bits)))
-		totalAvailable += 1 << bits
+		// same as: totalAvailable += 2^(maxBits-pfx.Bits)
+		totalAvailable.Add(totalAvailable, new(big.Int).Lsh(big.NewInt(1), uint(bits))) // nolint:gosec
 		availablePrefixes = append(availablePrefixes, pfx.String())
 	}
-	// we are not reporting more that 2^31 available prefixes
-	if totalAvailable > math.MaxInt32 {
-		totalAvailable = math.MaxInt32
-	}
 	return totalAvailable, availablePrefixes
 }
 
+// freeChildIPSet returns the addresses of the Prefix child prefixes can be acquired from,
+// these are neither part of an acquired child prefix nor excluded.
+func (p *Prefix) freeChildIPSet() (*netipx.IPSet, error) {
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+	}
+	var ipsetBuilder netipx.IPSetBuilder
+	ipsetBuilder.AddPrefix(ipprefix)
+	for cp, available := range p.availableChildPrefixes {
+		if available {
+			continue
+		}
+		cpipprefix, err := netip.ParsePrefix(cp)
+		if err != nil {
+			return nil, err
+		}
+		ipsetBuilder.RemovePrefix(cpipprefix)
+	}
+	// excluded ranges are never part of a child prefix
+	excluded, err := p.exclusionIPSet()
+	if err != nil {
+		return nil, err
+	}
+	ipsetBuilder.RemoveSet(excluded)
+
+	ipset, err := ipsetBuilder.IPSet()
+	if err != nil {
+		return nil, fmt.Errorf("error constructing ipset:%w", err)
+	}
+	return ipset, nil
+}
+
 // acquiredPrefixes return the amount of acquired prefixes of this prefix if this is a parent prefix
 func (p *Prefix) acquiredPrefixes() uint64 {
 	var count uint64
@@ -690,13 +1698,47 @@ func (p *Prefix) acquiredPrefixes() uint64 {
 
 // Usage report Prefix usage.
 func (p *Prefix) Usage() Usage {
-	sp, ap := p.availablePrefixes()
+	sp, ap := p.availablePrefixesExact()
+	free, acquired := p.freeips()
 	return Usage{
-		AvailableIPs:              p.availableips(),
-		AcquiredIPs:               p.acquiredips(),
-		AcquiredPrefixes:          p.acquiredPrefixes(),
-		AvailableSmallestPrefixes: sp,
-		AvailablePrefixes:         ap,
+		AvailableIPs:                   p.availableips(),
+		AcquiredIPs:                    p.acquiredips(),
+		ReservedIPs:                    p.reservedips(),
+		ExcludedIPs:                    p.excludedips(),
+		QuarantinedIPs:                 p.quarantinedips(),
+		AcquiredPrefixes:               p.acquiredPrefixes(),
+		AvailableSmallestPrefixes:      clampedUint64(sp),
+		AvailablePrefixes:              ap,
+		AvailableIPsExact:              p.availableipsExact(),
+		ReservedIPsExact:               p.reservedipsExact(),
+		ExcludedIPsExact:               p.excludedipsExact(),
+		AvailableSmallestPrefixesExact: sp,
+		FreeIPs:                        free,
+		Utilization:                    p.utilization(acquired),
+	}
+}
+
+// PowerOfTwoNotation formats n relative to the nearest power of two, e.g. "2^64 - 3" for the free ips of a /64
+// with the first ip reserved and two ips acquired. Numbers below 2^16 are formatted as decimal.
+func PowerOfTwoNotation(n *big.Int) string {
+	if n == nil {
+		return "0"
+	}
+	if n.Sign() <= 0 || n.BitLen() <= 16 {
+		return n.String()
+	}
+	exponent := n.BitLen()
+	upper := new(big.Int).Lsh(big.NewInt(1), uint(exponent)) // nolint:gosec
+	lower := new(big.Int).Rsh(upper, 1)
+	above := new(big.Int).Sub(n, lower)
+	below := new(big.Int).Sub(upper, n)
+	switch {
+	case above.Sign() == 0:
+		return fmt.Sprintf("2^%d", exponent-1)
+	case below.Cmp(above) < 0:
+		return fmt.Sprintf("2^%d - %s", exponent, below)
+	default:
+		return fmt.Sprintf("2^%d + %s", exponent-1, above)
 	}
 }
 
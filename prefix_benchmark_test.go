@@ -1,7 +1,9 @@
 package ipam
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/netip"
 	"testing"
 )
 
@@ -52,6 +54,104 @@ func BenchmarkAcquireIP(b *testing.B) {
 	})
 }
 
+func BenchmarkAcquireIPFilledPrefix(b *testing.B) {
+	ctx := b.Context()
+	testCidr := "10.0.0.0/16"
+	benchWithBackends(b, func(b *testing.B, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, testCidr)
+		if err != nil {
+			panic(err)
+		}
+		p.ips = filledIPs(testCidr, 50000)
+		_, err = ipam.storage.UpdatePrefix(ctx, *p, defaultNamespace)
+		if err != nil {
+			panic(err)
+		}
+		for b.Loop() {
+			ip, err := ipam.AcquireIP(ctx, testCidr)
+			if err != nil {
+				panic(err)
+			}
+			_, err = ipam.ReleaseIP(ctx, ip)
+			if err != nil {
+				panic(err)
+			}
+		}
+		p, err = ipam.PrefixFrom(ctx, testCidr)
+		if err != nil {
+			panic(err)
+		}
+		p.ips = nil
+		_, err = ipam.storage.UpdatePrefix(ctx, *p, defaultNamespace)
+		if err != nil {
+			panic(err)
+		}
+		_, err = ipam.DeletePrefix(ctx, testCidr)
+		if err != nil {
+			b.Fatalf("error deleting prefix:%v", err)
+		}
+	})
+}
+
+func BenchmarkPrefixJSONFilled(b *testing.B) {
+	testCidr := "10.0.0.0/16"
+	p := Prefix{
+		Cidr:     testCidr,
+		reserved: []string{"10.0.0.0"},
+		ips:      filledIPs(testCidr, 50000),
+	}
+	// the representation before acquired ips were stored as ranges
+	legacy := p.toPrefixJSON()
+	legacy.AcquiredIPs = nil
+	legacy.IPs = make(map[string]bool)
+	for _, r := range p.ips {
+		for ip := r.From(); ip.Compare(r.To()) <= 0; ip = ip.Next() {
+			legacy.IPs[ip.String()] = true
+		}
+	}
+	benchmarks := []struct {
+		name string
+		js   prefixJSON
+	}{
+		{name: "ranges", js: p.toPrefixJSON()},
+		{name: "legacy", js: legacy},
+	}
+	sizes := make(map[string]int)
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			var size int
+			for b.Loop() {
+				js, err := json.Marshal(bm.js)
+				if err != nil {
+					panic(err)
+				}
+				_, err = fromJSON(js)
+				if err != nil {
+					panic(err)
+				}
+				size = len(js)
+			}
+			sizes[bm.name] = size
+			b.ReportMetric(float64(size), "json-bytes")
+		})
+	}
+	// both sizes are only known if none of the sub benchmarks was filtered
+	if sizes["ranges"] > 0 && sizes["legacy"] > 0 && sizes["ranges"] >= sizes["legacy"] {
+		b.Fatalf("ranges encoding with %d bytes is not smaller than the legacy encoding with %d bytes", sizes["ranges"], sizes["legacy"])
+	}
+}
+
+// filledIPs returns the first count ips after the network address of cidr.
+func filledIPs(cidr string, count int) ipIntervals {
+	var ips ipIntervals
+	ip := netip.MustParsePrefix(cidr).Addr()
+	for range count {
+		ip = ip.Next()
+		ips.add(ip)
+	}
+	return ips
+}
+
 func BenchmarkAcquireChildPrefix(b *testing.B) {
 	ctx := b.Context()
 	benchmarks := []struct {
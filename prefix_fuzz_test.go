@@ -1,8 +1,12 @@
 package ipam
 
 import (
+	"errors"
 	"net/netip"
+	"slices"
 	"testing"
+
+	"go4.org/netipx"
 )
 
 func FuzzIpamer_AcquireIP(f *testing.F) {
@@ -60,3 +64,99 @@ func FuzzIpamer_AcquireIP(f *testing.F) {
 		}
 	})
 }
+
+func FuzzIpamer_AcquireChildPrefixStrategies(f *testing.F) {
+	ctx := f.Context()
+	tests := []struct {
+		name       string
+		prefixCIDR string
+		strategy   uint8
+		ops        []byte
+	}{
+		{
+			name:       "Mixed lengths with best fit",
+			prefixCIDR: "192.168.0.0/24",
+			strategy:   0,
+			ops:        []byte{4, 8, 2, 5, 12, 3, 6, 7},
+		},
+		{
+			name:       "Mixed lengths with first fit",
+			prefixCIDR: "192.168.0.0/24",
+			strategy:   1,
+			ops:        []byte{4, 8, 2, 5, 12, 3, 6, 7},
+		},
+		{
+			name:       "Mixed lengths with sparse",
+			prefixCIDR: "192.168.0.0/24",
+			strategy:   2,
+			ops:        []byte{4, 8, 2, 5, 12, 3, 6, 7},
+		},
+		{
+			name:       "IPv6 with sparse",
+			prefixCIDR: "2001:db8::/56",
+			strategy:   2,
+			ops:        []byte{16, 14, 1, 10, 9, 0, 15},
+		},
+	}
+	for _, tc := range tests {
+		f.Add(tc.prefixCIDR, tc.strategy, tc.ops)
+	}
+
+	f.Fuzz(func(t *testing.T, prefixCIDR string, strategy uint8, ops []byte) {
+		ipam := New(ctx)
+		s := ChildPrefixStrategies[int(strategy)%len(ChildPrefixStrategies)]
+		p, err := ipam.NewPrefix(ctx, prefixCIDR, WithChildPrefixStrategy(s))
+		if err != nil {
+			return
+		}
+		parent := netip.MustParsePrefix(p.Cidr)
+		hostBits := parent.Addr().BitLen() - parent.Bits()
+		if hostBits < 2 {
+			return
+		}
+
+		// an even op acquires a child prefix with a length derived from it, an odd op releases a child prefix
+		var children []*Prefix
+		for _, op := range ops {
+			if op%2 == 1 && len(children) > 0 {
+				idx := int(op/2) % len(children)
+				if err := ipam.ReleaseChildPrefix(ctx, children[idx]); err != nil {
+					t.Fatalf("unable to release child prefix:%s %v", children[idx].Cidr, err)
+				}
+				children = slices.Delete(children, idx, idx+1)
+				continue
+			}
+			length := parent.Bits() + 1 + int(op/2)%min(hostBits-1, 8)
+			child, err := ipam.AcquireChildPrefix(ctx, p.Cidr, uint8(length)) // nolint:gosec
+			if err != nil {
+				if !errors.Is(err, ErrNoPrefixAvailable) {
+					t.Fatalf("unexpected error acquiring length:%d from %s with %s: %v", length, p.Cidr, s, err)
+				}
+				// every strategy must find a free prefix if there is one
+				var builder netipx.IPSetBuilder
+				builder.AddPrefix(parent)
+				for _, c := range children {
+					builder.RemovePrefix(netip.MustParsePrefix(c.Cidr))
+				}
+				free, err := builder.IPSet()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, _, ok := free.RemoveFreePrefix(uint8(length)); ok { // nolint:gosec
+					t.Fatalf("no child prefix with length:%d acquired from %s with %s although there is one", length, p.Cidr, s)
+				}
+				continue
+			}
+			cp := netip.MustParsePrefix(child.Cidr)
+			if cp.Bits() != length || !parent.Contains(cp.Addr()) {
+				t.Fatalf("child prefix:%s with length:%d is not in %s", child.Cidr, length, p.Cidr)
+			}
+			for _, c := range children {
+				if netip.MustParsePrefix(c.Cidr).Overlaps(cp) {
+					t.Fatalf("child prefix:%s overlaps child prefix:%s", child.Cidr, c.Cidr)
+				}
+			}
+			children = append(children, child)
+		}
+	})
+}
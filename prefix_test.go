@@ -1,14 +1,20 @@
 package ipam
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"net"
 	"net/netip"
 	"reflect"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -153,7 +159,7 @@ func TestIpamer_AcquireIP(t *testing.T) {
 			}
 			t.Logf("Prefix:%#v", p)
 			for _, ipString := range test.fields.existingips {
-				p.ips[ipString] = true
+				p.ips.add(netip.MustParseAddr(ipString))
 			}
 
 			var updatedPrefix Prefix
@@ -226,22 +232,23 @@ func TestIpamer_AcquireSpecificIP(t *testing.T) {
 		prefix, err := ipam.NewPrefix(ctx, "192.168.99.0/24")
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		// network an broadcast are blocked
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		// network an broadcast are reserved
+		require.Equal(t, uint64(0), prefix.acquiredips())
+		require.Equal(t, uint64(2), prefix.reservedips())
 		ip1, err := ipam.AcquireSpecificIP(ctx, prefix.Cidr, "192.168.99.1")
 		require.NoError(t, err)
 		require.NotNil(t, ip1)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(3), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 		ip2, err := ipam.AcquireSpecificIP(ctx, prefix.Cidr, "192.168.99.2")
 		require.NoError(t, err)
 		require.NotEqual(t, ip1, ip2)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(4), prefix.acquiredips())
+		require.Equal(t, uint64(2), prefix.acquiredips())
 		require.Equal(t, "192.168.99.1", ip1.IP.String())
 		require.Equal(t, "192.168.99.2", ip2.IP.String())
 
@@ -267,33 +274,34 @@ func TestIpamer_AcquireSpecificIP(t *testing.T) {
 		prefix, err = ipam.ReleaseIP(ctx, ip1)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(3), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 
 		prefix, err = ipam.ReleaseIP(ctx, ip2)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		require.Equal(t, uint64(0), prefix.acquiredips())
 
 		// IPv6
 		prefix, err = ipam.NewPrefix(ctx, "2001:0db8:85a3::/120")
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		// network is blocked
-		require.Equal(t, uint64(1), prefix.acquiredips())
+		// network is reserved
+		require.Equal(t, uint64(0), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.reservedips())
 		ip1, err = ipam.AcquireSpecificIP(ctx, prefix.Cidr, "2001:db8:85a3::1")
 		require.NoError(t, err)
 		require.NotNil(t, ip1)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 		ip2, err = ipam.AcquireSpecificIP(ctx, prefix.Cidr, "2001:0db8:85a3::2")
 		require.NoError(t, err)
 		require.NotEqual(t, ip1, ip2)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(3), prefix.acquiredips())
+		require.Equal(t, uint64(2), prefix.acquiredips())
 		require.Equal(t, "2001:db8:85a3::1", ip1.IP.String())
 		require.Equal(t, "2001:db8:85a3::2", ip2.IP.String())
 
@@ -313,12 +321,166 @@ func TestIpamer_AcquireSpecificIP(t *testing.T) {
 		prefix, err = ipam.ReleaseIP(ctx, ip1)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 
 		prefix, err = ipam.ReleaseIP(ctx, ip2)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(1), prefix.acquiredips())
+		require.Equal(t, uint64(0), prefix.acquiredips())
+	})
+}
+
+func TestIpamer_AcquireIPWithAnnotations(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		prefix, err := ipam.NewPrefix(ctx, "192.168.100.0/24")
+		require.NoError(t, err)
+
+		annotations := map[string]string{"owner": "tenant-a", "hostname": "machine-1"}
+		ip1, err := ipam.AcquireIP(ctx, prefix.Cidr, AcquireWithAnnotations(annotations))
+		require.NoError(t, err)
+		require.Equal(t, "192.168.100.1", ip1.IP.String())
+		require.Equal(t, annotations, ip1.Annotations)
+
+		ip2, err := ipam.AcquireSpecificIP(ctx, prefix.Cidr, "192.168.100.42", AcquireWithAnnotations(map[string]string{"description": "gateway"}))
+		require.NoError(t, err)
+		require.Equal(t, "192.168.100.42", ip2.IP.String())
+
+		ip3, err := ipam.AcquireIP(ctx, prefix.Cidr)
+		require.NoError(t, err)
+
+		got, err := ipam.GetIP(ctx, prefix.Cidr, "192.168.100.1")
+		require.NoError(t, err)
+		require.Equal(t, ip1, got)
+
+		got, err = ipam.GetIP(ctx, prefix.Cidr, "192.168.100.42")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"description": "gateway"}, got.Annotations)
+
+		got, err = ipam.GetIP(ctx, prefix.Cidr, ip3.IP.String())
+		require.NoError(t, err)
+		require.Empty(t, got.Annotations)
+
+		_, err = ipam.GetIP(ctx, prefix.Cidr, "192.168.100.99")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		_, err = ipam.GetIP(ctx, "10.0.0.0/24", "10.0.0.1")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// annotations must survive a dump and load
+		data, err := ipam.Dump(ctx)
+		require.NoError(t, err)
+		err = ipam.storage.DeleteAllPrefixes(ctx, defaultNamespace)
+		require.NoError(t, err)
+		err = ipam.Load(ctx, data)
+		require.NoError(t, err)
+		got, err = ipam.GetIP(ctx, prefix.Cidr, "192.168.100.1")
+		require.NoError(t, err)
+		require.Equal(t, annotations, got.Annotations)
+
+		// annotations are removed together with the ip
+		_, err = ipam.ReleaseIP(ctx, ip1)
+		require.NoError(t, err)
+		_, err = ipam.GetIP(ctx, prefix.Cidr, "192.168.100.1")
+		require.ErrorIs(t, err, ErrNotFound)
+		ip1, err = ipam.AcquireIP(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "192.168.100.1", ip1.IP.String())
+		require.Empty(t, ip1.Annotations)
+		got, err = ipam.GetIP(ctx, prefix.Cidr, "192.168.100.1")
+		require.NoError(t, err)
+		require.Empty(t, got.Annotations)
+	})
+}
+
+func TestIpamer_AcquireIPs(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		prefix, err := ipam.NewPrefix(ctx, "192.168.0.0/28")
+		require.NoError(t, err)
+
+		ips, err := ipam.AcquireIPs(ctx, prefix.Cidr, 4, AcquireWithAnnotations(map[string]string{"rack": "r01"}))
+		require.NoError(t, err)
+		require.Len(t, ips, 4)
+		for i, ip := range ips {
+			require.Equal(t, fmt.Sprintf("192.168.0.%d", i+1), ip.IP.String())
+			require.Equal(t, prefix.Cidr, ip.ParentPrefix)
+			require.Equal(t, map[string]string{"rack": "r01"}, ip.Annotations)
+		}
+		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), prefix.acquiredips())
+		ip, err := ipam.GetIP(ctx, prefix.Cidr, "192.168.0.3")
+		require.NoError(t, err)
+		require.Equal(t, "r01", ip.Annotations["rack"])
+
+		// all or nothing
+		ips, err = ipam.AcquireIPs(ctx, prefix.Cidr, 11)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+		require.EqualError(t, err, "NoIPAvailableError: only 10 of 11 requested ips left in prefix: 192.168.0.0/28")
+		require.Nil(t, ips)
+		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), prefix.acquiredips())
+
+		ips, err = ipam.AcquireIPs(ctx, prefix.Cidr, 10, AcquireWithStrategy(LastFree))
+		require.NoError(t, err)
+		require.Len(t, ips, 10)
+		require.Equal(t, "192.168.0.14", ips[0].IP.String())
+		require.Equal(t, "192.168.0.5", ips[9].IP.String())
+
+		_, err = ipam.AcquireIPs(ctx, prefix.Cidr, 0)
+		require.EqualError(t, err, "count:0 must be greater than 0")
+		_, err = ipam.AcquireIPs(ctx, "10.0.0.0/24", 1)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestIpamer_AcquireIPRange(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		prefix, err := ipam.NewPrefix(ctx, "192.168.0.0/27")
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, prefix.Cidr, "192.168.0.4")
+		require.NoError(t, err)
+
+		// the range must not contain acquired or reserved ips
+		r1, err := ipam.AcquireIPRange(ctx, prefix.Cidr, 5)
+		require.NoError(t, err)
+		require.Equal(t, "192.168.0.5-192.168.0.9", r1.String())
+		require.Equal(t, prefix.Cidr, r1.ParentPrefix)
+		r2, err := ipam.AcquireIPRange(ctx, prefix.Cidr, 3)
+		require.NoError(t, err)
+		require.Equal(t, "192.168.0.1-192.168.0.3", r2.String())
+		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(9), prefix.acquiredips())
+
+		_, err = ipam.AcquireIPRange(ctx, prefix.Cidr, 22)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+		require.EqualError(t, err, "NoIPAvailableError: no 22 consecutive ips left in prefix: 192.168.0.0/27")
+		r3, err := ipam.AcquireIPRange(ctx, prefix.Cidr, 21)
+		require.NoError(t, err)
+		require.Equal(t, "192.168.0.10-192.168.0.30", r3.String())
+
+		// release is all or nothing
+		err = ipam.ReleaseIPFromPrefix(ctx, prefix.Cidr, "192.168.0.7")
+		require.NoError(t, err)
+		_, err = ipam.ReleaseIPRange(ctx, r1)
+		require.ErrorIs(t, err, ErrNotFound)
+		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(29), prefix.acquiredips())
+
+		prefix, err = ipam.ReleaseIPRange(ctx, r3)
+		require.NoError(t, err)
+		require.Equal(t, uint64(8), prefix.acquiredips())
+		r3, err = ipam.AcquireIPRange(ctx, prefix.Cidr, 10)
+		require.NoError(t, err)
+		require.Equal(t, "192.168.0.10-192.168.0.19", r3.String())
+
+		_, err = ipam.AcquireIPRange(ctx, prefix.Cidr, 0)
+		require.EqualError(t, err, "count:0 must be greater than 0")
 	})
 }
 
@@ -329,34 +491,35 @@ func TestIpamer_AcquireIPCountsIPv4(t *testing.T) {
 		prefix, err := ipam.NewPrefix(ctx, "192.168.0.0/24")
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		// network and broadcast are blocked
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		// network and broadcast are reserved
+		require.Equal(t, uint64(0), prefix.acquiredips())
+		require.Equal(t, uint64(2), prefix.reservedips())
 		ip1, err := ipam.AcquireIP(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.NotNil(t, ip1)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(3), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 		ip2, err := ipam.AcquireIP(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.NotEqual(t, ip1, ip2)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(4), prefix.acquiredips())
+		require.Equal(t, uint64(2), prefix.acquiredips())
 		require.True(t, strings.HasPrefix(ip1.IP.String(), "192.168.0"))
 		require.True(t, strings.HasPrefix(ip2.IP.String(), "192.168.0"))
 
 		prefix, err = ipam.ReleaseIP(ctx, ip1)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(3), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 
 		prefix, err = ipam.ReleaseIP(ctx, ip2)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		require.Equal(t, uint64(0), prefix.acquiredips())
 	})
 }
 
@@ -367,34 +530,35 @@ func TestIpamer_AcquireIPCountsIPv6(t *testing.T) {
 		prefix, err := ipam.NewPrefix(ctx, "2001:0db8:85a3::/120")
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		// network is blocked
-		require.Equal(t, uint64(1), prefix.acquiredips())
+		// network is reserved
+		require.Equal(t, uint64(0), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.reservedips())
 		ip1, err := ipam.AcquireIP(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.NotNil(t, ip1)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 		ip2, err := ipam.AcquireIP(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.NotEqual(t, ip1, ip2)
 		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(3), prefix.acquiredips())
+		require.Equal(t, uint64(2), prefix.acquiredips())
 		require.True(t, strings.HasPrefix(ip1.IP.String(), "2001:db8:85a3::"))
 		require.True(t, strings.HasPrefix(ip2.IP.String(), "2001:db8:85a3::"))
 
 		prefix, err = ipam.ReleaseIP(ctx, ip1)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(2), prefix.acquiredips())
+		require.Equal(t, uint64(1), prefix.acquiredips())
 
 		prefix, err = ipam.ReleaseIP(ctx, ip2)
 		require.NoError(t, err)
 		require.Equal(t, uint64(256), prefix.availableips())
-		require.Equal(t, uint64(1), prefix.acquiredips())
+		require.Equal(t, uint64(0), prefix.acquiredips())
 	})
 }
 
@@ -439,7 +603,7 @@ func TestIpamer_AcquireChildPrefixFragmented(t *testing.T) {
 
 		// acquire impossible size
 		_, err = ipam.AcquireChildPrefix(ctx, prefix.Cidr, 21)
-		require.EqualError(t, err, "no prefix found in 192.168.0.0/20 with length:21, but 192.168.12.0/22 is available")
+		require.EqualError(t, err, "NoPrefixAvailableError: no prefix found in 192.168.0.0/20 with length:21, but 192.168.12.0/22 is available")
 
 		// Release small, first half acquired
 		err = ipam.ReleaseChildPrefix(ctx, c2)
@@ -459,7 +623,7 @@ func TestIpamer_AcquireChildPrefixFragmented(t *testing.T) {
 
 		// acquire impossible size
 		_, err = ipam.AcquireChildPrefix(ctx, prefix.Cidr, 21)
-		require.EqualError(t, err, "no prefix found in 192.168.0.0/20 with length:21, but 192.168.8.16/28,192.168.8.32/27,192.168.8.64/26,192.168.8.128/25,192.168.9.0/24,192.168.10.0/23,192.168.12.0/22 are available")
+		require.EqualError(t, err, "NoPrefixAvailableError: no prefix found in 192.168.0.0/20 with length:21, but 192.168.8.16/28,192.168.8.32/27,192.168.8.64/26,192.168.8.128/25,192.168.9.0/24,192.168.10.0/23,192.168.12.0/22 are available")
 
 		// acquire a /22 which must be possible
 		c4, err := ipam.AcquireChildPrefix(ctx, prefix.Cidr, 22)
@@ -486,7 +650,7 @@ func TestIpamer_AcquireChildPrefixCounts(t *testing.T) {
 		require.Equal(t, uint64(0), prefix.Usage().AcquiredPrefixes)
 
 		usage := prefix.Usage()
-		require.Equal(t, "ip:2/4096", usage.String())
+		require.Equal(t, "ip:0/4096", usage.String())
 
 		allPrefixes, err = ipam.storage.ReadAllPrefixes(ctx, defaultNamespace)
 		require.NoError(t, err)
@@ -503,7 +667,7 @@ func TestIpamer_AcquireChildPrefixCounts(t *testing.T) {
 		require.Equal(t, uint64(1), prefix.Usage().AcquiredPrefixes)
 
 		usage = prefix.Usage()
-		require.Equal(t, "ip:2/4096 prefixes alloc:1 avail:768", usage.String())
+		require.Equal(t, "ip:0/4096 prefixes alloc:1 avail:768", usage.String())
 
 		allPrefixes, err = ipam.storage.ReadAllPrefixes(ctx, defaultNamespace)
 		require.NoError(t, err)
@@ -574,6 +738,85 @@ func TestIpamer_AcquireChildPrefixCounts(t *testing.T) {
 	})
 }
 
+func TestIpamer_AcquireChildPrefixes(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		parent, err := ipam.NewPrefix(ctx, "10.0.0.0/24")
+		require.NoError(t, err)
+
+		children, err := ipam.AcquireChildPrefixes(ctx, parent.Cidr, 28, 4, WithLabels(map[string]string{"tenant": "a"}))
+		require.NoError(t, err)
+		require.Len(t, children, 4)
+		cidrs := map[string]bool{}
+		for _, child := range children {
+			require.True(t, strings.HasSuffix(child.Cidr, "/28"))
+			require.Equal(t, parent.Cidr, child.ParentCidr)
+			require.Equal(t, "a", child.Labels["tenant"])
+			cidrs[child.Cidr] = true
+			p, err := ipam.PrefixFrom(ctx, child.Cidr)
+			require.NoError(t, err)
+			require.Equal(t, child.Cidr, p.Cidr)
+		}
+		require.Len(t, cidrs, 4)
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), parent.acquiredPrefixes())
+
+		// all or nothing
+		_, err = ipam.AcquireChildPrefixes(ctx, parent.Cidr, 28, 13)
+		require.EqualError(t, err, "NoPrefixAvailableError: only 12 of 13 requested prefixes with length:28 left in 10.0.0.0/24")
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), parent.acquiredPrefixes())
+
+		children, err = ipam.AcquireChildPrefixes(ctx, parent.Cidr, 28, 12)
+		require.NoError(t, err)
+		require.Len(t, children, 12)
+
+		_, err = ipam.AcquireChildPrefixes(ctx, parent.Cidr, 28, 0)
+		require.EqualError(t, err, "count:0 must be greater than 0")
+	})
+}
+
+// failingStorage fails to create prefixes after the given number of successful creations.
+type failingStorage struct {
+	Storage
+	creations int
+}
+
+func (f *failingStorage) CreatePrefix(ctx context.Context, prefix Prefix, namespace string) (Prefix, error) {
+	if f.creations == 0 {
+		return Prefix{}, fmt.Errorf("storage unavailable")
+	}
+	f.creations--
+	return f.Storage.CreatePrefix(ctx, prefix, namespace)
+}
+
+func TestIpamer_AcquireChildPrefixesRollback(t *testing.T) {
+	ctx := t.Context()
+	storage := &failingStorage{Storage: NewMemory(ctx), creations: 3}
+	ipam := NewWithStorage(storage)
+
+	parent, err := ipam.NewPrefix(ctx, "10.0.0.0/24")
+	require.NoError(t, err)
+	released, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 28)
+	require.NoError(t, err)
+	require.NoError(t, ipam.ReleaseChildPrefix(ctx, released))
+
+	// the parent and one child could be written, the second child fails
+	_, err = ipam.AcquireChildPrefixes(ctx, parent.Cidr, 28, 4)
+	require.EqualError(t, err, "unable to create child prefix:10.0.0.16/28 error:storage unavailable")
+
+	parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), parent.acquiredPrefixes())
+	require.True(t, parent.isParent)
+	require.Equal(t, map[string]bool{released.Cidr: true}, parent.availableChildPrefixes)
+	cidrs, err := ipam.ReadAllPrefixCidrs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{parent.Cidr}, cidrs)
+}
+
 func TestIpamer_AcquireChildPrefixIPv4(t *testing.T) {
 	ctx := t.Context()
 
@@ -604,7 +847,7 @@ func TestIpamer_AcquireChildPrefixIPv4(t *testing.T) {
 		require.NotNil(t, cp)
 		cp, err = ipam.AcquireChildPrefix(ctx, prefix.Cidr, 21)
 		require.Error(t, err)
-		require.Equal(t, "no prefix found in 192.168.0.0/20 with length:21", err.Error())
+		require.Equal(t, "NoPrefixAvailableError: no prefix found in 192.168.0.0/20 with length:21", err.Error())
 		require.Nil(t, cp)
 
 		// Prefix has ips
@@ -675,7 +918,7 @@ func TestIpamer_AcquireChildPrefixIPv6(t *testing.T) {
 		require.NotNil(t, cp)
 		cp, err = ipam.AcquireChildPrefix(ctx, prefix.Cidr, 117)
 		require.Error(t, err)
-		require.Equal(t, "no prefix found in 2001:db8:85a3::/116 with length:117", err.Error())
+		require.Equal(t, "NoPrefixAvailableError: no prefix found in 2001:db8:85a3::/116 with length:117", err.Error())
 		require.Nil(t, cp)
 
 		// Prefix has ips
@@ -1134,117 +1377,1884 @@ func TestIpamer_NewPrefix(t *testing.T) {
 	}
 }
 
-func TestIpamer_DeletePrefix(t *testing.T) {
+func TestIpamer_PrefixLabels(t *testing.T) {
 	ctx := t.Context()
-
 	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
-		// IPv4
-		prefix, err := ipam.NewPrefix(ctx, "192.168.0.0/20")
+		parent, err := ipam.NewPrefix(ctx, "10.0.0.0/16", WithLabels(map[string]string{"site": "fra1"}), WithDescription("fra1 supernet"))
 		require.NoError(t, err)
-		s, _ := prefix.availablePrefixes()
-		require.Equal(t, uint64(1024), s)
-		require.Equal(t, uint64(0), prefix.acquiredPrefixes())
-		require.Equal(t, uint64(0), prefix.Usage().AcquiredPrefixes)
+		require.Equal(t, map[string]string{"site": "fra1"}, parent.Labels)
+		require.Equal(t, "fra1 supernet", parent.Description)
 
-		ip, err := ipam.AcquireIP(ctx, prefix.Cidr)
+		underlay, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 24, WithLabels(map[string]string{"site": "fra1", "purpose": "underlay"}))
 		require.NoError(t, err)
-		require.NotNil(t, ip)
-
-		_, err = ipam.DeletePrefix(ctx, prefix.Cidr)
-		require.Error(t, err)
-		require.Equal(t, "prefix 192.168.0.0/20 has ips, delete prefix not possible", err.Error())
-
-		// IPv6
-		prefix, err = ipam.NewPrefix(ctx, "2001:0db8:85a3::/120")
+		overlay, err := ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, "10.0.128.0/24", WithLabels(map[string]string{"site": "fra1", "purpose": "overlay"}))
+		require.NoError(t, err)
+		_, err = ipam.NewPrefix(ctx, "10.1.0.0/16", WithLabels(map[string]string{"site": "muc1", "purpose": "underlay"}))
 		require.NoError(t, err)
-		s, _ = prefix.availablePrefixes()
-		require.Equal(t, uint64(64), s)
-		require.Equal(t, uint64(0), prefix.acquiredPrefixes())
-		require.Equal(t, uint64(0), prefix.Usage().AcquiredPrefixes)
 
-		ip, err = ipam.AcquireIP(ctx, prefix.Cidr)
+		p, err := ipam.PrefixFrom(ctx, underlay.Cidr)
 		require.NoError(t, err)
-		require.NotNil(t, ip)
+		require.Equal(t, map[string]string{"site": "fra1", "purpose": "underlay"}, p.Labels)
 
-		_, err = ipam.DeletePrefix(ctx, prefix.Cidr)
+		cidrsOf := func(selector string) []string {
+			pfxs, err := ipam.ListPrefixes(ctx, selector)
+			require.NoError(t, err)
+			var cidrs []string
+			for _, p := range pfxs {
+				cidrs = append(cidrs, p.Cidr)
+			}
+			return cidrs
+		}
+		require.Len(t, cidrsOf(""), 4)
+		require.ElementsMatch(t, []string{"10.0.0.0/16", underlay.Cidr, overlay.Cidr}, cidrsOf("site=fra1"))
+		require.ElementsMatch(t, []string{underlay.Cidr}, cidrsOf("site=fra1,purpose=underlay"))
+		require.ElementsMatch(t, []string{underlay.Cidr, overlay.Cidr}, cidrsOf("purpose in (underlay,overlay),site!=muc1"))
+		require.ElementsMatch(t, []string{"10.0.0.0/16"}, cidrsOf("!purpose"))
+
+		_, err = ipam.ListPrefixes(ctx, "site in (fra1")
 		require.Error(t, err)
-		require.Equal(t, "prefix 2001:db8:85a3::/120 has ips, delete prefix not possible", err.Error())
 
-		_, err = ipam.ReleaseIP(ctx, ip)
+		// labels and description are editable
+		p, err = ipam.EditPrefix(ctx, overlay.Cidr, WithLabels(map[string]string{"site": "fra1", "purpose": "storage"}), WithDescription("ceph"))
 		require.NoError(t, err)
-		_, err = ipam.DeletePrefix(ctx, prefix.Cidr)
+		require.Equal(t, "storage", p.Labels["purpose"])
+		require.Equal(t, "ceph", p.Description)
+		p, err = ipam.PrefixFrom(ctx, overlay.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "storage", p.Labels["purpose"])
+		require.Equal(t, "ceph", p.Description)
+		require.Empty(t, cidrsOf("purpose=overlay"))
+
+		// editing must not touch allocations
+		_, err = ipam.AcquireIP(ctx, overlay.Cidr)
+		require.NoError(t, err)
+		p, err = ipam.EditPrefix(ctx, overlay.Cidr, WithLabels(nil))
 		require.NoError(t, err)
+		require.Nil(t, p.Labels)
+		require.Equal(t, "ceph", p.Description)
+		require.True(t, p.hasIPs())
+
+		_, err = ipam.EditPrefix(ctx, "192.168.0.0/24", WithDescription("unknown"))
+		require.ErrorIs(t, err, ErrNotFound)
+
+		_, err = ipam.NewPrefix(ctx, "10.2.0.0/16", WithLabels(map[string]string{"": "empty"}))
+		require.EqualError(t, err, "label key must not be empty")
 	})
 }
 
-func TestIpamer_PrefixFrom(t *testing.T) {
+func TestIpamer_ReservedIPs(t *testing.T) {
 	ctx := t.Context()
-
 	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
-		prefix, err := ipam.PrefixFrom(ctx, "192.168.0.0/20")
-		require.ErrorIs(t, err, ErrNotFound)
-		require.Error(t, err)
-		require.Nil(t, prefix)
+		// point-to-point links use both addresses
+		p2p, err := ipam.NewPrefix(ctx, "10.0.0.0/31", WithoutReservedIPs())
+		require.NoError(t, err)
+		require.Empty(t, p2p.ReservedIPs())
+		ip1, err := ipam.AcquireIP(ctx, p2p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.0", ip1.IP.String())
+		ip2, err := ipam.AcquireIP(ctx, p2p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.1", ip2.IP.String())
+		_, err = ipam.AcquireIP(ctx, p2p.Cidr)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
 
-		prefix, err = ipam.NewPrefix(ctx, "192.168.0.0/20")
+		// loopbacks
+		loopbacks, err := ipam.NewPrefix(ctx, "10.1.0.0/24", WithoutReservedIPs())
 		require.NoError(t, err)
-		require.NotNil(t, prefix)
+		lo, err := ipam.AcquireSpecificIP(ctx, loopbacks.Cidr, "10.1.0.0")
+		require.NoError(t, err)
+		require.Equal(t, "10.1.0.0", lo.IP.String())
+		loopbacks, err = ipam.PrefixFrom(ctx, loopbacks.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), loopbacks.Usage().AcquiredIPs)
+		require.Equal(t, uint64(0), loopbacks.Usage().ReservedIPs)
 
-		prefix, err = ipam.PrefixFrom(ctx, "192.168.0.0/20")
+		// first and last n addresses and explicit ranges
+		p, err := ipam.NewPrefix(ctx, "10.2.0.0/24", WithReservedFirst(10), WithReservedLast(5), WithReservedIPs("10.2.0.100-10.2.0.109", "10.2.0.128/30", "10.2.0.200"))
 		require.NoError(t, err)
-		require.NotNil(t, prefix)
+		require.Equal(t, []string{"10.2.0.0-10.2.0.9", "10.2.0.251-10.2.0.255", "10.2.0.100-10.2.0.109", "10.2.0.128-10.2.0.131", "10.2.0.200"}, p.ReservedIPs())
+		usage := p.Usage()
+		require.Equal(t, uint64(30), usage.ReservedIPs)
+		require.Equal(t, uint64(0), usage.AcquiredIPs)
+		require.False(t, p.hasIPs())
 
-		// non canonical form still returns the same prefix
-		prefix2, err := ipam.PrefixFrom(ctx, "10.0.5.0/8")
-		require.ErrorIs(t, err, ErrNotFound)
+		ip, err := ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.2.0.10", ip.IP.String())
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.2.0.105")
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+		require.EqualError(t, err, "AlreadyAllocatedError: given ip:10.2.0.105 is reserved")
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.2.0.99")
+		require.NoError(t, err)
+
+		// reserved addresses are skipped
+		for range 256 - 30 - 2 {
+			ip, err = ipam.AcquireIP(ctx, p.Cidr)
+			require.NoError(t, err)
+			require.False(t, p.isReserved(ip.IP), "acquired reserved ip:%s", ip.IP)
+		}
+		_, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(226), p.Usage().AcquiredIPs)
+		require.Equal(t, uint64(30), p.Usage().ReservedIPs)
+
+		// invalid reservations
+		_, err = ipam.NewPrefix(ctx, "10.3.0.0/24", WithReservedIPs("10.4.0.1"))
+		require.EqualError(t, err, "reserved range:10.4.0.1 is not in prefix:10.3.0.0/24")
+		_, err = ipam.NewPrefix(ctx, "10.3.0.0/24", WithReservedFirst(257))
+		require.EqualError(t, err, "unable to reserve the first 257 addresses of prefix:10.3.0.0/24")
+		_, err = ipam.NewPrefix(ctx, "10.3.0.0/24", WithReservedIPs("10.3.0.x"))
 		require.Error(t, err)
-		require.Nil(t, prefix2)
 
-		prefix2a, err := ipam.NewPrefix(ctx, "10.8.0.0/8")
+		// reservations are editable but must not contain acquired ips
+		_, err = ipam.EditPrefix(ctx, loopbacks.Cidr, WithReservedFirst(2))
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+		loopbacks, err = ipam.EditPrefix(ctx, loopbacks.Cidr, WithReservedLast(1))
 		require.NoError(t, err)
-		require.NotNil(t, prefix2a)
+		require.Equal(t, []string{"10.1.0.255"}, loopbacks.ReservedIPs())
 
-		prefix2b, err := ipam.PrefixFrom(ctx, "10.2.0.0/8")
+		// child prefixes with reserved ips only can be released
+		parent, err := ipam.NewPrefix(ctx, "2001:db8::/64")
 		require.NoError(t, err)
-		require.NotNil(t, prefix2b)
-		require.Equal(t, prefix2a.Cidr, prefix2b.Cidr)
+		child, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 120, WithReservedFirst(16))
+		require.NoError(t, err)
+		require.Equal(t, uint64(16), child.Usage().ReservedIPs)
+		ip, err = ipam.AcquireIP(ctx, child.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "2001:db8::10", ip.IP.String())
+		_, err = ipam.ReleaseIP(ctx, ip)
+		require.NoError(t, err)
+		require.NoError(t, ipam.ReleaseChildPrefix(ctx, child))
 	})
 }
 
-func TestIpamerAcquireIP(t *testing.T) {
+func TestIpamer_Exclusions(t *testing.T) {
 	ctx := t.Context()
-
 	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
-		cidr := "10.0.0.0/16"
-		p, err := ipam.NewPrefix(ctx, cidr)
+		p, err := ipam.NewPrefix(ctx, "10.5.0.0/24", WithExclusions("10.5.0.1-10.5.0.10", "10.5.0.128/25"))
 		require.NoError(t, err)
-		for range 10 {
-			if len(p.ips) != 2 {
-				t.Fatalf("expected 2 ips in prefix, got %d", len(p.ips))
-			}
-			ip, err := ipam.AcquireIP(ctx, p.Cidr)
-			require.NoError(t, err)
-			require.NotNil(t, ip, "IP is nil")
-			p, err = ipam.ReleaseIP(ctx, ip)
-			require.NoError(t, err)
+		require.Equal(t, []string{"10.5.0.1-10.5.0.10", "10.5.0.128-10.5.0.255"}, p.Exclusions())
+		usage := p.Usage()
+		require.Equal(t, uint64(138), usage.ExcludedIPs)
+		require.Equal(t, uint64(2), usage.ReservedIPs)
+
+		// excluded addresses are skipped
+		ip, err := ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.5.0.11", ip.IP.String())
+		ip, err = ipam.AcquireIP(ctx, p.Cidr, AcquireWithStrategy(LastFree))
+		require.NoError(t, err)
+		require.Equal(t, "10.5.0.127", ip.IP.String())
+		ips, err := ipam.AcquireIPs(ctx, p.Cidr, 115)
+		require.NoError(t, err)
+		for _, ip := range ips {
+			require.False(t, p.isExcluded(ip.IP), "acquired excluded ip:%s", ip.IP)
 		}
-		_, err = ipam.DeletePrefix(ctx, cidr)
-		require.NoError(t, err, "error deleting prefix:%v", err)
+		_, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+
+		// excluded addresses are only acquired if forced
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.5.0.5")
+		require.EqualError(t, err, "AlreadyAllocatedError: given ip:10.5.0.5 is excluded")
+		ip, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.5.0.5", AcquireForced())
+		require.NoError(t, err)
+		require.Equal(t, "10.5.0.5", ip.IP.String())
+
+		// exclusions are replaced on edit
+		p, err = ipam.EditPrefix(ctx, p.Cidr, WithExclusions("10.5.0.200"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.5.0.200"}, p.Exclusions())
+		ip, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.5.0.1", ip.IP.String())
+		p, err = ipam.EditPrefix(ctx, p.Cidr, WithExclusions())
+		require.NoError(t, err)
+		require.Empty(t, p.Exclusions())
+		require.Equal(t, uint64(0), p.Usage().ExcludedIPs)
+
+		_, err = ipam.NewPrefix(ctx, "10.6.0.0/24", WithExclusions("10.7.0.0/24"))
+		require.EqualError(t, err, "excluded range:10.7.0.0-10.7.0.255 is not in prefix:10.6.0.0/24")
+		_, err = ipam.NewPrefix(ctx, "10.6.0.0/24", WithExclusions("10.6.0.x"))
+		require.Error(t, err)
+
+		// child prefixes skip excluded ranges
+		parent, err := ipam.NewPrefix(ctx, "10.8.0.0/16", WithExclusions("10.8.0.0/24", "10.8.2.10"))
+		require.NoError(t, err)
+		child, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 24)
+		require.NoError(t, err)
+		require.Equal(t, "10.8.1.0/24", child.Cidr)
+		child, err = ipam.AcquireChildPrefix(ctx, parent.Cidr, 24)
+		require.NoError(t, err)
+		require.Equal(t, "10.8.3.0/24", child.Cidr)
+		_, err = ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, "10.8.0.0/25")
+		require.EqualError(t, err, "specific prefix 10.8.0.0/25 is not available in prefix 10.8.0.0/16")
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.NotContains(t, parent.Usage().AvailablePrefixes, "10.8.0.0/24")
+
+		// exclusions must not overlap acquired child prefixes
+		_, err = ipam.EditPrefix(ctx, parent.Cidr, WithExclusions("10.8.3.0-10.8.4.0"))
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
 	})
 }
 
-func TestIpamerAcquireIPv6(t *testing.T) {
+func TestIpamer_Leases(t *testing.T) {
 	ctx := t.Context()
-
 	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
-		cidr := "2001:0db8:85a3::/120"
-		p, err := ipam.NewPrefix(ctx, cidr)
+		p, err := ipam.NewPrefix(ctx, "10.9.0.0/24")
 		require.NoError(t, err)
-		for range 10 {
-			if len(p.ips) != 1 {
-				t.Fatalf("expected 1 ips in prefix, got %d", len(p.ips))
-			}
-			ip, err := ipam.AcquireIP(ctx, p.Cidr)
+
+		// ips without ttl are not leased
+		permanent, err := ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Nil(t, permanent.Lease)
+
+		short, err := ipam.AcquireIP(ctx, p.Cidr, AcquireWithTTL(50*time.Millisecond))
+		require.NoError(t, err)
+		require.NotNil(t, short.Lease)
+		require.Equal(t, short.IP.String(), short.Lease.IP)
+		require.Equal(t, p.Cidr, short.Lease.ParentPrefix)
+
+		long, err := ipam.AcquireIP(ctx, p.Cidr, AcquireWithTTL(time.Hour))
+		require.NoError(t, err)
+		got, err := ipam.GetIP(ctx, p.Cidr, long.IP.String())
+		require.NoError(t, err)
+		require.Equal(t, long.Lease, got.Lease)
+
+		parent, err := ipam.NewPrefix(ctx, "10.10.0.0/16")
+		require.NoError(t, err)
+		child, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 24, WithTTL(50*time.Millisecond))
+		require.NoError(t, err)
+		busy, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 24, WithTTL(50*time.Millisecond))
+		require.NoError(t, err)
+		busyIP, err := ipam.AcquireIP(ctx, busy.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.NewPrefix(ctx, "10.11.0.0/16", WithTTL(time.Hour))
+		require.EqualError(t, err, "ttl is only supported for child prefixes")
+
+		leases, err := ipam.ListLeases(ctx)
+		require.NoError(t, err)
+		require.Len(t, leases, 4)
+		require.Equal(t, long.Lease.ID, leases[3].ID)
+		require.ElementsMatch(t, []string{short.Lease.ID, child.lease.ID, busy.lease.ID}, []string{leases[0].ID, leases[1].ID, leases[2].ID})
+
+		// nothing is expired yet
+		released, err := ipam.ReleaseExpiredLeases(ctx)
+		require.NoError(t, err)
+		require.Empty(t, released)
+
+		renewed, err := ipam.RenewLease(ctx, long.Lease.ID, 2*time.Hour)
+		require.NoError(t, err)
+		require.True(t, renewed.Expires.After(long.Lease.Expires))
+		_, err = ipam.RenewLease(ctx, "unknown", time.Hour)
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = ipam.RenewLease(ctx, long.Lease.ID, 0)
+		require.EqualError(t, err, "ttl:0s must be greater than 0")
+
+		time.Sleep(100 * time.Millisecond)
+
+		// expired leases can not be renewed
+		_, err = ipam.RenewLease(ctx, short.Lease.ID, time.Hour)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// the expired child prefix with an ip is skipped
+		released, err = ipam.ReleaseExpiredLeases(ctx)
+		require.NoError(t, err)
+		require.Len(t, released, 2)
+		require.ElementsMatch(t, []string{short.Lease.ID, child.lease.ID}, []string{released[0].ID, released[1].ID})
+		_, err = ipam.PrefixFrom(ctx, busy.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.ReleaseIP(ctx, busyIP)
+		require.NoError(t, err)
+		released, err = ipam.ReleaseExpiredLeases(ctx)
+		require.NoError(t, err)
+		require.Len(t, released, 1)
+		require.Equal(t, busy.lease.ID, released[0].ID)
+
+		_, err = ipam.GetIP(ctx, p.Cidr, short.IP.String())
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = ipam.GetIP(ctx, p.Cidr, permanent.IP.String())
+		require.NoError(t, err)
+		_, err = ipam.GetIP(ctx, p.Cidr, long.IP.String())
+		require.NoError(t, err)
+		_, err = ipam.PrefixFrom(ctx, child.Cidr)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		leases, err = ipam.ListLeases(ctx)
+		require.NoError(t, err)
+		require.Len(t, leases, 1)
+		require.Equal(t, renewed, &leases[0])
+
+		// releasing the ip removes its lease
+		_, err = ipam.ReleaseIP(ctx, long)
+		require.NoError(t, err)
+		leases, err = ipam.ListLeases(ctx)
+		require.NoError(t, err)
+		require.Empty(t, leases)
+	})
+}
+
+func TestIpamer_Quarantine(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, "10.12.0.0/29", WithQuarantine(100*time.Millisecond))
+		require.NoError(t, err)
+		require.Equal(t, 100*time.Millisecond, p.Quarantine)
+
+		ips, err := ipam.AcquireIPs(ctx, p.Cidr, 3)
+		require.NoError(t, err)
+		require.Equal(t, "10.12.0.1", ips[0].IP.String())
+		require.NoError(t, ipam.ReleaseIPFromPrefix(ctx, p.Cidr, "10.12.0.1"))
+		require.NoError(t, ipam.ReleaseIPFromPrefix(ctx, p.Cidr, "10.12.0.2"))
+
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		usage := p.Usage()
+		require.Equal(t, uint64(2), usage.QuarantinedIPs)
+		require.Equal(t, uint64(1), usage.AcquiredIPs)
+
+		// quarantined ips are skipped
+		ip, err := ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.12.0.4", ip.IP.String())
+		_, err = ipam.AcquireIPRange(ctx, p.Cidr, 3)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+
+		// a specific ip is acquired even if quarantined
+		ip, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.12.0.2")
+		require.NoError(t, err)
+		require.Equal(t, "10.12.0.2", ip.IP.String())
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), p.Usage().QuarantinedIPs)
+
+		time.Sleep(150 * time.Millisecond)
+
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), p.Usage().QuarantinedIPs)
+		ip, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.12.0.1", ip.IP.String())
+
+		// without quarantine released ips are free immediately
+		p, err = ipam.EditPrefix(ctx, p.Cidr, WithQuarantine(0))
+		require.NoError(t, err)
+		require.NoError(t, ipam.ReleaseIPFromPrefix(ctx, p.Cidr, "10.12.0.1"))
+		ip, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.12.0.1", ip.IP.String())
+
+		_, err = ipam.NewPrefix(ctx, "10.13.0.0/24", WithQuarantine(-time.Second))
+		require.EqualError(t, err, "quarantine:-1s must not be negative")
+	})
+}
+
+func TestIpamer_IdempotencyKeys(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, "10.14.0.0/16", WithIdempotencyKey("create-1"))
+		require.NoError(t, err)
+		// a repeated create returns the original prefix instead of an overlap error
+		again, err := ipam.NewPrefix(ctx, "10.14.0.0/16", WithIdempotencyKey("create-1"))
+		require.NoError(t, err)
+		require.Equal(t, p.Cidr, again.Cidr)
+		_, err = ipam.NewPrefix(ctx, "10.14.0.0/16", WithIdempotencyKey("create-2"))
+		require.EqualError(t, err, "10.14.0.0/16 overlaps 10.14.0.0/16")
+
+		child, err := ipam.AcquireChildPrefix(ctx, p.Cidr, 24, WithIdempotencyKey("child-1"))
+		require.NoError(t, err)
+		again, err = ipam.AcquireChildPrefix(ctx, p.Cidr, 24, WithIdempotencyKey("child-1"))
+		require.NoError(t, err)
+		require.Equal(t, child.Cidr, again.Cidr)
+		other, err := ipam.AcquireChildPrefix(ctx, p.Cidr, 24, WithIdempotencyKey("child-2"))
+		require.NoError(t, err)
+		require.NotEqual(t, child.Cidr, other.Cidr)
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), p.Usage().AcquiredPrefixes)
+
+		ip, err := ipam.AcquireIP(ctx, child.Cidr, AcquireWithIdempotencyKey("ip-1"), AcquireWithAnnotations(map[string]string{"owner": "a"}))
+		require.NoError(t, err)
+		ipagain, err := ipam.AcquireIP(ctx, child.Cidr, AcquireWithIdempotencyKey("ip-1"))
+		require.NoError(t, err)
+		require.Equal(t, ip, ipagain)
+		ipother, err := ipam.AcquireIP(ctx, child.Cidr, AcquireWithIdempotencyKey("ip-2"))
+		require.NoError(t, err)
+		require.NotEqual(t, ip.IP, ipother.IP)
+
+		ips, err := ipam.AcquireIPs(ctx, child.Cidr, 3, AcquireWithIdempotencyKey("ips-1"))
+		require.NoError(t, err)
+		ipsagain, err := ipam.AcquireIPs(ctx, child.Cidr, 3, AcquireWithIdempotencyKey("ips-1"))
+		require.NoError(t, err)
+		require.Equal(t, ips, ipsagain)
+
+		child, err = ipam.PrefixFrom(ctx, child.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), child.Usage().AcquiredIPs)
+
+		// expired keys are forgotten
+		record := child.idempotencyKeys[idempotencyIPs.recordKey("ip-1")]
+		record.Created = record.Created.Add(-IdempotencyRetention)
+		child.idempotencyKeys[idempotencyIPs.recordKey("ip-1")] = record
+		_, err = ipam.storage.UpdatePrefix(ctx, *child, defaultNamespace)
+		require.NoError(t, err)
+		ipagain, err = ipam.AcquireIP(ctx, child.Cidr, AcquireWithIdempotencyKey("ip-1"))
+		require.NoError(t, err)
+		require.NotEqual(t, ip.IP, ipagain.IP)
+	})
+}
+
+func TestIpamer_IdempotencyKindsAndReleasedResults(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, "10.15.0.0/16", WithIdempotencyKey("shared"))
+		require.NoError(t, err)
+
+		// the key of the created prefix is not returned as child prefix
+		child, err := ipam.AcquireChildPrefix(ctx, p.Cidr, 24, WithIdempotencyKey("shared"))
+		require.NoError(t, err)
+		require.Equal(t, "10.15.0.0/24", child.Cidr)
+		require.Empty(t, child.idempotencyKeys)
+
+		// neither the key of the acquired child prefixes nor the key of the created prefix is returned as ip
+		ip, err := ipam.AcquireIP(ctx, child.Cidr, AcquireWithIdempotencyKey("shared"))
+		require.NoError(t, err)
+		require.Equal(t, "10.15.0.1", ip.IP.String())
+		again, err := ipam.AcquireChildPrefix(ctx, p.Cidr, 24, WithIdempotencyKey("shared"))
+		require.NoError(t, err)
+		require.Equal(t, child.Cidr, again.Cidr)
+
+		// a released ip is not returned again, the request acquires a new one
+		err = ipam.ReleaseIPFromPrefix(ctx, child.Cidr, ip.IP.String())
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(ctx, child.Cidr)
+		require.NoError(t, err)
+		ipagain, err := ipam.AcquireIP(ctx, child.Cidr, AcquireWithIdempotencyKey("shared"))
+		require.NoError(t, err)
+		require.Equal(t, "10.15.0.2", ipagain.IP.String())
+
+		// a released child prefix is not returned again
+		_, err = ipam.DeletePrefix(ctx, child.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+		other, err := ipam.AcquireChildPrefix(ctx, p.Cidr, 24, WithIdempotencyKey("shared"))
+		require.NoError(t, err)
+		require.Equal(t, "10.15.0.0/24", other.Cidr)
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), p.Usage().AcquiredPrefixes)
+
+		_, err = ipam.DeletePrefix(ctx, p.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+	})
+}
+
+func TestIpamer_PrefixTree(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		root, err := ipam.NewPrefix(ctx, "10.15.0.0/16")
+		require.NoError(t, err)
+		_, err = ipam.NewPrefix(ctx, "10.16.0.0/16")
+		require.NoError(t, err)
+		second, err := ipam.AcquireSpecificChildPrefix(ctx, root.Cidr, "10.15.1.0/24")
+		require.NoError(t, err)
+		first, err := ipam.AcquireSpecificChildPrefix(ctx, root.Cidr, "10.15.0.0/24")
+		require.NoError(t, err)
+		grandchild, err := ipam.AcquireChildPrefix(ctx, first.Cidr, 28)
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(ctx, grandchild.Cidr)
+		require.NoError(t, err)
+
+		tree, err := ipam.PrefixTree(ctx, "", 0)
+		require.NoError(t, err)
+		require.Len(t, tree, 2)
+		require.Equal(t, "10.15.0.0/16", tree[0].Prefix.Cidr)
+		require.Equal(t, "10.16.0.0/16", tree[1].Prefix.Cidr)
+		require.Empty(t, tree[1].Children)
+		require.Equal(t, uint64(2), tree[0].Usage.AcquiredPrefixes)
+		require.Len(t, tree[0].Children, 2)
+		require.Equal(t, first.Cidr, tree[0].Children[0].Prefix.Cidr)
+		require.Equal(t, second.Cidr, tree[0].Children[1].Prefix.Cidr)
+		require.Len(t, tree[0].Children[0].Children, 1)
+		require.Equal(t, grandchild.Cidr, tree[0].Children[0].Children[0].Prefix.Cidr)
+		require.Equal(t, uint64(1), tree[0].Children[0].Children[0].Usage.AcquiredIPs)
+
+		tree, err = ipam.PrefixTree(ctx, first.Cidr, 0)
+		require.NoError(t, err)
+		require.Len(t, tree, 1)
+		require.Equal(t, first.Cidr, tree[0].Prefix.Cidr)
+		require.Len(t, tree[0].Children, 1)
+
+		tree, err = ipam.PrefixTree(ctx, root.Cidr, 1)
+		require.NoError(t, err)
+		require.Len(t, tree, 1)
+		require.Len(t, tree[0].Children, 2)
+		require.Empty(t, tree[0].Children[0].Children)
+
+		_, err = ipam.PrefixTree(ctx, "10.17.0.0/16", 0)
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = ipam.PrefixTree(ctx, root.Cidr, -1)
+		require.EqualError(t, err, "depth:-1 must not be negative")
+	})
+}
+
+func TestIpamer_ResizePrefix(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, "10.20.0.0/25", WithLabels(map[string]string{"site": "fra1"}))
+		require.NoError(t, err)
+		_, err = ipam.NewPrefix(ctx, "10.20.1.0/24")
+		require.NoError(t, err)
+		ip, err := ipam.AcquireIP(ctx, p.Cidr, AcquireWithAnnotations(map[string]string{"owner": "tenant-a"}), AcquireWithTTL(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, "10.20.0.1", ip.IP.String())
+
+		// growing keeps all allocations and moves the default reservations
+		grown, err := ipam.ResizePrefix(ctx, p.Cidr, 24)
+		require.NoError(t, err)
+		require.Equal(t, "10.20.0.0/24", grown.Cidr)
+		require.Equal(t, map[string]string{"site": "fra1"}, grown.Labels)
+		require.Equal(t, []string{"10.20.0.0", "10.20.0.255"}, grown.ReservedIPs())
+		require.Equal(t, uint64(1), grown.Usage().AcquiredIPs)
+		_, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.ErrorIs(t, err, ErrNotFound)
+		acquired, err := ipam.GetIP(ctx, grown.Cidr, "10.20.0.1")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"owner": "tenant-a"}, acquired.Annotations)
+		require.NotNil(t, acquired.Lease)
+		require.Equal(t, grown.Cidr, acquired.Lease.ParentPrefix)
+		lookup, err := ipam.LookupIP(ctx, "10.20.0.200")
+		require.NoError(t, err)
+		require.Equal(t, grown.Cidr, lookup.Prefixes[0].Cidr)
+
+		_, err = ipam.ResizePrefix(ctx, grown.Cidr, 23)
+		require.EqualError(t, err, "10.20.0.0/23 overlaps 10.20.1.0/24")
+		_, err = ipam.ResizePrefix(ctx, grown.Cidr, 33)
+		require.EqualError(t, err, "given length:33 must not be greater than 32")
+		_, err = ipam.ResizePrefix(ctx, "10.20.1.0/24", 23)
+		require.EqualError(t, err, "prefix:10.20.1.0/24 can not grow to length:23, the network address would change to 10.20.0.0/23")
+		same, err := ipam.ResizePrefix(ctx, grown.Cidr, 24)
+		require.NoError(t, err)
+		require.Equal(t, grown.Cidr, same.Cidr)
+
+		// shrinking must keep all acquired ips
+		_, err = ipam.AcquireSpecificIP(ctx, grown.Cidr, "10.20.0.130")
+		require.NoError(t, err)
+		_, err = ipam.ResizePrefix(ctx, grown.Cidr, 25)
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+		err = ipam.ReleaseIPFromPrefix(ctx, grown.Cidr, "10.20.0.130")
+		require.NoError(t, err)
+		shrunk, err := ipam.ResizePrefix(ctx, grown.Cidr, 25)
+		require.NoError(t, err)
+		require.Equal(t, "10.20.0.0/25", shrunk.Cidr)
+		require.Equal(t, []string{"10.20.0.0", "10.20.0.127"}, shrunk.ReservedIPs())
+		_, err = ipam.PrefixFrom(ctx, grown.Cidr)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestIpamer_ResizeChildPrefix(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		parent, err := ipam.NewPrefix(ctx, "10.21.0.0/16")
+		require.NoError(t, err)
+		child, err := ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, "10.21.0.0/24")
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, "10.21.2.0/24")
+		require.NoError(t, err)
+		grandchild, err := ipam.AcquireSpecificChildPrefix(ctx, child.Cidr, "10.21.0.64/26", WithTTL(time.Hour))
+		require.NoError(t, err)
+
+		// the child must not overlap its siblings and must fit into the parent
+		_, err = ipam.ResizePrefix(ctx, child.Cidr, 22)
+		require.EqualError(t, err, "10.21.0.0/22 overlaps 10.21.2.0/24")
+		_, err = ipam.ResizePrefix(ctx, child.Cidr, 16)
+		require.EqualError(t, err, "prefix:10.21.0.0/16 does not fit into parent prefix:10.21.0.0/16")
+		_, err = ipam.ResizePrefix(ctx, child.Cidr, 26)
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+
+		grown, err := ipam.ResizePrefix(ctx, child.Cidr, 23)
+		require.NoError(t, err)
+		require.Equal(t, "10.21.0.0/23", grown.Cidr)
+		require.Equal(t, parent.Cidr, grown.ParentCidr)
+
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{"10.21.0.0/23": false, "10.21.2.0/24": false}, parent.availableChildPrefixes)
+
+		grandchild, err = ipam.PrefixFrom(ctx, grandchild.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, grown.Cidr, grandchild.ParentCidr)
+		require.Equal(t, grown.Cidr, grandchild.Lease().ParentPrefix)
+
+		// the moved links are consistent, the grandchild and the child can be released again
+		err = ipam.ReleaseChildPrefix(ctx, grandchild)
+		require.NoError(t, err)
+		err = ipam.ReleaseChildPrefix(ctx, grown)
+		require.NoError(t, err)
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), parent.Usage().AcquiredPrefixes)
+	})
+}
+
+func TestIpamer_SplitPrefix(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, "10.22.0.0/24", WithLabels(map[string]string{"site": "fra1"}))
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.22.0.10", AcquireWithAnnotations(map[string]string{"owner": "tenant-a"}))
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.22.0.200", AcquireWithTTL(time.Hour))
+		require.NoError(t, err)
+
+		_, err = ipam.SplitPrefix(ctx, p.Cidr, 24)
+		require.EqualError(t, err, "given length:24 must be greater than prefix length:24")
+		_, err = ipam.SplitPrefix(ctx, p.Cidr, 33)
+		require.EqualError(t, err, "given length:33 must not be greater than 32")
+		// the last address of the first half is acquired and can not become its broadcast address
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.22.0.127")
+		require.NoError(t, err)
+		_, err = ipam.SplitPrefix(ctx, p.Cidr, 25)
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+		require.EqualError(t, err, "AlreadyAllocatedError: ip:10.22.0.127 is acquired and can not become the network or broadcast address of prefix:10.22.0.0/25")
+		err = ipam.ReleaseIPFromPrefix(ctx, p.Cidr, "10.22.0.127")
+		require.NoError(t, err)
+		// the first address of the second half is acquired and can not become its network address
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.22.0.128")
+		require.NoError(t, err)
+		_, err = ipam.SplitPrefix(ctx, p.Cidr, 25)
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+		require.EqualError(t, err, "AlreadyAllocatedError: ip:10.22.0.128 is acquired and can not become the network or broadcast address of prefix:10.22.0.128/25")
+		err = ipam.ReleaseIPFromPrefix(ctx, p.Cidr, "10.22.0.128")
+		require.NoError(t, err)
+		_, err = ipam.PrefixFrom(ctx, "10.22.0.128/25")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		parts, err := ipam.SplitPrefix(ctx, p.Cidr, 25)
+		require.NoError(t, err)
+		require.Len(t, parts, 2)
+		require.Equal(t, "10.22.0.0/25", parts[0].Cidr)
+		require.Equal(t, "10.22.0.128/25", parts[1].Cidr)
+		require.Equal(t, map[string]string{"site": "fra1"}, parts[1].Labels)
+		require.Equal(t, []string{"10.22.0.128", "10.22.0.255"}, parts[1].ReservedIPs())
+		require.Equal(t, uint64(1), parts[0].Usage().AcquiredIPs)
+		require.Equal(t, uint64(1), parts[1].Usage().AcquiredIPs)
+		_, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		ip, err := ipam.GetIP(ctx, parts[0].Cidr, "10.22.0.10")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"owner": "tenant-a"}, ip.Annotations)
+		ip, err = ipam.GetIP(ctx, parts[1].Cidr, "10.22.0.200")
+		require.NoError(t, err)
+		require.NotNil(t, ip.Lease)
+		require.Equal(t, parts[1].Cidr, ip.Lease.ParentPrefix)
+		_, err = ipam.GetIP(ctx, parts[1].Cidr, "10.22.0.10")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// merging the halves restores the original prefix
+		merged, err := ipam.MergePrefixes(ctx, parts[0].Cidr, parts[1].Cidr)
+		require.NoError(t, err)
+		require.Equal(t, p.Cidr, merged.Cidr)
+		require.Equal(t, []string{"10.22.0.0", "10.22.0.255"}, merged.ReservedIPs())
+		require.Equal(t, uint64(2), merged.Usage().AcquiredIPs)
+		ip, err = ipam.GetIP(ctx, merged.Cidr, "10.22.0.200")
+		require.NoError(t, err)
+		require.Equal(t, merged.Cidr, ip.Lease.ParentPrefix)
+		_, err = ipam.PrefixFrom(ctx, parts[0].Cidr)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestIpamer_SplitAndMergeChildPrefixes(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		parent, err := ipam.NewPrefix(ctx, "10.23.0.0/16")
+		require.NoError(t, err)
+		child, err := ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, "10.23.0.0/23")
+		require.NoError(t, err)
+		grandchild, err := ipam.AcquireSpecificChildPrefix(ctx, child.Cidr, "10.23.1.0/26")
+		require.NoError(t, err)
+		sibling, err := ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, "10.23.3.0/24")
+		require.NoError(t, err)
+
+		_, err = ipam.SplitPrefix(ctx, child.Cidr, 27)
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+		parts, err := ipam.SplitPrefix(ctx, child.Cidr, 24)
+		require.NoError(t, err)
+		require.Len(t, parts, 2)
+		require.Equal(t, parent.Cidr, parts[0].ParentCidr)
+
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{"10.23.0.0/24": false, "10.23.1.0/24": false, "10.23.3.0/24": false}, parent.availableChildPrefixes)
+		grandchild, err = ipam.PrefixFrom(ctx, grandchild.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.23.1.0/24", grandchild.ParentCidr)
+
+		_, err = ipam.MergePrefixes(ctx, parts[0].Cidr)
+		require.EqualError(t, err, "at least two prefixes are required for a merge, got 1")
+		_, err = ipam.MergePrefixes(ctx, parts[1].Cidr, sibling.Cidr)
+		require.EqualError(t, err, "prefixes 10.23.1.0/24,10.23.3.0/24 are not adjacent and do not form a single supernet")
+		_, err = ipam.MergePrefixes(ctx, parts[1].Cidr, grandchild.Cidr)
+		require.EqualError(t, err, "10.23.1.0/26 overlaps 10.23.1.0/24")
+		_, err = ipam.MergePrefixes(ctx, parts[0].Cidr, "10.22.0.0/24")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = ipam.MergePrefixes(ctx, parts[0].Cidr, "10.23.2.0/24")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		merged, err := ipam.MergePrefixes(ctx, parts[1].Cidr, parts[0].Cidr)
+		require.NoError(t, err)
+		require.Equal(t, child.Cidr, merged.Cidr)
+		require.Equal(t, parent.Cidr, merged.ParentCidr)
+		grandchild, err = ipam.PrefixFrom(ctx, grandchild.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, child.Cidr, grandchild.ParentCidr)
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{"10.23.0.0/23": false, "10.23.3.0/24": false}, parent.availableChildPrefixes)
+
+		// the merged prefix must not overlap other siblings
+		_, err = ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, "10.23.2.0/24")
+		require.NoError(t, err)
+		_, err = ipam.MergePrefixes(ctx, "10.23.2.0/24", sibling.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.MergePrefixes(ctx, "10.23.2.0/23", merged.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.PrefixFrom(ctx, "10.23.0.0/22")
+		require.NoError(t, err)
+	})
+}
+
+func TestIpamer_LookupIP(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		root, err := ipam.NewPrefix(ctx, "10.18.0.0/16")
+		require.NoError(t, err)
+		child, err := ipam.AcquireChildPrefix(ctx, root.Cidr, 24)
+		require.NoError(t, err)
+		_, err = ipam.EditPrefix(ctx, child.Cidr, WithExclusions("10.18.0.200-10.18.0.210"))
+		require.NoError(t, err)
+		ip, err := ipam.AcquireIP(ctx, child.Cidr, AcquireWithAnnotations(map[string]string{"owner": "a"}))
+		require.NoError(t, err)
+
+		lookup, err := ipam.LookupIP(ctx, ip.IP.String())
+		require.NoError(t, err)
+		require.Equal(t, IPAcquired, lookup.State)
+		require.Equal(t, *ip, lookup.IP)
+		require.Len(t, lookup.Prefixes, 2)
+		require.Equal(t, root.Cidr, lookup.Prefixes[0].Cidr)
+		require.Equal(t, child.Cidr, lookup.Prefixes[1].Cidr)
+
+		lookup, err = ipam.LookupIP(ctx, "10.18.0.2")
+		require.NoError(t, err)
+		require.Equal(t, IPFree, lookup.State)
+		require.Equal(t, child.Cidr, lookup.IP.ParentPrefix)
+		lookup, err = ipam.LookupIP(ctx, "10.18.0.0")
+		require.NoError(t, err)
+		require.Equal(t, IPReserved, lookup.State)
+		lookup, err = ipam.LookupIP(ctx, "10.18.0.205")
+		require.NoError(t, err)
+		require.Equal(t, IPExcluded, lookup.State)
+
+		lookup, err = ipam.LookupIP(ctx, "10.18.1.1")
+		require.NoError(t, err)
+		require.Len(t, lookup.Prefixes, 1)
+		require.Equal(t, root.Cidr, lookup.IP.ParentPrefix)
+
+		_, err = ipam.LookupIP(ctx, "10.19.0.1")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = ipam.LookupIP(ctx, "10.19.0")
+		require.EqualError(t, err, "given ip:10.19.0 in not valid")
+
+		// changes of another ipamer sharing the storage are found as well
+		other := &ipamer{storage: ipam.storage}
+		grandchild, err := other.AcquireChildPrefix(ctx, root.Cidr, 28)
+		require.NoError(t, err)
+		lookup, err = ipam.LookupIP(ctx, "10.18.1.1")
+		require.NoError(t, err)
+		require.Len(t, lookup.Prefixes, 2)
+		require.Equal(t, grandchild.Cidr, lookup.IP.ParentPrefix)
+		err = other.ReleaseChildPrefix(ctx, grandchild)
+		require.NoError(t, err)
+		lookup, err = ipam.LookupIP(ctx, "10.18.1.1")
+		require.NoError(t, err)
+		require.Len(t, lookup.Prefixes, 1)
+		_, err = ipam.LookupIP(ctx, "10.19.0.1")
+		require.ErrorIs(t, err, ErrNotFound)
+		created, err := other.NewPrefix(ctx, "10.19.0.0/24")
+		require.NoError(t, err)
+		lookup, err = ipam.LookupIP(ctx, "10.19.0.1")
+		require.NoError(t, err)
+		require.Equal(t, created.Cidr, lookup.IP.ParentPrefix)
+		_, err = other.DeletePrefix(ctx, created.Cidr)
+		require.NoError(t, err)
+
+		err = ipam.ReleaseIPFromPrefix(ctx, child.Cidr, ip.IP.String())
+		require.NoError(t, err)
+		err = ipam.ReleaseChildPrefix(ctx, child)
+		require.NoError(t, err)
+		lookup, err = ipam.LookupIP(ctx, ip.IP.String())
+		require.NoError(t, err)
+		require.Len(t, lookup.Prefixes, 1)
+		require.Equal(t, IPFree, lookup.State)
+	})
+}
+
+func TestPrefixTrie(t *testing.T) {
+	trie := newPrefixTrie()
+	for _, cidr := range []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "2001:db8::/32", "0.0.0.0/0"} {
+		trie.insert(netip.MustParsePrefix(cidr))
+	}
+	require.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/0"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("10.1.0.0/16"),
+		netip.MustParsePrefix("10.1.2.0/24"),
+	}, trie.lookup(netip.MustParseAddr("10.1.2.3")))
+	require.Equal(t, []netip.Prefix{netip.MustParsePrefix("2001:db8::/32")}, trie.lookup(netip.MustParseAddr("2001:db8::1")))
+	require.Empty(t, trie.lookup(netip.MustParseAddr("2001:db9::1")))
+
+	trie.remove(netip.MustParsePrefix("10.1.0.0/16"))
+	trie.remove(netip.MustParsePrefix("10.1.2.0/24"))
+	require.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/0"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+	}, trie.lookup(netip.MustParseAddr("10.1.2.3")))
+}
+
+func TestIpamer_AllocationStrategies(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		acquire := func(cidr string, opts ...AcquireOption) string {
+			ip, err := ipam.AcquireIP(ctx, cidr, opts...)
+			require.NoError(t, err)
+			return ip.IP.String()
+		}
+
+		// first free hands out a released ip again
+		p, err := ipam.NewPrefix(ctx, "10.0.0.0/29")
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.1", acquire(p.Cidr))
+		require.Equal(t, "10.0.0.2", acquire(p.Cidr))
+		require.NoError(t, ipam.ReleaseIPFromPrefix(ctx, p.Cidr, "10.0.0.1"))
+		require.Equal(t, "10.0.0.1", acquire(p.Cidr))
+
+		// next after last continues after the last acquired ip and wraps around
+		p, err = ipam.NewPrefix(ctx, "10.0.1.0/29", WithAllocationStrategy(NextAfterLast))
+		require.NoError(t, err)
+		require.Equal(t, NextAfterLast, p.AllocationStrategy)
+		require.Equal(t, "10.0.1.1", acquire(p.Cidr))
+		require.Equal(t, "10.0.1.2", acquire(p.Cidr))
+		require.NoError(t, ipam.ReleaseIPFromPrefix(ctx, p.Cidr, "10.0.1.1"))
+		require.Equal(t, "10.0.1.3", acquire(p.Cidr))
+		_, err = ipam.AcquireSpecificIP(ctx, p.Cidr, "10.0.1.5")
+		require.NoError(t, err)
+		require.Equal(t, "10.0.1.6", acquire(p.Cidr))
+		require.Equal(t, "10.0.1.1", acquire(p.Cidr))
+		require.Equal(t, "10.0.1.4", acquire(p.Cidr))
+		_, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+
+		// last free starts at the highest address which is not reserved
+		p, err = ipam.NewPrefix(ctx, "10.0.2.0/29", WithAllocationStrategy(LastFree))
+		require.NoError(t, err)
+		require.Equal(t, "10.0.2.6", acquire(p.Cidr))
+		require.Equal(t, "10.0.2.5", acquire(p.Cidr))
+		// the strategy can be overridden per call
+		require.Equal(t, "10.0.2.1", acquire(p.Cidr, AcquireWithStrategy(FirstFree)))
+
+		// random stays within the prefix and acquires every ip exactly once
+		p, err = ipam.NewPrefix(ctx, "10.0.3.0/26", WithAllocationStrategy(Random))
+		require.NoError(t, err)
+		seen := map[string]bool{}
+		for range 62 {
+			ip := acquire(p.Cidr)
+			require.False(t, seen[ip], "ip:%s acquired twice", ip)
+			seen[ip] = true
+		}
+		require.False(t, seen["10.0.3.0"])
+		require.False(t, seen["10.0.3.63"])
+		_, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+
+		// random in huge prefixes
+		p, err = ipam.NewPrefix(ctx, "2001:db8::/64", WithAllocationStrategy(Random))
+		require.NoError(t, err)
+		ip, err := ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.True(t, netip.MustParsePrefix(p.Cidr).Contains(ip.IP))
+
+		// the strategy is persisted and editable
+		p, err = ipam.EditPrefix(ctx, "10.0.0.0/29", WithAllocationStrategy(LastFree))
+		require.NoError(t, err)
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, LastFree, p.AllocationStrategy)
+		require.Equal(t, "10.0.0.6", acquire(p.Cidr))
+
+		_, err = ipam.NewPrefix(ctx, "10.0.4.0/29", WithAllocationStrategy("round-robin"))
+		require.EqualError(t, err, `unknown allocation strategy:"round-robin", supported are [first-free last-free next-after-last random]`)
+		_, err = ipam.AcquireIP(ctx, "10.0.0.0/29", AcquireWithStrategy("round-robin"))
+		require.Error(t, err)
+	})
+}
+
+func TestIpamer_DeletePrefix(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		// IPv4
+		prefix, err := ipam.NewPrefix(ctx, "192.168.0.0/20")
+		require.NoError(t, err)
+		s, _ := prefix.availablePrefixes()
+		require.Equal(t, uint64(1024), s)
+		require.Equal(t, uint64(0), prefix.acquiredPrefixes())
+		require.Equal(t, uint64(0), prefix.Usage().AcquiredPrefixes)
+
+		ip, err := ipam.AcquireIP(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		require.NotNil(t, ip)
+
+		_, err = ipam.DeletePrefix(ctx, prefix.Cidr)
+		require.Error(t, err)
+		require.Equal(t, "prefix 192.168.0.0/20 has ips, delete prefix not possible", err.Error())
+
+		// IPv6
+		prefix, err = ipam.NewPrefix(ctx, "2001:0db8:85a3::/120")
+		require.NoError(t, err)
+		s, _ = prefix.availablePrefixes()
+		require.Equal(t, uint64(64), s)
+		require.Equal(t, uint64(0), prefix.acquiredPrefixes())
+		require.Equal(t, uint64(0), prefix.Usage().AcquiredPrefixes)
+
+		ip, err = ipam.AcquireIP(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		require.NotNil(t, ip)
+
+		_, err = ipam.DeletePrefix(ctx, prefix.Cidr)
+		require.Error(t, err)
+		require.Equal(t, "prefix 2001:db8:85a3::/120 has ips, delete prefix not possible", err.Error())
+
+		_, err = ipam.ReleaseIP(ctx, ip)
+		require.NoError(t, err)
+		_, err = ipam.DeletePrefix(ctx, prefix.Cidr)
+		require.NoError(t, err)
+	})
+}
+
+func TestIpamer_DeletePrefixRecursive(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		root, err := ipam.NewPrefix(ctx, "10.30.0.0/16")
+		require.NoError(t, err)
+		site, err := ipam.AcquireSpecificChildPrefix(ctx, root.Cidr, "10.30.0.0/20")
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificChildPrefix(ctx, root.Cidr, "10.30.16.0/20")
+		require.NoError(t, err)
+		rack, err := ipam.AcquireSpecificChildPrefix(ctx, site.Cidr, "10.30.1.0/24")
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, rack.Cidr, "10.30.1.1", AcquireWithAnnotations(map[string]string{"host": "a"}))
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, rack.Cidr, "10.30.1.2")
+		require.NoError(t, err)
+		empty, err := ipam.AcquireSpecificChildPrefix(ctx, site.Cidr, "10.30.2.0/24")
+		require.NoError(t, err)
+
+		_, err = ipam.DeletePrefix(ctx, rack.Cidr)
+		require.EqualError(t, err, "prefix 10.30.1.0/24 has ips, delete prefix not possible")
+
+		// a dry run lists descendants before their parents and changes nothing
+		var report DeleteReport
+		deleted, err := ipam.DeletePrefix(ctx, site.Cidr, DeleteRecursive(), DeleteDryRun(), DeleteWithReport(&report))
+		require.NoError(t, err)
+		require.Equal(t, site.Cidr, deleted.Cidr)
+		var cidrs []string
+		for _, p := range report.Prefixes {
+			cidrs = append(cidrs, p.Cidr)
+		}
+		require.Equal(t, []string{rack.Cidr, empty.Cidr, site.Cidr}, cidrs)
+		require.Equal(t, map[string][]string{rack.Cidr: {"10.30.1.1-10.30.1.2"}}, report.IPRanges)
+		require.Len(t, report.IPs, 1)
+		require.Equal(t, "10.30.1.1", report.IPs[0].IP.String())
+		require.Equal(t, map[string]string{"host": "a"}, report.IPs[0].Annotations)
+		_, err = ipam.PrefixFrom(ctx, rack.Cidr)
+		require.NoError(t, err)
+
+		_, err = ipam.DeletePrefix(ctx, empty.Cidr, DeleteDryRun(), DeleteWithReport(&report))
+		require.NoError(t, err)
+		require.Len(t, report.Prefixes, 1)
+		require.Empty(t, report.IPRanges)
+		require.Empty(t, report.IPs)
+		_, err = ipam.PrefixFrom(ctx, empty.Cidr)
+		require.NoError(t, err)
+
+		_, err = ipam.DeletePrefix(ctx, site.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+		for _, cidr := range []string{site.Cidr, rack.Cidr, empty.Cidr} {
+			_, err = ipam.PrefixFrom(ctx, cidr)
+			require.ErrorIs(t, err, ErrNotFound)
+		}
+		root, err = ipam.PrefixFrom(ctx, root.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), root.Usage().AcquiredPrefixes)
+
+		// the released child prefix can be acquired again
+		_, err = ipam.AcquireSpecificChildPrefix(ctx, root.Cidr, site.Cidr)
+		require.NoError(t, err)
+
+		_, err = ipam.DeletePrefix(ctx, root.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+		cidrs, err = ipam.ReadAllPrefixCidrs(ctx)
+		require.NoError(t, err)
+		require.Empty(t, cidrs)
+
+		// the last address of the address space ends the report
+		last, err := ipam.NewPrefix(ctx, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff0/124")
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, last.Cidr, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+		require.NoError(t, err)
+		_, err = ipam.DeletePrefix(ctx, last.Cidr, DeleteRecursive(), DeleteWithReport(&report))
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{last.Cidr: {"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"}}, report.IPRanges)
+		require.Empty(t, report.IPs)
+	})
+}
+
+func TestIpamer_Policy(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		_, err := ipam.NewPrefix(ctx, "10.31.0.0/16", WithPolicy(Policy{MinChildLength: 26, MaxChildLength: 24}))
+		require.EqualError(t, err, "minimum child length:26 must not be greater than maximum child length:24")
+		_, err = ipam.NewPrefix(ctx, "10.31.0.0/16", WithPolicy(Policy{MaxChildLength: 33}))
+		require.EqualError(t, err, "maximum child length:33 must not be greater than 32")
+
+		policy := Policy{MinChildLength: 20, MaxChildLength: 24, MaxChildPrefixes: 3, DenyIPs: true}
+		root, err := ipam.NewPrefix(ctx, "10.31.0.0/16", WithPolicy(policy))
+		require.NoError(t, err)
+		require.Equal(t, &policy, root.Policy)
+
+		_, err = ipam.AcquireIP(ctx, root.Cidr)
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		require.EqualError(t, err, "PolicyViolation: acquisition of ips from prefix:10.31.0.0/16 is denied")
+		_, err = ipam.AcquireChildPrefix(ctx, root.Cidr, 18)
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		require.EqualError(t, err, "PolicyViolation: child prefix length:18 is smaller than the minimum length:20 of prefix:10.31.0.0/16")
+		_, err = ipam.AcquireSpecificChildPrefix(ctx, root.Cidr, "10.31.0.0/25")
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		require.EqualError(t, err, "PolicyViolation: child prefix length:25 is greater than the maximum length:24 of prefix:10.31.0.0/16")
+
+		_, err = ipam.AcquireChildPrefixes(ctx, root.Cidr, 24, 2)
+		require.NoError(t, err)
+		_, err = ipam.AcquireChildPrefixes(ctx, root.Cidr, 24, 2)
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		require.EqualError(t, err, "PolicyViolation: prefix:10.31.0.0/16 has 2 of at most 3 child prefixes, 2 more requested")
+		child, err := ipam.AcquireChildPrefix(ctx, root.Cidr, 20, WithIdempotencyKey("third"))
+		require.NoError(t, err)
+		require.Nil(t, child.Policy)
+		_, err = ipam.AcquireChildPrefix(ctx, root.Cidr, 24)
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		// a retry of the last allowed child returns it instead of a policy violation
+		again, err := ipam.AcquireChildPrefix(ctx, root.Cidr, 20, WithIdempotencyKey("third"))
+		require.NoError(t, err)
+		require.Equal(t, child.Cidr, again.Cidr)
+
+		// released child prefixes do not count
+		err = ipam.ReleaseChildPrefix(ctx, child)
+		require.NoError(t, err)
+		_, err = ipam.AcquireChildPrefix(ctx, root.Cidr, 24)
+		require.NoError(t, err)
+
+		// the zero policy removes all restrictions
+		root, err = ipam.EditPrefix(ctx, root.Cidr, WithPolicy(Policy{}))
+		require.NoError(t, err)
+		require.Nil(t, root.Policy)
+		_, err = ipam.AcquireChildPrefix(ctx, root.Cidr, 28)
+		require.NoError(t, err)
+
+		leaf, err := ipam.NewPrefix(ctx, "10.32.0.0/24", WithPolicy(Policy{DenyIPs: true}))
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, leaf.Cidr, "10.32.0.1")
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		_, err = ipam.AcquireIPRange(ctx, leaf.Cidr, 2)
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		leaf, err = ipam.EditPrefix(ctx, leaf.Cidr, WithPolicy(Policy{MaxChildPrefixes: 1}))
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, leaf.Cidr, "10.32.0.1")
+		require.NoError(t, err)
+
+		data, err := leaf.GobEncode()
+		require.NoError(t, err)
+		decoded := &Prefix{}
+		require.NoError(t, decoded.GobDecode(data))
+		require.Equal(t, leaf.Policy, decoded.Policy)
+	})
+}
+
+// countingStorage counts the reads of quotas and of all prefixes of a namespace.
+type countingStorage struct {
+	Storage
+	quotaReads  int
+	prefixScans int
+}
+
+func (c *countingStorage) ReadNamespaceQuota(ctx context.Context, namespace string) (Quota, error) {
+	c.quotaReads++
+	return c.Storage.ReadNamespaceQuota(ctx, namespace)
+}
+
+func (c *countingStorage) ReadAllPrefixes(ctx context.Context, namespace string) (Prefixes, error) {
+	c.prefixScans++
+	return c.Storage.ReadAllPrefixes(ctx, namespace)
+}
+
+func TestIpamer_NamespaceQuotaCache(t *testing.T) {
+	ctx := t.Context()
+	storage := &countingStorage{Storage: NewMemory(ctx)}
+	ipam := NewWithStorage(storage)
+
+	p, err := ipam.NewPrefix(ctx, "10.43.0.0/24")
+	require.NoError(t, err)
+	for range 5 {
+		_, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+	}
+	// without a quota the usage is never counted and the quota is read once
+	require.Equal(t, 1, storage.quotaReads)
+	require.Zero(t, storage.prefixScans)
+
+	err = ipam.SetNamespaceQuota(ctx, defaultNamespace, Quota{MaxPrefixes: 5})
+	require.NoError(t, err)
+	for range 5 {
+		_, err = ipam.AcquireIP(ctx, p.Cidr)
+		require.NoError(t, err)
+	}
+	// the quota does not limit ips
+	require.Equal(t, 2, storage.quotaReads)
+	require.Zero(t, storage.prefixScans)
+
+	err = ipam.SetNamespaceQuota(ctx, defaultNamespace, Quota{MaxIPs: 12})
+	require.NoError(t, err)
+	ip, err := ipam.AcquireIP(ctx, p.Cidr)
+	require.NoError(t, err)
+	_, err = ipam.AcquireIP(ctx, p.Cidr)
+	require.NoError(t, err)
+	require.Equal(t, 3, storage.quotaReads)
+	require.Equal(t, 1, storage.prefixScans)
+	_, err = ipam.AcquireIP(ctx, p.Cidr)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	require.Equal(t, 2, storage.prefixScans)
+
+	// the cached usage does not know about the release, it is counted again before rejecting
+	err = ipam.ReleaseIPFromPrefix(ctx, p.Cidr, ip.IP.String())
+	require.NoError(t, err)
+	_, err = ipam.AcquireIP(ctx, p.Cidr)
+	require.NoError(t, err)
+	require.Equal(t, 3, storage.prefixScans)
+}
+
+func TestIpamer_NamespaceQuota(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		namespace := "quotans"
+		quota := Quota{MaxPrefixes: 2, MaxIPs: 3, MaxAddressSpace: big.NewInt(512)}
+		err := ipam.SetNamespaceQuota(ctx, namespace, quota)
+		require.ErrorIs(t, err, ErrNamespaceDoesNotExist)
+		// quotas and pools are stored next to the prefixes of the namespaces in some storages
+		err = ipam.CreateNamespace(ctx, quotaKey)
+		require.EqualError(t, err, "namespace:@quotas must not start with \"@\", it is reserved")
+		err = ipam.CreateNamespace(ctx, namespace)
+		require.NoError(t, err)
+		err = ipam.SetNamespaceQuota(ctx, namespace, Quota{MaxAddressSpace: big.NewInt(-1)})
+		require.EqualError(t, err, "max address space:-1 must not be negative")
+		err = ipam.SetNamespaceQuota(ctx, namespace, quota)
+		require.NoError(t, err)
+
+		nsCtx := NewContextWithNamespace(ctx, namespace)
+		p1, err := ipam.NewPrefix(nsCtx, "10.40.0.0/24")
+		require.NoError(t, err)
+		_, err = ipam.NewPrefix(nsCtx, "10.41.0.0/23")
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		require.EqualError(t, err, "QuotaExceeded: namespace:quotans has 256 of at most 512 addresses, 512 more requested")
+		p2, err := ipam.NewPrefix(nsCtx, "10.41.0.0/24")
+		require.NoError(t, err)
+		_, err = ipam.NewPrefix(nsCtx, "10.42.0.0/30")
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		require.EqualError(t, err, "QuotaExceeded: namespace:quotans has 2 of at most 2 prefixes, 1 more requested")
+		_, err = ipam.ResizePrefix(nsCtx, p2.Cidr, 23)
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		_, err = ipam.SplitPrefix(nsCtx, p2.Cidr, 25)
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+
+		// child prefixes are part of the address space of their parent
+		child, err := ipam.AcquireChildPrefix(nsCtx, p1.Cidr, 26)
+		require.NoError(t, err)
+
+		_, err = ipam.AcquireIPs(nsCtx, p2.Cidr, 2)
+		require.NoError(t, err)
+		_, err = ipam.AcquireIPs(nsCtx, p2.Cidr, 2)
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		require.EqualError(t, err, "QuotaExceeded: namespace:quotans has 2 of at most 3 ips, 2 more requested")
+		_, err = ipam.AcquireIP(nsCtx, child.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(nsCtx, p2.Cidr)
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		_, err = ipam.AcquireSpecificIP(nsCtx, p2.Cidr, "10.41.0.100")
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		_, err = ipam.AcquireIPRange(nsCtx, p2.Cidr, 1)
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+
+		usage, err := ipam.GetNamespaceQuota(ctx, namespace)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), usage.Prefixes)
+		require.Equal(t, uint64(3), usage.IPs)
+		require.Equal(t, "512", usage.AddressSpace.String())
+		require.Equal(t, quota.MaxPrefixes, usage.Quota.MaxPrefixes)
+		require.Equal(t, quota.MaxIPs, usage.Quota.MaxIPs)
+		require.Equal(t, "512", usage.Quota.MaxAddressSpace.String())
+
+		// the zero quota removes all limits
+		err = ipam.SetNamespaceQuota(ctx, namespace, Quota{})
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(nsCtx, p2.Cidr)
+		require.NoError(t, err)
+		usage, err = ipam.GetNamespaceQuota(ctx, namespace)
+		require.NoError(t, err)
+		require.Equal(t, Quota{}, usage.Quota)
+		require.Equal(t, uint64(4), usage.IPs)
+
+		err = ipam.SetNamespaceQuota(ctx, namespace, quota)
+		require.NoError(t, err)
+		_, err = ipam.DeletePrefix(nsCtx, p1.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+		_, err = ipam.DeletePrefix(nsCtx, p2.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+		err = ipam.DeleteNamespace(ctx, namespace)
+		require.NoError(t, err)
+		_, err = ipam.GetNamespaceQuota(ctx, namespace)
+		require.ErrorIs(t, err, ErrNamespaceDoesNotExist)
+	})
+}
+
+func TestIpamer_Pools(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p1, err := ipam.NewPrefix(ctx, "10.50.0.0/30")
+		require.NoError(t, err)
+		p2, err := ipam.NewPrefix(ctx, "10.51.0.0/29")
+		require.NoError(t, err)
+		p3, err := ipam.NewPrefix(ctx, "10.52.0.0/29")
+		require.NoError(t, err)
+
+		_, err = ipam.CreatePool(ctx, "public", "round-robin", p1.Cidr)
+		require.EqualError(t, err, "unknown pool strategy:\"round-robin\", supported are [fill-first spread]")
+		_, err = ipam.CreatePool(ctx, "public", FillFirst)
+		require.EqualError(t, err, "pool:public must have at least one prefix")
+		_, err = ipam.CreatePool(ctx, "public", FillFirst, p1.Cidr, "10.53.0.0/29")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = ipam.CreatePool(ctx, "public", FillFirst, p1.Cidr, p1.Cidr)
+		require.EqualError(t, err, "prefix:10.50.0.0/30 is given more than once")
+
+		pool, err := ipam.CreatePool(ctx, "public", FillFirst, p1.Cidr, p2.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, &Pool{Name: "public", Prefixes: []string{p1.Cidr, p2.Cidr}, Strategy: FillFirst}, pool)
+		_, err = ipam.CreatePool(ctx, "public", FillFirst, p3.Cidr)
+		require.EqualError(t, err, "pool:public already exists")
+
+		// fill first falls through to the next prefix if the first one is exhausted
+		for _, expected := range []string{"10.50.0.1", "10.50.0.2", "10.51.0.1"} {
+			ip, err := ipam.AcquireIPFromPool(ctx, "public")
+			require.NoError(t, err)
+			require.Equal(t, expected, ip.IP.String())
+		}
+
+		// spread prefers the least utilized prefix
+		_, err = ipam.UpdatePool(ctx, "unknown", Spread, p3.Cidr)
+		require.ErrorIs(t, err, ErrNotFound)
+		pool, err = ipam.UpdatePool(ctx, "public", Spread, p2.Cidr, p3.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, Spread, pool.Strategy)
+		ip, err := ipam.AcquireIPFromPool(ctx, "public")
+		require.NoError(t, err)
+		require.Equal(t, "10.52.0.1", ip.IP.String())
+
+		// a repeated request returns the original ip even if another prefix is less utilized now
+		ip, err = ipam.AcquireIPFromPool(ctx, "public", AcquireWithIdempotencyKey("request-1"))
+		require.NoError(t, err)
+		require.Equal(t, "10.51.0.2", ip.IP.String())
+		_, err = ipam.AcquireIPs(ctx, p2.Cidr, 2)
+		require.NoError(t, err)
+		repeated, err := ipam.AcquireIPFromPool(ctx, "public", AcquireWithIdempotencyKey("request-1"))
+		require.NoError(t, err)
+		require.Equal(t, ip.IP, repeated.IP)
+
+		_, err = ipam.UpdatePool(ctx, "public", FillFirst, p1.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.AcquireIPFromPool(ctx, "public")
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+		require.EqualError(t, err, "NoIPAvailableError: no ip left in any prefix of pool:public")
+
+		parent1, err := ipam.NewPrefix(ctx, "10.60.0.0/24")
+		require.NoError(t, err)
+		parent2, err := ipam.NewPrefix(ctx, "10.61.0.0/24")
+		require.NoError(t, err)
+		_, err = ipam.CreatePool(ctx, "networks", FillFirst, parent1.Cidr, parent2.Cidr)
+		require.NoError(t, err)
+		var children []string
+		for range 4 {
+			child, err := ipam.AcquireChildPrefixFromPool(ctx, "networks", 25)
+			require.NoError(t, err)
+			children = append(children, child.Cidr)
+		}
+		require.Equal(t, []string{"10.60.0.0/25", "10.60.0.128/25", "10.61.0.0/25", "10.61.0.128/25"}, children)
+		_, err = ipam.AcquireChildPrefixFromPool(ctx, "networks", 25)
+		require.ErrorIs(t, err, ErrNoPrefixAvailable)
+		require.EqualError(t, err, "NoPrefixAvailableError: no prefix with length:25 left in any prefix of pool:networks")
+
+		pools, err := ipam.ListPools(ctx)
+		require.NoError(t, err)
+		require.Len(t, pools, 2)
+		require.Equal(t, "networks", pools[0].Name)
+		require.Equal(t, "public", pools[1].Name)
+
+		_, err = ipam.AcquireIPFromPool(ctx, "unknown")
+		require.ErrorIs(t, err, ErrNotFound)
+		for _, name := range []string{"networks", "public"} {
+			err = ipam.DeletePool(ctx, name)
+			require.NoError(t, err)
+		}
+		_, err = ipam.GetPool(ctx, "public")
+		require.ErrorIs(t, err, ErrNotFound)
+		err = ipam.DeletePool(ctx, "public")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestIpamer_PoolMemberFallthrough(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		parent, err := ipam.NewPrefix(ctx, "10.54.0.0/24")
+		require.NoError(t, err)
+		_, err = ipam.AcquireChildPrefix(ctx, parent.Cidr, 25)
+		require.NoError(t, err)
+		denied, err := ipam.NewPrefix(ctx, "10.55.0.0/24", WithPolicy(Policy{DenyIPs: true, MaxChildPrefixes: 1}))
+		require.NoError(t, err)
+		usable, err := ipam.NewPrefix(ctx, "10.56.0.0/24")
+		require.NoError(t, err)
+
+		// the parent prefix and the prefix which denies ips are skipped
+		_, err = ipam.CreatePool(ctx, "ips", FillFirst, parent.Cidr, denied.Cidr, usable.Cidr)
+		require.NoError(t, err)
+		ip, err := ipam.AcquireIPFromPool(ctx, "ips")
+		require.NoError(t, err)
+		require.Equal(t, "10.56.0.1", ip.IP.String())
+
+		// the prefix with the maximum number of child prefixes is skipped once it is reached
+		_, err = ipam.CreatePool(ctx, "networks", FillFirst, denied.Cidr, parent.Cidr)
+		require.NoError(t, err)
+		var children []string
+		for range 2 {
+			child, err := ipam.AcquireChildPrefixFromPool(ctx, "networks", 26)
+			require.NoError(t, err)
+			children = append(children, child.Cidr)
+		}
+		require.Equal(t, []string{"10.55.0.0/26", "10.54.0.128/26"}, children)
+
+		_, err = ipam.UpdatePool(ctx, "ips", FillFirst, parent.Cidr, denied.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.AcquireIPFromPool(ctx, "ips")
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+
+		for _, name := range []string{"ips", "networks"} {
+			err = ipam.DeletePool(ctx, name)
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestIpamer_ReplacePoolMembers(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		other, err := ipam.NewPrefix(ctx, "10.57.0.0/24")
+		require.NoError(t, err)
+		p, err := ipam.NewPrefix(ctx, "10.58.0.0/24")
+		require.NoError(t, err)
+		_, err = ipam.CreatePool(ctx, "public", FillFirst, p.Cidr, other.Cidr)
+		require.NoError(t, err)
+
+		// the parts take the position of the split member
+		_, err = ipam.SplitPrefix(ctx, p.Cidr, 25)
+		require.NoError(t, err)
+		pool, err := ipam.GetPool(ctx, "public")
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.58.0.0/25", "10.58.0.128/25", other.Cidr}, pool.Prefixes)
+
+		_, err = ipam.MergePrefixes(ctx, "10.58.0.0/25", "10.58.0.128/25")
+		require.NoError(t, err)
+		pool, err = ipam.GetPool(ctx, "public")
+		require.NoError(t, err)
+		require.Equal(t, []string{p.Cidr, other.Cidr}, pool.Prefixes)
+
+		_, err = ipam.ResizePrefix(ctx, p.Cidr, 23)
+		require.NoError(t, err)
+		pool, err = ipam.GetPool(ctx, "public")
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.58.0.0/23", other.Cidr}, pool.Prefixes)
+		ip, err := ipam.AcquireIPFromPool(ctx, "public")
+		require.NoError(t, err)
+		require.Equal(t, "10.58.0.1", ip.IP.String())
+
+		err = ipam.DeletePool(ctx, "public")
+		require.NoError(t, err)
+	})
+}
+
+func TestIpamer_AcquireDualStack(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		v4, err := ipam.NewPrefix(ctx, "10.70.0.0/30")
+		require.NoError(t, err)
+		v6, err := ipam.NewPrefix(ctx, "2001:db8:70::/126")
+		require.NoError(t, err)
+
+		_, err = ipam.AcquireDualStack(ctx, v6.Cidr, v4.Cidr)
+		require.EqualError(t, err, "prefix:2001:db8:70::/126 is not an IPv4 prefix")
+		_, err = ipam.AcquireDualStack(ctx, v4.Cidr, "2001:db8:71::/126")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		ips, err := ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr, AcquireWithAnnotations(map[string]string{"machine": "m1"}), AcquireWithIdempotencyKey("m1"))
+		require.NoError(t, err)
+		require.Equal(t, "10.70.0.1", ips.IPv4.IP.String())
+		require.Equal(t, "2001:db8:70::1", ips.IPv6.IP.String())
+		require.Equal(t, map[string]string{"machine": "m1"}, ips.IPv6.Annotations)
+
+		again, err := ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr, AcquireWithIdempotencyKey("m1"))
+		require.NoError(t, err)
+		require.Equal(t, ips, again)
+
+		// only the released side is acquired again, the surviving ip is kept
+		_, err = ipam.ReleaseIP(ctx, ips.IPv6)
+		require.NoError(t, err)
+		again, err = ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr, AcquireWithIdempotencyKey("m1"))
+		require.NoError(t, err)
+		require.Equal(t, ips.IPv4, again.IPv4)
+		require.Equal(t, "2001:db8:70::1", again.IPv6.IP.String())
+		v4, err = ipam.PrefixFrom(ctx, v4.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), v4.Usage().AcquiredIPs)
+
+		// the ipv6 prefix is exhausted after the second pair, the ipv4 address must not be acquired then
+		_, err = ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(ctx, v6.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr)
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+		v4, err = ipam.PrefixFrom(ctx, v4.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), v4.Usage().AcquiredIPs)
+
+		_, err = ipam.DeletePrefix(ctx, v4.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+		_, err = ipam.DeletePrefix(ctx, v6.Cidr, DeleteRecursive())
+		require.NoError(t, err)
+	})
+}
+
+func TestIpamer_AcquireDualStackEmbedded(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		v4, err := ipam.NewPrefix(ctx, "10.71.1.0/24")
+		require.NoError(t, err)
+		v6, err := ipam.NewPrefix(ctx, "2001:db8:71::/64")
+		require.NoError(t, err)
+		small, err := ipam.NewPrefix(ctx, "2001:db8:72::/120")
+		require.NoError(t, err)
+
+		// only the last octet fits into the host part of the /120
+		for _, expected := range []string{"2001:db8:72::1", "2001:db8:72::2"} {
+			ips, err := ipam.AcquireDualStack(ctx, v4.Cidr, small.Cidr, AcquireWithEmbeddedIPv4())
+			require.NoError(t, err)
+			require.Equal(t, expected, ips.IPv6.IP.String())
+		}
+
+		// the embedded address of the next free ipv4 address is taken, it is skipped
+		_, err = ipam.AcquireSpecificIP(ctx, v6.Cidr, "2001:db8:71::a47:103")
+		require.NoError(t, err)
+		ips, err := ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr, AcquireWithEmbeddedIPv4())
+		require.NoError(t, err)
+		require.Equal(t, "10.71.1.4", ips.IPv4.IP.String())
+		require.Equal(t, "2001:db8:71::a47:104", ips.IPv6.IP.String())
+
+		ip, err := ipam.AcquireIP(ctx, v4.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.71.1.3", ip.IP.String(), "skipped ipv4 address must stay free")
+
+		// the released ipv4 address of an idempotent pair is the one embedded into the surviving ipv6 address
+		ips, err = ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr, AcquireWithEmbeddedIPv4(), AcquireWithIdempotencyKey("m5"))
+		require.NoError(t, err)
+		require.Equal(t, "2001:db8:71::a47:105", ips.IPv6.IP.String())
+		_, err = ipam.ReleaseIP(ctx, ips.IPv4)
+		require.NoError(t, err)
+		again, err := ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr, AcquireWithEmbeddedIPv4(), AcquireWithIdempotencyKey("m5"))
+		require.NoError(t, err)
+		require.Equal(t, "10.71.1.5", again.IPv4.IP.String())
+		require.Equal(t, ips.IPv6, again.IPv6)
+		_, err = ipam.ReleaseIP(ctx, again.IPv4)
+		require.NoError(t, err)
+		_, err = ipam.AcquireSpecificIP(ctx, v4.Cidr, "10.71.1.5")
+		require.NoError(t, err)
+		_, err = ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr, AcquireWithEmbeddedIPv4(), AcquireWithIdempotencyKey("m5"))
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+
+		tiny, err := ipam.NewPrefix(ctx, "2001:db8:73::/124")
+		require.NoError(t, err)
+		_, err = ipam.AcquireDualStack(ctx, v4.Cidr, tiny.Cidr, AcquireWithEmbeddedIPv4())
+		require.EqualError(t, err, "host part of prefix:2001:db8:73::/124 is too small to embed the addresses of prefix:10.71.1.0/24")
+
+		for _, p := range []*Prefix{v4, v6, small, tiny} {
+			_, err = ipam.DeletePrefix(ctx, p.Cidr, DeleteRecursive())
+			require.NoError(t, err)
+		}
+	})
+}
+
+// failingUpdateStorage fails to update the prefix with the given cidr once.
+type failingUpdateStorage struct {
+	Storage
+	cidr string
+}
+
+func (f *failingUpdateStorage) UpdatePrefix(ctx context.Context, prefix Prefix, namespace string) (Prefix, error) {
+	if prefix.Cidr == f.cidr {
+		f.cidr = ""
+		return Prefix{}, fmt.Errorf("storage unavailable")
+	}
+	return f.Storage.UpdatePrefix(ctx, prefix, namespace)
+}
+
+func TestIpamer_AcquireDualStackRollback(t *testing.T) {
+	ctx := t.Context()
+	storage := &failingUpdateStorage{Storage: NewMemory(ctx)}
+	ipam := NewWithStorage(storage)
+
+	v4, err := ipam.NewPrefix(ctx, "10.74.0.0/29", WithQuarantine(50*time.Millisecond))
+	require.NoError(t, err)
+	v6, err := ipam.NewPrefix(ctx, "2001:db8:74::/126")
+	require.NoError(t, err)
+	err = ipam.SetNamespaceQuota(ctx, defaultNamespace, Quota{MaxIPs: 10})
+	require.NoError(t, err)
+	ips, err := ipam.AcquireIPs(ctx, v4.Cidr, 2)
+	require.NoError(t, err)
+	_, err = ipam.ReleaseIP(ctx, ips[0])
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// the expired quarantine of the first ip is removed on acquisition and must be restored together with the cursor
+	storage.cidr = v6.Cidr
+	_, err = ipam.AcquireDualStack(ctx, v4.Cidr, v6.Cidr)
+	require.EqualError(t, err, "unable to persist acquired ip:2001:db8:74::/126 error:storage unavailable")
+	v4, err = ipam.PrefixFrom(ctx, v4.Cidr)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.74.0.2"}, v4.ips.strings())
+	require.Equal(t, "10.74.0.2", v4.lastAllocated)
+	require.Contains(t, v4.quarantined, "10.74.0.1")
+	// the pair is not added to the cached usage, which does not know about the release before
+	require.Equal(t, uint64(2), ipam.(*ipamer).quotas[defaultNamespace].usage.IPs)
+}
+
+func Test_embedIPv4(t *testing.T) {
+	tests := []struct {
+		v6   string
+		v4   string
+		want string
+	}{
+		{v6: "2001:db8::/64", v4: "10.0.1.5", want: "2001:db8::a00:105"},
+		{v6: "2001:db8::/96", v4: "192.168.0.1", want: "2001:db8::c0a8:1"},
+		{v6: "2001:db8::/112", v4: "192.168.10.20", want: "2001:db8::a14"},
+		{v6: "2001:db8::ff00/120", v4: "192.168.10.20", want: "2001:db8::ff14"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v6+"-"+tt.v4, func(t *testing.T) {
+			got := embedIPv4(netip.MustParsePrefix(tt.v6), netip.MustParseAddr(tt.v4))
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestIpamer_AcquireIPFromMAC(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, "2001:db8:80::/64")
+		require.NoError(t, err)
+		long, err := ipam.NewPrefix(ctx, "2001:db8:81::/80")
+		require.NoError(t, err)
+
+		ip, err := ipam.AcquireIPFromMAC(ctx, p.Cidr, "00:00:5e:00:53:01", AcquireWithAnnotations(map[string]string{"machine": "m1"}))
+		require.NoError(t, err)
+		require.Equal(t, "2001:db8:80:0:200:5eff:fe00:5301", ip.IP.String())
+		require.Equal(t, map[string]string{"machine": "m1"}, ip.Annotations)
+
+		_, err = ipam.AcquireIPFromMAC(ctx, p.Cidr, "00:00:5E:00:53:01")
+		require.ErrorIs(t, err, ErrAlreadyAllocated)
+
+		// the machine gets the same address again after it was released
+		_, err = ipam.ReleaseIP(ctx, ip)
+		require.NoError(t, err)
+		again, err := ipam.AcquireIPFromMAC(ctx, p.Cidr, "00-00-5e-00-53-01")
+		require.NoError(t, err)
+		require.Equal(t, ip.IP, again.IP)
+
+		_, err = ipam.AcquireIPFromMAC(ctx, p.Cidr, "no mac")
+		require.EqualError(t, err, "given mac:no mac is not valid address no mac: invalid MAC address")
+		_, err = ipam.AcquireIPFromMAC(ctx, long.Cidr, "00:00:5e:00:53:01")
+		require.EqualError(t, err, "prefix:2001:db8:81::/80 must not be longer than 64 bits to contain eui-64 addresses")
+		_, err = ipam.AcquireIPFromMAC(ctx, "2001:db8:82::/64", "00:00:5e:00:53:01")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		for _, p := range []*Prefix{p, long} {
+			_, err = ipam.DeletePrefix(ctx, p.Cidr, DeleteRecursive())
+			require.NoError(t, err)
+		}
+	})
+}
+
+func Test_eui64Address(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		mac     string
+		want    string
+		wantErr string
+	}{
+		{prefix: "2001:db8::/64", mac: "00:00:5e:00:53:01", want: "2001:db8::200:5eff:fe00:5301"},
+		{prefix: "2001:db8:1:2::/64", mac: "02:42:ac:11:00:02", want: "2001:db8:1:2:42:acff:fe11:2"},
+		{prefix: "2001:db8::/48", mac: "00:00:5e:00:53:01", want: "2001:db8::200:5eff:fe00:5301"},
+		{prefix: "2001:db8::/64", mac: "00:00:5e:ff:fe:00:53:01", want: "2001:db8::200:5eff:fe00:5301"},
+		{prefix: "10.0.0.0/8", mac: "00:00:5e:00:53:01", wantErr: "prefix:10.0.0.0/8 is not an IPv6 prefix"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix+"-"+tt.mac, func(t *testing.T) {
+			mac, err := net.ParseMAC(tt.mac)
+			require.NoError(t, err)
+			got, err := eui64Address(netip.MustParsePrefix(tt.prefix), mac)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestIpamer_AcquireIPForKey(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		p, err := ipam.NewPrefix(ctx, "10.80.0.0/16")
+		require.NoError(t, err)
+
+		_, err = ipam.AcquireIPForKey(ctx, p.Cidr, "")
+		require.EqualError(t, err, "key must not be empty")
+
+		ip, err := ipam.AcquireIPForKey(ctx, p.Cidr, "machine-1", AcquireWithAnnotations(map[string]string{"owner": "tenant-a"}))
+		require.NoError(t, err)
+		require.Equal(t, hashedAddress(netip.MustParsePrefix(p.Cidr), "machine-1"), ip.IP)
+		require.Equal(t, map[string]string{"owner": "tenant-a", KeyAnnotation: "machine-1"}, ip.Annotations)
+
+		// a repeated call returns the ip already acquired for the key
+		repeated, err := ipam.AcquireIPForKey(ctx, p.Cidr, "machine-1")
+		require.NoError(t, err)
+		require.Equal(t, ip, repeated)
+		p, err = ipam.PrefixFrom(ctx, p.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), p.Usage().AcquiredIPs)
+
+		// the address is taken, the next free one after it is acquired
+		taken, err := ipam.AcquireSpecificIP(ctx, p.Cidr, hashedAddress(netip.MustParsePrefix(p.Cidr), "machine-2").String())
+		require.NoError(t, err)
+		collision, err := ipam.AcquireIPForKey(ctx, p.Cidr, "machine-2")
+		require.NoError(t, err)
+		require.Equal(t, taken.IP.Next(), collision.IP)
+
+		// a re-provisioned machine gets the same address again
+		_, err = ipam.ReleaseIP(ctx, ip)
+		require.NoError(t, err)
+		again, err := ipam.AcquireIPForKey(ctx, p.Cidr, "machine-1")
+		require.NoError(t, err)
+		require.Equal(t, ip.IP, again.IP)
+
+		// on collision all free addresses of the prefix are probed until it is exhausted
+		small, err := ipam.NewPrefix(ctx, "10.81.0.0/30")
+		require.NoError(t, err)
+		for _, key := range []string{"machine-1", "machine-2"} {
+			_, err = ipam.AcquireIPForKey(ctx, small.Cidr, key)
+			require.NoError(t, err)
+		}
+		_, err = ipam.AcquireIPForKey(ctx, small.Cidr, "machine-3")
+		require.ErrorIs(t, err, ErrNoIPAvailable)
+
+		for _, p := range []*Prefix{p, small} {
+			_, err = ipam.DeletePrefix(ctx, p.Cidr, DeleteRecursive())
+			require.NoError(t, err)
+		}
+	})
+}
+
+func Test_hashedAddress(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/8", "192.168.0.0/30", "2001:db8::/64", "2001:db8::1/128"} {
+		prefix := netip.MustParsePrefix(cidr)
+		ip := hashedAddress(prefix, "machine-1")
+		require.True(t, prefix.Contains(ip), "%s not in %s", ip, prefix)
+		require.Equal(t, ip, hashedAddress(prefix, "machine-1"))
+	}
+}
+
+func TestIpamer_ChildPrefixStrategies(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		_, err := ipam.NewPrefix(ctx, "10.90.0.0/24", WithChildPrefixStrategy("worst-fit"))
+		require.EqualError(t, err, "unknown child prefix strategy:\"worst-fit\", supported are [best-fit first-fit sparse]")
+
+		// free are 10.90.0.0/25 and 10.90.0.160/27
+		parent, err := ipam.NewPrefix(ctx, "10.90.0.0/24", WithChildPrefixStrategy(BestFit))
+		require.NoError(t, err)
+		require.Equal(t, BestFit, parent.ChildPrefixStrategy)
+		for _, cidr := range []string{"10.90.0.128/27", "10.90.0.192/26"} {
+			_, err = ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, cidr)
+			require.NoError(t, err)
+		}
+
+		_, err = ipam.AcquireChildPrefix(ctx, parent.Cidr, 27, WithChildPlacement("worst-fit"))
+		require.ErrorContains(t, err, "unknown child prefix strategy:\"worst-fit\"")
+
+		first, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 27, WithChildPlacement(FirstFit))
+		require.NoError(t, err)
+		require.Equal(t, "10.90.0.0/27", first.Cidr)
+		require.Empty(t, first.placement)
+		err = ipam.ReleaseChildPrefix(ctx, first)
+		require.NoError(t, err)
+
+		// best fit of the parent takes the smaller free block and keeps the /25 for large requests
+		best, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 27)
+		require.NoError(t, err)
+		require.Equal(t, "10.90.0.160/27", best.Cidr)
+		large, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 25)
+		require.NoError(t, err)
+		require.Equal(t, "10.90.0.0/25", large.Cidr)
+
+		parent, err = ipam.EditPrefix(ctx, "10.91.0.0/24", WithChildPrefixStrategy(Sparse))
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = ipam.NewPrefix(ctx, "10.91.0.0/24")
+		require.NoError(t, err)
+		parent, err = ipam.EditPrefix(ctx, "10.91.0.0/24", WithChildPrefixStrategy(Sparse))
+		require.NoError(t, err)
+		require.Equal(t, Sparse, parent.ChildPrefixStrategy)
+
+		// sparse bisects the parent, every child has room to grow
+		children, err := ipam.AcquireChildPrefixes(ctx, parent.Cidr, 28, 4)
+		require.NoError(t, err)
+		var cidrs []string
+		for _, c := range children {
+			cidrs = append(cidrs, c.Cidr)
+		}
+		require.Equal(t, []string{"10.91.0.0/28", "10.91.0.128/28", "10.91.0.64/28", "10.91.0.192/28"}, cidrs)
+		next, err := ipam.AcquireChildPrefix(ctx, parent.Cidr, 28)
+		require.NoError(t, err)
+		require.Equal(t, "10.91.0.32/28", next.Cidr)
+
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, Sparse, parent.ChildPrefixStrategy)
+
+		for _, cidr := range []string{"10.90.0.0/24", "10.91.0.0/24"} {
+			_, err = ipam.DeletePrefix(ctx, cidr, DeleteRecursive())
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestIpamer_PrefixFrom(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		prefix, err := ipam.PrefixFrom(ctx, "192.168.0.0/20")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Error(t, err)
+		require.Nil(t, prefix)
+
+		prefix, err = ipam.NewPrefix(ctx, "192.168.0.0/20")
+		require.NoError(t, err)
+		require.NotNil(t, prefix)
+
+		prefix, err = ipam.PrefixFrom(ctx, "192.168.0.0/20")
+		require.NoError(t, err)
+		require.NotNil(t, prefix)
+
+		// non canonical form still returns the same prefix
+		prefix2, err := ipam.PrefixFrom(ctx, "10.0.5.0/8")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Error(t, err)
+		require.Nil(t, prefix2)
+
+		prefix2a, err := ipam.NewPrefix(ctx, "10.8.0.0/8")
+		require.NoError(t, err)
+		require.NotNil(t, prefix2a)
+
+		prefix2b, err := ipam.PrefixFrom(ctx, "10.2.0.0/8")
+		require.NoError(t, err)
+		require.NotNil(t, prefix2b)
+		require.Equal(t, prefix2a.Cidr, prefix2b.Cidr)
+	})
+}
+
+func TestIpamerAcquireIP(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		cidr := "10.0.0.0/16"
+		p, err := ipam.NewPrefix(ctx, cidr)
+		require.NoError(t, err)
+		for range 10 {
+			if p.acquiredips() != 0 {
+				t.Fatalf("expected no ips in prefix, got %d", p.acquiredips())
+			}
+			ip, err := ipam.AcquireIP(ctx, p.Cidr)
+			require.NoError(t, err)
+			require.NotNil(t, ip, "IP is nil")
+			p, err = ipam.ReleaseIP(ctx, ip)
+			require.NoError(t, err)
+		}
+		_, err = ipam.DeletePrefix(ctx, cidr)
+		require.NoError(t, err, "error deleting prefix:%v", err)
+	})
+}
+
+func TestIpamerAcquireIPv6(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		cidr := "2001:0db8:85a3::/120"
+		p, err := ipam.NewPrefix(ctx, cidr)
+		require.NoError(t, err)
+		for range 10 {
+			if p.acquiredips() != 0 {
+				t.Fatalf("expected no ips in prefix, got %d", p.acquiredips())
+			}
+			ip, err := ipam.AcquireIP(ctx, p.Cidr)
 			require.NoError(t, err)
 			require.NotNil(t, ip, "IP is nil")
 			p, err = ipam.ReleaseIP(ctx, ip)
@@ -1308,7 +3318,7 @@ func TestGetHostAddresses(t *testing.T) {
 		ip, err := ipam.AcquireIP(ctx, cidr)
 		require.Error(t, err)
 		require.ErrorIs(t, err, ErrNoIPAvailable)
-		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 4.1.0.0/24 left, length of prefix.ips: 256", err.Error())
+		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 4.1.0.0/24 left, length of prefix.ips: 254", err.Error())
 		require.Nil(t, ip)
 
 		cidr = "3.1.0.0/26"
@@ -1320,7 +3330,7 @@ func TestGetHostAddresses(t *testing.T) {
 		ip, err = ipam.AcquireIP(ctx, cidr)
 		require.Error(t, err)
 		require.ErrorIs(t, err, ErrNoIPAvailable)
-		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 3.1.0.0/26 left, length of prefix.ips: 64", err.Error())
+		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 3.1.0.0/26 left, length of prefix.ips: 62", err.Error())
 		require.Nil(t, ip)
 	})
 }
@@ -1337,7 +3347,7 @@ func TestGetHostAddressesIPv6(t *testing.T) {
 		ip, err := ipam.AcquireIP(ctx, cidr)
 		require.Error(t, err)
 		require.ErrorIs(t, err, ErrNoIPAvailable)
-		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 2001:db8:85a3::/120 left, length of prefix.ips: 256", err.Error())
+		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 2001:db8:85a3::/120 left, length of prefix.ips: 255", err.Error())
 		require.Nil(t, ip)
 
 		cidr = "2001:0db8:95a3::/122"
@@ -1349,7 +3359,7 @@ func TestGetHostAddressesIPv6(t *testing.T) {
 		ip, err = ipam.AcquireIP(ctx, cidr)
 		require.Error(t, err)
 		require.ErrorIs(t, err, ErrNoIPAvailable)
-		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 2001:db8:95a3::/122 left, length of prefix.ips: 64", err.Error())
+		require.Equal(t, "NoIPAvailableError: no more ips in prefix: 2001:db8:95a3::/122 left, length of prefix.ips: 63", err.Error())
 		require.Nil(t, ip)
 	})
 }
@@ -1360,13 +3370,13 @@ func TestPrefixDeepCopy(t *testing.T) {
 		ParentCidr:             "4.1.0.0/16",
 		availableChildPrefixes: map[string]bool{},
 		isParent:               true,
-		ips:                    map[string]bool{},
 		version:                2,
 	}
 
 	p1.availableChildPrefixes["4.1.2.0/24"] = true
-	p1.ips["4.1.1.1"] = true
-	p1.ips["4.1.1.2"] = true
+	p1.ips.add(netip.MustParseAddr("4.1.1.1"))
+	p1.ips.add(netip.MustParseAddr("4.1.1.2"))
+	p1.ipAnnotations = map[string]map[string]string{"4.1.1.1": {"owner": "tenant-a"}}
 
 	p2 := p1.deepCopy()
 
@@ -1374,6 +3384,13 @@ func TestPrefixDeepCopy(t *testing.T) {
 	require.Equal(t, p1, p2)
 	require.Equal(t, p1.availableChildPrefixes, p2.availableChildPrefixes)
 	require.Equal(t, p1.ips, p2.ips)
+	require.Equal(t, p1.ipAnnotations, p2.ipAnnotations)
+
+	p2.ipAnnotations["4.1.1.1"]["owner"] = "tenant-b"
+	require.Equal(t, "tenant-a", p1.ipAnnotations["4.1.1.1"]["owner"])
+
+	p2.ips.add(netip.MustParseAddr("4.1.1.3"))
+	require.Equal(t, []string{"4.1.1.1-4.1.1.2"}, p1.ips.strings())
 }
 
 func TestGob(t *testing.T) {
@@ -1393,6 +3410,37 @@ func TestGob(t *testing.T) {
 	})
 }
 
+func TestGob_Unversioned(t *testing.T) {
+	// the encoding of prefixes before the encoding was versioned, the network and broadcast addresses were acquired
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	for _, v := range []any{
+		map[string]bool{},
+		0,
+		false,
+		map[string]bool{"192.168.0.0": true, "192.168.0.1": true, "192.168.0.2": true, "192.168.0.255": true},
+		int64(3),
+		"192.168.0.0/24",
+		"10.0.0.0/8",
+	} {
+		require.NoError(t, encoder.Encode(v))
+	}
+
+	prefix := &Prefix{}
+	err := prefix.GobDecode(w.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "192.168.0.0/24", prefix.Cidr)
+	require.Equal(t, "10.0.0.0/8", prefix.ParentCidr)
+	require.Equal(t, int64(3), prefix.version)
+	require.Equal(t, []string{"192.168.0.1-192.168.0.2"}, prefix.ips.strings())
+	require.Equal(t, []string{"192.168.0.0", "192.168.0.255"}, prefix.ReservedIPs())
+
+	w.Reset()
+	require.NoError(t, gob.NewEncoder(w).Encode(prefixGobVersion+1))
+	err = prefix.GobDecode(w.Bytes())
+	require.EqualError(t, err, "unsupported prefix encoding version:3")
+}
+
 func TestPrefix_availablePrefixes(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -1458,6 +3506,62 @@ func TestPrefix_availablePrefixes(t *testing.T) {
 	}
 }
 
+func TestPrefix_UsageExact(t *testing.T) {
+	ctx := t.Context()
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		prefix, err := ipam.NewPrefix(ctx, "2001:db8:1::/64", WithExclusions("2001:db8:1::100-2001:db8:1::1ff"))
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(ctx, prefix.Cidr)
+		require.NoError(t, err)
+		prefix, err = ipam.PrefixFrom(ctx, prefix.Cidr)
+		require.NoError(t, err)
+
+		usage := prefix.Usage()
+		require.Equal(t, uint64(math.MaxInt32), usage.AvailableIPs)
+		require.Equal(t, "18446744073709551616", usage.AvailableIPsExact.String())
+		require.Equal(t, "1", usage.ReservedIPsExact.String())
+		require.Equal(t, "256", usage.ExcludedIPsExact.String())
+		require.Equal(t, "18446744073709551357", usage.FreeIPs.String())
+		require.Equal(t, "2^64 - 259", PowerOfTwoNotation(usage.FreeIPs))
+		require.InDelta(t, 2/float64(1<<64-257)*100, usage.Utilization, 1e-25)
+
+		parent, err := ipam.NewPrefix(ctx, "2001:db8:2::/48")
+		require.NoError(t, err)
+		_, err = ipam.AcquireChildPrefix(ctx, parent.Cidr, 50)
+		require.NoError(t, err)
+		parent, err = ipam.PrefixFrom(ctx, parent.Cidr)
+		require.NoError(t, err)
+
+		usage = parent.Usage()
+		require.Equal(t, uint64(math.MaxInt32), usage.AvailableSmallestPrefixes)
+		require.Equal(t, new(big.Int).Mul(big.NewInt(3), new(big.Int).Lsh(big.NewInt(1), 76)), usage.AvailableSmallestPrefixesExact)
+		require.Equal(t, "0", usage.FreeIPs.String())
+		require.InDelta(t, 25.0, usage.Utilization, 1e-9)
+	})
+}
+
+func TestPowerOfTwoNotation(t *testing.T) {
+	tests := []struct {
+		n    *big.Int
+		want string
+	}{
+		{n: nil, want: "0"},
+		{n: big.NewInt(0), want: "0"},
+		{n: big.NewInt(65535), want: "65535"},
+		{n: big.NewInt(65536), want: "2^16"},
+		{n: big.NewInt(65539), want: "2^16 + 3"},
+		{n: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(3)), want: "2^64 - 3"},
+		{n: new(big.Int).Lsh(big.NewInt(1), 128), want: "2^128"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			require.Equal(t, test.want, PowerOfTwoNotation(test.n))
+		})
+	}
+}
+
 func TestAcquireIPParallel(t *testing.T) {
 	ctx := t.Context()
 	ipsCount := 50
@@ -1801,3 +3905,104 @@ func TestChildPrefixParallel(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestIpamer_FragmentationReport(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		_, err := ipam.FragmentationReport(ctx, "10.92.0.0/24")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		parent, err := ipam.NewPrefix(ctx, "10.92.0.0/24")
+		require.NoError(t, err)
+		report, err := ipam.FragmentationReport(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint8(25), report.LargestFreeLength)
+		require.Equal(t, map[uint8]uint64{24: 1}, report.FreeBlocks)
+		require.Equal(t, "256", report.FreeAddresses.String())
+		require.InDelta(t, 0, report.Score, 0.001)
+
+		// free are 10.92.0.64/27, 10.92.0.128/26, 10.92.0.208/28 and 10.92.0.224/27
+		for _, cidr := range []string{"10.92.0.0/26", "10.92.0.96/27", "10.92.0.192/28"} {
+			_, err = ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, cidr)
+			require.NoError(t, err)
+		}
+		report, err = ipam.FragmentationReport(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, "10.92.0.0/24", report.Cidr)
+		require.Equal(t, uint8(26), report.LargestFreeLength)
+		require.Equal(t, map[uint8]uint64{26: 1, 27: 2, 28: 1}, report.FreeBlocks)
+		require.Equal(t, "144", report.FreeAddresses.String())
+		require.InDelta(t, 1-64.0/144.0, report.Score, 0.001)
+
+		_, err = ipam.EditPrefix(ctx, parent.Cidr, WithPolicy(Policy{MaxChildLength: 25}))
+		require.NoError(t, err)
+		report, err = ipam.FragmentationReport(ctx, parent.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint8(0), report.LargestFreeLength)
+
+		withIPs, err := ipam.NewPrefix(ctx, "10.93.0.0/24")
+		require.NoError(t, err)
+		_, err = ipam.AcquireIP(ctx, withIPs.Cidr)
+		require.NoError(t, err)
+		report, err = ipam.FragmentationReport(ctx, withIPs.Cidr)
+		require.NoError(t, err)
+		require.Equal(t, uint8(0), report.LargestFreeLength)
+
+		for _, cidr := range []string{"10.92.0.0/24", "10.93.0.0/24"} {
+			_, err = ipam.DeletePrefix(ctx, cidr, DeleteRecursive())
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestIpamer_PlanDefragmentation(t *testing.T) {
+	ctx := t.Context()
+
+	testWithBackends(t, func(t *testing.T, ipam *ipamer) {
+		_, err := ipam.PlanDefragmentation(ctx, "10.94.0.0/24", 25)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// free are 10.94.0.64/27, 10.94.0.128/26, 10.94.0.208/28 and 10.94.0.224/27
+		parent, err := ipam.NewPrefix(ctx, "10.94.0.0/24")
+		require.NoError(t, err)
+		for _, cidr := range []string{"10.94.0.0/26", "10.94.0.96/27", "10.94.0.192/28"} {
+			_, err = ipam.AcquireSpecificChildPrefix(ctx, parent.Cidr, cidr)
+			require.NoError(t, err)
+		}
+
+		_, err = ipam.PlanDefragmentation(ctx, parent.Cidr, 24)
+		require.EqualError(t, err, "given length:24 must be greater than prefix length:24 and not greater than 32")
+
+		plan, err := ipam.PlanDefragmentation(ctx, parent.Cidr, 26)
+		require.NoError(t, err)
+		require.Equal(t, &DefragmentationPlan{Block: "10.94.0.128/26"}, plan)
+
+		// freeing 10.94.0.0/25 would need two moves
+		plan, err = ipam.PlanDefragmentation(ctx, parent.Cidr, 25)
+		require.NoError(t, err)
+		require.Equal(t, &DefragmentationPlan{
+			Block: "10.94.0.128/25",
+			Moves: []ChildPrefixMove{{From: "10.94.0.192/28", To: "10.94.0.64/28"}},
+		}, plan)
+
+		// nothing is moved
+		p, err := ipam.PrefixFrom(ctx, "10.94.0.192/28")
+		require.NoError(t, err)
+		require.Equal(t, parent.Cidr, p.ParentCidr)
+
+		full, err := ipam.NewPrefix(ctx, "10.95.0.0/24")
+		require.NoError(t, err)
+		for _, cidr := range []string{"10.95.0.0/26", "10.95.0.64/26", "10.95.0.128/26", "10.95.0.192/26"} {
+			_, err = ipam.AcquireSpecificChildPrefix(ctx, full.Cidr, cidr)
+			require.NoError(t, err)
+		}
+		_, err = ipam.PlanDefragmentation(ctx, full.Cidr, 25)
+		require.ErrorIs(t, err, ErrNoPrefixAvailable)
+
+		for _, cidr := range []string{"10.94.0.0/24", "10.95.0.0/24"} {
+			_, err = ipam.DeletePrefix(ctx, cidr, DeleteRecursive())
+			require.NoError(t, err)
+		}
+	})
+}
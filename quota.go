@@ -0,0 +1,249 @@
+package ipam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"time"
+)
+
+// quotaCacheTTL is the duration an ipamer uses the cached Quota and usage of a namespace before reading them again,
+// a Quota changed by another ipamer sharing the storage is applied after this duration.
+const quotaCacheTTL = 10 * time.Second
+
+// Quota limits the prefixes, ips and address space of a namespace, the zero value of a field does not limit anything.
+// A Quota only applies to new allocations, a namespace which already exceeds a changed Quota keeps its prefixes and ips.
+type Quota struct {
+	// MaxPrefixes is the maximum number of top level prefixes in the namespace
+	MaxPrefixes uint64 `json:"MaxPrefixes,omitempty"`
+	// MaxIPs is the maximum number of acquired ips in all prefixes of the namespace
+	MaxIPs uint64 `json:"MaxIPs,omitempty"`
+	// MaxAddressSpace is the maximum number of addresses of all top level prefixes in the namespace
+	MaxAddressSpace *big.Int `json:"MaxAddressSpace,omitempty"`
+}
+
+// QuotaUsage is the Quota of a namespace together with the current usage it limits.
+type QuotaUsage struct {
+	Quota Quota
+	// Prefixes is the number of top level prefixes in the namespace
+	Prefixes uint64
+	// IPs is the number of acquired ips in all prefixes of the namespace
+	IPs uint64
+	// AddressSpace is the number of addresses of all top level prefixes in the namespace
+	AddressSpace *big.Int
+}
+
+// quotaCacheEntry is the cached Quota of a namespace together with its usage, which is counted on first use
+// and increased by the allocations of this ipamer.
+type quotaCacheEntry struct {
+	quota Quota
+	read  time.Time
+	usage *QuotaUsage
+	// counted is the time the usage was counted from the storage
+	counted time.Time
+}
+
+// isZero returns true if the Quota does not limit anything.
+func (q Quota) isZero() bool {
+	return q.MaxPrefixes == 0 && q.MaxIPs == 0 && (q.MaxAddressSpace == nil || q.MaxAddressSpace.Sign() == 0)
+}
+
+func (q Quota) validate() error {
+	if q.MaxAddressSpace != nil && q.MaxAddressSpace.Sign() < 0 {
+		return fmt.Errorf("max address space:%s must not be negative", q.MaxAddressSpace)
+	}
+	return nil
+}
+
+// deepCopy returns a Quota which does not share the address space with q.
+func (q Quota) deepCopy() Quota {
+	if q.MaxAddressSpace != nil {
+		q.MaxAddressSpace = new(big.Int).Set(q.MaxAddressSpace)
+	}
+	return q
+}
+
+func (q Quota) toJSON() ([]byte, error) {
+	qj, err := json.Marshal(q)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal quota:%w", err)
+	}
+	return qj, nil
+}
+
+func quotaFromJSON(js []byte) (Quota, error) {
+	var q Quota
+	if len(js) == 0 {
+		return q, nil
+	}
+	err := json.Unmarshal(js, &q)
+	if err != nil {
+		return Quota{}, fmt.Errorf("unable to unmarshal quota:%w", err)
+	}
+	return q, nil
+}
+
+// limits returns true if the Quota limits one of the given additional prefixes, ips or addresses,
+// the usage does not need to be counted otherwise.
+func (q Quota) limits(prefixes, ips uint64, addresses *big.Int) bool {
+	return q.MaxPrefixes > 0 && prefixes > 0 ||
+		q.MaxIPs > 0 && ips > 0 ||
+		q.MaxAddressSpace != nil && q.MaxAddressSpace.Sign() > 0 && addresses != nil && addresses.Sign() > 0
+}
+
+// check returns an ErrQuotaExceeded if the given additional prefixes, ips or addresses exceed the Quota with the given usage.
+func (q Quota) check(namespace string, usage *QuotaUsage, prefixes, ips uint64, addresses *big.Int) error {
+	if q.MaxPrefixes > 0 && prefixes > 0 && usage.Prefixes+prefixes > q.MaxPrefixes {
+		return fmt.Errorf("%w: namespace:%s has %d of at most %d prefixes, %d more requested", ErrQuotaExceeded, namespace, usage.Prefixes, q.MaxPrefixes, prefixes)
+	}
+	if q.MaxIPs > 0 && ips > 0 && usage.IPs+ips > q.MaxIPs {
+		return fmt.Errorf("%w: namespace:%s has %d of at most %d ips, %d more requested", ErrQuotaExceeded, namespace, usage.IPs, q.MaxIPs, ips)
+	}
+	if q.MaxAddressSpace != nil && q.MaxAddressSpace.Sign() > 0 && addresses != nil && addresses.Sign() > 0 &&
+		new(big.Int).Add(usage.AddressSpace, addresses).Cmp(q.MaxAddressSpace) > 0 {
+		return fmt.Errorf("%w: namespace:%s has %s of at most %s addresses, %s more requested", ErrQuotaExceeded, namespace, usage.AddressSpace, q.MaxAddressSpace, addresses)
+	}
+	return nil
+}
+
+func (i *ipamer) SetNamespaceQuota(ctx context.Context, namespace string, quota Quota) error {
+	if err := quota.validate(); err != nil {
+		return err
+	}
+	if quota.isZero() {
+		quota = Quota{}
+	}
+	if err := i.storage.UpdateNamespaceQuota(ctx, namespace, quota); err != nil {
+		return err
+	}
+	i.dropQuota(namespace)
+	return nil
+}
+
+func (i *ipamer) GetNamespaceQuota(ctx context.Context, namespace string) (*QuotaUsage, error) {
+	quota, err := i.storage.ReadNamespaceQuota(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := i.namespaceUsage(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	usage.Quota = quota
+	return usage, nil
+}
+
+// namespaceUsage returns the number of top level prefixes, acquired ips and addresses of the namespace.
+func (i *ipamer) namespaceUsage(ctx context.Context, namespace string) (*QuotaUsage, error) {
+	prefixes, err := i.storage.ReadAllPrefixes(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read prefixes:%w", err)
+	}
+	usage := &QuotaUsage{AddressSpace: new(big.Int)}
+	for _, p := range prefixes {
+		usage.IPs += p.ips.count()
+		if p.ParentCidr != "" {
+			continue
+		}
+		ipprefix, err := netip.ParsePrefix(p.Cidr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+		}
+		usage.Prefixes++
+		usage.AddressSpace.Add(usage.AddressSpace, prefixSize(ipprefix))
+	}
+	return usage, nil
+}
+
+// checkQuota returns an ErrQuotaExceeded if the given number of additional top level prefixes, acquired ips or addresses
+// exceed the Quota of the namespace, otherwise they are added to the cached usage.
+// The usage is only counted if the Quota limits one of them, a cached usage is counted again before an allocation is rejected.
+// Allocations by several ipamers at the same time may exceed the Quota slightly.
+func (i *ipamer) checkQuota(ctx context.Context, namespace string, prefixes, ips uint64, addresses *big.Int) error {
+	i.quotaMu.Lock()
+	defer i.quotaMu.Unlock()
+	entry, err := i.cachedQuota(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if !entry.quota.limits(prefixes, ips, addresses) {
+		return nil
+	}
+	if entry.usage == nil || time.Since(entry.counted) >= quotaCacheTTL {
+		if err := i.countQuotaUsage(ctx, namespace, entry); err != nil {
+			return err
+		}
+	}
+	if err := entry.quota.check(namespace, entry.usage, prefixes, ips, addresses); err != nil {
+		// the cached usage does not know about releases, count again before rejecting
+		if countErr := i.countQuotaUsage(ctx, namespace, entry); countErr != nil {
+			return countErr
+		}
+		if err := entry.quota.check(namespace, entry.usage, prefixes, ips, addresses); err != nil {
+			return err
+		}
+	}
+	entry.usage.Prefixes += prefixes
+	entry.usage.IPs += ips
+	if addresses != nil {
+		entry.usage.AddressSpace.Add(entry.usage.AddressSpace, addresses)
+	}
+	return nil
+}
+
+// releaseQuota removes the prefixes, ips or addresses added to the cached usage by checkQuota again,
+// if their allocation failed afterwards.
+func (i *ipamer) releaseQuota(namespace string, prefixes, ips uint64, addresses *big.Int) {
+	i.quotaMu.Lock()
+	defer i.quotaMu.Unlock()
+	entry, ok := i.quotas[namespace]
+	if !ok || entry.usage == nil || !entry.quota.limits(prefixes, ips, addresses) {
+		return
+	}
+	entry.usage.Prefixes -= min(prefixes, entry.usage.Prefixes)
+	entry.usage.IPs -= min(ips, entry.usage.IPs)
+	if addresses != nil {
+		entry.usage.AddressSpace.Sub(entry.usage.AddressSpace, addresses)
+		if entry.usage.AddressSpace.Sign() < 0 {
+			entry.usage.AddressSpace.SetInt64(0)
+		}
+	}
+}
+
+// cachedQuota returns the cached Quota of the namespace, it is read from the storage if it is older than the quotaCacheTTL.
+// quotaMu must be held.
+func (i *ipamer) cachedQuota(ctx context.Context, namespace string) (*quotaCacheEntry, error) {
+	if entry, ok := i.quotas[namespace]; ok && time.Since(entry.read) < quotaCacheTTL {
+		return entry, nil
+	}
+	quota, err := i.storage.ReadNamespaceQuota(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read quota of namespace:%s %w", namespace, err)
+	}
+	if i.quotas == nil {
+		i.quotas = make(map[string]*quotaCacheEntry)
+	}
+	entry := &quotaCacheEntry{quota: quota, read: time.Now()}
+	i.quotas[namespace] = entry
+	return entry, nil
+}
+
+// countQuotaUsage counts the usage of the namespace from the storage. quotaMu must be held.
+func (i *ipamer) countQuotaUsage(ctx context.Context, namespace string, entry *quotaCacheEntry) error {
+	usage, err := i.namespaceUsage(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	entry.usage = usage
+	entry.counted = time.Now()
+	return nil
+}
+
+// dropQuota forgets the cached Quota and usage of the namespace, they are read again on next use.
+func (i *ipamer) dropQuota(namespace string) {
+	i.quotaMu.Lock()
+	defer i.quotaMu.Unlock()
+	delete(i.quotas, namespace)
+}
@@ -9,7 +9,19 @@ import (
 	redigo "github.com/redis/go-redis/v9"
 )
 
-const namespaceKey = "namespaces"
+// reservedNamespacePrefix starts the keys of quotas and pools in the storages which share one keyspace with the prefixes
+// of all namespaces. Namespaces must not start with it, their prefixes could not be told apart from these records otherwise.
+const reservedNamespacePrefix = "@"
+
+const (
+	namespaceKey = "namespaces"
+	quotaKey     = reservedNamespacePrefix + "quotas"
+	poolKey      = reservedNamespacePrefix + "pools"
+)
+
+func redisPoolKey(namespace string) string {
+	return poolKey + "/" + namespace
+}
 
 type redis struct {
 	rdb        *redigo.Client
@@ -231,9 +243,122 @@ func (r *redis) DeleteNamespace(ctx context.Context, namespace string) error {
 	}
 	r.lock.Lock()
 	defer r.lock.Unlock()
+	if err := r.rdb.HDel(ctx, quotaKey, namespace).Err(); err != nil {
+		return err
+	}
+	if err := r.rdb.Del(ctx, redisPoolKey(namespace)).Err(); err != nil {
+		return err
+	}
 	if err := r.rdb.SRem(ctx, namespaceKey, namespace).Err(); err != nil {
 		return err
 	}
 	delete(r.namespaces, namespace)
 	return nil
 }
+
+func (r *redis) ReadNamespaceQuota(ctx context.Context, namespace string) (Quota, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if err := r.checkNamespaceExists(ctx, namespace); err != nil {
+		return Quota{}, err
+	}
+
+	result, err := r.rdb.HGet(ctx, quotaKey, namespace).Result()
+	if errors.Is(err, redigo.Nil) {
+		return Quota{}, nil
+	}
+	if err != nil {
+		return Quota{}, fmt.Errorf("unable to read quota of namespace:%s, error:%w", namespace, err)
+	}
+	return quotaFromJSON([]byte(result))
+}
+
+func (r *redis) UpdateNamespaceQuota(ctx context.Context, namespace string, quota Quota) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	if quota.isZero() {
+		return r.rdb.HDel(ctx, quotaKey, namespace).Err()
+	}
+	q, err := quota.toJSON()
+	if err != nil {
+		return err
+	}
+	return r.rdb.HSet(ctx, quotaKey, namespace, q).Err()
+}
+
+func (r *redis) ReadPool(ctx context.Context, name, namespace string) (Pool, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if err := r.checkNamespaceExists(ctx, namespace); err != nil {
+		return Pool{}, err
+	}
+
+	result, err := r.rdb.HGet(ctx, redisPoolKey(namespace), name).Result()
+	if err != nil {
+		return Pool{}, fmt.Errorf("%w unable to read existing pool:%s, error:%w", ErrNotFound, name, err)
+	}
+	return poolFromJSON([]byte(result))
+}
+
+func (r *redis) ReadAllPools(ctx context.Context, namespace string) ([]Pool, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if err := r.checkNamespaceExists(ctx, namespace); err != nil {
+		return nil, err
+	}
+
+	pools, err := r.rdb.HGetAll(ctx, redisPoolKey(namespace)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all pools:%w", err)
+	}
+	result := make([]Pool, 0, len(pools))
+	for _, p := range pools {
+		pool, err := poolFromJSON([]byte(p))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pool)
+	}
+	return result, nil
+}
+
+func (r *redis) UpdatePool(ctx context.Context, pool Pool, namespace string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	p, err := pool.toJSON()
+	if err != nil {
+		return err
+	}
+	return r.rdb.HSet(ctx, redisPoolKey(namespace), pool.Name, p).Err()
+}
+
+func (r *redis) DeletePool(ctx context.Context, name, namespace string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+
+	deleted, err := r.rdb.HDel(ctx, redisPoolKey(namespace), name).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w pool:%s not found", ErrNotFound, name)
+	}
+	return nil
+}
@@ -0,0 +1,378 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"go4.org/netipx"
+)
+
+// replacePrefixes replaces the prefixes olds with the prefixes news, which cover at least the addresses of all acquired ips
+// and child prefixes of olds, e.g. on resize, split and merge. parent is the parent prefix of olds, nil for top level prefixes.
+// The new prefixes are created first, then the parent, the children and the pools are pointed to them and the old prefixes are deleted last.
+// If one of the steps fails, the steps before are rolled back.
+func (i *ipamer) replacePrefixes(ctx context.Context, namespace string, parent *Prefix, olds, news []*Prefix) error {
+	var children []*Prefix
+	for _, old := range olds {
+		cs, err := i.childPrefixesOf(ctx, old.acquiredChildPrefixCidrs())
+		if err != nil {
+			return err
+		}
+		children = append(children, cs...)
+	}
+	pools, err := i.poolsWithMembers(ctx, namespace, cidrsOf(olds))
+	if err != nil {
+		return err
+	}
+
+	var (
+		created, reparented, rewritten, deleted int
+		parentUpdated                           bool
+	)
+	rollback := func(err error) error {
+		if rollbackErr := i.rollbackReplacePrefixes(ctx, namespace, olds, news[:created], parentUpdated, children[:reparented], pools[:rewritten], olds[:deleted]); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+
+	for _, p := range news {
+		_, err := i.storage.CreatePrefix(ctx, *p, namespace)
+		if err != nil {
+			return rollback(fmt.Errorf("unable to create prefix:%s error:%w", p.Cidr, err))
+		}
+		created++
+	}
+	if parent != nil {
+		parent.replaceChildPrefixes(cidrsOf(olds), cidrsOf(news))
+		_, err := i.storage.UpdatePrefix(ctx, *parent, namespace)
+		if err != nil {
+			return rollback(fmt.Errorf("unable to update parent prefix:%s error:%w", parent.Cidr, err))
+		}
+		parentUpdated = true
+	}
+	for _, child := range children {
+		container, ok := containerOf(news, child.Cidr)
+		if !ok {
+			return rollback(fmt.Errorf("child prefix:%s is not in one of %s", child.Cidr, cidrsOf(news)))
+		}
+		child.reparent(container.Cidr)
+		_, err := i.storage.UpdatePrefix(ctx, *child, namespace)
+		if err != nil {
+			return rollback(fmt.Errorf("unable to update child prefix:%s error:%w", child.Cidr, err))
+		}
+		reparented++
+	}
+	for _, pool := range pools {
+		updated := pool.deepCopy()
+		updated.replaceMembers(cidrsOf(olds), cidrsOf(news))
+		if err := i.storage.UpdatePool(ctx, updated, namespace); err != nil {
+			return rollback(fmt.Errorf("unable to update pool:%s error:%w", pool.Name, err))
+		}
+		rewritten++
+	}
+	// fails if one of the prefixes was modified since it was read, its allocations would be lost otherwise
+	for _, old := range olds {
+		_, err := i.storage.UpdatePrefix(ctx, *old, namespace)
+		if err != nil {
+			return rollback(fmt.Errorf("unable to update prefix:%s error:%w", old.Cidr, err))
+		}
+	}
+	for _, old := range olds {
+		_, err := i.storage.DeletePrefix(ctx, *old, namespace)
+		if err != nil {
+			return rollback(fmt.Errorf("unable to delete prefix:%s error:%w", old.Cidr, err))
+		}
+		deleted++
+	}
+
+	for _, old := range olds {
+		i.unindexPrefix(namespace, old.Cidr)
+	}
+	for _, p := range news {
+		i.indexPrefix(namespace, p.Cidr)
+	}
+	return nil
+}
+
+// rollbackReplacePrefixes recreates the deleted old prefixes, restores the rewritten pools, points the parent and the reparented
+// children back to olds and deletes the created new prefixes.
+func (i *ipamer) rollbackReplacePrefixes(ctx context.Context, namespace string, olds, created []*Prefix, parentUpdated bool, reparented []*Prefix, rewritten []Pool, deleted []*Prefix) error {
+	for _, old := range deleted {
+		_, err := i.storage.CreatePrefix(ctx, *old, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to rollback deleted prefix:%s error:%w", old.Cidr, err)
+		}
+	}
+	for _, pool := range rewritten {
+		if err := i.storage.UpdatePool(ctx, pool, namespace); err != nil {
+			return fmt.Errorf("unable to rollback pool:%s error:%w", pool.Name, err)
+		}
+	}
+	for _, child := range reparented {
+		container, ok := containerOf(olds, child.Cidr)
+		if !ok {
+			continue
+		}
+		err := retryOnOptimisticLock(func() error {
+			c, err := i.PrefixFrom(ctx, child.Cidr)
+			if err != nil {
+				return fmt.Errorf("unable to rollback child prefix:%s error:%w", child.Cidr, err)
+			}
+			c.reparent(container.Cidr)
+			_, err = i.storage.UpdatePrefix(ctx, *c, namespace)
+			if err != nil {
+				return fmt.Errorf("unable to rollback child prefix:%s error:%w", child.Cidr, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if parentUpdated {
+		parentCidr := olds[0].ParentCidr
+		err := retryOnOptimisticLock(func() error {
+			parent, err := i.PrefixFrom(ctx, parentCidr)
+			if err != nil {
+				return fmt.Errorf("unable to rollback parent prefix:%s error:%w", parentCidr, err)
+			}
+			parent.replaceChildPrefixes(cidrsOf(created), cidrsOf(olds))
+			_, err = i.storage.UpdatePrefix(ctx, *parent, namespace)
+			if err != nil {
+				return fmt.Errorf("unable to rollback parent prefix:%s error:%w", parentCidr, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, p := range created {
+		_, err := i.storage.DeletePrefix(ctx, *p, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to rollback created prefix:%s error:%w", p.Cidr, err)
+		}
+	}
+	return nil
+}
+
+// checkAvailable returns an error if target overlaps other prefixes than the replaced ones or does not fit into the parent prefix,
+// parent is nil for top level prefixes.
+func (i *ipamer) checkAvailable(ctx context.Context, namespace string, parent *Prefix, replaced []string, target netip.Prefix) error {
+	var existingPrefixes []string
+	if parent == nil {
+		prefixes, err := i.storage.ReadAllPrefixes(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("unable to read prefixes:%w", err)
+		}
+		for _, other := range prefixes {
+			if other.ParentCidr == "" && !slices.Contains(replaced, other.Cidr) {
+				existingPrefixes = append(existingPrefixes, other.Cidr)
+			}
+		}
+		return PrefixesOverlapping(existingPrefixes, []string{target.String()})
+	}
+
+	parentprefix, err := netip.ParsePrefix(parent.Cidr)
+	if err != nil {
+		return fmt.Errorf("unable to parse parent prefix:%s %w", parent.Cidr, err)
+	}
+	if parentprefix.Bits() >= target.Bits() || !parentprefix.Contains(target.Addr()) {
+		return fmt.Errorf("prefix:%s does not fit into parent prefix:%s", target, parent.Cidr)
+	}
+	for cp, available := range parent.availableChildPrefixes {
+		if !available && !slices.Contains(replaced, cp) {
+			existingPrefixes = append(existingPrefixes, cp)
+		}
+	}
+	if err := PrefixesOverlapping(existingPrefixes, []string{target.String()}); err != nil {
+		return err
+	}
+	excluded, err := parent.exclusionIPSet()
+	if err != nil {
+		return err
+	}
+	if excluded.OverlapsPrefix(target) {
+		return fmt.Errorf("prefix:%s overlaps the exclusions of parent prefix:%s", target, parent.Cidr)
+	}
+	return nil
+}
+
+// copyTo returns a copy of the Prefix which is stored under the cidr target, with all acquired ips, child prefixes and
+// other state of the addresses within target. Default reservations are moved to the bounds of target,
+// other reservations and exclusions are cut to it.
+func (p *Prefix) copyTo(target netip.Prefix) (*Prefix, error) {
+	r := p.deepCopy()
+	r.Cidr = target.String()
+	r.version = 0
+	r.ips = p.ips.within(netipx.RangeOfPrefix(target))
+
+	if ipprefix, err := netip.ParsePrefix(p.Cidr); err == nil && slices.Equal(p.reserved, defaultReservedIPs(ipprefix)) {
+		r.reserved = nil
+		for _, ip := range defaultReservedIPs(target) {
+			if r.ips.contains(netip.MustParseAddr(ip)) {
+				return nil, fmt.Errorf("%w: ip:%s is acquired and can not become the network or broadcast address of prefix:%s", ErrAlreadyAllocated, ip, target)
+			}
+		}
+		if err := r.apply(WithReservedIPs(defaultReservedIPs(target)...)); err != nil {
+			return nil, err
+		}
+	} else if r.reserved != nil {
+		r.reserved = clipRanges(r.reserved, target)
+	}
+	if r.exclusions = clipRanges(r.exclusions, target); len(r.exclusions) == 0 {
+		r.exclusions = nil
+	}
+	for cp := range r.availableChildPrefixes {
+		if cpipprefix, err := netip.ParsePrefix(cp); err != nil || cpipprefix.Bits() < target.Bits() || !target.Contains(cpipprefix.Addr()) {
+			delete(r.availableChildPrefixes, cp)
+		}
+	}
+	r.isParent = p.isParent && len(r.availableChildPrefixes) > 0
+	for ip := range r.ipAnnotations {
+		if !containsAddress(target, ip) {
+			delete(r.ipAnnotations, ip)
+		}
+	}
+	for ip, lease := range r.leases {
+		if !containsAddress(target, ip) {
+			delete(r.leases, ip)
+			continue
+		}
+		lease.ParentPrefix = r.Cidr
+		r.leases[ip] = lease
+	}
+	for ip := range r.quarantined {
+		if !containsAddress(target, ip) {
+			delete(r.quarantined, ip)
+		}
+	}
+	if !containsAddress(target, r.lastAllocated) {
+		r.lastAllocated = ""
+	}
+	if r.lease != nil {
+		r.lease.Cidr = r.Cidr
+	}
+	// a record belongs to the prefix which contains its first result, the acquired ip or the created prefix
+	for key, record := range r.idempotencyKeys {
+		if len(record.Results) > 0 && !containsAddress(target, record.Results[0]) {
+			delete(r.idempotencyKeys, key)
+		}
+	}
+	r.replaceIdempotencyResults([]string{p.Cidr}, []string{r.Cidr})
+	return r, nil
+}
+
+// clipRanges returns the given addresses and ranges cut to ipprefix, ranges outside of ipprefix are dropped.
+func clipRanges(ranges []string, ipprefix netip.Prefix) []string {
+	bounds := netipx.RangeOfPrefix(ipprefix)
+	clipped := []string{}
+	for _, r := range ranges {
+		iprange, err := parseIPRange(r)
+		if err != nil || !iprange.Overlaps(bounds) {
+			continue
+		}
+		from, to := iprange.From(), iprange.To()
+		if from.Less(bounds.From()) {
+			from = bounds.From()
+		}
+		if bounds.To().Less(to) {
+			to = bounds.To()
+		}
+		clipped = append(clipped, formatIPRange(netipx.IPRangeFrom(from, to)))
+	}
+	return clipped
+}
+
+// containsAddress returns true if target contains the given ip or the network address of the given cidr.
+func containsAddress(target netip.Prefix, s string) bool {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return target.Contains(addr)
+	}
+	if ipprefix, err := netip.ParsePrefix(s); err == nil {
+		return target.Contains(ipprefix.Addr())
+	}
+	return false
+}
+
+// acquiredChildPrefixCidrs returns the sorted cidrs of the acquired child prefixes of this Prefix.
+func (p *Prefix) acquiredChildPrefixCidrs() []string {
+	var cidrs []string
+	for cp, available := range p.availableChildPrefixes {
+		if !available {
+			cidrs = append(cidrs, cp)
+		}
+	}
+	slices.Sort(cidrs)
+	return cidrs
+}
+
+// replaceChildPrefixes replaces the acquired child prefixes from with the acquired child prefixes to,
+// the prefix must be persisted afterwards.
+func (p *Prefix) replaceChildPrefixes(from, to []string) {
+	for _, cidr := range from {
+		delete(p.availableChildPrefixes, cidr)
+	}
+	for _, cidr := range to {
+		p.availableChildPrefixes[cidr] = false
+	}
+	p.replaceIdempotencyResults(from, to)
+}
+
+// replaceIdempotencyResults replaces the results from with the results to in all idempotency records which contain one of from.
+func (p *Prefix) replaceIdempotencyResults(from, to []string) {
+	for key, record := range p.idempotencyKeys {
+		results := slices.DeleteFunc(slices.Clone(record.Results), func(result string) bool {
+			return slices.Contains(from, result)
+		})
+		if len(results) == len(record.Results) {
+			continue
+		}
+		for _, result := range to {
+			if !slices.Contains(results, result) {
+				results = append(results, result)
+			}
+		}
+		record.Results = results
+		p.idempotencyKeys[key] = record
+	}
+}
+
+// reparent points the child Prefix and its lease to the parent with the given cidr, the prefix must be persisted afterwards.
+func (p *Prefix) reparent(parentCidr string) {
+	p.ParentCidr = parentCidr
+	if p.lease != nil {
+		p.lease.ParentPrefix = parentCidr
+	}
+}
+
+// containerOf returns the prefix which contains the given cidr.
+func containerOf(prefixes []*Prefix, cidr string) (*Prefix, bool) {
+	ipprefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, false
+	}
+	for _, p := range prefixes {
+		container, err := netip.ParsePrefix(p.Cidr)
+		if err != nil {
+			continue
+		}
+		if container.Bits() <= ipprefix.Bits() && container.Contains(ipprefix.Addr()) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// cidrsOf returns the cidrs of the given prefixes.
+func cidrsOf(prefixes []*Prefix) []string {
+	cidrs := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		cidrs = append(cidrs, p.Cidr)
+	}
+	return cidrs
+}
@@ -0,0 +1,104 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+
+	"go4.org/netipx"
+)
+
+func (i *ipamer) ResizePrefix(ctx context.Context, cidr string, length uint8) (*Prefix, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	namespace := namespaceFromContext(ctx)
+	var prefix *Prefix
+	return prefix, retryOnOptimisticLock(func() error {
+		var err error
+		prefix, err = i.resizePrefixInternal(ctx, namespace, cidr, int(length))
+		return err
+	})
+}
+
+// resizePrefixInternal moves the given Prefix with all its allocations to the cidr with the given length.
+func (i *ipamer) resizePrefixInternal(ctx context.Context, namespace, cidr string, length int) (*Prefix, error) {
+	p, err := i.PrefixFrom(ctx, cidr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
+	}
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+	}
+	if length > ipprefix.Addr().BitLen() {
+		return nil, fmt.Errorf("given length:%d must not be greater than %d", length, ipprefix.Addr().BitLen())
+	}
+	if length == ipprefix.Bits() {
+		return p, nil
+	}
+	resized, err := ipprefix.Addr().Prefix(length)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resize prefix:%s to length:%d %w", p.Cidr, length, err)
+	}
+	if resized.Addr() != ipprefix.Addr() {
+		return nil, fmt.Errorf("prefix:%s can not grow to length:%d, the network address would change to %s", p.Cidr, length, resized)
+	}
+
+	var parent *Prefix
+	if p.ParentCidr != "" {
+		parent, err = i.PrefixFrom(ctx, p.ParentCidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to find parent prefix for cidr:%s error:%s", ErrNotFound, p.ParentCidr, err.Error())
+		}
+	}
+	if length < ipprefix.Bits() {
+		if parent == nil {
+			grown := new(big.Int).Sub(prefixSize(resized), prefixSize(ipprefix))
+			if err := i.checkQuota(ctx, namespace, 0, 0, grown); err != nil {
+				return nil, err
+			}
+		}
+		err = i.checkAvailable(ctx, namespace, parent, []string{p.Cidr}, resized)
+	} else {
+		err = p.checkShrink(resized)
+	}
+	if err != nil {
+		return nil, err
+	}
+	newPrefix, err := p.copyTo(resized)
+	if err != nil {
+		return nil, err
+	}
+	err = i.replacePrefixes(ctx, namespace, parent, []*Prefix{p}, []*Prefix{newPrefix})
+	if err != nil {
+		return nil, err
+	}
+	return i.PrefixFrom(ctx, newPrefix.Cidr)
+}
+
+// checkShrink returns an error if acquired ips or child prefixes of the Prefix are outside of resized.
+func (p *Prefix) checkShrink(resized netip.Prefix) error {
+	if len(p.ips) > 0 {
+		bounds := netipx.RangeOfPrefix(resized)
+		if first := p.ips[0].From(); first.Less(bounds.From()) {
+			return fmt.Errorf("%w: acquired ip:%s is not in resized prefix:%s", ErrAlreadyAllocated, first, resized)
+		}
+		if last := p.ips[len(p.ips)-1].To(); bounds.To().Less(last) {
+			return fmt.Errorf("%w: acquired ip:%s is not in resized prefix:%s", ErrAlreadyAllocated, last, resized)
+		}
+	}
+	for cp, available := range p.availableChildPrefixes {
+		if available {
+			continue
+		}
+		cpipprefix, err := netip.ParsePrefix(cp)
+		if err != nil {
+			return err
+		}
+		if cpipprefix.Bits() <= resized.Bits() || !resized.Contains(cpipprefix.Addr()) {
+			return fmt.Errorf("%w: child prefix:%s is not in resized prefix:%s", ErrAlreadyAllocated, cp, resized)
+		}
+	}
+	return nil
+}
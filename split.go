@@ -0,0 +1,247 @@
+package ipam
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"go4.org/netipx"
+)
+
+// maxSplitPrefixes is the maximum number of prefixes a Prefix can be split into at once.
+const maxSplitPrefixes = 256
+
+func (i *ipamer) SplitPrefix(ctx context.Context, cidr string, length uint8) (Prefixes, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	namespace := namespaceFromContext(ctx)
+	var prefixes Prefixes
+	return prefixes, retryOnOptimisticLock(func() error {
+		parts, err := i.splitPrefixInternal(ctx, namespace, cidr, int(length))
+		if err != nil {
+			return err
+		}
+		prefixes = make(Prefixes, 0, len(parts))
+		for _, part := range parts {
+			prefixes = append(prefixes, *part)
+		}
+		return nil
+	})
+}
+
+// splitPrefixInternal replaces the given Prefix with all its parts of the given length, every acquired ip and child prefix
+// is moved to the part which contains it.
+func (i *ipamer) splitPrefixInternal(ctx context.Context, namespace, cidr string, length int) ([]*Prefix, error) {
+	p, err := i.PrefixFrom(ctx, cidr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
+	}
+	ipprefix, err := netip.ParsePrefix(p.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+	}
+	if ipprefix.Bits() >= length {
+		return nil, fmt.Errorf("given length:%d must be greater than prefix length:%d", length, ipprefix.Bits())
+	}
+	if length > ipprefix.Addr().BitLen() {
+		return nil, fmt.Errorf("given length:%d must not be greater than %d", length, ipprefix.Addr().BitLen())
+	}
+	if 1<<min(length-ipprefix.Bits(), 16) > maxSplitPrefixes {
+		return nil, fmt.Errorf("prefix:%s can not be split into more than %d prefixes", p.Cidr, maxSplitPrefixes)
+	}
+	for _, cp := range p.acquiredChildPrefixCidrs() {
+		cpipprefix, err := netip.ParsePrefix(cp)
+		if err != nil {
+			return nil, err
+		}
+		if cpipprefix.Bits() < length {
+			return nil, fmt.Errorf("%w: child prefix:%s does not fit into a prefix with length:%d", ErrAlreadyAllocated, cp, length)
+		}
+	}
+	var parent *Prefix
+	if p.ParentCidr != "" {
+		parent, err = i.PrefixFrom(ctx, p.ParentCidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to find parent prefix for cidr:%s error:%s", ErrNotFound, p.ParentCidr, err.Error())
+		}
+	}
+
+	var parts []*Prefix
+	for addr := ipprefix.Addr(); addr.IsValid() && ipprefix.Contains(addr); {
+		target := netip.PrefixFrom(addr, length)
+		part, err := p.copyTo(target)
+		if err != nil {
+			return nil, err
+		}
+		// lease ids must be unique, only the first part keeps the lease id of the split prefix
+		if part.lease != nil && len(parts) > 0 {
+			part.lease.ID = rand.Text()
+		}
+		parts = append(parts, part)
+		addr = netipx.RangeOfPrefix(target).To().Next()
+	}
+
+	if parent == nil {
+		if err := i.checkQuota(ctx, namespace, uint64(len(parts)-1), 0, nil); err != nil { // nolint:gosec
+			return nil, err
+		}
+	}
+	err = i.replacePrefixes(ctx, namespace, parent, []*Prefix{p}, parts)
+	if err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+func (i *ipamer) MergePrefixes(ctx context.Context, cidrs ...string) (*Prefix, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	namespace := namespaceFromContext(ctx)
+	var prefix *Prefix
+	return prefix, retryOnOptimisticLock(func() error {
+		var err error
+		prefix, err = i.mergePrefixesInternal(ctx, namespace, cidrs)
+		return err
+	})
+}
+
+// mergePrefixesInternal replaces the given sibling Prefixes with their supernet, which contains all their acquired ips and child prefixes.
+// Labels, description, allocation strategy, quarantine and policy are taken from the first Prefix.
+func (i *ipamer) mergePrefixesInternal(ctx context.Context, namespace string, cidrs []string) (*Prefix, error) {
+	if len(cidrs) < 2 {
+		return nil, fmt.Errorf("at least two prefixes are required for a merge, got %d", len(cidrs))
+	}
+	var (
+		prefixes     []*Prefix
+		ipsetBuilder netipx.IPSetBuilder
+	)
+	for _, cidr := range cidrs {
+		p, err := i.PrefixFrom(ctx, cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to find prefix for cidr:%s error:%s", ErrNotFound, cidr, err.Error())
+		}
+		if err := PrefixesOverlapping(cidrsOf(prefixes), []string{p.Cidr}); err != nil {
+			return nil, err
+		}
+		if len(prefixes) > 0 && p.ParentCidr != prefixes[0].ParentCidr {
+			return nil, fmt.Errorf("prefix:%s and prefix:%s do not have the same parent prefix", p.Cidr, prefixes[0].Cidr)
+		}
+		ipprefix, err := netip.ParsePrefix(p.Cidr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse prefix:%s %w", p.Cidr, err)
+		}
+		ipsetBuilder.AddPrefix(ipprefix)
+		prefixes = append(prefixes, p)
+	}
+	ipset, err := ipsetBuilder.IPSet()
+	if err != nil {
+		return nil, fmt.Errorf("error constructing ipset:%w", err)
+	}
+	supernets := ipset.Prefixes()
+	if len(supernets) != 1 {
+		return nil, fmt.Errorf("prefixes %s are not adjacent and do not form a single supernet", strings.Join(cidrsOf(prefixes), ","))
+	}
+	supernet := supernets[0]
+
+	var withIPs, withChildren *Prefix
+	for _, p := range prefixes {
+		if p.hasIPs() {
+			withIPs = p
+		}
+		if len(p.acquiredChildPrefixCidrs()) > 0 {
+			withChildren = p
+		}
+	}
+	if withIPs != nil && withChildren != nil {
+		return nil, fmt.Errorf("prefix %s has ips and prefix %s has child prefixes, merge not possible", withIPs.Cidr, withChildren.Cidr)
+	}
+
+	var parent *Prefix
+	if prefixes[0].ParentCidr != "" {
+		parent, err = i.PrefixFrom(ctx, prefixes[0].ParentCidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to find parent prefix for cidr:%s error:%s", ErrNotFound, prefixes[0].ParentCidr, err.Error())
+		}
+	}
+	err = i.checkAvailable(ctx, namespace, parent, cidrsOf(prefixes), supernet)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergedPrefix(prefixes, supernet)
+	if err != nil {
+		return nil, err
+	}
+	err = i.replacePrefixes(ctx, namespace, parent, prefixes, []*Prefix{merged})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// mergedPrefix returns the Prefix stored under the cidr supernet which combines the state of all given prefixes.
+// If all prefixes have default reservations, the merged Prefix has the default reservations of supernet, otherwise all reservations are kept.
+func mergedPrefix(prefixes []*Prefix, supernet netip.Prefix) (*Prefix, error) {
+	allDefault := true
+	for _, p := range prefixes {
+		ipprefix, err := netip.ParsePrefix(p.Cidr)
+		if err != nil || !slices.Equal(p.reserved, defaultReservedIPs(ipprefix)) {
+			allDefault = false
+		}
+	}
+
+	merged, err := prefixes[0].copyTo(supernet)
+	if err != nil {
+		return nil, err
+	}
+	merged.reserved = slices.Clone(prefixes[0].reserved)
+	for _, p := range prefixes[1:] {
+		merged.ips = merged.ips.union(p.ips)
+		merged.ipAnnotations = mergeMaps(merged.ipAnnotations, copyAnnotations(p.ipAnnotations))
+		leases := make(map[string]Lease, len(p.leases))
+		for ip, lease := range p.leases {
+			lease.ParentPrefix = merged.Cidr
+			leases[ip] = lease
+		}
+		merged.leases = mergeMaps(merged.leases, leases)
+		merged.quarantined = mergeMaps(merged.quarantined, p.quarantined)
+		merged.availableChildPrefixes = mergeMaps(merged.availableChildPrefixes, p.availableChildPrefixes)
+		merged.isParent = merged.isParent || p.isParent
+		if p.reserved != nil {
+			merged.reserved = append(merged.reserved, p.reserved...)
+		}
+		merged.exclusions = append(merged.exclusions, p.exclusions...)
+		merged.idempotencyKeys = mergeMaps(merged.idempotencyKeys, copyIdempotencyKeys(p.idempotencyKeys))
+		merged.replaceIdempotencyResults([]string{p.Cidr}, []string{merged.Cidr})
+		// the merged prefix only expires if all merged prefixes expire, it expires with the last of them
+		switch {
+		case merged.lease == nil || p.lease == nil:
+			merged.lease = nil
+		case merged.lease.Expires.Before(p.lease.Expires):
+			merged.lease.Expires = p.lease.Expires
+		}
+	}
+	if allDefault {
+		merged.reserved = nil
+		if err := merged.apply(WithReservedIPs(defaultReservedIPs(supernet)...)); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// mergeMaps copies all entries of src to dst, dst is created if it is nil.
+func mergeMaps[M ~map[K]V, K comparable, V any](dst, src M) M {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(M, len(src))
+	}
+	maps.Copy(dst, src)
+	return dst
+}
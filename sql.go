@@ -273,9 +273,121 @@ func (s *sql) DeleteNamespace(ctx context.Context, namespace string) error {
 	if err != nil {
 		return fmt.Errorf("unable delete prefix:%w", err)
 	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM quotas WHERE namespace=$1", namespace)
+	if err != nil {
+		return fmt.Errorf("unable delete quota:%w", err)
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM pools WHERE namespace=$1", namespace)
+	if err != nil {
+		return fmt.Errorf("unable delete pools:%w", err)
+	}
 	if err := tx.Commit(); err != nil {
 		return err
 	}
 	s.tables.Delete(namespace)
 	return nil
 }
+
+func (s *sql) ReadNamespaceQuota(ctx context.Context, namespace string) (Quota, error) {
+	if err := s.checkNamespaceExists(ctx, namespace); err != nil {
+		return Quota{}, err
+	}
+	var result []byte
+	err := s.db.GetContext(ctx, &result, "SELECT quota FROM quotas WHERE namespace=$1", namespace)
+	if err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return Quota{}, nil
+		}
+		return Quota{}, fmt.Errorf("unable to read quota:%w", err)
+	}
+	return quotaFromJSON(result)
+}
+
+func (s *sql) UpdateNamespaceQuota(ctx context.Context, namespace string, quota Quota) error {
+	if err := s.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+	if quota.isZero() {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM quotas WHERE namespace=$1", namespace); err != nil {
+			return fmt.Errorf("unable to delete quota:%w", err)
+		}
+		return nil
+	}
+	qj, err := quota.toJSON()
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, "INSERT INTO quotas (namespace, quota) VALUES ($1, $2) ON CONFLICT (namespace) DO UPDATE SET quota=EXCLUDED.quota", namespace, qj)
+	if err != nil {
+		return fmt.Errorf("unable to update quota:%w", err)
+	}
+	return nil
+}
+
+func (s *sql) ReadPool(ctx context.Context, name, namespace string) (Pool, error) {
+	if err := s.checkNamespaceExists(ctx, namespace); err != nil {
+		return Pool{}, err
+	}
+	var result []byte
+	err := s.db.GetContext(ctx, &result, "SELECT pool FROM pools WHERE namespace=$1 AND name=$2", namespace, name)
+	if err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			return Pool{}, fmt.Errorf("%w pool:%s not found:%s", ErrNotFound, name, err.Error())
+		}
+		return Pool{}, fmt.Errorf("unable to read pool:%w", err)
+	}
+	return poolFromJSON(result)
+}
+
+func (s *sql) ReadAllPools(ctx context.Context, namespace string) ([]Pool, error) {
+	if err := s.checkNamespaceExists(ctx, namespace); err != nil {
+		return nil, err
+	}
+	var pools [][]byte
+	err := s.db.SelectContext(ctx, &pools, "SELECT pool FROM pools WHERE namespace=$1", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read pools:%w", err)
+	}
+	result := make([]Pool, 0, len(pools))
+	for _, p := range pools {
+		pool, err := poolFromJSON(p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pool)
+	}
+	return result, nil
+}
+
+func (s *sql) UpdatePool(ctx context.Context, pool Pool, namespace string) error {
+	if err := s.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+	pj, err := pool.toJSON()
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, "INSERT INTO pools (namespace, name, pool) VALUES ($1, $2, $3) ON CONFLICT (namespace, name) DO UPDATE SET pool=EXCLUDED.pool", namespace, pool.Name, pj)
+	if err != nil {
+		return fmt.Errorf("unable to update pool:%w", err)
+	}
+	return nil
+}
+
+func (s *sql) DeletePool(ctx context.Context, name, namespace string) error {
+	if err := s.checkNamespaceExists(ctx, namespace); err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, "DELETE FROM pools WHERE namespace=$1 AND name=$2", namespace, name)
+	if err != nil {
+		return fmt.Errorf("unable to delete pool:%w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w pool:%s not found", ErrNotFound, name)
+	}
+	return nil
+}
@@ -15,4 +15,14 @@ type Storage interface {
 	CreateNamespace(ctx context.Context, namespace string) error
 	ListNamespaces(ctx context.Context) ([]string, error)
 	DeleteNamespace(ctx context.Context, namespace string) error
+	// ReadNamespaceQuota returns the zero Quota if no Quota was stored for the namespace.
+	ReadNamespaceQuota(ctx context.Context, namespace string) (Quota, error)
+	UpdateNamespaceQuota(ctx context.Context, namespace string, quota Quota) error
+	// ReadPool returns a NotFound error if there is no Pool with the given name in the namespace.
+	ReadPool(ctx context.Context, name, namespace string) (Pool, error)
+	ReadAllPools(ctx context.Context, namespace string) ([]Pool, error)
+	// UpdatePool creates the Pool or replaces the Pool with the same name.
+	UpdatePool(ctx context.Context, pool Pool, namespace string) error
+	// DeletePool returns a NotFound error if there is no Pool with the given name in the namespace.
+	DeletePool(ctx context.Context, name, namespace string) error
 }
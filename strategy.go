@@ -0,0 +1,252 @@
+package ipam
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net/netip"
+	"slices"
+
+	"go4.org/netipx"
+)
+
+// AllocationStrategy defines in which order free ips of a Prefix are acquired.
+type AllocationStrategy string
+
+const (
+	// FirstFree acquires the lowest free ip of the prefix, this is the default.
+	FirstFree AllocationStrategy = "first-free"
+	// LastFree acquires the highest free ip of the prefix.
+	LastFree AllocationStrategy = "last-free"
+	// NextAfterLast acquires the next free ip after the last acquired one and wraps around at the end of the prefix.
+	// Released ips are therefore not handed out again until all other ips were used.
+	NextAfterLast AllocationStrategy = "next-after-last"
+	// Random acquires a random free ip of the prefix.
+	Random AllocationStrategy = "random"
+)
+
+// AllocationStrategies contains all supported allocation strategies.
+var AllocationStrategies = []AllocationStrategy{FirstFree, LastFree, NextAfterLast, Random}
+
+func (s AllocationStrategy) validate() error {
+	if s == "" || slices.Contains(AllocationStrategies, s) {
+		return nil
+	}
+	return fmt.Errorf("unknown allocation strategy:%q, supported are %v", s, AllocationStrategies)
+}
+
+// nextFreeIP returns a free ip of the prefix according to the given strategy.
+// The second return value is false if there is no free ip left.
+func (p *Prefix) nextFreeIP(strategy AllocationStrategy) (netip.Addr, bool, error) {
+	allocatable, err := p.allocatableIPSet()
+	if err != nil {
+		return netip.Addr{}, false, err
+	}
+	ranges := allocatable.Ranges()
+	if len(ranges) == 0 {
+		return netip.Addr{}, false, nil
+	}
+
+	switch strategy {
+	case FirstFree, "":
+		ip, ok := p.firstFreeIPFrom(ranges, ranges[0].From())
+		return ip, ok, nil
+	case LastFree:
+		ip, ok := p.lastFreeIP(ranges)
+		return ip, ok, nil
+	case NextAfterLast:
+		start := ranges[0].From()
+		if last, err := netip.ParseAddr(p.lastAllocated); err == nil && last.Next().IsValid() {
+			start = last.Next()
+		}
+		ip, ok := p.firstFreeIPFrom(ranges, start)
+		return ip, ok, nil
+	case Random:
+		start, err := randomIP(ranges)
+		if err != nil {
+			return netip.Addr{}, false, err
+		}
+		ip, ok := p.firstFreeIPFrom(ranges, start)
+		return ip, ok, nil
+	default:
+		return netip.Addr{}, false, strategy.validate()
+	}
+}
+
+// firstFreeIPFrom returns the first free ip in ranges at or after start, wrapping around at the end of the ranges.
+func (p *Prefix) firstFreeIPFrom(ranges []netipx.IPRange, start netip.Addr) (netip.Addr, bool) {
+	// first pass from start to the end of the ranges
+	for _, r := range ranges {
+		if r.To().Less(start) {
+			continue
+		}
+		from := r.From()
+		if from.Less(start) {
+			from = start
+		}
+		if ip, ok := p.firstFreeIPIn(from, r.To()); ok {
+			return ip, true
+		}
+	}
+	// second pass from the beginning of the ranges up to start
+	for _, r := range ranges {
+		if !r.From().Less(start) {
+			break
+		}
+		to := r.To()
+		if !to.Less(start) {
+			to = start.Prev()
+		}
+		if ip, ok := p.firstFreeIPIn(r.From(), to); ok {
+			return ip, true
+		}
+	}
+	return netip.Addr{}, false
+}
+
+// firstFreeIPIn returns the lowest free ip between from and to, acquired ips are skipped range by range.
+func (p *Prefix) firstFreeIPIn(from, to netip.Addr) (netip.Addr, bool) {
+	for ip := from; ip.IsValid() && !to.Less(ip); {
+		if acquired, ok := p.ips.rangeOf(ip); ok {
+			ip = acquired.To().Next()
+			continue
+		}
+		if !p.isQuarantined(ip.String()) {
+			return ip, true
+		}
+		ip = ip.Next()
+	}
+	return netip.Addr{}, false
+}
+
+// lastFreeIP returns the highest free ip in ranges, acquired ips are skipped range by range.
+func (p *Prefix) lastFreeIP(ranges []netipx.IPRange) (netip.Addr, bool) {
+	for _, r := range slices.Backward(ranges) {
+		for ip := r.To(); ip.IsValid() && !ip.Less(r.From()); {
+			if acquired, ok := p.ips.rangeOf(ip); ok {
+				ip = acquired.From().Prev()
+				continue
+			}
+			if !p.isQuarantined(ip.String()) {
+				return ip, true
+			}
+			ip = ip.Prev()
+		}
+	}
+	return netip.Addr{}, false
+}
+
+// randomIP returns a uniformly distributed random ip out of the given ranges.
+func randomIP(ranges []netipx.IPRange) (netip.Addr, error) {
+	total := new(big.Int)
+	for _, r := range ranges {
+		total.Add(total, rangeSize(r))
+	}
+	offset, err := rand.Int(rand.Reader, total)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("unable to pick random ip:%w", err)
+	}
+	for _, r := range ranges {
+		size := rangeSize(r)
+		if offset.Cmp(size) < 0 {
+			ip, _ := addrOffset(r.From(), offset)
+			return ip, nil
+		}
+		offset.Sub(offset, size)
+	}
+	return ranges[len(ranges)-1].To(), nil
+}
+
+// ChildPrefixStrategy defines which free block of a parent Prefix a child prefix is acquired from.
+type ChildPrefixStrategy string
+
+const (
+	// BestFit acquires the child prefix from the smallest free block it fits into, large free blocks are kept for large requests.
+	// This is the default.
+	BestFit ChildPrefixStrategy = "best-fit"
+	// FirstFit acquires the child prefix from the lowest free block it fits into.
+	FirstFit ChildPrefixStrategy = "first-fit"
+	// Sparse acquires child prefixes as far away from each other as possible by bisecting the parent, similar to the
+	// centermost allocation of RFC 3531. The first child is placed at the start, the second in the middle, the next ones
+	// in the middle of the quarters and so on, which leaves every child room to grow.
+	Sparse ChildPrefixStrategy = "sparse"
+)
+
+// ChildPrefixStrategies contains all supported child prefix strategies.
+var ChildPrefixStrategies = []ChildPrefixStrategy{BestFit, FirstFit, Sparse}
+
+func (s ChildPrefixStrategy) validate() error {
+	if s == "" || slices.Contains(ChildPrefixStrategies, s) {
+		return nil
+	}
+	return fmt.Errorf("unknown child prefix strategy:%q, supported are %v", s, ChildPrefixStrategies)
+}
+
+// placementOf returns the child prefix strategy configured by WithChildPlacement, empty if there is none.
+func placementOf(opts ...PrefixOption) ChildPrefixStrategy {
+	probe := &Prefix{}
+	for _, opt := range opts {
+		// only WithChildPlacement is of interest here, all other options are validated on the real prefix
+		_ = opt(probe)
+	}
+	return probe.placement
+}
+
+// removeFreePrefix returns a free prefix with the given length out of free according to the strategy together with
+// the remaining free set. The third return value is false if there is no free prefix with this length.
+func removeFreePrefix(free *netipx.IPSet, length int, strategy ChildPrefixStrategy) (netip.Prefix, *netipx.IPSet, bool, error) {
+	if strategy == BestFit || strategy == "" {
+		// picks the smallest free block, the lowest one if there are several
+		cp, remaining, ok := free.RemoveFreePrefix(uint8(length)) // nolint:gosec
+		return cp, remaining, ok, nil
+	}
+	if err := strategy.validate(); err != nil {
+		return netip.Prefix{}, nil, false, err
+	}
+
+	// the prefixes of the set are the largest aligned free blocks, a child prefix is always placed at the start of one
+	var (
+		block netip.Prefix
+		found bool
+	)
+	for _, b := range free.Prefixes() {
+		if b.Bits() > length {
+			continue
+		}
+		if strategy == FirstFit {
+			block, found = b, true
+			break
+		}
+		// sparse takes the block whose start is the coarsest bisection step of the parent
+		if !found || alignment(b.Addr()) > alignment(block.Addr()) {
+			block, found = b, true
+		}
+	}
+	if !found {
+		return netip.Prefix{}, free, false, nil
+	}
+
+	cp := netip.PrefixFrom(block.Addr(), length)
+	var builder netipx.IPSetBuilder
+	builder.AddSet(free)
+	builder.RemovePrefix(cp)
+	remaining, err := builder.IPSet()
+	if err != nil {
+		return netip.Prefix{}, nil, false, fmt.Errorf("error constructing ipset:%w", err)
+	}
+	return cp, remaining, true, nil
+}
+
+// alignment returns the number of trailing zero bits of the address, a higher alignment is a coarser bisection step.
+func alignment(addr netip.Addr) int {
+	b := addr.AsSlice()
+	zeros := 0
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] != 0 {
+			return zeros + bits.TrailingZeros8(b[i])
+		}
+		zeros += 8
+	}
+	return zeros
+}
@@ -452,6 +452,11 @@ func (f *file) cleanup() error {
 	if err := f.clearParent(context.Background()); err != nil {
 		return err
 	}
+	for _, sidecar := range []string{f.quotaPath(), f.poolPath()} {
+		if err := os.Remove(sidecar); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
 	if _, err := os.Stat(f.path); errors.Is(err, fs.ErrNotExist) {
 		return nil
 	}
@@ -0,0 +1,86 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+)
+
+// PrefixNode is a Prefix in the parent/child hierarchy of a namespace together with its Usage.
+type PrefixNode struct {
+	Prefix Prefix
+	Usage  Usage
+	// Children are the child Prefixes acquired from this Prefix ordered by their network address,
+	// empty if the depth limit is reached
+	Children []PrefixNode
+}
+
+func (i *ipamer) PrefixTree(ctx context.Context, rootCidr string, depth int) ([]PrefixNode, error) {
+	namespace := namespaceFromContext(ctx)
+	if depth < 0 {
+		return nil, fmt.Errorf("depth:%d must not be negative", depth)
+	}
+	if rootCidr != "" {
+		ipprefix, err := netip.ParsePrefix(rootCidr)
+		if err != nil {
+			return nil, err
+		}
+		rootCidr = ipprefix.Masked().String()
+	}
+	prefixes, err := i.storage.ReadAllPrefixes(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read prefixes:%w", err)
+	}
+
+	var (
+		roots    []Prefix
+		children = make(map[string][]Prefix)
+	)
+	for _, p := range prefixes {
+		if p.ParentCidr != "" {
+			children[p.ParentCidr] = append(children[p.ParentCidr], p)
+		}
+		if (rootCidr == "" && p.ParentCidr == "") || p.Cidr == rootCidr {
+			roots = append(roots, p)
+		}
+	}
+	if rootCidr != "" && len(roots) == 0 {
+		return nil, fmt.Errorf("%w: prefix:%s not found", ErrNotFound, rootCidr)
+	}
+	levels := depth
+	if depth == 0 {
+		levels = -1
+	}
+	return prefixNodes(roots, children, levels), nil
+}
+
+// prefixNodes returns the nodes of the given prefixes with their children up to levels below, all levels if levels is negative.
+func prefixNodes(prefixes []Prefix, children map[string][]Prefix, levels int) []PrefixNode {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	slices.SortFunc(prefixes, comparePrefixes)
+	nodes := make([]PrefixNode, 0, len(prefixes))
+	for _, p := range prefixes {
+		node := PrefixNode{
+			Prefix: p,
+			Usage:  p.Usage(),
+		}
+		if levels != 0 {
+			node.Children = prefixNodes(children[p.Cidr], children, levels-1)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// comparePrefixes orders prefixes by their network address, prefixes with the same address by their length.
+func comparePrefixes(a, b Prefix) int {
+	ap, aerr := netip.ParsePrefix(a.Cidr)
+	bp, berr := netip.ParsePrefix(b.Cidr)
+	if aerr != nil || berr != nil {
+		return 0
+	}
+	return ap.Compare(bp)
+}
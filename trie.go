@@ -0,0 +1,91 @@
+package ipam
+
+import (
+	"net/netip"
+)
+
+// prefixTrie is a binary trie of prefixes keyed by their network bits, used to find all prefixes containing an ip
+// without scanning all prefixes.
+type prefixTrie struct {
+	v4 *trieNode
+	v6 *trieNode
+}
+
+type trieNode struct {
+	children [2]*trieNode
+	// prefix ends at this node if set
+	prefix *netip.Prefix
+}
+
+func newPrefixTrie() *prefixTrie {
+	return &prefixTrie{
+		v4: &trieNode{},
+		v6: &trieNode{},
+	}
+}
+
+func (t *prefixTrie) rootOf(addr netip.Addr) *trieNode {
+	if addr.Is4() {
+		return t.v4
+	}
+	return t.v6
+}
+
+// insert adds the given prefix, inserting it twice has no effect.
+func (t *prefixTrie) insert(prefix netip.Prefix) {
+	prefix = prefix.Masked()
+	node := t.rootOf(prefix.Addr())
+	bits := prefix.Addr().AsSlice()
+	for i := range prefix.Bits() {
+		b := bitAt(bits, i)
+		if node.children[b] == nil {
+			node.children[b] = &trieNode{}
+		}
+		node = node.children[b]
+	}
+	node.prefix = &prefix
+}
+
+// remove deletes the given prefix and all nodes which do not lead to another prefix anymore.
+func (t *prefixTrie) remove(prefix netip.Prefix) {
+	prefix = prefix.Masked()
+	t.rootOf(prefix.Addr()).remove(prefix.Addr().AsSlice(), 0, prefix.Bits())
+}
+
+// remove returns true if the node is empty afterwards and can be dropped by its parent.
+func (n *trieNode) remove(bits []byte, depth, length int) bool {
+	if depth == length {
+		n.prefix = nil
+	} else {
+		b := bitAt(bits, depth)
+		child := n.children[b]
+		if child != nil && child.remove(bits, depth+1, length) {
+			n.children[b] = nil
+		}
+	}
+	return n.prefix == nil && n.children[0] == nil && n.children[1] == nil
+}
+
+// lookup returns all prefixes which contain the given ip, the shortest prefix first.
+func (t *prefixTrie) lookup(addr netip.Addr) []netip.Prefix {
+	var (
+		result []netip.Prefix
+		node   = t.rootOf(addr)
+		bits   = addr.AsSlice()
+	)
+	for i := 0; node != nil; i++ {
+		if node.prefix != nil {
+			result = append(result, *node.prefix)
+		}
+		if i == addr.BitLen() {
+			break
+		}
+		node = node.children[bitAt(bits, i)]
+	}
+	return result
+}
+
+// bitAt returns the bit at position i of the given address bytes, counted from the most significant bit.
+func bitAt(bits []byte, i int) int {
+	return int(bits[i/8]>>(7-i%8)) & 1
+}